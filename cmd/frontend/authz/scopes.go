@@ -1,13 +1,76 @@
 package authz
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
 const (
 	// Access token scopes.
 	ScopeUserAll       = "user:all"        // Full control of all resources accessible to the user account.
 	ScopeSiteAdminSudo = "site-admin:sudo" // Ability to perform any action as any other user.
+
+	// Restricted access token scopes. An access token with these scopes, but without
+	// ScopeUserAll, can only be used for the operations they allow.
+	ScopeSearchRead      = "search:read"      // Ability to run searches.
+	ScopeRepoRead        = "repo:read"        // Ability to read repositories and their contents.
+	ScopeCampaignsWrite  = "campaigns:write"  // Ability to read, create and update campaigns.
+	ScopeCodeIntelUpload = "codeintel:upload" // Ability to upload LSIF data.
 )
 
 // AllScopes is a list of all known access token scopes.
 var AllScopes = []string{
 	ScopeUserAll,
 	ScopeSiteAdminSudo,
+	ScopeSearchRead,
+	ScopeRepoRead,
+	ScopeCampaignsWrite,
+	ScopeCodeIntelUpload,
+}
+
+// RestrictedScopes is a list of the access token scopes that restrict the
+// access token to the operations they allow.
+var RestrictedScopes = []string{
+	ScopeSearchRead,
+	ScopeRepoRead,
+	ScopeCampaignsWrite,
+	ScopeCodeIntelUpload,
+}
+
+// IsRestrictedScope reports whether scope is one of RestrictedScopes.
+func IsRestrictedScope(scope string) bool {
+	for _, s := range RestrictedScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrScopeRequired is returned by CheckScope if the actor was authenticated
+// with an access token that doesn't have the required scope.
+type ErrScopeRequired struct {
+	Scope string
+}
+
+func (e *ErrScopeRequired) Error() string {
+	return fmt.Sprintf("access token scope %q is required", e.Scope)
+}
+
+// CheckScope returns an ErrScopeRequired if the actor in ctx was authenticated
+// with an access token that is restricted to scopes other than scope. Actors
+// that weren't authenticated with a restricted access token are allowed.
+func CheckScope(ctx context.Context, scope string) error {
+	a := actor.FromContext(ctx)
+	if !a.IsRestricted() {
+		return nil
+	}
+	for _, s := range a.Scopes {
+		if s == scope {
+			return nil
+		}
+	}
+	return &ErrScopeRequired{Scope: scope}
 }
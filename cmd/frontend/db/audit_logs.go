@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+)
+
+// The actions that are recorded in the audit log.
+const (
+	AuditActionUpdateSiteConfiguration          = "updateSiteConfiguration"
+	AuditActionAddExternalService               = "addExternalService"
+	AuditActionUpdateExternalService            = "updateExternalService"
+	AuditActionDeleteExternalService            = "deleteExternalService"
+	AuditActionSetUserIsSiteAdmin               = "setUserIsSiteAdmin"
+	AuditActionCreateAccessToken                = "createAccessToken"
+	AuditActionDeleteAccessToken                = "deleteAccessToken"
+	AuditActionSetRepositoryPermissionsForUsers = "setRepositoryPermissionsForUsers"
+	AuditActionAddUserToOrganization            = "addUserToOrganization"
+	AuditActionRemoveUserFromOrganization       = "removeUserFromOrganization"
+	AuditActionAcceptOrganizationInvitation     = "acceptOrganizationInvitation"
+)
+
+// AuditLogEntry describes a security-sensitive action in the audit log.
+type AuditLogEntry struct {
+	ID                int64
+	ActorUserID       int32  // the user who performed the action, or 0 for internal actors
+	ActorIP           string // the IP address of the immediate peer of the request
+	ActorForwardedFor string // the X-Forwarded-For header of the request
+	Action            string
+	Target            string          // the GraphQL ID of the object the action was performed on, if any
+	Arguments         json.RawMessage // details of the action, which must not contain secrets
+	CreatedAt         time.Time
+}
+
+type auditLogs struct{}
+
+// Insert adds an entry to the audit log. Its ID and CreatedAt are ignored.
+//
+// Audit log entries can't be updated or deleted.
+func (*auditLogs) Insert(ctx context.Context, e *AuditLogEntry) error {
+	if Mocks.AuditLogs.Insert != nil {
+		return Mocks.AuditLogs.Insert(e)
+	}
+
+	arguments := e.Arguments
+	if arguments == nil {
+		arguments = json.RawMessage(`{}`)
+	}
+
+	_, err := dbconn.Global.ExecContext(
+		ctx,
+		"INSERT INTO audit_logs(actor_user_id, actor_ip, actor_forwarded_for, action, target, arguments) VALUES($1, $2, $3, $4, $5, $6)",
+		e.ActorUserID,
+		e.ActorIP,
+		e.ActorForwardedFor,
+		e.Action,
+		e.Target,
+		arguments,
+	)
+	if err != nil {
+		return errors.Wrap(err, "INSERT")
+	}
+	return nil
+}
+
+// Record adds an entry for the action performed by the actor of ctx to the audit log. The
+// arguments are marshaled to JSON.
+//
+// Callers must call Record before they perform the action, and must not perform it if Record
+// returns an error, so that no action goes unrecorded. An action that was recorded can still fail
+// afterwards.
+func (l *auditLogs) Record(ctx context.Context, action, target string, arguments interface{}) error {
+	e := &AuditLogEntry{
+		ActorUserID: actor.FromContext(ctx).UID,
+		Action:      action,
+		Target:      target,
+	}
+	if c := requestclient.FromContext(ctx); c != nil {
+		e.ActorIP = c.IP
+		e.ActorForwardedFor = c.ForwardedFor
+	}
+
+	var err error
+	if arguments != nil {
+		e.Arguments, err = json.Marshal(arguments)
+	}
+	if err == nil {
+		err = l.Insert(ctx, e)
+	}
+	if err != nil {
+		log15.Error("Failed to record audit log entry.", "action", action, "target", target, "actorUserID", e.ActorUserID, "error", err)
+		return errors.Wrap(err, "recording audit log entry")
+	}
+	return nil
+}
+
+// AuditLogsListOptions specifies the options for listing audit log entries.
+type AuditLogsListOptions struct {
+	ActorUserID int32         // only list entries of actions performed by this user
+	Action      string        // only list entries of this action
+	Since       *time.Time    // only list entries created at or after this time
+	Until       *time.Time    // only list entries created before this time
+	AfterID     int64         // only list entries with a greater ID
+	MinAge      time.Duration // only list entries created at least this long ago (by the database's clock)
+	Ascending   bool          // list the oldest entries first
+	*LimitOffset
+}
+
+func (o AuditLogsListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o.ActorUserID != 0 {
+		conds = append(conds, sqlf.Sprintf("actor_user_id=%d", o.ActorUserID))
+	}
+	if o.Action != "" {
+		conds = append(conds, sqlf.Sprintf("action=%s", o.Action))
+	}
+	if o.Since != nil {
+		conds = append(conds, sqlf.Sprintf("created_at>=%s", *o.Since))
+	}
+	if o.Until != nil {
+		conds = append(conds, sqlf.Sprintf("created_at<%s", *o.Until))
+	}
+	if o.AfterID != 0 {
+		conds = append(conds, sqlf.Sprintf("id>%d", o.AfterID))
+	}
+	if o.MinAge != 0 {
+		conds = append(conds, sqlf.Sprintf("created_at<=now()-%s*interval '1 second'", o.MinAge.Seconds()))
+	}
+	return conds
+}
+
+// List lists the audit log entries that satisfy the options, newest first unless opt.Ascending
+// is set.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (*auditLogs) List(ctx context.Context, opt AuditLogsListOptions) ([]*AuditLogEntry, error) {
+	if Mocks.AuditLogs.List != nil {
+		return Mocks.AuditLogs.List(opt)
+	}
+
+	order := sqlf.Sprintf("DESC")
+	if opt.Ascending {
+		order = sqlf.Sprintf("ASC")
+	}
+	q := sqlf.Sprintf(`
+SELECT id, actor_user_id, actor_ip, actor_forwarded_for, action, target, arguments, created_at FROM audit_logs
+WHERE (%s)
+ORDER BY id %s
+%s`,
+		sqlf.Join(opt.sqlConditions(), ") AND ("),
+		order,
+		opt.LimitOffset.SQL(),
+	)
+
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*AuditLogEntry
+	for rows.Next() {
+		var e AuditLogEntry
+		if err := rows.Scan(&e.ID, &e.ActorUserID, &e.ActorIP, &e.ActorForwardedFor, &e.Action, &e.Target, &e.Arguments, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// Count counts the audit log entries that satisfy the options (ignoring limit and offset).
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (*auditLogs) Count(ctx context.Context, opt AuditLogsListOptions) (int, error) {
+	if Mocks.AuditLogs.Count != nil {
+		return Mocks.AuditLogs.Count(opt)
+	}
+
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM audit_logs WHERE (%s)", sqlf.Join(opt.sqlConditions(), ") AND ("))
+	var count int
+	if err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+type MockAuditLogs struct {
+	Insert func(e *AuditLogEntry) error
+	List   func(opt AuditLogsListOptions) ([]*AuditLogEntry, error)
+	Count  func(opt AuditLogsListOptions) (int, error)
+}
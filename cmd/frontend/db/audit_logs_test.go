@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/requestclient"
+)
+
+func TestAuditLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	start := time.Now().Add(-time.Minute)
+
+	ctx1 := actor.WithActor(ctx, actor.FromUser(1))
+	ctx1 = requestclient.WithClient(ctx1, &requestclient.Client{IP: "192.0.2.1", ForwardedFor: "198.51.100.1"})
+	if err := AuditLogs.Record(ctx1, AuditActionUpdateSiteConfiguration, "", map[string]int32{"lastID": 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AuditLogs.Record(ctx1, AuditActionSetUserIsSiteAdmin, "VXNlcjoy", map[string]bool{"siteAdmin": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx2 := actor.WithActor(ctx, actor.FromUser(2))
+	if err := AuditLogs.Record(ctx2, AuditActionDeleteAccessToken, "QWNjZXNzVG9rZW46MQ==", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := AuditLogs.List(ctx, AuditLogsListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 3; len(entries) != want {
+		t.Fatalf("got %d entries, want %d", len(entries), want)
+	}
+
+	// Entries are listed newest first.
+	e := entries[2]
+	if e.CreatedAt.Before(start) {
+		t.Errorf("got CreatedAt %s, want after %s", e.CreatedAt, start)
+	}
+	e.ID, e.CreatedAt = 0, time.Time{}
+	want := &AuditLogEntry{
+		ActorUserID:       1,
+		ActorIP:           "192.0.2.1",
+		ActorForwardedFor: "198.51.100.1",
+		Action:            AuditActionUpdateSiteConfiguration,
+		Arguments:         []byte(`{"lastID": 3}`),
+	}
+	if !reflect.DeepEqual(e, want) {
+		t.Errorf("got entry %+v, want %+v", e, want)
+	}
+	if got, want := string(entries[0].Arguments), "{}"; got != want {
+		t.Errorf("got arguments %q, want %q", got, want)
+	}
+
+	tests := map[string]struct {
+		opt  AuditLogsListOptions
+		want []string
+	}{
+		"actor": {
+			opt:  AuditLogsListOptions{ActorUserID: 1},
+			want: []string{AuditActionSetUserIsSiteAdmin, AuditActionUpdateSiteConfiguration},
+		},
+		"action": {
+			opt:  AuditLogsListOptions{Action: AuditActionDeleteAccessToken},
+			want: []string{AuditActionDeleteAccessToken},
+		},
+		"since": {
+			opt:  AuditLogsListOptions{Since: &start},
+			want: []string{AuditActionDeleteAccessToken, AuditActionSetUserIsSiteAdmin, AuditActionUpdateSiteConfiguration},
+		},
+		"until": {
+			opt:  AuditLogsListOptions{Until: &start},
+			want: nil,
+		},
+		"min age": {
+			opt:  AuditLogsListOptions{MinAge: time.Hour},
+			want: nil,
+		},
+		"after ID ascending": {
+			opt:  AuditLogsListOptions{AfterID: entries[2].ID, Ascending: true},
+			want: []string{AuditActionSetUserIsSiteAdmin, AuditActionDeleteAccessToken},
+		},
+		"limit": {
+			opt:  AuditLogsListOptions{LimitOffset: &LimitOffset{Limit: 1, Offset: 1}},
+			want: []string{AuditActionSetUserIsSiteAdmin},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			entries, err := AuditLogs.List(ctx, test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var actions []string
+			for _, e := range entries {
+				actions = append(actions, e.Action)
+			}
+			if !reflect.DeepEqual(actions, test.want) {
+				t.Errorf("got actions %q, want %q", actions, test.want)
+			}
+
+			if test.opt.LimitOffset != nil {
+				return
+			}
+			count, err := AuditLogs.Count(ctx, test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != len(test.want) {
+				t.Errorf("got count %d, want %d", count, len(test.want))
+			}
+		})
+	}
+
+	// 🚨 SECURITY: Entries must not be modifiable.
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE audit_logs SET actor_user_id=3"); err == nil {
+		t.Error("want error updating audit log entries")
+	}
+	if _, err := dbconn.Global.ExecContext(ctx, "DELETE FROM audit_logs"); err == nil {
+		t.Error("want error deleting audit log entries")
+	}
+}
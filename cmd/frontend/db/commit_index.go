@@ -0,0 +1,271 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// CommitIndexEntry is a commit in the commit index, with a patch of its
+// changes that contains only the file names, hunk headers and added and
+// removed lines.
+type CommitIndexEntry struct {
+	RepoID         api.RepoID
+	CommitID       api.CommitID
+	AuthorName     string
+	AuthorEmail    string
+	AuthorDate     time.Time
+	CommitterName  string
+	CommitterEmail string
+	CommitterDate  time.Time
+	Message        string
+	Diff           string
+}
+
+// commitIndex stores the commits of the default branches of repositories, so
+// that commit and diff searches do not need to run `git log` in every
+// repository.
+type commitIndex struct{}
+
+// Head returns the commit up to which the repository is indexed, or "" if it
+// is not indexed, and the index's horizon (see SetHead).
+func (s *commitIndex) Head(ctx context.Context, repoID api.RepoID) (head api.CommitID, horizon *time.Time, err error) {
+	err = dbconn.Global.QueryRowContext(ctx, `SELECT head_commit, horizon FROM commit_index_repos WHERE repo_id=$1`, repoID).Scan(&head, &horizon)
+	if err == sql.ErrNoRows {
+		return "", nil, nil
+	}
+	return head, horizon, err
+}
+
+// IndexedRepos returns the horizons of the given repositories that are
+// indexed. The horizon is the zero time if all of a repository's commits are
+// indexed.
+func (s *commitIndex) IndexedRepos(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
+	if Mocks.CommitIndex.IndexedRepos != nil {
+		return Mocks.CommitIndex.IndexedRepos(repoIDs)
+	}
+
+	ids := make([]int64, len(repoIDs))
+	for i, id := range repoIDs {
+		ids[i] = int64(id)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT repo_id, horizon FROM commit_index_repos WHERE repo_id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	indexed := map[api.RepoID]time.Time{}
+	for rows.Next() {
+		var (
+			id      api.RepoID
+			horizon *time.Time
+		)
+		if err := rows.Scan(&id, &horizon); err != nil {
+			return nil, err
+		}
+		indexed[id] = time.Time{}
+		if horizon != nil {
+			indexed[id] = *horizon
+		}
+	}
+	return indexed, rows.Err()
+}
+
+// Insert adds the commits to the index. Commits that are already indexed are
+// left unchanged.
+func (s *commitIndex) Insert(ctx context.Context, entries []*CommitIndexEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		for _, e := range entries {
+			_, err := tx.ExecContext(ctx, `
+INSERT INTO commit_index (repo_id, commit_id, author_name, author_email, author_date, committer_name, committer_email, committer_date, message, diff)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (repo_id, commit_id) DO NOTHING
+`,
+				e.RepoID, e.CommitID, e.AuthorName, e.AuthorEmail, e.AuthorDate, e.CommitterName, e.CommitterEmail, e.CommitterDate, e.Message, e.Diff,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetHead records that the repository is indexed up to the commit. If the
+// horizon is not nil, commits committed before it may be missing from the
+// index, because the repository has more history than is indexed.
+func (s *commitIndex) SetHead(ctx context.Context, repoID api.RepoID, head api.CommitID, horizon *time.Time) error {
+	_, err := dbconn.Global.ExecContext(ctx, `
+INSERT INTO commit_index_repos (repo_id, head_commit, horizon, indexed_at) VALUES ($1, $2, $3, now())
+ON CONFLICT (repo_id) DO UPDATE SET head_commit=EXCLUDED.head_commit, horizon=EXCLUDED.horizon, indexed_at=EXCLUDED.indexed_at
+`, repoID, head, horizon)
+	return err
+}
+
+// Reset removes the repository and its commits from the index.
+func (s *commitIndex) Reset(ctx context.Context, repoID api.RepoID) error {
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM commit_index_repos WHERE repo_id=$1`, repoID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM commit_index WHERE repo_id=$1`, repoID)
+		return err
+	})
+}
+
+// Enqueue schedules an update of the index of the repository. It does nothing
+// if the repository is already enqueued.
+func (s *commitIndex) Enqueue(ctx context.Context, repo api.RepoName) error {
+	_, err := dbconn.Global.ExecContext(ctx, `
+INSERT INTO commit_index_queue (repo_id)
+SELECT id FROM repo WHERE name=$1 AND deleted_at IS NULL
+ON CONFLICT (repo_id) DO NOTHING
+`, repo)
+	return err
+}
+
+// EnqueueAll schedules an update of the index of all repositories.
+func (s *commitIndex) EnqueueAll(ctx context.Context) error {
+	_, err := dbconn.Global.ExecContext(ctx, `
+INSERT INTO commit_index_queue (repo_id)
+SELECT id FROM repo WHERE deleted_at IS NULL
+ON CONFLICT (repo_id) DO NOTHING
+`)
+	return err
+}
+
+// Dequeue removes the repository that was enqueued first from the queue and
+// returns its ID. It returns false if the queue is empty.
+func (s *commitIndex) Dequeue(ctx context.Context) (api.RepoID, bool, error) {
+	var id api.RepoID
+	err := dbconn.Global.QueryRowContext(ctx, `
+DELETE FROM commit_index_queue WHERE repo_id = (
+	SELECT repo_id FROM commit_index_queue
+	ORDER BY queued_at, repo_id
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING repo_id
+`).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	return id, err == nil, err
+}
+
+// CommitIndexSearchOptions contains options for searching the commit index.
+// The patterns are POSIX regular expressions.
+type CommitIndexSearchOptions struct {
+	RepoIDs         []api.RepoID
+	IsCaseSensitive bool
+
+	// The commit's author ("name <email>"), committer and message must match
+	// all of the patterns and none of the negated patterns.
+	AuthorPatterns, NegatedAuthorPatterns       []string
+	CommitterPatterns, NegatedCommitterPatterns []string
+	MessagePatterns, NegatedMessagePatterns     []string
+
+	DiffPattern string // if set, the commit's diff must match this pattern
+
+	Before, After *time.Time // only commits committed in this (inclusive) time range
+
+	Limit  int // maximum number of commits to return (0 for no limit)
+	Offset int // number of commits to skip
+}
+
+func (o CommitIndexSearchOptions) sqlConditions() []*sqlf.Query {
+	ids := make([]int64, len(o.RepoIDs))
+	for i, id := range o.RepoIDs {
+		ids[i] = int64(id)
+	}
+	conds := []*sqlf.Query{sqlf.Sprintf("repo_id = ANY(%s)", pq.Array(ids))}
+
+	op := "~*"
+	if o.IsCaseSensitive {
+		op = "~"
+	}
+	match := func(column string, patterns []string, negated bool) {
+		for _, p := range patterns {
+			q := sqlf.Sprintf(column+" "+op+" %s", p)
+			if negated {
+				q = sqlf.Sprintf("NOT (%s)", q)
+			}
+			conds = append(conds, q)
+		}
+	}
+	const (
+		author    = "(author_name || ' <' || author_email || '>')"
+		committer = "(committer_name || ' <' || committer_email || '>')"
+	)
+	match(author, o.AuthorPatterns, false)
+	match(author, o.NegatedAuthorPatterns, true)
+	match(committer, o.CommitterPatterns, false)
+	match(committer, o.NegatedCommitterPatterns, true)
+	match("message", o.MessagePatterns, false)
+	match("message", o.NegatedMessagePatterns, true)
+	if o.DiffPattern != "" {
+		match("diff", []string{o.DiffPattern}, false)
+	}
+
+	if o.Before != nil {
+		conds = append(conds, sqlf.Sprintf("committer_date <= %s", *o.Before))
+	}
+	if o.After != nil {
+		conds = append(conds, sqlf.Sprintf("committer_date >= %s", *o.After))
+	}
+	return conds
+}
+
+// Search returns the indexed commits that satisfy the options, most recently
+// authored first.
+//
+// 🚨 SECURITY: The caller must only pass the IDs of repositories that the
+// actor may access.
+func (s *commitIndex) Search(ctx context.Context, opt CommitIndexSearchOptions) ([]*CommitIndexEntry, error) {
+	if Mocks.CommitIndex.Search != nil {
+		return Mocks.CommitIndex.Search(opt)
+	}
+
+	limit := sqlf.Sprintf("OFFSET %d", opt.Offset)
+	if opt.Limit > 0 {
+		limit = sqlf.Sprintf("LIMIT %d OFFSET %d", opt.Limit, opt.Offset)
+	}
+	q := sqlf.Sprintf(`
+SELECT repo_id, commit_id, author_name, author_email, author_date, committer_name, committer_email, committer_date, message, diff
+FROM commit_index
+WHERE (%s)
+ORDER BY author_date DESC, repo_id, commit_id
+%s`,
+		sqlf.Join(opt.sqlConditions(), ") AND ("), limit,
+	)
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*CommitIndexEntry
+	for rows.Next() {
+		var e CommitIndexEntry
+		if err := rows.Scan(&e.RepoID, &e.CommitID, &e.AuthorName, &e.AuthorEmail, &e.AuthorDate, &e.CommitterName, &e.CommitterEmail, &e.CommitterDate, &e.Message, &e.Diff); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+type MockCommitIndex struct {
+	IndexedRepos func(repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
+	Search       func(opt CommitIndexSearchOptions) ([]*CommitIndexEntry, error)
+}
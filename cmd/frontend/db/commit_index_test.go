@@ -0,0 +1,163 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestCommitIndex(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	repos := mustCreate(ctx, t, &types.Repo{Name: "a"}, &types.Repo{Name: "b"})
+	t1 := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.AddDate(0, 0, 7)
+
+	entry := func(repoID api.RepoID, commitID, author, message, diff string, date time.Time) *CommitIndexEntry {
+		return &CommitIndexEntry{
+			RepoID:         repoID,
+			CommitID:       api.CommitID(commitID),
+			AuthorName:     author,
+			AuthorEmail:    author + "@example.com",
+			AuthorDate:     date,
+			CommitterName:  author,
+			CommitterEmail: author + "@example.com",
+			CommitterDate:  date,
+			Message:        message,
+			Diff:           diff,
+		}
+	}
+	if err := CommitIndex.Insert(ctx, []*CommitIndexEntry{
+		entry(repos[0].ID, "c1", "alice", "add foo", "+foo\n", t1),
+		entry(repos[0].ID, "c2", "bob", "fix Foo", "-foo\n+bar\n", t2),
+		entry(repos[1].ID, "c3", "alice", "add baz", "+baz\n", t2),
+		entry(repos[0].ID, "c1", "alice", "duplicate", "", t1), // ignored
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := CommitIndex.SetHead(ctx, repos[0].ID, "c2", &t1); err != nil {
+		t.Fatal(err)
+	}
+
+	if head, horizon, err := CommitIndex.Head(ctx, repos[0].ID); err != nil || head != "c2" || horizon == nil || !horizon.Equal(t1) {
+		t.Errorf("got head %q, horizon %v, %v, want c2, %s", head, horizon, err, t1)
+	}
+	if head, horizon, err := CommitIndex.Head(ctx, repos[1].ID); err != nil || head != "" || horizon != nil {
+		t.Errorf("got head %q, horizon %v, %v, want none", head, horizon, err)
+	}
+	if indexed, err := CommitIndex.IndexedRepos(ctx, []api.RepoID{repos[0].ID, repos[1].ID}); err != nil || len(indexed) != 1 || !indexed[repos[0].ID].Equal(t1) {
+		t.Errorf("got indexed repos %v, %v, want only %d", indexed, err, repos[0].ID)
+	}
+
+	// Updating the head without a horizon records that all commits are
+	// indexed.
+	if err := CommitIndex.SetHead(ctx, repos[0].ID, "c2", nil); err != nil {
+		t.Fatal(err)
+	}
+	if indexed, err := CommitIndex.IndexedRepos(ctx, []api.RepoID{repos[0].ID}); err != nil || !indexed[repos[0].ID].IsZero() {
+		t.Errorf("got indexed repos %v, %v, want no horizon", indexed, err)
+	}
+
+	commitIDs := func(opt CommitIndexSearchOptions) []api.CommitID {
+		t.Helper()
+		entries, err := CommitIndex.Search(ctx, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []api.CommitID
+		for _, e := range entries {
+			ids = append(ids, e.CommitID)
+		}
+		return ids
+	}
+	all := []api.RepoID{repos[0].ID, repos[1].ID}
+	tests := map[string]struct {
+		opt  CommitIndexSearchOptions
+		want []api.CommitID
+	}{
+		"all":               {CommitIndexSearchOptions{RepoIDs: all}, []api.CommitID{"c2", "c3", "c1"}},
+		"repo":              {CommitIndexSearchOptions{RepoIDs: all[1:]}, []api.CommitID{"c3"}},
+		"author":            {CommitIndexSearchOptions{RepoIDs: all, AuthorPatterns: []string{"alice@"}}, []api.CommitID{"c3", "c1"}},
+		"negated author":    {CommitIndexSearchOptions{RepoIDs: all, NegatedAuthorPatterns: []string{"alice"}}, []api.CommitID{"c2"}},
+		"message":           {CommitIndexSearchOptions{RepoIDs: all, MessagePatterns: []string{"foo"}}, []api.CommitID{"c2", "c1"}},
+		"case sensitive":    {CommitIndexSearchOptions{RepoIDs: all, MessagePatterns: []string{"foo"}, IsCaseSensitive: true}, []api.CommitID{"c1"}},
+		"diff":              {CommitIndexSearchOptions{RepoIDs: all, DiffPattern: "ba[rz]"}, []api.CommitID{"c2", "c3"}},
+		"before":            {CommitIndexSearchOptions{RepoIDs: all, Before: &t1}, []api.CommitID{"c1"}},
+		"after":             {CommitIndexSearchOptions{RepoIDs: all, After: &t2}, []api.CommitID{"c2", "c3"}},
+		"limit":             {CommitIndexSearchOptions{RepoIDs: all, Limit: 1}, []api.CommitID{"c2"}},
+		"offset":            {CommitIndexSearchOptions{RepoIDs: all, Limit: 1, Offset: 1}, []api.CommitID{"c3"}},
+		"no matching repos": {CommitIndexSearchOptions{}, nil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := commitIDs(test.opt)
+			if len(got) != len(test.want) {
+				t.Fatalf("got %v, want %v", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("got %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+
+	if err := CommitIndex.Reset(ctx, repos[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if head, _, err := CommitIndex.Head(ctx, repos[0].ID); err != nil || head != "" {
+		t.Errorf("got head %q, %v after reset, want none", head, err)
+	}
+	if got := commitIDs(CommitIndexSearchOptions{RepoIDs: all}); len(got) != 1 || got[0] != "c3" {
+		t.Errorf("got %v after reset, want [c3]", got)
+	}
+}
+
+func TestCommitIndex_queue(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	repos := mustCreate(ctx, t, &types.Repo{Name: "a"}, &types.Repo{Name: "b"})
+
+	dequeueAll := func() (ids []api.RepoID) {
+		t.Helper()
+		for {
+			id, ok, err := CommitIndex.Dequeue(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				return ids
+			}
+			ids = append(ids, id)
+		}
+	}
+
+	for _, name := range []api.RepoName{"b", "a", "b", "unknown"} {
+		if err := CommitIndex.Enqueue(ctx, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := dequeueAll(), []api.RepoID{repos[1].ID, repos[0].ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got dequeued %v, want %v", got, want)
+	}
+
+	if err := CommitIndex.EnqueueAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := dequeueAll(); len(got) != 2 {
+		t.Errorf("got dequeued %v after enqueuing all, want both repositories", got)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
@@ -37,8 +38,10 @@ type ExternalServicesStore struct {
 // external services.
 var ExternalServiceKinds = map[string]ExternalServiceKind{
 	extsvc.KindAWSCodeCommit:   {CodeHost: true, JSONSchema: schema.AWSCodeCommitSchemaJSON},
+	extsvc.KindAzureDevOps:     {CodeHost: true, JSONSchema: schema.AzureDevOpsSchemaJSON},
 	extsvc.KindBitbucketCloud:  {CodeHost: true, JSONSchema: schema.BitbucketCloudSchemaJSON},
 	extsvc.KindBitbucketServer: {CodeHost: true, JSONSchema: schema.BitbucketServerSchemaJSON},
+	extsvc.KindGerrit:          {CodeHost: true, JSONSchema: schema.GerritSchemaJSON},
 	extsvc.KindGitHub:          {CodeHost: true, JSONSchema: schema.GitHubSchemaJSON},
 	extsvc.KindGitLab:          {CodeHost: true, JSONSchema: schema.GitLabSchemaJSON},
 	extsvc.KindGitolite:        {CodeHost: true, JSONSchema: schema.GitoliteSchemaJSON},
@@ -56,7 +59,8 @@ type ExternalServiceKind struct {
 
 // ExternalServicesListOptions contains options for listing external services.
 type ExternalServicesListOptions struct {
-	Kinds []string
+	Kinds  []string
+	RepoID api.RepoID // only list the external services that the repository is synced from
 	*LimitOffset
 }
 
@@ -69,6 +73,9 @@ func (o ExternalServicesListOptions) sqlConditions() []*sqlf.Query {
 		}
 		conds = append(conds, sqlf.Sprintf("kind IN (%s)", sqlf.Join(kinds, ",")))
 	}
+	if o.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("id IN (SELECT split_part(jsonb_object_keys(sources), ':', 3)::bigint FROM repo WHERE id=%s)", o.RepoID))
+	}
 	return conds
 }
 
@@ -399,6 +406,52 @@ func (e *ExternalServicesStore) GetByID(ctx context.Context, id int64) (*types.E
 	return ess[0], nil
 }
 
+// GetSyncState returns the sync state of the external service with the given
+// id, or nil if it hasn't been synced yet.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (e *ExternalServicesStore) GetSyncState(ctx context.Context, id int64) (*types.ExternalServiceSyncState, error) {
+	if Mocks.ExternalServices.GetSyncState != nil {
+		return Mocks.ExternalServices.GetSyncState(id)
+	}
+
+	q := sqlf.Sprintf(`
+		SELECT
+		  external_service_id,
+		  last_sync_started_at,
+		  last_sync_finished_at,
+		  next_sync_at,
+		  last_sync_error,
+		  repos_sourced,
+		  repos_added,
+		  repos_modified,
+		  repos_deleted
+		FROM external_service_sync_states
+		WHERE external_service_id = %s`,
+		id,
+	)
+
+	var st types.ExternalServiceSyncState
+	err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(
+		&st.ExternalServiceID,
+		&st.LastSyncStartedAt,
+		&st.LastSyncFinishedAt,
+		&st.NextSyncAt,
+		&st.LastSyncError,
+		&st.ReposSourced,
+		&st.ReposAdded,
+		&st.ReposModified,
+		&st.ReposDeleted,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
 // List returns all external services.
 //
 // 🚨 SECURITY: The caller must ensure that the actor is a site admin.
@@ -468,6 +521,17 @@ func (e *ExternalServicesStore) ListAWSCodeCommitConnections(ctx context.Context
 	return connections, nil
 }
 
+// ListAzureDevOpsConnections returns a list of AzureDevOps configs.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (e *ExternalServicesStore) ListAzureDevOpsConnections(ctx context.Context) ([]*types.AzureDevOpsConnection, error) {
+	var connections []*types.AzureDevOpsConnection
+	if err := e.listConfigs(ctx, extsvc.KindAzureDevOps, &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
 // ListBitbucketCloudConnections returns a list of BitbucketCloud configs.
 //
 // 🚨 SECURITY: The caller must ensure that the actor is a site admin.
@@ -490,6 +554,17 @@ func (e *ExternalServicesStore) ListBitbucketServerConnections(ctx context.Conte
 	return connections, nil
 }
 
+// ListGerritConnections returns a list of GerritConnection configs.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (e *ExternalServicesStore) ListGerritConnections(ctx context.Context) ([]*types.GerritConnection, error) {
+	var connections []*types.GerritConnection
+	if err := e.listConfigs(ctx, extsvc.KindGerrit, &connections); err != nil {
+		return nil, err
+	}
+	return connections, nil
+}
+
 // ListGitHubConnections returns a list of GitHubConnection configs.
 //
 // 🚨 SECURITY: The caller must ensure that the actor is a site admin.
@@ -591,9 +666,10 @@ func (*ExternalServicesStore) Count(ctx context.Context, opt ExternalServicesLis
 
 // MockExternalServices mocks the external services store.
 type MockExternalServices struct {
-	Create  func(ctx context.Context, confGet func() *conf.Unified, externalService *types.ExternalService) error
-	Delete  func(ctx context.Context, id int64) error
-	GetByID func(id int64) (*types.ExternalService, error)
-	List    func(opt ExternalServicesListOptions) ([]*types.ExternalService, error)
-	Update  func(ctx context.Context, ps []schema.AuthProviders, id int64, update *ExternalServiceUpdate) error
+	Create       func(ctx context.Context, confGet func() *conf.Unified, externalService *types.ExternalService) error
+	Delete       func(ctx context.Context, id int64) error
+	GetByID      func(id int64) (*types.ExternalService, error)
+	GetSyncState func(id int64) (*types.ExternalServiceSyncState, error)
+	List         func(opt ExternalServicesListOptions) ([]*types.ExternalService, error)
+	Update       func(ctx context.Context, ps []schema.AuthProviders, id int64, update *ExternalServiceUpdate) error
 }
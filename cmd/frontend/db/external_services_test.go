@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -287,6 +288,58 @@ func TestExternalServicesStore_GetByID(t *testing.T) {
 	}
 }
 
+func TestExternalServicesStore_GetSyncState(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	// Create a new external service
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	err := (&ExternalServicesStore{}).Create(ctx, confGet, es)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Should get no sync state before the first sync
+	st, err := (&ExternalServicesStore{}).GetSyncState(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st != nil {
+		t.Fatalf("want no sync state but got %+v", st)
+	}
+
+	_, err = dbconn.Global.ExecContext(ctx, `
+INSERT INTO external_service_sync_states (external_service_id, last_sync_started_at, last_sync_finished_at, last_sync_error, repos_sourced)
+VALUES ($1, now(), now(), 'boom', 3)`, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err = (&ExternalServicesStore{}).GetSyncState(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st == nil {
+		t.Fatal("want sync state but got nil")
+	}
+	if st.LastSyncStartedAt == nil || st.LastSyncFinishedAt == nil || st.NextSyncAt != nil {
+		t.Errorf("unexpected sync times: %+v", st)
+	}
+	if st.LastSyncError != "boom" || st.ReposSourced != 3 {
+		t.Errorf("unexpected sync state: %+v", st)
+	}
+}
+
 func TestExternalServicesStore_List(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -0,0 +1,180 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
+)
+
+// InsightSeriesPoint is the number of matches of a code insights series'
+// query in a repository at a point in time.
+type InsightSeriesPoint struct {
+	SeriesID string
+	Query    string // the query of the series when the point was recorded
+	RepoID   api.RepoID
+	Time     time.Time
+	Value    int32
+}
+
+type insightSeriesPoints struct{}
+
+// Upsert records the points in a single transaction, replacing any points
+// previously recorded for the same series, query, repository and time.
+func (s *insightSeriesPoints) Upsert(ctx context.Context, points ...*InsightSeriesPoint) error {
+	if Mocks.InsightSeriesPoints.Upsert != nil {
+		return Mocks.InsightSeriesPoints.Upsert(points...)
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	return dbutil.Transaction(ctx, dbconn.Global, func(tx *sql.Tx) error {
+		for _, p := range points {
+			_, err := tx.ExecContext(ctx, `
+INSERT INTO insight_series_points (series_id, query, repo_id, "time", value)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (series_id, query, repo_id, "time") DO UPDATE SET value=EXCLUDED.value
+`,
+				p.SeriesID, p.Query, p.RepoID, p.Time, p.Value,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RecordedTimes returns the distinct times for which points were recorded for
+// the series with the given query, in ascending order.
+func (s *insightSeriesPoints) RecordedTimes(ctx context.Context, seriesID, query string) ([]time.Time, error) {
+	if Mocks.InsightSeriesPoints.RecordedTimes != nil {
+		return Mocks.InsightSeriesPoints.RecordedTimes(seriesID, query)
+	}
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT DISTINCT "time" FROM insight_series_points WHERE series_id=$1 AND query=$2 ORDER BY "time"`, seriesID, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var times []time.Time
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, rows.Err()
+}
+
+// InsightSeriesPointsListOptions contains options for listing code insights
+// series points.
+type InsightSeriesPointsListOptions struct {
+	SeriesID string
+	Query    string
+	From, To *time.Time // only list points in this (inclusive) time range
+}
+
+func (o InsightSeriesPointsListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{
+		sqlf.Sprintf("series_id=%s", o.SeriesID),
+		sqlf.Sprintf("query=%s", o.Query),
+	}
+	if o.From != nil {
+		conds = append(conds, sqlf.Sprintf(`"time" >= %s`, *o.From))
+	}
+	if o.To != nil {
+		conds = append(conds, sqlf.Sprintf(`"time" <= %s`, *o.To))
+	}
+	return conds
+}
+
+// List lists the points that satisfy the options, ordered by time.
+//
+// 🚨 SECURITY: The points contain information about all repositories that
+// the series' query matched. The caller must only show the points of
+// repositories that the actor may access, which ListVisible does.
+func (s *insightSeriesPoints) List(ctx context.Context, opt InsightSeriesPointsListOptions) ([]*InsightSeriesPoint, error) {
+	if Mocks.InsightSeriesPoints.List != nil {
+		return Mocks.InsightSeriesPoints.List(opt)
+	}
+
+	q := sqlf.Sprintf(`SELECT series_id, query, repo_id, "time", value FROM insight_series_points WHERE (%s) ORDER BY "time", repo_id`,
+		sqlf.Join(opt.sqlConditions(), ") AND ("),
+	)
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []*InsightSeriesPoint
+	for rows.Next() {
+		var p InsightSeriesPoint
+		if err := rows.Scan(&p.SeriesID, &p.Query, &p.RepoID, &p.Time, &p.Value); err != nil {
+			return nil, err
+		}
+		points = append(points, &p)
+	}
+	return points, rows.Err()
+}
+
+// ListVisible is like List, but only returns the points of repositories that
+// the actor in ctx may access.
+func (s *insightSeriesPoints) ListVisible(ctx context.Context, opt InsightSeriesPointsListOptions) ([]*InsightSeriesPoint, error) {
+	points, err := s.List(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[api.RepoID]bool{}
+	var ids []api.RepoID
+	for _, p := range points {
+		if !seen[p.RepoID] {
+			seen[p.RepoID] = true
+			ids = append(ids, p.RepoID)
+		}
+	}
+
+	// 🚨 SECURITY: GetByIDs filters out the repositories that the actor may
+	// not access.
+	repos, err := Repos.GetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	visible := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, r := range repos {
+		visible[r.ID] = r
+	}
+
+	filtered := points[:0]
+	for _, p := range points {
+		if visible[p.RepoID] != nil {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+// DeleteOtherQueries deletes the points of the series that were recorded for a
+// query other than the given one, because the series' query was changed.
+func (s *insightSeriesPoints) DeleteOtherQueries(ctx context.Context, seriesID, query string) error {
+	if Mocks.InsightSeriesPoints.DeleteOtherQueries != nil {
+		return Mocks.InsightSeriesPoints.DeleteOtherQueries(seriesID, query)
+	}
+	_, err := dbconn.Global.ExecContext(ctx, `DELETE FROM insight_series_points WHERE series_id=$1 AND query<>$2`, seriesID, query)
+	return err
+}
+
+type MockInsightSeriesPoints struct {
+	Upsert             func(points ...*InsightSeriesPoint) error
+	RecordedTimes      func(seriesID, query string) ([]time.Time, error)
+	DeleteOtherQueries func(seriesID, query string) error
+	List               func(opt InsightSeriesPointsListOptions) ([]*InsightSeriesPoint, error)
+}
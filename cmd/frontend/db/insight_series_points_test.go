@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestInsightSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	repos := mustCreate(ctx, t, &types.Repo{Name: "a"}, &types.Repo{Name: "b"})
+	t1 := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.AddDate(0, 0, 7)
+
+	for _, p := range []*InsightSeriesPoint{
+		{SeriesID: "s", Query: "foo", RepoID: repos[0].ID, Time: t1, Value: 1},
+		{SeriesID: "s", Query: "foo", RepoID: repos[1].ID, Time: t1, Value: 2},
+		{SeriesID: "s", Query: "foo", RepoID: repos[0].ID, Time: t2, Value: 3},
+		{SeriesID: "s", Query: "foo", RepoID: repos[0].ID, Time: t2, Value: 4}, // replaces the previous point
+		{SeriesID: "s", Query: "old", RepoID: repos[0].ID, Time: t1, Value: 5},
+	} {
+		if err := InsightSeriesPoints.Upsert(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	times, err := InsightSeriesPoints.RecordedTimes(ctx, "s", "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(times) != 2 || !times[0].Equal(t1) || !times[1].Equal(t2) {
+		t.Errorf("got recorded times %v, want [%s %s]", times, t1, t2)
+	}
+
+	points, err := InsightSeriesPoints.ListVisible(ctx, InsightSeriesPointsListOptions{SeriesID: "s", Query: "foo", From: &t2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || points[0].Value != 4 {
+		t.Errorf("got points %+v, want a single point with value 4", points)
+	}
+
+	if err := InsightSeriesPoints.DeleteOtherQueries(ctx, "s", "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if times, err := InsightSeriesPoints.RecordedTimes(ctx, "s", "old"); err != nil || len(times) != 0 {
+		t.Errorf("got %v, %v for the old query, want no recorded times", times, err)
+	}
+}
@@ -5,11 +5,14 @@ var Mocks MockStores
 // MockStores has a field for each store interface with the concrete mock type (to obviate the need for tedious type assertions in test code).
 type MockStores struct {
 	AccessTokens MockAccessTokens
+	AuditLogs    MockAuditLogs
 
 	Repos         MockRepos
 	Orgs          MockOrgs
 	OrgMembers    MockOrgMembers
 	SavedSearches MockSavedSearches
+	SearchExports MockSearchExports
+	SearchHistory MockSearchHistory
 	Settings      MockSettings
 	Users         MockUsers
 	UserEmails    MockUserEmails
@@ -22,5 +25,9 @@ type MockStores struct {
 
 	ExternalServices MockExternalServices
 
+	InsightSeriesPoints MockInsightSeriesPoints
+
+	CommitIndex MockCommitIndex
+
 	Authz MockAuthz
 }
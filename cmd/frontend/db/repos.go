@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	regexpsyntax "regexp/syntax"
 	"strings"
@@ -117,6 +118,40 @@ func (s *repos) GetByIDs(ctx context.Context, ids ...api.RepoID) ([]*types.Repo,
 	return s.getReposBySQL(ctx, true, q)
 }
 
+// GetStars returns the number of stars of the repositories with the given
+// IDs on their code hosts. Repositories that do not exist are omitted.
+func (s *repos) GetStars(ctx context.Context, ids ...api.RepoID) (map[api.RepoID]int, error) {
+	if Mocks.Repos.GetStars != nil {
+		return Mocks.Repos.GetStars(ctx, ids...)
+	}
+
+	stars := make(map[api.RepoID]int, len(ids))
+	if len(ids) == 0 {
+		return stars, nil
+	}
+
+	items := make([]*sqlf.Query, len(ids))
+	for i := range ids {
+		items[i] = sqlf.Sprintf("%d", ids[i])
+	}
+	q := sqlf.Sprintf("SELECT id, stars FROM repo WHERE id IN (%s) AND deleted_at IS NULL", sqlf.Join(items, ","))
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id api.RepoID
+		var n int
+		if err := rows.Scan(&id, &n); err != nil {
+			return nil, err
+		}
+		stars[id] = n
+	}
+	return stars, rows.Err()
+}
+
 func (s *repos) Count(ctx context.Context, opt ReposListOptions) (int, error) {
 	if Mocks.Repos.Count != nil {
 		return Mocks.Repos.Count(ctx, opt)
@@ -154,6 +189,10 @@ var getBySQLColumns = []string{
 	"language",
 	"fork",
 	"archived",
+	"topics",
+	"stars",
+	"pushed_at",
+	"license",
 }
 
 func (s *repos) getBySQL(ctx context.Context, querySuffix *sqlf.Query) ([]*types.Repo, error) {
@@ -210,7 +249,8 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		)
 	}
 
-	return rows.Scan(
+	var topics []byte
+	err = rows.Scan(
 		&r.ID,
 		&r.Name,
 		&r.Private,
@@ -222,7 +262,23 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		&r.Language,
 		&r.Fork,
 		&r.Archived,
+		&topics,
+		&r.Stars,
+		&dbutil.NullTime{Time: &r.PushedAt},
+		&r.License,
 	)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(topics, &r.Topics); err != nil {
+		return errors.Wrap(err, "scanRepo: failed to unmarshal topics")
+	}
+	if len(r.Topics) == 0 {
+		r.Topics = nil
+	}
+
+	return nil
 }
 
 // ReposListOptions specifies the options for listing repositories.
@@ -270,6 +326,32 @@ type ReposListOptions struct {
 	// OnlyPrivate excludes non-private repositories from the list.
 	OnlyPrivate bool
 
+	// Topics is a list of topics, all of which must be topics of all
+	// repositories returned in the list.
+	Topics []string
+
+	// ExcludeTopics excludes repositories with any of the given topics from
+	// the list.
+	ExcludeTopics []string
+
+	// Languages is a list of languages, all of which must match the primary
+	// language of all repositories returned in the list. Matching is case
+	// insensitive.
+	Languages []string
+
+	// ExcludeLanguages excludes repositories whose primary language matches
+	// any of the given languages from the list.
+	ExcludeLanguages []string
+
+	// Licenses is a list of SPDX license identifiers, all of which must match
+	// the license of all repositories returned in the list. Matching is case
+	// insensitive.
+	Licenses []string
+
+	// ExcludeLicenses excludes repositories whose license matches any of the
+	// given SPDX license identifiers from the list.
+	ExcludeLicenses []string
+
 	// OnlyRepoIDs skips fetching of RepoFields in each Repo.
 	OnlyRepoIDs bool
 
@@ -318,6 +400,8 @@ type RepoListColumn string
 const (
 	RepoListCreatedAt RepoListColumn = "created_at"
 	RepoListName      RepoListColumn = "name"
+	RepoListStars     RepoListColumn = "stars"
+	RepoListID        RepoListColumn = "id"
 )
 
 // List lists repositories in the Sourcegraph repository
@@ -464,6 +548,24 @@ func (*repos) listSQL(opt ReposListOptions) (conds []*sqlf.Query, err error) {
 	if opt.OnlyPrivate {
 		conds = append(conds, sqlf.Sprintf("private"))
 	}
+	for _, topic := range opt.Topics {
+		conds = append(conds, sqlf.Sprintf("topics ? %s", topic))
+	}
+	for _, topic := range opt.ExcludeTopics {
+		conds = append(conds, sqlf.Sprintf("NOT topics ? %s", topic))
+	}
+	for _, language := range opt.Languages {
+		conds = append(conds, sqlf.Sprintf("lower(language) = lower(%s)", language))
+	}
+	for _, language := range opt.ExcludeLanguages {
+		conds = append(conds, sqlf.Sprintf("lower(COALESCE(language, '')) != lower(%s)", language))
+	}
+	for _, license := range opt.Licenses {
+		conds = append(conds, sqlf.Sprintf("lower(license) = lower(%s)", license))
+	}
+	for _, license := range opt.ExcludeLicenses {
+		conds = append(conds, sqlf.Sprintf("lower(license) != lower(%s)", license))
+	}
 	if len(opt.Names) > 0 {
 		queries := make([]*sqlf.Query, 0, len(opt.Names))
 		for _, repo := range opt.Names {
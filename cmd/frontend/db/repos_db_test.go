@@ -235,6 +235,29 @@ func TestRepos_GetByIDs(t *testing.T) {
 	}
 }
 
+func TestRepos_GetStars(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	repos := mustCreate(ctx, t, &types.Repo{Name: "a"}, &types.Repo{Name: "b"})
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE repo SET stars=42 WHERE id=$1", repos[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	stars, err := Repos.GetStars(ctx, repos[0].ID, repos[1].ID, 404)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[api.RepoID]int{repos[0].ID: 42, repos[1].ID: 0}
+	if !reflect.DeepEqual(stars, want) {
+		t.Errorf("got %v, want %v", stars, want)
+	}
+}
+
 func TestRepos_List(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
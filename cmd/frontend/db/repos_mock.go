@@ -13,6 +13,7 @@ type MockRepos struct {
 	Get       func(ctx context.Context, repo api.RepoID) (*types.Repo, error)
 	GetByName func(ctx context.Context, repo api.RepoName) (*types.Repo, error)
 	GetByIDs  func(ctx context.Context, ids ...api.RepoID) ([]*types.Repo, error)
+	GetStars  func(ctx context.Context, ids ...api.RepoID) (map[api.RepoID]int, error)
 	List      func(v0 context.Context, v1 ReposListOptions) ([]*types.Repo, error)
 	Count     func(ctx context.Context, opt ReposListOptions) (int, error)
 }
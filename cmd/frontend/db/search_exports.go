@@ -0,0 +1,328 @@
+package db
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// SearchExport describes an asynchronous job that writes all results of a
+// search query to a downloadable file.
+type SearchExport struct {
+	ID          int64
+	UserID      int32 // the user who requested the export and as whom the search is run
+	Query       string
+	Version     string
+	PatternType *string
+	Format      string // one of the SearchExportFormat* values
+	State       string // one of the SearchExportState* values
+	ResultCount int32
+	Error       *string
+	Attempt     int32 // incremented each time a worker starts processing the export
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+}
+
+// All search export formats.
+const (
+	SearchExportFormatCSV   = "csv"
+	SearchExportFormatJSONL = "jsonl"
+)
+
+// All search export states.
+const (
+	SearchExportStateQueued     = "queued"
+	SearchExportStateProcessing = "processing"
+	SearchExportStateCompleted  = "completed"
+	SearchExportStateErrored    = "errored"
+	SearchExportStateCanceled   = "canceled"
+)
+
+// ErrSearchExportNotFound occurs when a database operation expects a specific
+// search export to exist but it does not exist.
+var ErrSearchExportNotFound = errors.New("search export not found")
+
+// ErrSearchExportNotProcessing occurs when a worker writes to a search export
+// that it is no longer processing, for example because it was canceled or
+// restarted by another worker.
+var ErrSearchExportNotProcessing = errors.New("search export is not processing")
+
+// searchExportStaleAfter is how long a processing search export may go
+// without a heartbeat before it is assumed that its worker died, and another
+// worker restarts it.
+const searchExportStaleAfter = "10 minutes"
+
+type searchExports struct{}
+
+// Create queues a search export of the given query for the user.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is the user or a site admin.
+func (s *searchExports) Create(ctx context.Context, userID int32, query, version string, patternType *string, format string) (*SearchExport, error) {
+	if Mocks.SearchExports.Create != nil {
+		return Mocks.SearchExports.Create(userID, query, version, patternType, format)
+	}
+
+	q := sqlf.Sprintf(`
+INSERT INTO search_exports (user_id, query, version, pattern_type, format)
+VALUES (%s, %s, %s, %s, %s)
+RETURNING `+searchExportColumns,
+		userID, query, version, patternType, format,
+	)
+	return s.getOne(ctx, q)
+}
+
+// GetByID retrieves the search export with the given ID.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to view this search export.
+func (s *searchExports) GetByID(ctx context.Context, id int64) (*SearchExport, error) {
+	if Mocks.SearchExports.GetByID != nil {
+		return Mocks.SearchExports.GetByID(id)
+	}
+
+	q := sqlf.Sprintf(`SELECT `+searchExportColumns+` FROM search_exports WHERE id=%d`, id)
+	return s.getOne(ctx, q)
+}
+
+// SearchExportsListOptions contains options for listing search exports.
+type SearchExportsListOptions struct {
+	UserID int32 // only list search exports requested by this user
+	*LimitOffset
+}
+
+func (o SearchExportsListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id=%d", o.UserID))
+	}
+	return conds
+}
+
+// List lists the search exports that satisfy the options, most recent first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to list with the specified
+// options.
+func (s *searchExports) List(ctx context.Context, opt SearchExportsListOptions) ([]*SearchExport, error) {
+	q := sqlf.Sprintf(`SELECT `+searchExportColumns+` FROM search_exports WHERE (%s) ORDER BY id DESC %s`,
+		sqlf.Join(opt.sqlConditions(), ") AND ("),
+		opt.LimitOffset.SQL(),
+	)
+	return s.list(ctx, q)
+}
+
+// Count counts the search exports that satisfy the options (ignoring limit and offset).
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to count the search exports.
+func (s *searchExports) Count(ctx context.Context, opt SearchExportsListOptions) (int, error) {
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM search_exports WHERE (%s)", sqlf.Join(opt.sqlConditions(), ") AND ("))
+	var count int
+	if err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Dequeue marks the oldest queued search export as processing and returns
+// it. Processing search exports that have not had a heartbeat in a while are
+// restarted from scratch, because their worker most likely died. Each call
+// starts a new attempt, and only the returned attempt may write to the search
+// export. Dequeue returns nil if there is nothing to do.
+func (s *searchExports) Dequeue(ctx context.Context) (*SearchExport, error) {
+	q := sqlf.Sprintf(`
+WITH candidate AS (
+	SELECT id FROM search_exports
+	WHERE state=%s OR (state=%s AND updated_at < now() - interval '`+searchExportStaleAfter+`')
+	ORDER BY id
+	LIMIT 1
+	FOR UPDATE SKIP LOCKED
+),
+deleted_chunks AS (
+	DELETE FROM search_export_chunks WHERE search_export_id IN (SELECT id FROM candidate)
+)
+UPDATE search_exports SET state=%s, result_count=0, attempt=attempt+1, started_at=now(), updated_at=now()
+WHERE id IN (SELECT id FROM candidate)
+RETURNING `+searchExportColumns,
+		SearchExportStateQueued, SearchExportStateProcessing, SearchExportStateProcessing,
+	)
+	e, err := s.getOne(ctx, q)
+	if err == ErrSearchExportNotFound {
+		return nil, nil
+	}
+	return e, err
+}
+
+// Heartbeat records that the given attempt is still processing the search
+// export, so that it is not restarted by another worker. It returns
+// ErrSearchExportNotProcessing if the attempt is no longer processing the
+// search export, in which case the caller should stop working on it.
+func (s *searchExports) Heartbeat(ctx context.Context, id int64, attempt int32) error {
+	q := sqlf.Sprintf(`
+UPDATE search_exports SET updated_at=now()
+WHERE id=%d AND state=%s AND attempt=%d
+`,
+		id, SearchExportStateProcessing, attempt,
+	)
+	return s.exec(ctx, q, ErrSearchExportNotProcessing)
+}
+
+// AppendChunk appends data containing resultCount results to the file of the
+// search export that the given attempt is processing. It returns
+// ErrSearchExportNotProcessing if the attempt is no longer processing the
+// search export, in which case the caller should stop working on it.
+func (s *searchExports) AppendChunk(ctx context.Context, id int64, attempt int32, data []byte, resultCount int32) error {
+	q := sqlf.Sprintf(`
+WITH export AS (
+	UPDATE search_exports SET result_count=result_count+%d, updated_at=now()
+	WHERE id=%d AND state=%s AND attempt=%d
+	RETURNING id
+)
+INSERT INTO search_export_chunks (search_export_id, seq, data)
+SELECT export.id, COALESCE((SELECT MAX(seq) FROM search_export_chunks WHERE search_export_id=export.id), 0) + 1, %s
+FROM export
+`,
+		resultCount, id, SearchExportStateProcessing, attempt, data,
+	)
+	return s.exec(ctx, q, ErrSearchExportNotProcessing)
+}
+
+// Finish marks the search export that the given attempt is processing as
+// completed, or as errored with the given error message if errMsg is non-nil.
+// It returns ErrSearchExportNotProcessing if the attempt is no longer
+// processing the search export.
+func (s *searchExports) Finish(ctx context.Context, id int64, attempt int32, errMsg *string) error {
+	state := SearchExportStateCompleted
+	if errMsg != nil {
+		state = SearchExportStateErrored
+	}
+	q := sqlf.Sprintf(`
+UPDATE search_exports SET state=%s, error=%s, updated_at=now(), finished_at=now()
+WHERE id=%d AND state=%s AND attempt=%d
+`,
+		state, errMsg, id, SearchExportStateProcessing, attempt,
+	)
+	return s.exec(ctx, q, ErrSearchExportNotProcessing)
+}
+
+// Cancel cancels the search export if it is queued or processing. Its worker
+// notices the cancellation when it next appends results.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is the user who requested the export or a
+// site admin.
+func (s *searchExports) Cancel(ctx context.Context, id int64) error {
+	if Mocks.SearchExports.Cancel != nil {
+		return Mocks.SearchExports.Cancel(id)
+	}
+
+	q := sqlf.Sprintf(`
+UPDATE search_exports SET state=%s, updated_at=now(), finished_at=now()
+WHERE id=%d AND state IN (%s, %s)
+`,
+		SearchExportStateCanceled, id, SearchExportStateQueued, SearchExportStateProcessing,
+	)
+	return s.exec(ctx, q, errors.New("search export has already finished"))
+}
+
+// WriteContents writes the file of the search export to w.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to view this search export.
+func (s *searchExports) WriteContents(ctx context.Context, id int64, w io.Writer) error {
+	if Mocks.SearchExports.WriteContents != nil {
+		return Mocks.SearchExports.WriteContents(id, w)
+	}
+
+	rows, err := dbconn.Global.QueryContext(ctx, `SELECT data FROM search_export_chunks WHERE search_export_id=$1 ORDER BY seq`, id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// DeleteOlderThan deletes the search exports that were created before the
+// given time, along with their files.
+func (s *searchExports) DeleteOlderThan(ctx context.Context, t time.Time) error {
+	_, err := dbconn.Global.ExecContext(ctx, `DELETE FROM search_exports WHERE created_at < $1`, t)
+	return err
+}
+
+const searchExportColumns = `id, user_id, query, version, pattern_type, format, state, result_count, error, attempt, created_at, started_at, finished_at`
+
+func (s *searchExports) getOne(ctx context.Context, q *sqlf.Query) (*SearchExport, error) {
+	results, err := s.list(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrSearchExportNotFound
+	}
+	return results[0], nil
+}
+
+func (s *searchExports) list(ctx context.Context, q *sqlf.Query) ([]*SearchExport, error) {
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*SearchExport
+	for rows.Next() {
+		var e SearchExport
+		if err := rows.Scan(
+			&e.ID,
+			&e.UserID,
+			&e.Query,
+			&e.Version,
+			&e.PatternType,
+			&e.Format,
+			&e.State,
+			&e.ResultCount,
+			&e.Error,
+			&e.Attempt,
+			&e.CreatedAt,
+			&e.StartedAt,
+			&e.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, &e)
+	}
+	return results, rows.Err()
+}
+
+// exec executes q and returns errNoRows if it did not affect any rows.
+func (s *searchExports) exec(ctx context.Context, q *sqlf.Query, errNoRows error) error {
+	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	nrows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if nrows == 0 {
+		return errNoRows
+	}
+	return nil
+}
+
+type MockSearchExports struct {
+	Create        func(userID int32, query, version string, patternType *string, format string) (*SearchExport, error)
+	GetByID       func(id int64) (*SearchExport, error)
+	Cancel        func(id int64) error
+	WriteContents func(id int64, w io.Writer) error
+}
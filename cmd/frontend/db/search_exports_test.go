@@ -0,0 +1,127 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestSearchExports(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	u, err := Users.Create(ctx, NewUser{Username: "u"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := SearchExports.Create(ctx, u.ID, "foo", "V2", nil, SearchExportFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.State != SearchExportStateQueued {
+		t.Errorf("got state %q, want %q", e.State, SearchExportStateQueued)
+	}
+
+	// Chunks can only be appended while the export is processing.
+	if err := SearchExports.AppendChunk(ctx, e.ID, 0, []byte("a"), 1); err != ErrSearchExportNotProcessing {
+		t.Fatalf("got error %v, want %v", err, ErrSearchExportNotProcessing)
+	}
+
+	dequeued, err := SearchExports.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dequeued == nil || dequeued.ID != e.ID || dequeued.State != SearchExportStateProcessing {
+		t.Fatalf("got dequeued export %+v, want export %d in state %q", dequeued, e.ID, SearchExportStateProcessing)
+	}
+	if again, err := SearchExports.Dequeue(ctx); err != nil || again != nil {
+		t.Fatalf("got %+v, %v from second Dequeue, want nil, nil", again, err)
+	}
+	if err := SearchExports.AppendChunk(ctx, e.ID, dequeued.Attempt, []byte("stale\n"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// A stale export is restarted from scratch, and its previous attempt may
+	// no longer write to it.
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE search_exports SET updated_at = now() - interval '1 hour' WHERE id=$1", e.ID); err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := SearchExports.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restarted == nil || restarted.ID != e.ID || restarted.Attempt != dequeued.Attempt+1 {
+		t.Fatalf("got restarted export %+v, want export %d with attempt %d", restarted, e.ID, dequeued.Attempt+1)
+	}
+	if err := SearchExports.AppendChunk(ctx, e.ID, dequeued.Attempt, []byte("a"), 1); err != ErrSearchExportNotProcessing {
+		t.Fatalf("got error %v, want %v", err, ErrSearchExportNotProcessing)
+	}
+	if err := SearchExports.Heartbeat(ctx, e.ID, dequeued.Attempt); err != ErrSearchExportNotProcessing {
+		t.Fatalf("got error %v, want %v", err, ErrSearchExportNotProcessing)
+	}
+	if err := SearchExports.Heartbeat(ctx, e.ID, restarted.Attempt); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunk := range []string{"header\n", "row 1\nrow 2\n", "row 3\n"} {
+		if err := SearchExports.AppendChunk(ctx, e.ID, restarted.Attempt, []byte(chunk), int32(bytes.Count([]byte(chunk), []byte("row")))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := SearchExports.Finish(ctx, e.ID, dequeued.Attempt, nil); err != ErrSearchExportNotProcessing {
+		t.Fatalf("got error %v, want %v", err, ErrSearchExportNotProcessing)
+	}
+	if err := SearchExports.Finish(ctx, e.ID, restarted.Attempt, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	e, err = SearchExports.GetByID(ctx, e.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.State != SearchExportStateCompleted || e.ResultCount != 3 || e.FinishedAt == nil {
+		t.Errorf("got export %+v, want completed export with 3 results", e)
+	}
+
+	var buf bytes.Buffer
+	if err := SearchExports.WriteContents(ctx, e.ID, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := buf.String(), "header\nrow 1\nrow 2\nrow 3\n"; have != want {
+		t.Errorf("got contents %q, want %q", have, want)
+	}
+
+	// Finished exports can't be canceled, but queued and processing ones can.
+	if err := SearchExports.Cancel(ctx, e.ID); err == nil {
+		t.Error("canceled a completed export")
+	}
+	e2, err := SearchExports.Create(ctx, u.ID, "bar", "V2", nil, SearchExportFormatJSONL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dequeued, err = SearchExports.Dequeue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SearchExports.Cancel(ctx, e2.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := SearchExports.AppendChunk(ctx, e2.ID, dequeued.Attempt, []byte("a"), 1); err != ErrSearchExportNotProcessing {
+		t.Fatalf("got error %v, want %v", err, ErrSearchExportNotProcessing)
+	}
+
+	exports, err := SearchExports.List(ctx, SearchExportsListOptions{UserID: u.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exports) != 2 || exports[0].ID != e2.ID || exports[0].State != SearchExportStateCanceled {
+		t.Errorf("got exports %+v, want the canceled export first", exports)
+	}
+}
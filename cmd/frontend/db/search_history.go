@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+)
+
+// SearchHistoryEntry is a distinct query that a user has searched for.
+type SearchHistoryEntry struct {
+	ID              int64
+	UserID          int32
+	Query           string
+	SearchCount     int32 // the number of times the user searched for the query
+	FirstSearchedAt time.Time
+	LastSearchedAt  time.Time
+}
+
+type searchHistory struct{}
+
+// Record adds the query to the user's search history, or bumps its count and
+// last searched time if the user has searched for it before. Afterwards, only
+// the maxEntries most recently searched queries of the user are kept.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is the user.
+func (s *searchHistory) Record(ctx context.Context, userID int32, query string, maxEntries int) error {
+	if Mocks.SearchHistory.Record != nil {
+		return Mocks.SearchHistory.Record(userID, query, maxEntries)
+	}
+
+	q := sqlf.Sprintf(`
+INSERT INTO search_history (user_id, query) VALUES (%s, %s)
+ON CONFLICT ON CONSTRAINT search_history_user_id_query_unique
+DO UPDATE SET search_count=search_history.search_count+1, last_searched_at=now()
+`,
+		userID, query,
+	)
+	if _, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...); err != nil {
+		return err
+	}
+
+	q = sqlf.Sprintf(`
+DELETE FROM search_history WHERE id IN (
+	SELECT id FROM search_history WHERE user_id=%s
+	ORDER BY last_searched_at DESC, id DESC
+	OFFSET %s
+)
+`,
+		userID, maxEntries,
+	)
+	_, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	return err
+}
+
+// SearchHistoryListOptions contains options for listing search history
+// entries.
+type SearchHistoryListOptions struct {
+	UserID int32 // only list the search history of this user
+
+	// Query, if non-empty, only lists entries whose query contains this string
+	// (case-insensitively).
+	Query string
+
+	// OrderByFrequency lists the most frequently searched queries first. By
+	// default, the most recently searched queries are listed first.
+	OrderByFrequency bool
+
+	*LimitOffset
+}
+
+func (o SearchHistoryListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id=%d", o.UserID))
+	}
+	if o.Query != "" {
+		conds = append(conds, sqlf.Sprintf("strpos(lower(query), lower(%s)) > 0", o.Query))
+	}
+	return conds
+}
+
+func (o SearchHistoryListOptions) sqlOrderBy() *sqlf.Query {
+	if o.OrderByFrequency {
+		return sqlf.Sprintf("search_count DESC, last_searched_at DESC, id DESC")
+	}
+	return sqlf.Sprintf("last_searched_at DESC, id DESC")
+}
+
+// List lists the search history entries that satisfy the options.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to list with the specified
+// options.
+func (s *searchHistory) List(ctx context.Context, opt SearchHistoryListOptions) ([]*SearchHistoryEntry, error) {
+	if Mocks.SearchHistory.List != nil {
+		return Mocks.SearchHistory.List(opt)
+	}
+
+	q := sqlf.Sprintf(`SELECT `+searchHistoryColumns+` FROM search_history WHERE (%s) ORDER BY %s %s`,
+		sqlf.Join(opt.sqlConditions(), ") AND ("),
+		opt.sqlOrderBy(),
+		opt.LimitOffset.SQL(),
+	)
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*SearchHistoryEntry
+	for rows.Next() {
+		var e SearchHistoryEntry
+		if err := rows.Scan(&e.ID, &e.UserID, &e.Query, &e.SearchCount, &e.FirstSearchedAt, &e.LastSearchedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// Count counts the search history entries that satisfy the options (ignoring limit and offset).
+//
+// 🚨 SECURITY: The caller must ensure that the actor is permitted to count the entries.
+func (s *searchHistory) Count(ctx context.Context, opt SearchHistoryListOptions) (int, error) {
+	if Mocks.SearchHistory.Count != nil {
+		return Mocks.SearchHistory.Count(opt)
+	}
+
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM search_history WHERE (%s)", sqlf.Join(opt.sqlConditions(), ") AND ("))
+	var count int
+	if err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeleteByUser deletes the entire search history of the user.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is the user or a site admin.
+func (s *searchHistory) DeleteByUser(ctx context.Context, userID int32) error {
+	if Mocks.SearchHistory.DeleteByUser != nil {
+		return Mocks.SearchHistory.DeleteByUser(userID)
+	}
+
+	_, err := dbconn.Global.ExecContext(ctx, `DELETE FROM search_history WHERE user_id=$1`, userID)
+	return err
+}
+
+// DeleteOlderThan deletes the search history entries of all users that were
+// last searched before the given time.
+func (s *searchHistory) DeleteOlderThan(ctx context.Context, t time.Time) error {
+	_, err := dbconn.Global.ExecContext(ctx, `DELETE FROM search_history WHERE last_searched_at < $1`, t)
+	return err
+}
+
+const searchHistoryColumns = `id, user_id, query, search_count, first_searched_at, last_searched_at`
+
+type MockSearchHistory struct {
+	Record       func(userID int32, query string, maxEntries int) error
+	List         func(opt SearchHistoryListOptions) ([]*SearchHistoryEntry, error)
+	Count        func(opt SearchHistoryListOptions) (int, error)
+	DeleteByUser func(userID int32) error
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestSearchHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	u1, err := Users.Create(ctx, NewUser{Username: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u2, err := Users.Create(ctx, NewUser{Username: "u2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, query := range []string{"foo", "bar", "foo", "Foobar", "foo"} {
+		if err := SearchHistory.Record(ctx, u1.ID, query, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := SearchHistory.Record(ctx, u2.ID, "baz", 10); err != nil {
+		t.Fatal(err)
+	}
+
+	queries := func(opt SearchHistoryListOptions) []string {
+		t.Helper()
+		entries, err := SearchHistory.List(ctx, opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var queries []string
+		for _, e := range entries {
+			queries = append(queries, e.Query)
+		}
+		return queries
+	}
+
+	if got, want := queries(SearchHistoryListOptions{UserID: u1.ID}), []string{"foo", "Foobar", "bar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got recent queries %q, want %q", got, want)
+	}
+	if got, want := queries(SearchHistoryListOptions{UserID: u1.ID, OrderByFrequency: true, LimitOffset: &LimitOffset{Limit: 2}}), []string{"foo", "Foobar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got frequent queries %q, want %q", got, want)
+	}
+	if got, want := queries(SearchHistoryListOptions{UserID: u1.ID, Query: "FOOB"}), []string{"Foobar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got matching queries %q, want %q", got, want)
+	}
+
+	entries, err := SearchHistory.List(ctx, SearchHistoryListOptions{UserID: u1.ID, Query: "foo", OrderByFrequency: true, LimitOffset: &LimitOffset{Limit: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].SearchCount != 3 {
+		t.Fatalf("got entries %+v, want 1 entry searched 3 times", entries)
+	}
+
+	// Recording a query beyond the limit deletes the least recently searched
+	// queries of the user only.
+	if err := SearchHistory.Record(ctx, u1.ID, "qux", 2); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := queries(SearchHistoryListOptions{UserID: u1.ID}), []string{"qux", "foo"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got queries %q after trimming, want %q", got, want)
+	}
+	if count, err := SearchHistory.Count(ctx, SearchHistoryListOptions{UserID: u2.ID}); err != nil || count != 1 {
+		t.Errorf("got count %d, %v for other user, want 1, nil", count, err)
+	}
+
+	if err := SearchHistory.DeleteOlderThan(ctx, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := SearchHistory.Count(ctx, SearchHistoryListOptions{}); err != nil || count != 3 {
+		t.Errorf("got count %d, %v after deleting old entries, want 3, nil", count, err)
+	}
+
+	if err := SearchHistory.DeleteByUser(ctx, u1.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got := queries(SearchHistoryListOptions{UserID: u1.ID}); len(got) != 0 {
+		t.Errorf("got queries %q after clearing, want none", got)
+	}
+	if got, want := queries(SearchHistoryListOptions{}), []string{"baz"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got queries %q of all users, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package db
 
 var (
 	AccessTokens     = &accessTokens{}
+	AuditLogs        = &auditLogs{}
 	ExternalServices = &ExternalServicesStore{}
 	DefaultRepos     = &defaultRepos{}
 	Repos            = &repos{}
@@ -10,6 +11,8 @@ var (
 	Orgs             = &orgs{}
 	OrgMembers       = &orgMembers{}
 	SavedSearches    = &savedSearches{}
+	SearchExports    = &searchExports{}
+	SearchHistory    = &searchHistory{}
 	Settings         = &settings{}
 	Users            = &users{}
 	UserEmails       = &userEmails{}
@@ -17,6 +20,10 @@ var (
 
 	SurveyResponses = &surveyResponses{}
 
+	InsightSeriesPoints = &insightSeriesPoints{}
+
+	CommitIndex = &commitIndex{}
+
 	ExternalAccounts = &userExternalAccounts{}
 
 	OrgInvitations = &orgInvitations{}
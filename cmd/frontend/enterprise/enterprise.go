@@ -12,6 +12,7 @@ import (
 type Services struct {
 	GithubWebhook             http.Handler
 	BitbucketServerWebhook    http.Handler
+	CampaignReport            http.Handler
 	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
 	AuthzResolver             graphqlbackend.AuthzResolver
 	CampaignsResolver         graphqlbackend.CampaignsResolver
@@ -27,6 +28,7 @@ func DefaultServices() Services {
 	return Services{
 		GithubWebhook:             makeNotFoundHandler("github webhook"),
 		BitbucketServerWebhook:    makeNotFoundHandler("bitbucket server webhook"),
+		CampaignReport:            makeNotFoundHandler("campaign report"),
 		NewCodeIntelUploadHandler: func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		AuthzResolver:             graphqlbackend.DefaultAuthzResolver,
 		CampaignsResolver:         graphqlbackend.DefaultCampaignsResolver,
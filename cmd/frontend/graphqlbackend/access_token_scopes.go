@@ -0,0 +1,195 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+// accessTokenScopeFields are the top-level fields of queries and mutations that access tokens with a
+// restricted scope can use.
+//
+// 🚨 SECURITY: The resolvers of these fields must not expose data or operations beyond what the
+// scope allows. The node field is additionally restricted by accessTokenScopeNodeKinds.
+var accessTokenScopeFields = map[string]struct{ query, mutation []string }{
+	authz.ScopeSearchRead: {
+		query: []string{"search", "searchFilterSuggestions"},
+	},
+	authz.ScopeRepoRead: {
+		query: []string{"node", "repository", "repositoryRedirect", "repositories"},
+	},
+	authz.ScopeCampaignsWrite: {
+		query: []string{"node", "campaigns", "campaignTemplates"},
+		mutation: []string{
+			"createChangesets",
+			"addChangesetsToCampaign",
+			"createCampaign",
+			"createPatchSetFromPatches",
+			"createPatchSetFromSpec",
+			"updateCampaign",
+			"retryCampaignChangesets",
+			"deleteCampaign",
+			"closeCampaign",
+			"publishCampaignChangesets",
+			"publishChangeset",
+			"markCampaignChangesetsReadyForReview",
+			"runChangesetBulkAction",
+			"importChangesets",
+			"deleteChangesetImport",
+			"createCampaignTemplate",
+			"updateCampaignTemplate",
+			"deleteCampaignTemplate",
+			"createPatchSetFromCampaignTemplate",
+			"reapplyCampaignTemplate",
+			"syncChangeset",
+		},
+	},
+}
+
+// accessTokenScopeNodeKinds are the kinds of nodes that access tokens with a restricted scope can
+// resolve with the node field.
+var accessTokenScopeNodeKinds = map[string][]string{
+	authz.ScopeRepoRead: {"Repository", "GitRef", "GitCommit"},
+	authz.ScopeCampaignsWrite: {
+		"Campaign",
+		"PatchSet",
+		"CampaignTemplate",
+		"ExternalChangeset",
+		"HiddenExternalChangeset",
+		"Patch",
+		"HiddenPatch",
+	},
+}
+
+// CheckAccessTokenScopes returns an error if the actor in ctx was authenticated with a restricted
+// access token whose scopes don't allow all the top-level fields of the operations in the GraphQL
+// query.
+//
+// 🚨 SECURITY: This must be called before executing GraphQL queries of restricted actors, since most
+// resolvers don't check the scopes of the actor. It only checks the top-level fields, so the
+// resolvers of nested fields that expose account data call checkAccessTokenAllowsAccountData.
+func CheckAccessTokenScopes(ctx context.Context, query string) error {
+	a := actor.FromContext(ctx)
+	if !a.IsRestricted() {
+		return nil
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return err
+	}
+
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		if f, ok := def.(*ast.FragmentDefinition); ok && f.Name != nil {
+			fragments[f.Name.Value] = f
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		fields, err := topLevelFields(op.SelectionSet, fragments, map[string]bool{})
+		if err != nil {
+			return err
+		}
+		for _, field := range fields {
+			if !accessTokenScopesAllowField(a.Scopes, op.Operation, field) {
+				return fmt.Errorf("access token scopes %q don't allow %s field %q", a.Scopes, op.Operation, field)
+			}
+		}
+	}
+	return nil
+}
+
+// topLevelFields returns the names of the fields in the selection set, including the fields of
+// fragments.
+func topLevelFields(set *ast.SelectionSet, fragments map[string]*ast.FragmentDefinition, seen map[string]bool) ([]string, error) {
+	if set == nil {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, sel := range set.Selections {
+		var (
+			more []string
+			err  error
+		)
+		switch sel := sel.(type) {
+		case *ast.Field:
+			fields = append(fields, sel.Name.Value)
+		case *ast.InlineFragment:
+			more, err = topLevelFields(sel.SelectionSet, fragments, seen)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			f, ok := fragments[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %q", name)
+			}
+			more, err = topLevelFields(f.SelectionSet, fragments, seen)
+		}
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, more...)
+	}
+	return fields, nil
+}
+
+func accessTokenScopesAllowField(scopes []string, operation, field string) bool {
+	switch field {
+	case "__typename", "__schema", "__type":
+		return operation == ast.OperationTypeQuery || field == "__typename"
+	}
+
+	for _, scope := range scopes {
+		var allowed []string
+		switch operation {
+		case ast.OperationTypeQuery:
+			allowed = accessTokenScopeFields[scope].query
+		case ast.OperationTypeMutation:
+			allowed = accessTokenScopeFields[scope].mutation
+		}
+		for _, f := range allowed {
+			if f == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkAccessTokenScopesForNode returns an error if the actor in ctx was authenticated with a
+// restricted access token whose scopes don't allow resolving nodes of the given kind.
+func checkAccessTokenScopesForNode(ctx context.Context, kind string) error {
+	a := actor.FromContext(ctx)
+	if !a.IsRestricted() {
+		return nil
+	}
+	for _, scope := range a.Scopes {
+		for _, k := range accessTokenScopeNodeKinds[scope] {
+			if k == kind {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("access token scopes %q don't allow resolving %s nodes", a.Scopes, kind)
+}
+
+// checkAccessTokenAllowsAccountData returns an error if the actor in ctx was authenticated with a
+// restricted access token. No restricted scope allows reading the private data of users,
+// organizations and the site (such as settings, emails and access tokens), but allowed top-level
+// fields can lead to them through nested fields (e.g. the author of a campaign).
+func checkAccessTokenAllowsAccountData(ctx context.Context) error {
+	return authz.CheckScope(ctx, authz.ScopeUserAll)
+}
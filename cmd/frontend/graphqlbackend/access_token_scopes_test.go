@@ -0,0 +1,141 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestCheckAccessTokenScopes(t *testing.T) {
+	tests := []struct {
+		name    string
+		scopes  []string
+		query   string
+		wantErr bool
+	}{
+		{
+			name:  "unrestricted",
+			query: `mutation { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+		},
+		{
+			name:   "allowed query",
+			scopes: []string{authz.ScopeSearchRead},
+			query:  `query Search($q: String!) { search(query: $q) { results { matchCount } } __typename }`,
+		},
+		{
+			name:    "disallowed query",
+			scopes:  []string{authz.ScopeSearchRead},
+			query:   `{ search(query: "a") { results { matchCount } } currentUser { username } }`,
+			wantErr: true,
+		},
+		{
+			name:   "allowed mutation",
+			scopes: []string{authz.ScopeSearchRead, authz.ScopeCampaignsWrite},
+			query:  `mutation { closeCampaign(campaign: "Q2FtcGFpZ246MQ==") { id } }`,
+		},
+		{
+			name:    "query field in mutation",
+			scopes:  []string{authz.ScopeCampaignsWrite},
+			query:   `mutation { campaigns { totalCount } }`,
+			wantErr: true,
+		},
+		{
+			name:    "disallowed field in fragment",
+			scopes:  []string{authz.ScopeRepoRead},
+			query:   `query { ...F } fragment F on Query { repository(name: "a") { id } ... on Query { site { id } } }`,
+			wantErr: true,
+		},
+		{
+			name:    "disallowed operation in document",
+			scopes:  []string{authz.ScopeRepoRead},
+			query:   `query A { repository(name: "a") { id } } mutation B { updateUser(user: "VXNlcjox") { alwaysNil } }`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid query",
+			scopes:  []string{authz.ScopeRepoRead},
+			query:   `{ repository(`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1, Scopes: tc.scopes})
+			err := CheckAccessTokenScopes(ctx, tc.query)
+			if have, want := err != nil, tc.wantErr; have != want {
+				t.Fatalf("have err %v, want error %t", err, want)
+			}
+		})
+	}
+}
+
+func TestCheckAccessTokenScopesForNode(t *testing.T) {
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1, Scopes: []string{authz.ScopeCampaignsWrite}})
+	if err := checkAccessTokenScopesForNode(ctx, "Campaign"); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkAccessTokenScopesForNode(ctx, "User"); err == nil {
+		t.Fatal("want error resolving User node")
+	}
+
+	ctx = actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	if err := checkAccessTokenScopesForNode(ctx, "User"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckAccessTokenScopesNestedFields(t *testing.T) {
+	resetMocks()
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "alice"}, nil
+	}
+	db.Mocks.Orgs.GetByID = func(_ context.Context, id int32) (*types.Org, error) {
+		return &types.Org{ID: id, Name: "acme"}, nil
+	}
+	defer resetMocks()
+
+	// The user is the actor, so only the scopes of the access token keep these fields from
+	// being resolved.
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 3, Scopes: []string{authz.ScopeCampaignsWrite}})
+
+	fields := []string{
+		`... on User { email }`,
+		`... on User { emails { email } }`,
+		`... on User { latestSettings { contents } }`,
+		`... on User { settingsCascade { final } }`,
+		`... on User { settingsCascade { subjects { ... on Site { configuration { effectiveContents } } } } }`,
+		`... on User { accessTokens { totalCount } }`,
+		`... on User { externalAccounts { totalCount } }`,
+		`... on User { session { canSignOut } }`,
+		`... on User { organizations { nodes { name } } }`,
+		`... on User { organizationMemberships { totalCount } }`,
+		`... on User { searchHistory { totalCount } }`,
+		`... on Org { members { totalCount } }`,
+		`... on Org { latestSettings { contents } }`,
+		`... on Org { settingsCascade { final } }`,
+	}
+	for _, field := range fields {
+		t.Run(field, func(t *testing.T) {
+			id := "VXNlcjoz"
+			if strings.Contains(field, "on Org") {
+				id = "T3JnOjM="
+			}
+			query := fmt.Sprintf(`{ namespace(id: %q) { %s } }`, id, field)
+
+			result := mustParseGraphQLSchema(t).Exec(ctx, query, "", nil)
+			if len(result.Errors) == 0 {
+				t.Fatalf("want error, have result %s", result.Data)
+			}
+			if msg := result.Errors[0].Message; !strings.Contains(msg, authz.ScopeUserAll) {
+				t.Fatalf("have error %q, want error about scope %q", msg, authz.ScopeUserAll)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
@@ -17,9 +18,10 @@ import (
 )
 
 type createAccessTokenInput struct {
-	User   graphql.ID
-	Scopes []string
-	Note   string
+	User      graphql.ID
+	Scopes    []string
+	Note      string
+	ExpiresAt *DateTime
 }
 
 func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAccessTokenInput) (*createAccessTokenResult, error) {
@@ -46,18 +48,21 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 	}
 
 	// Validate scopes.
-	var hasUserAllScope bool
+	var hasUserAllScope, hasSudoScope, hasRestrictedScope bool
 	seenScope := map[string]struct{}{}
 	sort.Strings(args.Scopes)
 	for _, scope := range args.Scopes {
-		switch scope {
-		case authz.ScopeUserAll:
+		switch {
+		case scope == authz.ScopeUserAll:
 			hasUserAllScope = true
-		case authz.ScopeSiteAdminSudo:
+		case scope == authz.ScopeSiteAdminSudo:
 			// 🚨 SECURITY: Only site admins may create a token with the "site-admin:sudo" scope.
 			if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 				return nil, err
 			}
+			hasSudoScope = true
+		case authz.IsRestrictedScope(scope):
+			hasRestrictedScope = true
 		default:
 			return nil, fmt.Errorf("unknown access token scope %q (valid scopes: %q)", scope, authz.AllScopes)
 		}
@@ -67,12 +72,36 @@ func (r *schemaResolver) CreateAccessToken(ctx context.Context, args *createAcce
 		}
 		seenScope[scope] = struct{}{}
 	}
-	if !hasUserAllScope {
-		return nil, fmt.Errorf("all access tokens must have scope %q", authz.ScopeUserAll)
+	switch {
+	case hasUserAllScope && hasRestrictedScope:
+		return nil, fmt.Errorf("access tokens with scope %q may not have restricted scopes (%q)", authz.ScopeUserAll, authz.RestrictedScopes)
+	case hasSudoScope && !hasUserAllScope:
+		return nil, fmt.Errorf("access tokens with scope %q must have scope %q", authz.ScopeSiteAdminSudo, authz.ScopeUserAll)
+	case !hasUserAllScope && !hasRestrictedScope:
+		return nil, fmt.Errorf("access tokens must have scope %q or at least one restricted scope (%q)", authz.ScopeUserAll, authz.RestrictedScopes)
 	}
 
-	id, token, err := db.AccessTokens.Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID)
-	return &createAccessTokenResult{id: marshalAccessTokenID(id), token: token}, err
+	var expiresAt *time.Time
+	if args.ExpiresAt != nil {
+		if !args.ExpiresAt.Time.After(time.Now()) {
+			return nil, errors.New("access token expiry time must be in the future")
+		}
+		expiresAt = &args.ExpiresAt.Time
+	}
+
+	// The ID of the token isn't known until it is created.
+	if err := db.AuditLogs.Record(ctx, db.AuditActionCreateAccessToken, "", map[string]interface{}{
+		"user":      args.User,
+		"scopes":    args.Scopes,
+		"expiresAt": expiresAt,
+	}); err != nil {
+		return nil, err
+	}
+	id, token, err := db.AccessTokens.Create(ctx, userID, args.Scopes, args.Note, actor.FromContext(ctx).UID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &createAccessTokenResult{id: marshalAccessTokenID(id), token: token}, nil
 }
 
 type createAccessTokenResult struct {
@@ -112,6 +141,9 @@ func (r *schemaResolver) DeleteAccessToken(ctx context.Context, args *deleteAcce
 		if err := backend.CheckSiteAdminOrSameUser(ctx, token.SubjectUserID); err != nil {
 			return nil, err
 		}
+		if err := db.AuditLogs.Record(ctx, db.AuditActionDeleteAccessToken, string(*args.ByID), map[string]graphql.ID{"user": MarshalUserID(token.SubjectUserID)}); err != nil {
+			return nil, err
+		}
 		if err := db.AccessTokens.DeleteByID(ctx, token.ID, token.SubjectUserID); err != nil {
 			return nil, err
 		}
@@ -119,6 +151,10 @@ func (r *schemaResolver) DeleteAccessToken(ctx context.Context, args *deleteAcce
 	case args.ByToken != nil:
 		// 🚨 SECURITY: This is easier than the ByID case because anyone holding the access token's
 		// secret value is assumed to be allowed to delete it.
+		// The ID of the token isn't known, and its secret value must not be recorded.
+		if err := db.AuditLogs.Record(ctx, db.AuditActionDeleteAccessToken, "", map[string]bool{"byToken": true}); err != nil {
+			return nil, err
+		}
 		if err := db.AccessTokens.DeleteByToken(ctx, *args.ByToken); err != nil {
 			return nil, err
 		}
@@ -129,13 +165,22 @@ func (r *schemaResolver) DeleteAccessToken(ctx context.Context, args *deleteAcce
 
 func (r *siteResolver) AccessTokens(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
+	Scope *string
 }) (*accessTokenConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only site admins can list all access tokens.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 		return nil, err
 	}
 
 	var opt db.AccessTokensListOptions
+	if args.Scope != nil {
+		opt.Scope = *args.Scope
+	}
 	args.ConnectionArgs.Set(&opt.LimitOffset)
 	return &accessTokenConnectionResolver{opt: opt}, nil
 }
@@ -143,6 +188,11 @@ func (r *siteResolver) AccessTokens(ctx context.Context, args *struct {
 func (r *UserResolver) AccessTokens(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
 }) (*accessTokenConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only site admins and the user can list a user's access tokens.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 		return nil, err
@@ -2,8 +2,10 @@ package graphqlbackend
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	graphql "github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/gqltesting"
@@ -17,7 +19,7 @@ import (
 // 🚨 SECURITY: This tests that users can't create tokens for users they aren't allowed to do so for.
 func TestMutation_CreateAccessToken(t *testing.T) {
 	mockAccessTokensCreate := func(t *testing.T, wantCreatorUserID int32, wantScopes []string) {
-		db.Mocks.AccessTokens.Create = func(subjectUserID int32, scopes []string, note string, creatorUserID int32) (int64, string, error) {
+		db.Mocks.AccessTokens.Create = func(subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (int64, string, error) {
 			if want := int32(1); subjectUserID != want {
 				t.Errorf("got %v, want %v", subjectUserID, want)
 			}
@@ -32,6 +34,18 @@ func TestMutation_CreateAccessToken(t *testing.T) {
 			}
 			return 1, "t", nil
 		}
+		db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+			if want := db.AuditActionCreateAccessToken; e.Action != want {
+				t.Errorf("got audit log action %q, want %q", e.Action, want)
+			}
+			if e.ActorUserID != wantCreatorUserID {
+				t.Errorf("got audit log actor %d, want %d", e.ActorUserID, wantCreatorUserID)
+			}
+			if e.Target != "" {
+				t.Errorf("got audit log target %q, want none", e.Target)
+			}
+			return nil
+		}
 	}
 
 	const uid1GQLID = "VXNlcjox"
@@ -129,6 +143,84 @@ func TestMutation_CreateAccessToken(t *testing.T) {
 		})
 	})
 
+	t.Run("authenticated as user, using restricted scopes and expiry", func(t *testing.T) {
+		resetMocks()
+		expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+		db.Mocks.AccessTokens.Create = func(subjectUserID int32, scopes []string, note string, creatorUserID int32, gotExpiresAt *time.Time) (int64, string, error) {
+			if want := []string{authz.ScopeCampaignsWrite, authz.ScopeSearchRead}; !reflect.DeepEqual(scopes, want) {
+				t.Errorf("got %q, want %q", scopes, want)
+			}
+			if gotExpiresAt == nil || !gotExpiresAt.Equal(expiresAt) {
+				t.Errorf("got expires at %v, want %v", gotExpiresAt, expiresAt)
+			}
+			return 1, "t", nil
+		}
+		db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+			if want := `{"expiresAt":"` + expiresAt.Format(time.RFC3339) + `","scopes":["campaigns:write","search:read"],"user":"VXNlcjox"}`; string(e.Arguments) != want {
+				t.Errorf("got audit log arguments %s, want %s", e.Arguments, want)
+			}
+			return nil
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := (&schemaResolver{}).CreateAccessToken(ctx, &createAccessTokenInput{
+			User:      uid1GQLID,
+			Scopes:    []string{authz.ScopeSearchRead, authz.ScopeCampaignsWrite},
+			Note:      "n",
+			ExpiresAt: &DateTime{Time: expiresAt},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("authenticated as user, audit log failure", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.AccessTokens.Create = func(subjectUserID int32, scopes []string, note string, creatorUserID int32, expiresAt *time.Time) (int64, string, error) {
+			t.Error("access token was created, although the audit log entry couldn't be recorded")
+			return 1, "t", nil
+		}
+		db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+			return errors.New("x")
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&schemaResolver{}).CreateAccessToken(ctx, &createAccessTokenInput{User: uid1GQLID, Scopes: []string{authz.ScopeUserAll}, Note: "n"})
+		if err == nil {
+			t.Error("err == nil")
+		}
+		if result != nil {
+			t.Errorf("got result %v, want nil", result)
+		}
+	})
+
+	for name, args := range map[string]*createAccessTokenInput{
+		"mixing user:all and restricted scopes": {
+			User:   uid1GQLID,
+			Scopes: []string{authz.ScopeUserAll, authz.ScopeRepoRead},
+			Note:   "n",
+		},
+		"expiry in the past": {
+			User:      uid1GQLID,
+			Scopes:    []string{authz.ScopeUserAll},
+			Note:      "n",
+			ExpiresAt: &DateTime{Time: time.Now().Add(-time.Hour)},
+		},
+	} {
+		t.Run("authenticated as user, "+name, func(t *testing.T) {
+			resetMocks()
+
+			ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+			result, err := (&schemaResolver{}).CreateAccessToken(ctx, args)
+			if err == nil {
+				t.Error("err == nil")
+			}
+			if result != nil {
+				t.Errorf("got result %v, want nil", result)
+			}
+		})
+	}
+
 	t.Run("authenticated as different user who is a site-admin", func(t *testing.T) {
 		resetMocks()
 		const differentSiteAdminUID = 234
@@ -220,6 +312,15 @@ func TestMutation_DeleteAccessToken(t *testing.T) {
 			}
 			return &db.AccessToken{ID: 1, SubjectUserID: 2}, nil
 		}
+		db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+			if want := db.AuditActionDeleteAccessToken; e.Action != want {
+				t.Errorf("got audit log action %q, want %q", e.Action, want)
+			}
+			if want := `{"user":"VXNlcjoy"}`; string(e.Arguments) != want {
+				t.Errorf("got audit log arguments %s, want %s", e.Arguments, want)
+			}
+			return nil
+		}
 	}
 
 	token1GQLID := graphql.ID("QWNjZXNzVG9rZW46MQ==")
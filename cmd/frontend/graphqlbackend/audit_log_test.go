@@ -0,0 +1,140 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func TestSiteAuditLog(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1}, nil
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := (&siteResolver{}).AuditLog(ctx, &struct {
+			graphqlutil.ConnectionArgs
+			Actor  *graphql.ID
+			Action *string
+			Since  *DateTime
+			Until  *DateTime
+		}{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+	})
+
+	resetMocks()
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1, SiteAdmin: true}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		if id == 1 {
+			return &types.User{ID: 1, Username: "alice"}, nil
+		}
+		return nil, &errcode.Mock{IsNotFound: true}
+	}
+	since := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	db.Mocks.AuditLogs.List = func(opt db.AuditLogsListOptions) ([]*db.AuditLogEntry, error) {
+		want := db.AuditLogsListOptions{
+			ActorUserID: 1,
+			Action:      db.AuditActionSetUserIsSiteAdmin,
+			Since:       &since,
+			LimitOffset: &db.LimitOffset{Limit: 3},
+		}
+		if diff := cmp.Diff(want, opt); diff != "" {
+			t.Errorf("opt: %s", diff)
+		}
+		return []*db.AuditLogEntry{
+			{
+				ActorUserID:       1,
+				ActorIP:           "192.0.2.1",
+				ActorForwardedFor: "198.51.100.1",
+				Action:            db.AuditActionSetUserIsSiteAdmin,
+				Target:            "VXNlcjoy",
+				Arguments:         []byte(`{"siteAdmin":true}`),
+				CreatedAt:         since.Add(time.Hour),
+			},
+			{
+				ActorUserID: 3,
+				ActorIP:     "192.0.2.2",
+				Action:      db.AuditActionSetUserIsSiteAdmin,
+				Target:      "VXNlcjo0",
+				Arguments:   []byte(`{"siteAdmin":false}`),
+				CreatedAt:   since,
+			},
+		}, nil
+	}
+	db.Mocks.AuditLogs.Count = func(db.AuditLogsListOptions) (int, error) { return 2, nil }
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			Schema:  mustParseGraphQLSchema(t),
+			Query: `
+				{
+					site {
+						auditLog(first: 2, actor: "VXNlcjox", action: "setUserIsSiteAdmin", since: "2020-05-01T00:00:00Z") {
+							nodes {
+								actorID
+								actor { username }
+								actorIP
+								actorForwardedFor
+								action
+								target
+								arguments
+								createdAt
+							}
+							totalCount
+							pageInfo { hasNextPage }
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"site": {
+						"auditLog": {
+							"nodes": [
+								{
+									"actorID": "VXNlcjox",
+									"actor": { "username": "alice" },
+									"actorIP": "192.0.2.1",
+									"actorForwardedFor": "198.51.100.1",
+									"action": "setUserIsSiteAdmin",
+									"target": "VXNlcjoy",
+									"arguments": { "siteAdmin": true },
+									"createdAt": "2020-05-01T01:00:00Z"
+								},
+								{
+									"actorID": "VXNlcjoz",
+									"actor": null,
+									"actorIP": "192.0.2.2",
+									"actorForwardedFor": null,
+									"action": "setUserIsSiteAdmin",
+									"target": "VXNlcjo0",
+									"arguments": { "siteAdmin": false },
+									"createdAt": "2020-05-01T00:00:00Z"
+								}
+							],
+							"totalCount": 2,
+							"pageInfo": { "hasNextPage": false }
+						}
+					}
+				}
+			`,
+		},
+	})
+}
@@ -0,0 +1,84 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	querytypes "github.com/sourcegraph/sourcegraph/internal/search/query/types"
+)
+
+// maxCampaignSpecRepositoryResults is the number of results that are fetched
+// to find the repositories matching the query of a campaign spec, unless the
+// query sets count: itself.
+const maxCampaignSpecRepositoryResults = 10000
+
+// RepositoryRevision is a repository and the revision of it that was
+// searched. Rev is empty if the default branch was searched.
+type RepositoryRevision struct {
+	Repo *types.Repo
+	Rev  string
+}
+
+// RepositoryRevisionsMatchingQuery runs the search query and returns the
+// repositories that contain a result, in the order of their first result. It
+// is used to find the repositories that the steps of a campaign spec run in.
+func RepositoryRevisionsMatchingQuery(ctx context.Context, q string) ([]RepositoryRevision, error) {
+	impl, err := NewSearchImplementer(&SearchArgs{Version: "V2", Query: q})
+	if err != nil {
+		return nil, err
+	}
+
+	var r *searchResolver
+	switch impl := impl.(type) {
+	case *searchAlert:
+		return nil, searchAlertError(impl)
+	case *searchResolver:
+		r = impl
+	default:
+		return nil, fmt.Errorf("unexpected search implementation %T", impl)
+	}
+	oq, ok := r.query.(*query.OrdinaryQuery)
+	if !ok {
+		return nil, errors.New("campaign specs do not support queries with and/or expressions")
+	}
+	if !r.countIsSet() {
+		count := strconv.Itoa(maxCampaignSpecRepositoryResults)
+		oq.Fields()[query.FieldCount] = []*querytypes.Value{{String: &count}}
+	}
+
+	results, err := r.Results(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if results.alert != nil {
+		return nil, searchAlertError(results.alert)
+	}
+
+	var repoRevs []RepositoryRevision
+	seen := map[RepositoryRevision]bool{}
+	add := func(repo *RepositoryResolver, rev *string) {
+		rr := RepositoryRevision{Repo: repo.repo}
+		if rev != nil {
+			rr.Rev = *rev
+		}
+		if !seen[rr] {
+			seen[rr] = true
+			repoRevs = append(repoRevs, rr)
+		}
+	}
+	for _, result := range results.Results() {
+		switch result := result.(type) {
+		case *RepositoryResolver:
+			add(result, &result.rev)
+		case *FileMatchResolver:
+			add(result.Repo, result.InputRev)
+		case *commitSearchResultResolver:
+			add(result.commit.repoResolver, result.commit.inputRev)
+		}
+	}
+	return repoRevs, nil
+}
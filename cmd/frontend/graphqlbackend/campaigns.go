@@ -23,6 +23,7 @@ type CreateCampaignArgs struct {
 		Description *string
 		Branch      *string
 		PatchSet    *graphql.ID
+		AutoMerge   *CampaignAutoMergeInput
 	}
 }
 
@@ -33,13 +34,44 @@ type UpdateCampaignArgs struct {
 		Description *string
 		Branch      *string
 		PatchSet    *graphql.ID
+		AutoMerge   *CampaignAutoMergeInput
 	}
 }
 
+type CampaignAutoMergeInput struct {
+	Enabled          bool
+	Method           *campaigns.ChangesetMergeMethod
+	MaxMergesPerHour *int32
+	WindowStart      *string
+	WindowEnd        *string
+}
+
+// AutoMerge returns the campaigns.AutoMerge settings described by the input.
+func (i *CampaignAutoMergeInput) AutoMerge() campaigns.AutoMerge {
+	a := campaigns.AutoMerge{Enabled: i.Enabled}
+	if i.Method != nil {
+		a.Method = *i.Method
+	}
+	if i.MaxMergesPerHour != nil {
+		a.MaxMergesPerHour = *i.MaxMergesPerHour
+	}
+	if i.WindowStart != nil {
+		a.WindowStart = *i.WindowStart
+	}
+	if i.WindowEnd != nil {
+		a.WindowEnd = *i.WindowEnd
+	}
+	return a
+}
+
 type CreatePatchSetFromPatchesArgs struct {
 	Patches []PatchInput
 }
 
+type CreatePatchSetFromSpecArgs struct {
+	Spec string
+}
+
 type PatchInput struct {
 	Repository   graphql.ID
 	BaseRevision api.CommitID
@@ -77,10 +109,93 @@ type CreateChangesetsArgs struct {
 
 type PublishCampaignChangesetsArgs struct {
 	Campaign graphql.ID
+	Draft    bool
+	Rollout  *ChangesetRolloutInput
+}
+
+type ChangesetRolloutInput struct {
+	WaveSize          int32
+	WaveIntervalHours int32
 }
 
 type PublishChangesetArgs struct {
 	Patch graphql.ID
+	Draft bool
+}
+
+type MarkCampaignChangesetsReadyForReviewArgs struct {
+	Campaign graphql.ID
+}
+
+type RunChangesetBulkActionArgs struct {
+	Campaign graphql.ID
+	Action   ChangesetBulkActionInput
+	Filter   *ChangesetBulkFilterInput
+}
+
+type ChangesetBulkActionInput struct {
+	Type      campaigns.ChangesetBulkActionType
+	Body      *string
+	Labels    *[]string
+	Reviewers *[]string
+}
+
+type ChangesetBulkFilterInput struct {
+	State        *campaigns.ChangesetState
+	ReviewState  *campaigns.ChangesetReviewState
+	CheckState   *campaigns.ChangesetCheckState
+	Repositories *[]graphql.ID
+}
+
+type ImportChangesetsArgs struct {
+	Campaign        graphql.ID
+	ExternalService graphql.ID
+	Query           string
+}
+
+type DeleteChangesetImportArgs struct {
+	ChangesetImport graphql.ID
+}
+
+type CreateCampaignTemplateArgs struct {
+	Input struct {
+		Namespace   graphql.ID
+		Name        string
+		Description *string
+		Spec        string
+	}
+}
+
+type UpdateCampaignTemplateArgs struct {
+	Input struct {
+		ID          graphql.ID
+		Name        *string
+		Description *string
+		Spec        *string
+	}
+}
+
+type DeleteCampaignTemplateArgs struct {
+	CampaignTemplate graphql.ID
+}
+
+type ListCampaignTemplatesArgs struct {
+	First     *int32
+	Namespace *graphql.ID
+}
+
+type CampaignTemplateArgumentInput struct {
+	Name  string
+	Value string
+}
+
+type CreatePatchSetFromCampaignTemplateArgs struct {
+	CampaignTemplate graphql.ID
+	Arguments        *[]CampaignTemplateArgumentInput
+}
+
+type ReapplyCampaignTemplateArgs struct {
+	Campaign graphql.ID
 }
 
 type SyncChangesetArgs struct {
@@ -102,14 +217,27 @@ type CampaignsResolver interface {
 	CloseCampaign(ctx context.Context, args *CloseCampaignArgs) (CampaignResolver, error)
 	PublishCampaignChangesets(ctx context.Context, args *PublishCampaignChangesetsArgs) (CampaignResolver, error)
 	PublishChangeset(ctx context.Context, args *PublishChangesetArgs) (*EmptyResponse, error)
+	MarkCampaignChangesetsReadyForReview(ctx context.Context, args *MarkCampaignChangesetsReadyForReviewArgs) (CampaignResolver, error)
+	RunChangesetBulkAction(ctx context.Context, args *RunChangesetBulkActionArgs) (ChangesetBulkActionResolver, error)
+	ImportChangesets(ctx context.Context, args *ImportChangesetsArgs) (ChangesetImportResolver, error)
+	DeleteChangesetImport(ctx context.Context, args *DeleteChangesetImportArgs) (*EmptyResponse, error)
 	SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error)
 
+	CreateCampaignTemplate(ctx context.Context, args *CreateCampaignTemplateArgs) (CampaignTemplateResolver, error)
+	UpdateCampaignTemplate(ctx context.Context, args *UpdateCampaignTemplateArgs) (CampaignTemplateResolver, error)
+	DeleteCampaignTemplate(ctx context.Context, args *DeleteCampaignTemplateArgs) (*EmptyResponse, error)
+	CampaignTemplateByID(ctx context.Context, id graphql.ID) (CampaignTemplateResolver, error)
+	CampaignTemplates(ctx context.Context, args *ListCampaignTemplatesArgs) (CampaignTemplatesConnectionResolver, error)
+	CreatePatchSetFromCampaignTemplate(ctx context.Context, args *CreatePatchSetFromCampaignTemplateArgs) (PatchSetResolver, error)
+	ReapplyCampaignTemplate(ctx context.Context, args *ReapplyCampaignTemplateArgs) (PatchSetResolver, error)
+
 	CreateChangesets(ctx context.Context, args *CreateChangesetsArgs) ([]ExternalChangesetResolver, error)
 	ChangesetByID(ctx context.Context, id graphql.ID) (ChangesetResolver, error)
 
 	AddChangesetsToCampaign(ctx context.Context, args *AddChangesetsToCampaignArgs) (CampaignResolver, error)
 
 	CreatePatchSetFromPatches(ctx context.Context, args CreatePatchSetFromPatchesArgs) (PatchSetResolver, error)
+	CreatePatchSetFromSpec(ctx context.Context, args CreatePatchSetFromSpecArgs) (PatchSetResolver, error)
 	PatchSetByID(ctx context.Context, id graphql.ID) (PatchSetResolver, error)
 
 	PatchByID(ctx context.Context, id graphql.ID) (PatchInterfaceResolver, error)
@@ -157,10 +285,54 @@ func (defaultCampaignsResolver) PublishChangeset(ctx context.Context, args *Publ
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) MarkCampaignChangesetsReadyForReview(ctx context.Context, args *MarkCampaignChangesetsReadyForReviewArgs) (CampaignResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) RunChangesetBulkAction(ctx context.Context, args *RunChangesetBulkActionArgs) (ChangesetBulkActionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ImportChangesets(ctx context.Context, args *ImportChangesetsArgs) (ChangesetImportResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) DeleteChangesetImport(ctx context.Context, args *DeleteChangesetImportArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) SyncChangeset(ctx context.Context, args *SyncChangesetArgs) (*EmptyResponse, error) {
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) CreateCampaignTemplate(ctx context.Context, args *CreateCampaignTemplateArgs) (CampaignTemplateResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) UpdateCampaignTemplate(ctx context.Context, args *UpdateCampaignTemplateArgs) (CampaignTemplateResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) DeleteCampaignTemplate(ctx context.Context, args *DeleteCampaignTemplateArgs) (*EmptyResponse, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignTemplateByID(ctx context.Context, id graphql.ID) (CampaignTemplateResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CampaignTemplates(ctx context.Context, args *ListCampaignTemplatesArgs) (CampaignTemplatesConnectionResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) CreatePatchSetFromCampaignTemplate(ctx context.Context, args *CreatePatchSetFromCampaignTemplateArgs) (PatchSetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
+func (defaultCampaignsResolver) ReapplyCampaignTemplate(ctx context.Context, args *ReapplyCampaignTemplateArgs) (PatchSetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) CreateChangesets(ctx context.Context, args *CreateChangesetsArgs) ([]ExternalChangesetResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
@@ -177,6 +349,10 @@ func (defaultCampaignsResolver) CreatePatchSetFromPatches(ctx context.Context, a
 	return nil, campaignsOnlyInEnterprise
 }
 
+func (defaultCampaignsResolver) CreatePatchSetFromSpec(ctx context.Context, args CreatePatchSetFromSpecArgs) (PatchSetResolver, error) {
+	return nil, campaignsOnlyInEnterprise
+}
+
 func (defaultCampaignsResolver) PatchSetByID(ctx context.Context, id graphql.ID) (PatchSetResolver, error) {
 	return nil, campaignsOnlyInEnterprise
 }
@@ -218,6 +394,95 @@ type CampaignResolver interface {
 	Patches(ctx context.Context, args *graphqlutil.ConnectionArgs) PatchConnectionResolver
 	HasUnpublishedPatches(ctx context.Context) (bool, error)
 	DiffStat(ctx context.Context) (*DiffStat, error)
+	AutoMerge() *CampaignAutoMergeResolver
+	ChangesetBulkActions(ctx context.Context, args *graphqlutil.ConnectionArgs) ([]ChangesetBulkActionResolver, error)
+	ChangesetImports(ctx context.Context) ([]ChangesetImportResolver, error)
+}
+
+// CampaignAutoMergeResolver resolves the auto-merge settings of a campaign.
+type CampaignAutoMergeResolver struct {
+	AutoMerge campaigns.AutoMerge
+}
+
+func (r *CampaignAutoMergeResolver) Enabled() bool { return r.AutoMerge.Enabled }
+
+func (r *CampaignAutoMergeResolver) Method() *campaigns.ChangesetMergeMethod {
+	if r.AutoMerge.Method == "" {
+		return nil
+	}
+	return &r.AutoMerge.Method
+}
+
+func (r *CampaignAutoMergeResolver) MaxMergesPerHour() *int32 {
+	if r.AutoMerge.MaxMergesPerHour == 0 {
+		return nil
+	}
+	return &r.AutoMerge.MaxMergesPerHour
+}
+
+func (r *CampaignAutoMergeResolver) WindowStart() *string {
+	if r.AutoMerge.WindowStart == "" {
+		return nil
+	}
+	return &r.AutoMerge.WindowStart
+}
+
+func (r *CampaignAutoMergeResolver) WindowEnd() *string {
+	if r.AutoMerge.WindowEnd == "" {
+		return nil
+	}
+	return &r.AutoMerge.WindowEnd
+}
+
+type ChangesetBulkActionResolver interface {
+	ID() graphql.ID
+	Type() campaigns.ChangesetBulkActionType
+	Body() *string
+	Labels() []string
+	Reviewers() []string
+	Author(ctx context.Context) (*UserResolver, error)
+	Status(ctx context.Context) (BackgroundProcessStatus, error)
+	CreatedAt() DateTime
+}
+
+type ChangesetImportResolver interface {
+	ID() graphql.ID
+	Query() string
+	ExternalService(ctx context.Context) (*ExternalServiceResolver, error)
+	Author(ctx context.Context) (*UserResolver, error)
+	LastRunAt() *DateTime
+	Error() *string
+	CreatedAt() DateTime
+}
+
+type CampaignTemplateResolver interface {
+	ID() graphql.ID
+	Name() string
+	Description() string
+	Spec() string
+	Parameters() ([]CampaignTemplateParameterResolver, error)
+	Version() int32
+	Namespace(ctx context.Context) (n NamespaceResolver, err error)
+	Author(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
+
+type CampaignTemplateParameterResolver interface {
+	Name() string
+	Description() string
+	Default() *string
+}
+
+type CampaignTemplateArgumentResolver interface {
+	Name() string
+	Value() string
+}
+
+type CampaignTemplatesConnectionResolver interface {
+	Nodes(ctx context.Context) ([]CampaignTemplateResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
 }
 
 type CampaignsConnectionResolver interface {
@@ -274,6 +539,7 @@ type ExternalChangesetResolver interface {
 	ExternalURL() (*externallink.Resolver, error)
 	ReviewState(context.Context) campaigns.ChangesetReviewState
 	CheckState(context.Context) (*campaigns.ChangesetCheckState, error)
+	Conflicted(context.Context) (bool, error)
 	Repository(ctx context.Context) (*RepositoryResolver, error)
 
 	Events(ctx context.Context, args *struct{ graphqlutil.ConnectionArgs }) (ChangesetEventsConnectionResolver, error)
@@ -359,4 +625,9 @@ type PatchSetResolver interface {
 
 	PreviewURL() string
 	DiffStat(ctx context.Context) (*DiffStat, error)
+	Status(ctx context.Context) (BackgroundProcessStatus, error)
+
+	CampaignTemplate(ctx context.Context) (CampaignTemplateResolver, error)
+	CampaignTemplateVersion() *int32
+	CampaignTemplateArguments() *[]CampaignTemplateArgumentResolver
 }
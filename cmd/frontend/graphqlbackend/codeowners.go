@@ -0,0 +1,108 @@
+package graphqlbackend
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// codeownersMaxBytes is the maximum size of a CODEOWNERS file that is read.
+const codeownersMaxBytes = 1 << 20
+
+// codeownersCache caches the parsed CODEOWNERS files of repository commits,
+// because the owners of all files matched in a commit are looked up in the same
+// file.
+var (
+	codeownersCacheMu sync.Mutex
+	codeownersCache   = lru.New(1000)
+)
+
+// codeownersAt returns the rules of the CODEOWNERS file at the commit, or nil
+// if the commit has no CODEOWNERS file.
+func codeownersAt(ctx context.Context, repo gitserver.Repo, commit api.CommitID) (*codeowners.Ruleset, error) {
+	key := string(repo.Name) + ":" + string(commit)
+	codeownersCacheMu.Lock()
+	v, ok := codeownersCache.Get(key)
+	codeownersCacheMu.Unlock()
+	if ok {
+		return v.(*codeowners.Ruleset), nil
+	}
+
+	var rs *codeowners.Ruleset
+	for _, path := range codeowners.Paths {
+		data, err := git.ReadFile(ctx, repo, commit, path, codeownersMaxBytes)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		rs, err = codeowners.Parse(bytes.NewReader(data))
+		if err != nil {
+			// Don't fail searches because of a broken CODEOWNERS file.
+			log15.Warn("ignoring invalid CODEOWNERS file", "repo", repo.Name, "commit", commit, "path", path, "error", err)
+		}
+		break
+	}
+
+	codeownersCacheMu.Lock()
+	codeownersCache.Add(key, rs)
+	codeownersCacheMu.Unlock()
+	return rs, nil
+}
+
+// Owners returns the owners of the file according to the CODEOWNERS file at
+// the searched revision.
+func (fm *FileMatchResolver) Owners(ctx context.Context) ([]string, error) {
+	rs, err := codeownersAt(ctx, gitserver.Repo{Name: fm.Repo.repo.Name}, fm.CommitID)
+	if err != nil || rs == nil {
+		return []string{}, err
+	}
+	owners := rs.Match(fm.JPath)
+	if owners == nil {
+		owners = []string{}
+	}
+	return owners, nil
+}
+
+// filterFileMatchesByOwner returns the matches whose files are owned by all of
+// the owners in the file:has.owner() filters of the search and by none of the
+// owners in its -file:has.owner() filters.
+func filterFileMatchesByOwner(ctx context.Context, info *search.TextPatternInfo, matches []*FileMatchResolver) ([]*FileMatchResolver, error) {
+	if len(info.FileOwnersMustInclude) == 0 && len(info.FileOwnersMustExclude) == 0 {
+		return matches, nil
+	}
+
+	filtered := matches[:0]
+	for _, fm := range matches {
+		owners, err := fm.Owners(ctx)
+		if err != nil {
+			return nil, err
+		}
+		has := make(map[string]bool, len(owners))
+		for _, o := range owners {
+			has[codeowners.NormalizeOwner(o)] = true
+		}
+
+		ok := true
+		for _, o := range info.FileOwnersMustInclude {
+			ok = ok && has[codeowners.NormalizeOwner(o)]
+		}
+		for _, o := range info.FileOwnersMustExclude {
+			ok = ok && !has[codeowners.NormalizeOwner(o)]
+		}
+		if ok {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,66 @@
+package graphqlbackend
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestFilterFileMatchesByOwner(t *testing.T) {
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		if commit == "c1" && name == ".github/CODEOWNERS" {
+			return []byte("*.go @Org/Go\n/docs/ alice@example.com @org/go\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	t.Cleanup(func() { git.Mocks.ReadFile = nil })
+
+	repo := &RepositoryResolver{repo: &types.Repo{Name: "codeowners-test"}}
+	matches := func() []*FileMatchResolver {
+		return []*FileMatchResolver{
+			{JPath: "main.go", Repo: repo, CommitID: "c1"},
+			{JPath: "docs/index.md", Repo: repo, CommitID: "c1"},
+			{JPath: "README.md", Repo: repo, CommitID: "c1"},
+			{JPath: "main.go", Repo: repo, CommitID: "c2"}, // no CODEOWNERS file
+		}
+	}
+	paths := func(matches []*FileMatchResolver) (paths []string) {
+		for _, fm := range matches {
+			paths = append(paths, string(fm.CommitID)+":"+fm.JPath)
+		}
+		return paths
+	}
+
+	for _, tc := range []struct {
+		include, exclude []string
+		want             []string
+	}{
+		{want: []string{"c1:main.go", "c1:docs/index.md", "c1:README.md", "c2:main.go"}},
+		{include: []string{"org/go"}, want: []string{"c1:main.go", "c1:docs/index.md"}},
+		{include: []string{"@org/go", "alice@example.com"}, want: []string{"c1:docs/index.md"}},
+		{exclude: []string{"@ALICE@example.com"}, want: []string{"c1:main.go", "c1:README.md", "c2:main.go"}},
+	} {
+		info := &search.TextPatternInfo{FileOwnersMustInclude: tc.include, FileOwnersMustExclude: tc.exclude}
+		have, err := filterFileMatchesByOwner(context.Background(), info, matches())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(paths(have), tc.want) {
+			t.Errorf("include %q, exclude %q: got %q, want %q", tc.include, tc.exclude, paths(have), tc.want)
+		}
+	}
+
+	owners, err := (&FileMatchResolver{JPath: "docs/index.md", Repo: repo, CommitID: "c1"}).Owners(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alice@example.com", "@org/go"}; !reflect.DeepEqual(owners, want) {
+		t.Errorf("got owners %q, want %q", owners, want)
+	}
+}
@@ -11,6 +11,11 @@ import (
 func (r *UserResolver) EventLogs(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
 }) (*userEventLogsConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Event logs can only be viewed by the user or site admin.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 		return nil, err
@@ -18,6 +18,11 @@ func (r *siteResolver) ExternalAccounts(ctx context.Context, args *struct {
 	ServiceID   *string
 	ClientID    *string
 }) (*externalAccountConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only site admins can list all external accounts.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 		return nil, err
@@ -47,6 +52,11 @@ func (r *siteResolver) ExternalAccounts(ctx context.Context, args *struct {
 func (r *UserResolver) ExternalAccounts(ctx context.Context, args *struct {
 	graphqlutil.ConnectionArgs
 }) (*externalAccountConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only site admins and the user can list a user's external accounts.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 		return nil, err
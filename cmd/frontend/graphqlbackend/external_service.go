@@ -16,24 +16,28 @@ import (
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
-type externalServiceResolver struct {
+type ExternalServiceResolver struct {
 	externalService *types.ExternalService
 	warning         string
 
 	webhookURLOnce sync.Once
 	webhookURL     string
 	webhookErr     error
+
+	syncStateOnce sync.Once
+	syncState     *types.ExternalServiceSyncState
+	syncStateErr  error
 }
 
 const externalServiceIDKind = "ExternalService"
 
-func externalServiceByID(ctx context.Context, id graphql.ID) (*externalServiceResolver, error) {
+func externalServiceByID(ctx context.Context, id graphql.ID) (*ExternalServiceResolver, error) {
 	// 🚨 SECURITY: Only site admins are allowed to read external services.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 		return nil, err
 	}
 
-	externalServiceID, err := unmarshalExternalServiceID(id)
+	externalServiceID, err := UnmarshalExternalServiceID(id)
 	if err != nil {
 		return nil, err
 	}
@@ -43,14 +47,18 @@ func externalServiceByID(ctx context.Context, id graphql.ID) (*externalServiceRe
 		return nil, err
 	}
 
-	return &externalServiceResolver{externalService: externalService}, nil
+	return &ExternalServiceResolver{externalService: externalService}, nil
+}
+
+func NewExternalServiceResolver(externalService *types.ExternalService) *ExternalServiceResolver {
+	return &ExternalServiceResolver{externalService: externalService}
 }
 
-func marshalExternalServiceID(id int64) graphql.ID {
+func MarshalExternalServiceID(id int64) graphql.ID {
 	return relay.MarshalID(externalServiceIDKind, id)
 }
 
-func unmarshalExternalServiceID(id graphql.ID) (externalServiceID int64, err error) {
+func UnmarshalExternalServiceID(id graphql.ID) (externalServiceID int64, err error) {
 	if kind := relay.UnmarshalKind(id); kind != externalServiceIDKind {
 		err = fmt.Errorf("expected graphql ID to have kind %q; got %q", externalServiceIDKind, kind)
 		return
@@ -59,31 +67,31 @@ func unmarshalExternalServiceID(id graphql.ID) (externalServiceID int64, err err
 	return
 }
 
-func (r *externalServiceResolver) ID() graphql.ID {
-	return marshalExternalServiceID(r.externalService.ID)
+func (r *ExternalServiceResolver) ID() graphql.ID {
+	return MarshalExternalServiceID(r.externalService.ID)
 }
 
-func (r *externalServiceResolver) Kind() string {
+func (r *ExternalServiceResolver) Kind() string {
 	return r.externalService.Kind
 }
 
-func (r *externalServiceResolver) DisplayName() string {
+func (r *ExternalServiceResolver) DisplayName() string {
 	return r.externalService.DisplayName
 }
 
-func (r *externalServiceResolver) Config() JSONCString {
+func (r *ExternalServiceResolver) Config() JSONCString {
 	return JSONCString(r.externalService.Config)
 }
 
-func (r *externalServiceResolver) CreatedAt() DateTime {
+func (r *ExternalServiceResolver) CreatedAt() DateTime {
 	return DateTime{Time: r.externalService.CreatedAt}
 }
 
-func (r *externalServiceResolver) UpdatedAt() DateTime {
+func (r *ExternalServiceResolver) UpdatedAt() DateTime {
 	return DateTime{Time: r.externalService.UpdatedAt}
 }
 
-func (r *externalServiceResolver) WebhookURL() (*string, error) {
+func (r *ExternalServiceResolver) WebhookURL() (*string, error) {
 	r.webhookURLOnce.Do(func() {
 		parsed, err := extsvc.ParseConfig(r.externalService.Kind, r.externalService.Config)
 		if err != nil {
@@ -111,9 +119,70 @@ func (r *externalServiceResolver) WebhookURL() (*string, error) {
 	return &r.webhookURL, r.webhookErr
 }
 
-func (r *externalServiceResolver) Warning() *string {
+func (r *ExternalServiceResolver) Warning() *string {
 	if r.warning == "" {
 		return nil
 	}
 	return &r.warning
 }
+
+func (r *ExternalServiceResolver) getSyncState(ctx context.Context) (*types.ExternalServiceSyncState, error) {
+	r.syncStateOnce.Do(func() {
+		r.syncState, r.syncStateErr = db.ExternalServices.GetSyncState(ctx, r.externalService.ID)
+	})
+	return r.syncState, r.syncStateErr
+}
+
+func (r *ExternalServiceResolver) LastSync(ctx context.Context) (*externalServiceSyncResolver, error) {
+	st, err := r.getSyncState(ctx)
+	if err != nil || st == nil || st.LastSyncStartedAt == nil {
+		return nil, err
+	}
+	return &externalServiceSyncResolver{state: st}, nil
+}
+
+func (r *ExternalServiceResolver) NextSyncAt(ctx context.Context) (*DateTime, error) {
+	st, err := r.getSyncState(ctx)
+	if err != nil || st == nil || st.NextSyncAt == nil {
+		return nil, err
+	}
+	return &DateTime{Time: *st.NextSyncAt}, nil
+}
+
+type externalServiceSyncResolver struct {
+	state *types.ExternalServiceSyncState
+}
+
+func (r *externalServiceSyncResolver) StartedAt() DateTime {
+	return DateTime{Time: *r.state.LastSyncStartedAt}
+}
+
+func (r *externalServiceSyncResolver) FinishedAt() DateTime {
+	if r.state.LastSyncFinishedAt == nil {
+		return DateTime{Time: *r.state.LastSyncStartedAt}
+	}
+	return DateTime{Time: *r.state.LastSyncFinishedAt}
+}
+
+func (r *externalServiceSyncResolver) Error() *string {
+	if r.state.LastSyncError == "" {
+		return nil
+	}
+	return &r.state.LastSyncError
+}
+
+func (r *externalServiceSyncResolver) ReposSourced() int32 {
+	return int32(r.state.ReposSourced)
+}
+
+func (r *externalServiceSyncResolver) ReposAdded() int32 {
+	return int32(r.state.ReposAdded)
+}
+
+func (r *externalServiceSyncResolver) ReposModified() int32 {
+	return int32(r.state.ReposModified)
+}
+
+func (r *externalServiceSyncResolver) ReposDeleted() int32 {
+	return int32(r.state.ReposDeleted)
+}
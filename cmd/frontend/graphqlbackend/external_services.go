@@ -29,7 +29,7 @@ func (r *schemaResolver) AddExternalService(ctx context.Context, args *struct {
 		DisplayName string
 		Config      string
 	}
-}) (*externalServiceResolver, error) {
+}) (*ExternalServiceResolver, error) {
 	// 🚨 SECURITY: Only site admins may add external services.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 		return nil, err
@@ -44,11 +44,18 @@ func (r *schemaResolver) AddExternalService(ctx context.Context, args *struct {
 		Config:      args.Input.Config,
 	}
 
+	// The ID of the external service isn't known until it is created.
+	if err := db.AuditLogs.Record(ctx, db.AuditActionAddExternalService, "", map[string]string{
+		"kind":        externalService.Kind,
+		"displayName": externalService.DisplayName,
+	}); err != nil {
+		return nil, err
+	}
 	if err := db.ExternalServices.Create(ctx, conf.Get, externalService); err != nil {
 		return nil, err
 	}
 
-	res := &externalServiceResolver{externalService: externalService}
+	res := &ExternalServiceResolver{externalService: externalService}
 	if err := syncExternalService(ctx, externalService); err != nil {
 		res.warning = fmt.Sprintf("External service created, but we encountered a problem while validating the external service: %s", err)
 	}
@@ -64,13 +71,13 @@ type UpdateExternalServiceInput struct {
 
 func (*schemaResolver) UpdateExternalService(ctx context.Context, args *struct {
 	Input UpdateExternalServiceInput
-}) (*externalServiceResolver, error) {
+}) (*ExternalServiceResolver, error) {
 	// 🚨 SECURITY: Only site admins are allowed to update the user.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 		return nil, err
 	}
 
-	externalServiceID, err := unmarshalExternalServiceID(args.Input.ID)
+	externalServiceID, err := UnmarshalExternalServiceID(args.Input.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +95,12 @@ func (*schemaResolver) UpdateExternalService(ctx context.Context, args *struct {
 		DisplayName: args.Input.DisplayName,
 		Config:      args.Input.Config,
 	}
+	if err := db.AuditLogs.Record(ctx, db.AuditActionUpdateExternalService, string(args.Input.ID), map[string]interface{}{
+		"displayName":   args.Input.DisplayName,
+		"configChanged": args.Input.Config != nil,
+	}); err != nil {
+		return nil, err
+	}
 	if err := db.ExternalServices.Update(ctx, ps, externalServiceID, update); err != nil {
 		return nil, err
 	}
@@ -97,7 +110,7 @@ func (*schemaResolver) UpdateExternalService(ctx context.Context, args *struct {
 		return nil, err
 	}
 
-	res := &externalServiceResolver{externalService: externalService}
+	res := &ExternalServiceResolver{externalService: externalService}
 	if err = syncExternalService(ctx, externalService); err != nil {
 		res.warning = fmt.Sprintf("External service updated, but we encountered a problem while validating the external service: %s", err)
 	}
@@ -139,7 +152,7 @@ func (*schemaResolver) DeleteExternalService(ctx context.Context, args *struct {
 		return nil, errors.New("deleting external service not allowed when using EXTSVC_CONFIG_FILE")
 	}
 
-	id, err := unmarshalExternalServiceID(args.ExternalService)
+	id, err := UnmarshalExternalServiceID(args.ExternalService)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +162,12 @@ func (*schemaResolver) DeleteExternalService(ctx context.Context, args *struct {
 		return nil, err
 	}
 
+	if err := db.AuditLogs.Record(ctx, db.AuditActionDeleteExternalService, string(args.ExternalService), map[string]string{
+		"kind":        externalService.Kind,
+		"displayName": externalService.DisplayName,
+	}); err != nil {
+		return nil, err
+	}
 	if err := db.ExternalServices.Delete(ctx, id); err != nil {
 		return nil, err
 	}
@@ -192,14 +211,14 @@ func (r *externalServiceConnectionResolver) compute(ctx context.Context) ([]*typ
 	return r.externalServices, r.err
 }
 
-func (r *externalServiceConnectionResolver) Nodes(ctx context.Context) ([]*externalServiceResolver, error) {
+func (r *externalServiceConnectionResolver) Nodes(ctx context.Context) ([]*ExternalServiceResolver, error) {
 	externalServices, err := r.compute(ctx)
 	if err != nil {
 		return nil, err
 	}
-	resolvers := make([]*externalServiceResolver, 0, len(externalServices))
+	resolvers := make([]*ExternalServiceResolver, 0, len(externalServices))
 	for _, externalService := range externalServices {
-		resolvers = append(resolvers, &externalServiceResolver{externalService: externalService})
+		resolvers = append(resolvers, &ExternalServiceResolver{externalService: externalService})
 	}
 	return resolvers, nil
 }
@@ -222,14 +241,14 @@ type computedExternalServiceConnectionResolver struct {
 	externalServices []*types.ExternalService
 }
 
-func (r *computedExternalServiceConnectionResolver) Nodes(ctx context.Context) []*externalServiceResolver {
+func (r *computedExternalServiceConnectionResolver) Nodes(ctx context.Context) []*ExternalServiceResolver {
 	svcs := r.externalServices
 	if r.args.First != nil && int(*r.args.First) < len(svcs) {
 		svcs = svcs[:*r.args.First]
 	}
-	resolvers := make([]*externalServiceResolver, 0, len(svcs))
+	resolvers := make([]*ExternalServiceResolver, 0, len(svcs))
 	for _, svc := range svcs {
-		resolvers = append(resolvers, &externalServiceResolver{externalService: svc})
+		resolvers = append(resolvers, &ExternalServiceResolver{externalService: svc})
 	}
 	return resolvers
 }
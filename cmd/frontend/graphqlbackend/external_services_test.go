@@ -3,7 +3,9 @@ package graphqlbackend
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/graph-gophers/graphql-go/gqltesting"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
@@ -37,11 +39,18 @@ func TestAddExternalService(t *testing.T) {
 		return &types.User{SiteAdmin: true}, nil
 	}
 	db.Mocks.ExternalServices.Create = func(ctx context.Context, confGet func() *conf.Unified, externalService *types.ExternalService) error {
+		externalService.ID = 5
+		return nil
+	}
+	var auditLogEntry *db.AuditLogEntry
+	db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+		auditLogEntry = e
 		return nil
 	}
 	t.Cleanup(func() {
 		db.Mocks.Users = db.MockUsers{}
 		db.Mocks.ExternalServices = db.MockExternalServices{}
+		db.Mocks.AuditLogs = db.MockAuditLogs{}
 	})
 
 	gqltesting.RunTests(t, []*gqltesting.Test{
@@ -71,6 +80,14 @@ func TestAddExternalService(t *testing.T) {
 		`,
 		},
 	})
+
+	want := &db.AuditLogEntry{
+		Action:    db.AuditActionAddExternalService,
+		Arguments: []byte(`{"displayName":"GITHUB #1","kind":"GITHUB"}`),
+	}
+	if !reflect.DeepEqual(auditLogEntry, want) {
+		t.Errorf("got audit log entry %+v, want %+v", auditLogEntry, want)
+	}
 }
 
 func TestUpdateExternalService(t *testing.T) {
@@ -134,9 +151,15 @@ func TestUpdateExternalService(t *testing.T) {
 			Config:      *cachedUpdate.Config,
 		}, nil
 	}
+	var auditLogEntry *db.AuditLogEntry
+	db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+		auditLogEntry = e
+		return nil
+	}
 	t.Cleanup(func() {
 		db.Mocks.Users = db.MockUsers{}
 		db.Mocks.ExternalServices = db.MockExternalServices{}
+		db.Mocks.AuditLogs = db.MockAuditLogs{}
 	})
 
 	gqltesting.RunTests(t, []*gqltesting.Test{
@@ -164,6 +187,16 @@ func TestUpdateExternalService(t *testing.T) {
 		`,
 		},
 	})
+
+	// The configuration contains secrets, so it must not be recorded.
+	want := &db.AuditLogEntry{
+		Action:    db.AuditActionUpdateExternalService,
+		Target:    "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
+		Arguments: []byte(`{"configChanged":true,"displayName":"GITHUB #2"}`),
+	}
+	if !reflect.DeepEqual(auditLogEntry, want) {
+		t.Errorf("got audit log entry %+v, want %+v", auditLogEntry, want)
+	}
 }
 
 func TestDeleteExternalService(t *testing.T) {
@@ -196,9 +229,15 @@ func TestDeleteExternalService(t *testing.T) {
 			ID: id,
 		}, nil
 	}
+	var auditLogEntry *db.AuditLogEntry
+	db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+		auditLogEntry = e
+		return nil
+	}
 	t.Cleanup(func() {
 		db.Mocks.Users = db.MockUsers{}
 		db.Mocks.ExternalServices = db.MockExternalServices{}
+		db.Mocks.AuditLogs = db.MockAuditLogs{}
 	})
 
 	gqltesting.RunTests(t, []*gqltesting.Test{
@@ -220,4 +259,90 @@ func TestDeleteExternalService(t *testing.T) {
 		`,
 		},
 	})
+
+	want := &db.AuditLogEntry{
+		Action:    db.AuditActionDeleteExternalService,
+		Target:    "RXh0ZXJuYWxTZXJ2aWNlOjQ=",
+		Arguments: []byte(`{"displayName":"","kind":""}`),
+	}
+	if !reflect.DeepEqual(auditLogEntry, want) {
+		t.Errorf("got audit log entry %+v, want %+v", auditLogEntry, want)
+	}
+}
+
+func TestExternalServiceLastSync(t *testing.T) {
+	started := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	finished := started.Add(time.Minute)
+	next := finished.Add(time.Hour)
+
+	db.Mocks.ExternalServices.GetSyncState = func(id int64) (*types.ExternalServiceSyncState, error) {
+		if id != 1 {
+			return nil, nil
+		}
+		return &types.ExternalServiceSyncState{
+			ExternalServiceID:  1,
+			LastSyncStartedAt:  &started,
+			LastSyncFinishedAt: &finished,
+			NextSyncAt:         &next,
+			LastSyncError:      "boom",
+			ReposSourced:       3,
+			ReposAdded:         1,
+		}, nil
+	}
+	t.Cleanup(func() {
+		db.Mocks.ExternalServices = db.MockExternalServices{}
+	})
+
+	ctx := context.Background()
+
+	t.Run("not synced", func(t *testing.T) {
+		r := &ExternalServiceResolver{externalService: &types.ExternalService{ID: 2}}
+		lastSync, err := r.LastSync(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lastSync != nil {
+			t.Errorf("lastSync: want nil but got %+v", lastSync)
+		}
+		nextSyncAt, err := r.NextSyncAt(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nextSyncAt != nil {
+			t.Errorf("nextSyncAt: want nil but got %v", nextSyncAt)
+		}
+	})
+
+	t.Run("synced", func(t *testing.T) {
+		r := &ExternalServiceResolver{externalService: &types.ExternalService{ID: 1}}
+		lastSync, err := r.LastSync(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lastSync == nil {
+			t.Fatal("lastSync: want non-nil")
+		}
+
+		have := fmt.Sprintf("%v %v %q %d %d %d %d",
+			lastSync.StartedAt().Time,
+			lastSync.FinishedAt().Time,
+			*lastSync.Error(),
+			lastSync.ReposSourced(),
+			lastSync.ReposAdded(),
+			lastSync.ReposModified(),
+			lastSync.ReposDeleted(),
+		)
+		want := fmt.Sprintf("%v %v %q %d %d %d %d", started, finished, "boom", 3, 1, 0, 0)
+		if have != want {
+			t.Errorf("lastSync: want %q but got %q", want, have)
+		}
+
+		nextSyncAt, err := r.NextSyncAt(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nextSyncAt == nil || !nextSyncAt.Time.Equal(next) {
+			t.Errorf("nextSyncAt: want %v but got %v", next, nextSyncAt)
+		}
+	})
 }
@@ -0,0 +1,139 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/fuzzy"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// maxFuzzyFilesFirst is the maximum number of files returned by
+// GitCommit.fuzzyFiles.
+const maxFuzzyFilesFirst = 1000
+
+// fuzzyFilesPathsCache caches the paths of all files in a commit. Commits are
+// immutable, so entries only expire to free up space.
+var fuzzyFilesPathsCache = rcache.NewWithTTL("fuzzy_files_paths", 86400) // 1d
+
+// maxFuzzyFilesCacheSize is the maximum size in bytes of the paths of a commit
+// that are stored in fuzzyFilesPathsCache. The paths of larger commits are
+// read from gitserver every time, so that they don't fill up Redis.
+var maxFuzzyFilesCacheSize = 1 << 20 // 1 MiB
+
+func (r *GitCommitResolver) FuzzyFiles(ctx context.Context, args *struct {
+	Query string
+	First int32
+}) ([]*fuzzyFileMatchResolver, error) {
+	first := int(args.First)
+	if first < 0 || first > maxFuzzyFilesFirst {
+		return nil, fmt.Errorf("first must be between 0 and %d", maxFuzzyFilesFirst)
+	}
+
+	paths, err := r.filePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := fuzzyMatchPaths(args.Query, paths, first)
+	resolvers := make([]*fuzzyFileMatchResolver, len(matches))
+	for i, m := range matches {
+		resolvers[i] = &fuzzyFileMatchResolver{
+			file:      &GitTreeEntryResolver{commit: r, stat: CreateFileInfo(m.path, false)},
+			score:     m.score,
+			positions: m.positions,
+		}
+	}
+	return resolvers, nil
+}
+
+// filePaths returns the paths of all files (including symlinks, but not
+// directories and submodules) in the commit, in the order of `git ls-tree`.
+func (r *GitCommitResolver) filePaths(ctx context.Context) ([]string, error) {
+	key := fmt.Sprintf("%d:%s", r.repoResolver.repo.ID, r.oid)
+	if b, ok := fuzzyFilesPathsCache.Get(key); ok {
+		if len(b) == 0 {
+			return nil, nil
+		}
+		return strings.Split(string(b), "\n"), nil
+	}
+
+	cachedRepo, err := backend.CachedGitRepo(ctx, r.repoResolver.repo)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := git.ReadDir(ctx, *cachedRepo, api.CommitID(r.oid), "", true)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Mode().IsRegular() || e.Mode()&os.ModeSymlink != 0 {
+			paths = append(paths, e.Name())
+		}
+	}
+
+	if b := strings.Join(paths, "\n"); len(b) <= maxFuzzyFilesCacheSize {
+		fuzzyFilesPathsCache.Set(key, []byte(b))
+	}
+	return paths, nil
+}
+
+type fuzzyPathMatch struct {
+	path      string
+	score     int
+	positions []int
+}
+
+// fuzzyMatchPaths returns the first paths that best match the fuzzy query,
+// ordered by descending score. Shorter paths rank higher among paths with the
+// same score. An empty query matches all paths in their original order.
+func fuzzyMatchPaths(query string, paths []string, first int) []fuzzyPathMatch {
+	var matches []fuzzyPathMatch
+	for _, path := range paths {
+		score, positions, ok := fuzzy.Match(query, path)
+		if ok {
+			matches = append(matches, fuzzyPathMatch{path: path, score: score, positions: positions})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		if len(a.path) != len(b.path) && strings.TrimSpace(query) != "" {
+			return len(a.path) < len(b.path)
+		}
+		return false
+	})
+	if len(matches) > first {
+		matches = matches[:first]
+	}
+	return matches
+}
+
+// fuzzyFileMatchResolver resolves a file matched by GitCommit.fuzzyFiles.
+type fuzzyFileMatchResolver struct {
+	file      *GitTreeEntryResolver
+	score     int
+	positions []int
+}
+
+func (r *fuzzyFileMatchResolver) File() *GitTreeEntryResolver { return r.file }
+
+func (r *fuzzyFileMatchResolver) Score() int32 { return int32(r.score) }
+
+func (r *fuzzyFileMatchResolver) Positions() []int32 {
+	positions := make([]int32, len(r.positions))
+	for i, p := range r.positions {
+		positions[i] = int32(p)
+	}
+	return positions
+}
@@ -0,0 +1,91 @@
+package graphqlbackend
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestFuzzyMatchPaths(t *testing.T) {
+	paths := []string{"cmd/frontend/main.go", "README.md", "cmd/frontend/graphqlbackend/schema.go", "schema/schema.go", "web/src/enterprise/main.ts"}
+
+	for _, tc := range []struct {
+		query string
+		first int
+		want  []string
+	}{
+		{query: "", first: 2, want: []string{"cmd/frontend/main.go", "README.md"}},
+		{query: "main", first: 10, want: []string{"cmd/frontend/main.go", "web/src/enterprise/main.ts"}},
+		{query: "main ts", first: 10, want: []string{"web/src/enterprise/main.ts"}},
+		{query: "gqlbe/schema", first: 10, want: []string{"cmd/frontend/graphqlbackend/schema.go"}},
+		{query: "schema.go", first: 1, want: []string{"schema/schema.go"}},
+		{query: "xyz", first: 10, want: nil},
+	} {
+		var have []string
+		for _, m := range fuzzyMatchPaths(tc.query, paths, tc.first) {
+			have = append(have, m.path)
+		}
+		if !reflect.DeepEqual(have, tc.want) {
+			t.Errorf("%q: got %q, want %q", tc.query, have, tc.want)
+		}
+	}
+}
+
+func TestGitCommitResolver_FuzzyFiles(t *testing.T) {
+	rcache.SetupForTest(t)
+
+	calls := 0
+	git.Mocks.ReadDir = func(commit api.CommitID, name string, recurse bool) ([]os.FileInfo, error) {
+		calls++
+		if commit != "c" || name != "" || !recurse {
+			t.Errorf("unexpected ReadDir(%q, %q, %v)", commit, name, recurse)
+		}
+		return []os.FileInfo{
+			CreateFileInfo("cmd", true),
+			CreateFileInfo("cmd/main.go", false),
+			CreateFileInfo("README.md", false),
+		}, nil
+	}
+	t.Cleanup(func() { git.Mocks.ReadDir = nil })
+
+	fuzzyFiles := func(r *GitCommitResolver) {
+		matches, err := r.FuzzyFiles(context.Background(), &struct {
+			Query string
+			First int32
+		}{Query: "cm", First: 50})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matches) != 1 || matches[0].File().Path() != "cmd/main.go" || !reflect.DeepEqual(matches[0].Positions(), []int32{0, 1}) {
+			t.Errorf("got unexpected matches %+v", matches)
+		}
+	}
+
+	r := &GitCommitResolver{repoResolver: &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "r"}}, oid: "c"}
+	for i := 0; i < 2; i++ {
+		fuzzyFiles(r)
+	}
+	if calls != 1 {
+		t.Errorf("got %d ReadDir calls, want 1 because the paths are cached", calls)
+	}
+
+	t.Run("too large to cache", func(t *testing.T) {
+		defer func(n int) { maxFuzzyFilesCacheSize = n }(maxFuzzyFilesCacheSize)
+		maxFuzzyFilesCacheSize = len("cmd/main.go")
+
+		calls = 0
+		r := &GitCommitResolver{repoResolver: &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "r2"}}, oid: "c"}
+		for i := 0; i < 2; i++ {
+			fuzzyFiles(r)
+		}
+		if calls != 2 {
+			t.Errorf("got %d ReadDir calls, want 2 because the paths are not cached", calls)
+		}
+	})
+}
@@ -237,6 +237,14 @@ func reposourceCloneURLToRepoName(ctx context.Context, cloneURL string) (repoNam
 		repoSources = append(repoSources, reposource.BitbucketServer{BitbucketServerConnection: c.BitbucketServerConnection})
 	}
 
+	gerrits, err := db.ExternalServices.ListGerritConnections(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range gerrits {
+		repoSources = append(repoSources, reposource.Gerrit{GerritConnection: c.GerritConnection})
+	}
+
 	awscodecommits, err := db.ExternalServices.ListAWSCodeCommitConnections(ctx)
 	if err != nil {
 		return "", err
@@ -245,6 +253,14 @@ func reposourceCloneURLToRepoName(ctx context.Context, cloneURL string) (repoNam
 		repoSources = append(repoSources, reposource.AWS{AWSCodeCommitConnection: c.AWSCodeCommitConnection})
 	}
 
+	azuredevopses, err := db.ExternalServices.ListAzureDevOpsConnections(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range azuredevopses {
+		repoSources = append(repoSources, reposource.AzureDevOps{AzureDevOpsConnection: c.AzureDevOpsConnection})
+	}
+
 	gitolites, err := db.ExternalServices.ListGitoliteConnections(ctx)
 	if err != nil {
 		return "", err
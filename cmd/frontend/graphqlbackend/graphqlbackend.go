@@ -377,6 +377,11 @@ func (r *NodeResolver) ToAccessToken() (*accessTokenResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToSearchExport() (*searchExportResolver, bool) {
+	n, ok := r.Node.(*searchExportResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToCampaign() (CampaignResolver, bool) {
 	n, ok := r.Node.(CampaignResolver)
 	return n, ok
@@ -387,6 +392,11 @@ func (r *NodeResolver) ToPatchSet() (PatchSetResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToCampaignTemplate() (CampaignTemplateResolver, bool) {
+	n, ok := r.Node.(CampaignTemplateResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToExternalChangeset() (ExternalChangesetResolver, bool) {
 	n, ok := r.Node.(ChangesetResolver)
 	if !ok {
@@ -439,8 +449,8 @@ func (r *NodeResolver) ToExternalAccount() (*externalAccountResolver, bool) {
 	return n, ok
 }
 
-func (r *NodeResolver) ToExternalService() (*externalServiceResolver, bool) {
-	n, ok := r.Node.(*externalServiceResolver)
+func (r *NodeResolver) ToExternalService() (*ExternalServiceResolver, bool) {
+	n, ok := r.Node.(*ExternalServiceResolver)
 	return n, ok
 }
 
@@ -542,13 +552,23 @@ func (r *schemaResolver) Node(ctx context.Context, args *struct{ ID graphql.ID }
 }
 
 func (r *schemaResolver) nodeByID(ctx context.Context, id graphql.ID) (Node, error) {
+	// 🚨 SECURITY: Actors authenticated with a restricted access token may only resolve the kinds
+	// of nodes that the scopes of the token allow.
+	if err := checkAccessTokenScopesForNode(ctx, relay.UnmarshalKind(id)); err != nil {
+		return nil, err
+	}
+
 	switch relay.UnmarshalKind(id) {
 	case "AccessToken":
 		return accessTokenByID(ctx, id)
+	case "SearchExport":
+		return searchExportByID(ctx, id)
 	case "Campaign":
 		return r.CampaignByID(ctx, id)
 	case "PatchSet":
 		return r.PatchSetByID(ctx, id)
+	case "CampaignTemplate":
+		return r.CampaignTemplateByID(ctx, id)
 	case "ExternalChangeset":
 		return r.ChangesetByID(ctx, id)
 	case "HiddenExternalChangeset":
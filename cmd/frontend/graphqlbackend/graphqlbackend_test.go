@@ -82,8 +82,10 @@ func TestResolverTo(t *testing.T) {
 	for _, r := range resolvers {
 		typ := reflect.TypeOf(r)
 		for i := 0; i < typ.NumMethod(); i++ {
-			if name := typ.Method(i).Name; strings.HasPrefix(name, "To") {
-				reflect.ValueOf(r).MethodByName(name).Call(nil)
+			// Skip resolvers of fields which start with "To", such as
+			// Topics, which take a context.
+			if m := typ.Method(i); strings.HasPrefix(m.Name, "To") && m.Type.NumIn() == 1 {
+				reflect.ValueOf(r).MethodByName(m.Name).Call(nil)
 			}
 		}
 	}
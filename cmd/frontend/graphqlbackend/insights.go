@@ -0,0 +1,76 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func (r *schemaResolver) Insights() []*insightSeriesResolver {
+	series := conf.Get().InsightsSeries
+	l := make([]*insightSeriesResolver, 0, len(series))
+	for _, s := range series {
+		l = append(l, &insightSeriesResolver{series: s})
+	}
+	return l
+}
+
+// insightSeriesResolver resolves a code insights series from the site
+// configuration.
+type insightSeriesResolver struct {
+	series *schema.InsightSeries
+}
+
+func (r *insightSeriesResolver) ID() string { return r.series.Id }
+
+func (r *insightSeriesResolver) Title() string { return r.series.Title }
+
+func (r *insightSeriesResolver) Query() string { return r.series.Query }
+
+func (r *insightSeriesResolver) Interval() string {
+	if r.series.Interval == "" {
+		return "WEEK"
+	}
+	return strings.ToUpper(r.series.Interval)
+}
+
+func (r *insightSeriesResolver) Points(ctx context.Context, args *struct {
+	From, To *DateTime
+}) ([]*insightDataPointResolver, error) {
+	opt := db.InsightSeriesPointsListOptions{SeriesID: r.series.Id, Query: r.series.Query}
+	if args.From != nil {
+		opt.From = &args.From.Time
+	}
+	if args.To != nil {
+		opt.To = &args.To.Time
+	}
+
+	// 🚨 SECURITY: Only count matches in repositories that the viewer may access.
+	points, err := db.InsightSeriesPoints.ListVisible(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sum the points of all repositories. They are ordered by time.
+	var l []*insightDataPointResolver
+	for _, p := range points {
+		if len(l) == 0 || !l[len(l)-1].time.Equal(p.Time) {
+			l = append(l, &insightDataPointResolver{time: p.Time})
+		}
+		l[len(l)-1].value += p.Value
+	}
+	return l, nil
+}
+
+type insightDataPointResolver struct {
+	time  time.Time
+	value int32
+}
+
+func (r *insightDataPointResolver) DateTime() DateTime { return DateTime{Time: r.time} }
+
+func (r *insightDataPointResolver) Value() int32 { return r.value }
@@ -0,0 +1,157 @@
+package graphqlbackend
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestInsightSeriesTimes(t *testing.T) {
+	now := time.Date(2020, 7, 15, 13, 30, 0, 0, time.UTC) // a Wednesday
+	date := func(month time.Month, day int) time.Time { return time.Date(2020, month, day, 0, 0, 0, 0, time.UTC) }
+	two := 2
+
+	for _, tc := range []struct {
+		series *schema.InsightSeries
+		want   []time.Time
+	}{
+		{
+			series: &schema.InsightSeries{Interval: "day", HistoricalPoints: &two},
+			want:   []time.Time{date(7, 13), date(7, 14), date(7, 15)},
+		},
+		{
+			series: &schema.InsightSeries{HistoricalPoints: &two},
+			want:   []time.Time{date(6, 29), date(7, 6), date(7, 13)},
+		},
+		{
+			series: &schema.InsightSeries{Interval: "month", HistoricalPoints: &two},
+			want:   []time.Time{date(5, 1), date(6, 1), date(7, 1)},
+		},
+	} {
+		t.Run(tc.series.Interval, func(t *testing.T) {
+			if have := insightSeriesTimes(tc.series, now); !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %v, want %v", have, tc.want)
+			}
+		})
+	}
+
+	if have := insightSeriesTimes(&schema.InsightSeries{}, now); len(have) != 13 {
+		t.Errorf("got %d times by default, want 13", len(have))
+	}
+}
+
+// 🚨 SECURITY: This tests that points only count matches in repositories that the viewer may access.
+func TestInsights(t *testing.T) {
+	resetMocks()
+	defer resetMocks()
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		InsightsSeries: []*schema.InsightSeries{{Id: "s", Title: "S", Query: "foo", Interval: "month"}},
+	}})
+	defer conf.Mock(nil)
+
+	t1 := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	db.Mocks.InsightSeriesPoints.List = func(opt db.InsightSeriesPointsListOptions) ([]*db.InsightSeriesPoint, error) {
+		if opt.SeriesID != "s" || opt.Query != "foo" {
+			t.Errorf("unexpected options %+v", opt)
+		}
+		return []*db.InsightSeriesPoint{
+			{RepoID: 1, Time: t1, Value: 1},
+			{RepoID: 2, Time: t1, Value: 10},
+			{RepoID: 3, Time: t1, Value: 100},
+			{RepoID: 1, Time: t2, Value: 2},
+			{RepoID: 3, Time: t2, Value: 200},
+		}, nil
+	}
+	db.Mocks.Repos.GetByIDs = func(_ context.Context, ids ...api.RepoID) ([]*types.Repo, error) {
+		// Repository 3 is not visible to the viewer.
+		return []*types.Repo{{ID: 1}, {ID: 2}}, nil
+	}
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t),
+			Query: `
+				{
+					insights {
+						id
+						title
+						interval
+						points {
+							dateTime
+							value
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"insights": [
+						{
+							"id": "s",
+							"title": "S",
+							"interval": "MONTH",
+							"points": [
+								{"dateTime": "2020-06-01T00:00:00Z", "value": 11},
+								{"dateTime": "2020-07-01T00:00:00Z", "value": 2}
+							]
+						}
+					]
+				}
+			`,
+		},
+	})
+}
+
+func TestRecordInsightSeries(t *testing.T) {
+	resetMocks()
+	defer resetMocks()
+	mockDecodedViewerFinalSettings = &schema.Settings{}
+	defer func() { mockDecodedViewerFinalSettings = nil }()
+
+	db.Mocks.Repos.List = func(context.Context, db.ReposListOptions) ([]*types.Repo, error) {
+		return []*types.Repo{{ID: 1, Name: "a"}, {ID: 2, Name: "empty"}}, nil
+	}
+	db.Mocks.Repos.Count = func(context.Context, db.ReposListOptions) (int, error) { return 0, nil }
+	db.Mocks.InsightSeriesPoints.DeleteOtherQueries = func(seriesID, query string) error { return nil }
+	db.Mocks.InsightSeriesPoints.RecordedTimes = func(seriesID, query string) ([]time.Time, error) { return nil, nil }
+	var upserted []*db.InsightSeriesPoint
+	db.Mocks.InsightSeriesPoints.Upsert = func(points ...*db.InsightSeriesPoint) error {
+		upserted = append(upserted, points...)
+		return nil
+	}
+
+	// HEAD doesn't resolve in the empty repository.
+	mockCountInsightMatchesAt = func(rr *search.RepositoryRevisions, t time.Time) (int32, error) {
+		if rr.Repo.Name == "empty" {
+			return 0, errors.New("revision not found: HEAD")
+		}
+		return 3, nil
+	}
+	defer func() { mockCountInsightMatchesAt = nil }()
+
+	now := time.Date(2020, 7, 15, 13, 30, 0, 0, time.UTC)
+	one := 1
+	series := &schema.InsightSeries{Id: "s", Query: "foo", Interval: "month", HistoricalPoints: &one}
+	if err := recordInsightSeries(context.Background(), series, now); err == nil {
+		t.Error("want error for the empty repository")
+	}
+
+	want := []*db.InsightSeriesPoint{
+		{SeriesID: "s", Query: "foo", RepoID: 1, Time: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), Value: 3},
+		{SeriesID: "s", Query: "foo", RepoID: 1, Time: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), Value: 3},
+	}
+	if !reflect.DeepEqual(upserted, want) {
+		t.Errorf("got points %+v, want %+v", upserted, want)
+	}
+}
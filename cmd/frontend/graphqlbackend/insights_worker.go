@@ -0,0 +1,240 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	querytypes "github.com/sourcegraph/sourcegraph/internal/search/query/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	// insightsWorkerInterval is how often the worker checks whether points
+	// are missing from the configured code insights series.
+	insightsWorkerInterval = time.Hour
+
+	// insightsMatchLimit is the maximum number of matches counted per
+	// repository and point, unless the series' query sets its own count.
+	insightsMatchLimit = 100000
+)
+
+// RunInsightsWorker records the points of the code insights series configured
+// in the site configuration until ctx is canceled. Missing historical points
+// are backfilled by searching the commits that were the latest at the time of
+// each point. Workers in different frontend replicas take turns, so that
+// only one of them searches at a time.
+func RunInsightsWorker(ctx context.Context) {
+	// 🚨 SECURITY: Points are recorded for all repositories. They are
+	// filtered by the viewer's repository permissions when they are shown.
+	ctx = actor.WithActor(ctx, &actor.Actor{Internal: true})
+
+	for ctx.Err() == nil {
+		recordInsightsSeries(ctx)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(insightsWorkerInterval):
+		}
+	}
+}
+
+// recordInsightsSeries records the points of all configured series, unless
+// another frontend replica is already doing so.
+func recordInsightsSeries(ctx context.Context) {
+	ctx, release, ok := rcache.TryAcquireMutex(ctx, "insights-worker")
+	if !ok {
+		return
+	}
+	defer release()
+
+	for _, s := range conf.Get().InsightsSeries {
+		if err := recordInsightSeries(ctx, s, time.Now()); err != nil {
+			log15.Error("recording code insights series", "series", s.Id, "error", err)
+		}
+	}
+}
+
+// recordInsightSeries records the points of the series that are due at now
+// and have not been recorded yet. The points of all repositories at a time are
+// recorded together, so that a time is either recorded or computed again on
+// the next run. Repositories whose matches can't be counted at a time, such as
+// empty repositories or repositories that are still being cloned, are left out
+// of it, so that they don't keep the series from being recorded. A time is
+// only computed again if no repository could be counted.
+func recordInsightSeries(ctx context.Context, s *schema.InsightSeries, now time.Time) error {
+	if err := db.InsightSeriesPoints.DeleteOtherQueries(ctx, s.Id, s.Query); err != nil {
+		return err
+	}
+
+	recorded, err := db.InsightSeriesPoints.RecordedTimes(ctx, s.Id, s.Query)
+	if err != nil {
+		return err
+	}
+	isRecorded := make(map[time.Time]bool, len(recorded))
+	for _, t := range recorded {
+		isRecorded[t.UTC()] = true
+	}
+	var missing []time.Time
+	for _, t := range insightSeriesTimes(s, now) {
+		if !isRecorded[t] {
+			missing = append(missing, t)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	r, err := newInsightSearchResolver(s.Query)
+	if err != nil {
+		return err
+	}
+	repoRevs, _, _, _, err := r.resolveRepositories(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	var errs *multierror.Error
+	for _, t := range missing {
+		points := make([]*db.InsightSeriesPoint, 0, len(repoRevs))
+		for _, rr := range repoRevs {
+			value, err := countInsightMatchesAt(ctx, s.Query, rr, t)
+			if err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "counting matches in %s at %s", rr.Repo.Name, t.Format(time.RFC3339)))
+				continue
+			}
+			points = append(points, &db.InsightSeriesPoint{
+				SeriesID: s.Id,
+				Query:    s.Query,
+				RepoID:   rr.Repo.ID,
+				Time:     t,
+				Value:    value,
+			})
+		}
+		if err := db.InsightSeriesPoints.Upsert(ctx, points...); err != nil {
+			return err
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+var mockCountInsightMatchesAt func(rr *search.RepositoryRevisions, t time.Time) (int32, error)
+
+// countInsightMatchesAt returns the number of matches of the query in the
+// latest commit before t of the repository's (first) searched revision.
+func countInsightMatchesAt(ctx context.Context, q string, rr *search.RepositoryRevisions, t time.Time) (int32, error) {
+	if mockCountInsightMatchesAt != nil {
+		return mockCountInsightMatchesAt(rr, t)
+	}
+
+	rev := "HEAD"
+	if revs := rr.RevSpecs(); len(revs) > 0 && revs[0] != "" {
+		rev = revs[0]
+	}
+	commits, err := git.Commits(ctx, gitserver.Repo{Name: rr.Repo.Name}, git.CommitsOptions{
+		Range:  rev,
+		Before: t.Format(time.RFC3339),
+		N:      1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(commits) == 0 {
+		// The repository has no history before t.
+		return 0, nil
+	}
+
+	r, err := newInsightSearchResolver(q)
+	if err != nil {
+		return 0, err
+	}
+	// Search only the commit, by seeding the cache of resolved repositories.
+	r.repoRevs = []*search.RepositoryRevisions{{
+		Repo: rr.Repo,
+		Revs: []search.RevisionSpecifier{{RevSpec: string(commits[0].ID)}},
+	}}
+	r.excludedRepos = &excludedRepos{}
+
+	results, err := r.Results(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if results.alert != nil {
+		return 0, searchAlertError(results.alert)
+	}
+	return results.MatchCount(), nil
+}
+
+// newInsightSearchResolver returns the searchResolver for a code insights
+// series' query. Unless the query says otherwise, it counts text matches.
+func newInsightSearchResolver(q string) (*searchResolver, error) {
+	impl, err := NewSearchImplementer(&SearchArgs{Version: "V2", Query: q})
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := impl.(type) {
+	case *searchAlert:
+		return nil, searchAlertError(r)
+	case *searchResolver:
+		oq, ok := r.query.(*query.OrdinaryQuery)
+		if !ok {
+			return nil, errors.New("code insights do not support queries with and/or expressions")
+		}
+		fields := oq.Fields()
+		if _, ok := fields[query.FieldType]; !ok {
+			fileValue := "file"
+			fields[query.FieldType] = []*querytypes.Value{{String: &fileValue}}
+		}
+		if !r.countIsSet() {
+			count := strconv.Itoa(insightsMatchLimit)
+			fields[query.FieldCount] = []*querytypes.Value{{String: &count}}
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unexpected search implementation %T", impl)
+	}
+}
+
+// insightSeriesTimes returns the times of the series' points that are due at
+// now, in ascending order: the start of the current interval and the starts of
+// the configured number of previous intervals. Weeks start on Monday, and all
+// times are in UTC.
+func insightSeriesTimes(s *schema.InsightSeries, now time.Time) []time.Time {
+	historicalPoints := 12
+	if s.HistoricalPoints != nil {
+		historicalPoints = *s.HistoricalPoints
+	}
+
+	now = now.UTC()
+	t := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	prev := func(t time.Time) time.Time { return t.AddDate(0, 0, -7) }
+	switch s.Interval {
+	case "day":
+		prev = func(t time.Time) time.Time { return t.AddDate(0, 0, -1) }
+	case "month":
+		t = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		prev = func(t time.Time) time.Time { return t.AddDate(0, -1, 0) }
+	default: // "week"
+		t = t.AddDate(0, 0, -(int(t.Weekday())+6)%7)
+	}
+
+	times := make([]time.Time, historicalPoints+1)
+	for i := len(times) - 1; i >= 0; i-- {
+		times[i] = t
+		t = prev(t)
+	}
+	return times
+}
@@ -82,6 +82,11 @@ func (o *OrgResolver) SettingsURL() *string { return strptr(o.URL() + "/settings
 func (o *OrgResolver) CreatedAt() DateTime { return DateTime{Time: o.org.CreatedAt} }
 
 func (o *OrgResolver) Members(ctx context.Context) (*staticUserConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only org members can list the org members.
 	if err := backend.CheckOrgAccess(ctx, o.org.ID); err != nil {
 		if err == backend.ErrNotAnOrgMember {
@@ -110,6 +115,11 @@ func (o *OrgResolver) settingsSubject() api.SettingsSubject {
 }
 
 func (o *OrgResolver) LatestSettings(ctx context.Context) (*settingsResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only organization members and site admins may access the settings, because they
 	// may contains secrets or other sensitive data.
 	if err := backend.CheckOrgAccess(ctx, o.org.ID); err != nil {
@@ -133,6 +143,11 @@ func (o *OrgResolver) SettingsCascade() *settingsCascade {
 func (o *OrgResolver) ConfigurationCascade() *settingsCascade { return o.SettingsCascade() }
 
 func (o *OrgResolver) ViewerPendingInvitation(ctx context.Context) (*organizationInvitationResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	if actor := actor.FromContext(ctx); actor.IsAuthenticated() {
 		orgInvitation, err := db.OrgInvitations.GetPending(ctx, o.org.ID, actor.UID)
 		if errcode.IsNotFound(err) {
@@ -242,8 +257,14 @@ func (*schemaResolver) RemoveUserFromOrganization(ctx context.Context, args *str
 		return nil, err
 	}
 
+	if err := db.AuditLogs.Record(ctx, db.AuditActionRemoveUserFromOrganization, string(args.Organization), map[string]graphql.ID{"user": args.User}); err != nil {
+		return nil, err
+	}
 	log15.Info("removing user from org", "user", userID, "org", orgID)
-	return nil, db.OrgMembers.Remove(ctx, orgID, userID)
+	if err := db.OrgMembers.Remove(ctx, orgID, userID); err != nil {
+		return nil, err
+	}
+	return nil, nil
 }
 
 func (*schemaResolver) AddUserToOrganization(ctx context.Context, args *struct {
@@ -265,6 +286,9 @@ func (*schemaResolver) AddUserToOrganization(ctx context.Context, args *struct {
 	if err != nil {
 		return nil, err
 	}
+	if err := db.AuditLogs.Record(ctx, db.AuditActionAddUserToOrganization, string(args.Organization), map[string]graphql.ID{"user": MarshalUserID(userToInvite.ID)}); err != nil {
+		return nil, err
+	}
 	if _, err := db.OrgMembers.Create(ctx, orgID, userToInvite.ID); err != nil {
 		return nil, err
 	}
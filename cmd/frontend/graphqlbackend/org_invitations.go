@@ -127,6 +127,18 @@ func (*schemaResolver) RespondToOrganizationInvitation(ctx context.Context, args
 		return nil, fmt.Errorf("invalid OrganizationInvitationResponseType value %q", args.ResponseType)
 	}
 
+	if accept {
+		// The invitation is only looked up to record its organization in the audit log. Respond
+		// checks that the current user is its recipient.
+		invitation, err := db.OrgInvitations.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := db.AuditLogs.Record(ctx, db.AuditActionAcceptOrganizationInvitation, string(marshalOrgID(invitation.OrgID)), map[string]graphql.ID{"organizationInvitation": args.OrganizationInvitation}); err != nil {
+			return nil, err
+		}
+	}
+
 	// 🚨 SECURITY: This fails if the org invitation's recipient is not the one given (or if the
 	// invitation is otherwise invalid), so we do not need to separately perform that check.
 	orgID, err := db.OrgInvitations.Respond(ctx, id, currentUser.user.ID, accept)
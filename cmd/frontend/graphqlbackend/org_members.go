@@ -9,6 +9,11 @@ import (
 )
 
 func (r *UserResolver) OrganizationMemberships(ctx context.Context) (*organizationMembershipConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	memberships, err := db.OrgMembers.GetByUserID(ctx, r.user.ID)
 	if err != nil {
 		return nil, err
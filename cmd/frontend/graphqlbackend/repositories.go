@@ -331,6 +331,8 @@ func toDBRepoListColumn(ob string) db.RepoListColumn {
 		return db.RepoListName
 	case "REPO_CREATED_AT", "REPOSITORY_CREATED_AT":
 		return db.RepoListCreatedAt
+	case "REPOSITORY_STARS":
+		return db.RepoListStars
 	default:
 		return ""
 	}
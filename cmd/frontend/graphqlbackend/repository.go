@@ -128,6 +128,51 @@ func (r *RepositoryResolver) Description(ctx context.Context) (string, error) {
 	return r.repo.Description, nil
 }
 
+func (r *RepositoryResolver) Topics(ctx context.Context) ([]string, error) {
+	err := r.hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.repo.Topics == nil {
+		return []string{}, nil
+	}
+	return r.repo.Topics, nil
+}
+
+func (r *RepositoryResolver) Stars(ctx context.Context) (int32, error) {
+	err := r.hydrate(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(r.repo.Stars), nil
+}
+
+func (r *RepositoryResolver) PushedAt(ctx context.Context) (*DateTime, error) {
+	err := r.hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.repo.PushedAt.IsZero() {
+		return nil, nil
+	}
+	return &DateTime{Time: r.repo.PushedAt}, nil
+}
+
+func (r *RepositoryResolver) License(ctx context.Context) (*string, error) {
+	err := r.hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.repo.License == "" {
+		return nil, nil
+	}
+	return &r.repo.License, nil
+}
+
 func (r *RepositoryResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
 		if err == backend.ErrMustBeSiteAdmin || err == backend.ErrNotAuthenticated {
@@ -206,10 +251,14 @@ func (r *RepositoryResolver) DefaultBranch(ctx context.Context) (*GitRefResolver
 }
 
 func (r *RepositoryResolver) Language(ctx context.Context) string {
-	// The repository language is the most common language at the HEAD commit of the repository.
-	// Note: the repository database field is no longer updated as of
-	// https://github.com/sourcegraph/sourcegraph/issues/2586, so we do not use it anymore and
-	// instead compute the language on the fly.
+	// Prefer the primary language reported by the code host, if repo-updater
+	// synced one.
+	if err := r.hydrate(ctx); err == nil && r.repo.Language != "" {
+		return r.repo.Language
+	}
+
+	// Otherwise, the repository language is the most common language at the
+	// HEAD commit of the repository, computed on the fly.
 
 	commitID, err := backend.Repos.ResolveRev(ctx, r.repo, "")
 	if err != nil {
@@ -278,6 +327,9 @@ func (r *RepositoryResolver) ToCommitSearchResult() (*commitSearchResultResolver
 func (r *RepositoryResolver) ToCodemodResult() (*codemodResultResolver, bool) {
 	return nil, false
 }
+func (r *RepositoryResolver) ToCommitAuthorSearchResult() (*commitAuthorSearchResultResolver, bool) {
+	return nil, false
+}
 
 func (r *RepositoryResolver) searchResultURIs() (string, string) {
 	return string(r.repo.Name), ""
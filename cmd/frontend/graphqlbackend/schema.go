@@ -47,6 +47,18 @@ type Mutation {
         # created from this PatchSet.
         patches: [PatchInput!]!
     ): PatchSet!
+    # Create a patchset from a campaign spec, whose steps are executed on the server in every
+    # repository that matches the spec's repositoriesMatchingQuery. The patches are computed in the
+    # background, and PatchSet.status reports the progress.
+    #
+    # Only site admins may create patchsets from campaign specs.
+    createPatchSetFromSpec(
+        # The campaign spec (in JSON), for example:
+        #
+        #   {"repositoriesMatchingQuery": "lang:go fmt.Sprintf(\"%d\", :[v])",
+        #    "steps": [{"type": "comby", "matchTemplate": "fmt.Sprintf(\"%d\", :[v])", "rewriteTemplate": "strconv.Itoa(:[v])", "fileExtension": ".go"}]}
+        spec: String!
+    ): PatchSet!
     # Updates a campaign. Updating is not allowed when any of the following are true:
     #
     # - The campaign has been closed.
@@ -79,14 +91,83 @@ type Mutation {
     # Callers can query the campaign's status to track the progress of this async operation.
     # If one of the patches has previously been published but the publication failed,
     # publication is NOT retried.
-    publishCampaignChangesets(campaign: ID!): Campaign!
+    # If draft is true, the changesets are created as drafts (e.g. draft pull requests on
+    # GitHub) that can later be marked as ready for review with
+    # markCampaignChangesetsReadyForReview.
+    # If rollout is given, the changesets are published in waves instead of all at once.
+    publishCampaignChangesets(
+        campaign: ID!
+        draft: Boolean = false
+        rollout: ChangesetRolloutInput
+    ): Campaign!
     # Create an ExternalChangeset on the code host asynchronously. The patch
     # must belong to a patchset that has been attached to a campaign;
     # otherwise, an error is returned and no ExternalChangeset is created.
     # Callers can query the campaign's status to track the progress of this async operation.
     # If the patch has previously been published but the publication failed,
-    # the patch is reset and publication will be retried.
-    publishChangeset(patch: ID!): EmptyResponse!
+    # the patch is reset and publication will be retried. If the patch is scheduled
+    # for a later wave of a rollout, it is published right away.
+    # If draft is true, the changeset is created as a draft.
+    publishChangeset(patch: ID!, draft: Boolean = false): EmptyResponse!
+    # Mark the draft changesets of a campaign as ready for review on their code hosts.
+    # Changesets of the campaign that have not been published yet are published as
+    # ready for review.
+    markCampaignChangesetsReadyForReview(campaign: ID!): Campaign!
+    # Run an action on the changesets of a campaign on their code hosts, e.g. comment on or close
+    # them. If filter is given, the action is only run on the changesets matching it.
+    # The action is run asynchronously for each changeset. Callers can query the status of the
+    # returned bulk action to track its progress.
+    # Only site admins and the author of the campaign may perform this mutation.
+    runChangesetBulkAction(
+        campaign: ID!
+        action: ChangesetBulkActionInput!
+        filter: ChangesetBulkFilterInput
+    ): ChangesetBulkAction!
+    # Import the existing changesets matching a search on the code host of an external service
+    # into a campaign. On GitHub, the query is a search query, e.g. "org:sourcegraph label:security-fix".
+    # On Bitbucket Server, the query is the "PROJECT/repo" name of a repository, optionally followed
+    # by the filters of its pull request listing, e.g. "SG/sourcegraph?state=ALL&filterText=security".
+    # The search is run periodically in the background and new matching changesets are added to the
+    # campaign. Changesets in repositories that don't exist on Sourcegraph are ignored.
+    # Only site admins may perform this mutation.
+    importChangesets(campaign: ID!, externalService: ID!, query: String!): ChangesetImport!
+    # Stop importing the changesets matching the search of a changeset import. The changesets it
+    # already imported stay in the campaign.
+    # Only site admins may perform this mutation.
+    deleteChangesetImport(changesetImport: ID!): EmptyResponse!
+    # Create a campaign template in a namespace. Templates are campaign specs (see
+    # createPatchSetFromSpec) with parameters, from which patchsets are created with
+    # createPatchSetFromCampaignTemplate.
+    # Only site admins may perform this mutation.
+    createCampaignTemplate(input: CreateCampaignTemplateInput!): CampaignTemplate!
+    # Update a campaign template. The version of the template is incremented if its spec changes,
+    # so that campaigns created from an earlier version can be re-applied with
+    # reapplyCampaignTemplate.
+    # Only site admins may perform this mutation.
+    updateCampaignTemplate(input: UpdateCampaignTemplateInput!): CampaignTemplate!
+    # Delete a campaign template. The campaigns created from it are kept.
+    # Only site admins may perform this mutation.
+    deleteCampaignTemplate(campaignTemplate: ID!): EmptyResponse!
+    # Create a patchset from the campaign spec of a campaign template, rendered with the given
+    # arguments, like createPatchSetFromSpec. Parameters without an argument get their default
+    # value.
+    #
+    # To create the campaign, call createCampaign with the returned PatchSet.id in the
+    # CreateCampaignInput.patchSet field.
+    #
+    # Only site admins may perform this mutation.
+    createPatchSetFromCampaignTemplate(
+        campaignTemplate: ID!
+        arguments: [CampaignTemplateArgumentInput!]
+    ): PatchSet!
+    # Create a patchset from the latest version of the campaign template that the patchset of the
+    # campaign was created from, with the same arguments.
+    #
+    # To re-apply the template to the campaign, call updateCampaign with the returned PatchSet.id
+    # in the UpdateCampaignInput.patchSet field.
+    #
+    # Only site admins may perform this mutation.
+    reapplyCampaignTemplate(campaign: ID!): PatchSet!
     # Enqueue the given changeset for high-priority syncing.
     syncChangeset(changeset: ID!): EmptyResponse!
 
@@ -200,15 +281,47 @@ type Mutation {
     #
     # - "user:all": Full control of all resources accessible to the user account.
     # - "site-admin:sudo": Ability to perform any action as any other user. (Only site admins may create tokens
-    #   with this scope.)
+    #   with this scope, and the token must also have the "user:all" scope.)
+    #
+    # Tokens without the "user:all" scope are restricted to the operations allowed by one or more of the
+    # following scopes:
+    #
+    # - "search:read": Running searches.
+    # - "repo:read": Reading repositories and their contents.
+    # - "campaigns:write": Reading, creating and updating campaigns.
+    # - "codeintel:upload": Uploading LSIF data.
+    #
+    # If expiresAt is set, the token can't be used after that time.
     #
     # Only the user or site admins may perform this mutation.
-    createAccessToken(user: ID!, scopes: [String!]!, note: String!): CreateAccessTokenResult!
+    createAccessToken(user: ID!, scopes: [String!]!, note: String!, expiresAt: DateTime): CreateAccessTokenResult!
     # Deletes and immediately revokes the specified access token, specified by either its ID or by the token
     # itself.
     #
     # Only site admins or the user who owns the token may perform this mutation.
     deleteAccessToken(byID: ID, byToken: String): EmptyResponse!
+    # Starts an export of all results of a search query to a downloadable file. The export runs in the
+    # background as the current user; poll the returned SearchExport for its progress.
+    #
+    # Exports contain text matches only and do not support queries with and/or expressions.
+    createSearchExport(
+        # The search query, as in Query.search.
+        query: String!
+        # The version of the search syntax being used.
+        version: SearchVersion = V2
+        # The search pattern type, if it is not specified in the query using the patternType: field.
+        patternType: SearchPatternType
+        # The format of the exported file.
+        format: SearchExportFormat!
+    ): SearchExport!
+    # Cancels a queued or running search export.
+    #
+    # Only the user who requested the export or site admins may perform this mutation.
+    cancelSearchExport(searchExport: ID!): SearchExport!
+    # Deletes all queries from the user's search history.
+    #
+    # Only the user and site admins may perform this mutation.
+    clearSearchHistory(user: ID!): EmptyResponse!
     # Deletes the association between an external account and its Sourcegraph user. It does NOT delete the external
     # account on the external service where it resides.
     #
@@ -478,6 +591,10 @@ input CreateCampaignInput {
     # create or update a campaign will retain it for the lifetime of the
     # campaign and prevent it from expiring.
     patchSet: ID
+
+    # The settings for automatically merging the changesets created by the campaign. If null,
+    # auto-merge is disabled.
+    autoMerge: CampaignAutoMergeInput
 }
 
 # Input arguments for updating a campaign.
@@ -498,6 +615,104 @@ input UpdateCampaignInput {
     # A patchset that describes a new set of changes to make. If set, the previous changesets are
     # updated or closed, and new changesetes are created, to reflect the new patchset.
     patchSet: ID
+
+    # The updated settings for automatically merging the changesets created by the campaign, if
+    # non-null.
+    autoMerge: CampaignAutoMergeInput
+}
+
+# Input arguments for publishing the changesets of a campaign in waves.
+input ChangesetRolloutInput {
+    # The number of changesets published per wave. The changesets are ordered by the name of
+    # their repository.
+    waveSize: Int!
+
+    # The number of hours between two waves.
+    waveIntervalHours: Int!
+}
+
+# Input arguments for an action run on many changesets of a campaign.
+input ChangesetBulkActionInput {
+    # The type of the action.
+    type: ChangesetBulkActionType!
+
+    # The body (as Markdown) of the comment created by a COMMENT action.
+    body: String
+
+    # The names of the labels added by an ADD_LABELS action. The labels must exist in the
+    # repositories of the changesets.
+    labels: [String!]
+
+    # The usernames on the code host of the users whose review is requested by a
+    # REQUEST_REVIEWERS action.
+    reviewers: [String!]
+}
+
+# Input arguments for selecting the changesets of a campaign an action is run on.
+input ChangesetBulkFilterInput {
+    # Only include changesets with the given state.
+    state: ChangesetState
+    # Only include changesets with the given review state.
+    reviewState: ChangesetReviewState
+    # Only include changesets with the given check state.
+    checkState: ChangesetCheckState
+    # Only include changesets in the given repositories.
+    repositories: [ID!]
+}
+
+# Input arguments for the settings with which the changesets created by a campaign are merged
+# automatically.
+input CampaignAutoMergeInput {
+    # Whether changesets are merged once they are approved and their checks passed.
+    enabled: Boolean!
+
+    # The method used to merge changesets. If null, the default merge method of the repository on
+    # the code host is used.
+    method: ChangesetMergeMethod
+
+    # The maximum number of changesets merged per hour on each code host. If null, there is no
+    # limit.
+    maxMergesPerHour: Int
+
+    # The start of the daily window (UTC, formatted as "HH:MM") in which changesets are merged.
+    # If null, changesets are merged at any time of day.
+    windowStart: String
+
+    # The end of the daily window (UTC, formatted as "HH:MM") in which changesets are merged. If
+    # it is before windowStart, the window spans midnight.
+    windowEnd: String
+}
+
+# The settings with which the changesets created by a campaign are merged automatically once they
+# are approved and their checks passed.
+type CampaignAutoMerge {
+    # Whether changesets are merged automatically.
+    enabled: Boolean!
+
+    # The method used to merge changesets. If null, the default merge method of the repository on
+    # the code host is used.
+    method: ChangesetMergeMethod
+
+    # The maximum number of changesets merged per hour on each code host. If null, there is no
+    # limit.
+    maxMergesPerHour: Int
+
+    # The start of the daily window (UTC, formatted as "HH:MM") in which changesets are merged.
+    # If null, changesets are merged at any time of day.
+    windowStart: String
+
+    # The end of the daily window (UTC, formatted as "HH:MM") in which changesets are merged.
+    windowEnd: String
+}
+
+# The method with which a changeset is merged on its code host.
+enum ChangesetMergeMethod {
+    # Merge the changeset with a merge commit.
+    MERGE
+    # Squash the commits of the changeset into a single commit.
+    SQUASH
+    # Rebase the commits of the changeset onto the base branch.
+    REBASE
 }
 
 # A set of patches that will be applied to code by a campaign. Each patch corresponds to a single
@@ -515,6 +730,23 @@ type PatchSet implements Node {
 
     # The diff stat for all the patches in the patchset.
     diffStat: DiffStat!
+
+    # The progress of the computation of the patches of a patchset created from a campaign spec.
+    # Patchsets created from patches computed by the caller are always completed.
+    status: BackgroundProcessStatus!
+
+    # The campaign template that the patchset was created from, if any. It is null if the
+    # template was deleted.
+    campaignTemplate: CampaignTemplate
+
+    # The version of the campaign template that the patchset was created from. If it is lower than
+    # the current version of the template, the template can be re-applied with
+    # reapplyCampaignTemplate.
+    campaignTemplateVersion: Int
+
+    # The arguments with which the campaign template was rendered, if the patchset was created
+    # from a campaign template.
+    campaignTemplateArguments: [CampaignTemplateArgument!]
 }
 
 # A paginated list of repository diffs committed to git.
@@ -639,6 +871,194 @@ type Campaign implements Node {
 
     # The diff stat for all the patches and changesets in the campaign.
     diffStat: DiffStat!
+
+    # The settings with which the changesets created by the campaign are merged automatically.
+    autoMerge: CampaignAutoMerge!
+
+    # The actions run on the changesets of the campaign, most recent first.
+    changesetBulkActions(first: Int): [ChangesetBulkAction!]!
+
+    # The searches whose matching changesets are imported into the campaign.
+    changesetImports: [ChangesetImport!]!
+}
+
+# The type of an action run on many changesets of a campaign.
+enum ChangesetBulkActionType {
+    # Add a comment to the changesets.
+    COMMENT
+    # Close the open changesets.
+    CLOSE
+    # Reopen the closed changesets.
+    REOPEN
+    # Add labels to the changesets. Not supported on Bitbucket Server.
+    ADD_LABELS
+    # Request reviews of the changesets from users.
+    REQUEST_REVIEWERS
+}
+
+# An action run on many changesets of a campaign.
+type ChangesetBulkAction {
+    # The unique ID for the bulk action.
+    id: ID!
+
+    # The type of the action.
+    type: ChangesetBulkActionType!
+
+    # The body of the comment of a COMMENT action.
+    body: String
+
+    # The labels added by an ADD_LABELS action.
+    labels: [String!]!
+
+    # The usernames of the reviewers requested by a REQUEST_REVIEWERS action.
+    reviewers: [String!]!
+
+    # The user who ran the action.
+    author: User
+
+    # The status of running the action on the changesets.
+    status: BackgroundProcessStatus!
+
+    # The date and time when the action was run.
+    createdAt: DateTime!
+}
+
+# A search for existing changesets on a code host, whose matching changesets are periodically
+# imported into a campaign.
+type ChangesetImport {
+    # The unique ID for the changeset import.
+    id: ID!
+
+    # The search for changesets on the code host.
+    query: String!
+
+    # The external service whose code host is searched. Only visible to site admins.
+    externalService: ExternalService
+
+    # The user who created the changeset import.
+    author: User
+
+    # The date and time when the search last ran, if it ran yet.
+    lastRunAt: DateTime
+
+    # The error of the last run of the search, if any.
+    error: String
+
+    # The date and time when the changeset import was created.
+    createdAt: DateTime!
+}
+
+# Input arguments for creating a campaign template.
+input CreateCampaignTemplateInput {
+    # The ID of the namespace where this campaign template is defined.
+    namespace: ID!
+
+    # The name of the campaign template.
+    name: String!
+
+    # The description of the campaign template (as Markdown).
+    description: String
+
+    # The spec of the campaign template (in JSON). It is a campaign spec (see createPatchSetFromSpec)
+    # with a list of parameters, which are referenced as ${{ name }} in its strings, for example:
+    #
+    #   {"parameters": [{"name": "version", "description": "The Go version", "default": "1.14"}],
+    #    "repositoriesMatchingQuery": "file:^go.mod$ -content:\"go ${{ version }}\"",
+    #    "steps": [{"type": "command", "container": "golang:${{ version }}-alpine", "run": "go mod edit -go=${{ version }}"}],
+    #    "commitMessage": "Update to Go ${{ version }}",
+    #    "changesetBody": "Owners: {{join .Repository.Owners \", \"}}"}
+    spec: String!
+}
+
+# Input arguments for updating a campaign template.
+input UpdateCampaignTemplateInput {
+    # The ID of the campaign template to update.
+    id: ID!
+
+    # The updated name of the campaign template (if non-null).
+    name: String
+
+    # The updated description of the campaign template (if non-null).
+    description: String
+
+    # The updated spec of the campaign template (if non-null).
+    spec: String
+}
+
+# An argument for a parameter of a campaign template.
+input CampaignTemplateArgumentInput {
+    # The name of the parameter.
+    name: String!
+
+    # The value of the parameter.
+    value: String!
+}
+
+# A campaign template is a campaign spec with parameters in a namespace, from which patchsets are
+# created with arguments.
+type CampaignTemplate implements Node {
+    # The unique ID for the campaign template.
+    id: ID!
+
+    # The name of the campaign template.
+    name: String!
+
+    # The description of the campaign template (as Markdown).
+    description: String!
+
+    # The spec of the campaign template (in JSON).
+    spec: String!
+
+    # The parameters of the spec of the campaign template.
+    parameters: [CampaignTemplateParameter!]!
+
+    # The version of the campaign template. It is incremented every time the spec changes.
+    version: Int!
+
+    # The namespace where this campaign template is defined.
+    namespace: Namespace!
+
+    # The user who created the campaign template.
+    author: User
+
+    # The date and time when the campaign template was created.
+    createdAt: DateTime!
+
+    # The date and time when the campaign template was updated.
+    updatedAt: DateTime!
+}
+
+# A parameter of a campaign template.
+type CampaignTemplateParameter {
+    # The name of the parameter, which is referenced as ${{ name }} in the spec.
+    name: String!
+
+    # The description of the parameter.
+    description: String!
+
+    # The default value of the parameter. Parameters without a default value are required.
+    default: String
+}
+
+# An argument with which a campaign template was rendered.
+type CampaignTemplateArgument {
+    # The name of the parameter.
+    name: String!
+
+    # The value of the parameter.
+    value: String!
+}
+
+# A list of campaign templates.
+type CampaignTemplateConnection {
+    # A list of campaign templates.
+    nodes: [CampaignTemplate!]!
+
+    # The total number of campaign templates in the connection.
+    totalCount: Int!
+
+    # Pagination information.
+    pageInfo: PageInfo!
 }
 
 # The counts of changesets in certain states at a specific point in time.
@@ -875,6 +1295,11 @@ type ExternalChangeset implements Node & Changeset {
     # The state of the checks (e.g., for continuous integration) on this changeset, or null if no
     # checks have been configured.
     checkState: ChangesetCheckState
+
+    # Whether the patch of this changeset no longer applies to the head of its base branch. Campaign
+    # changesets are rebased automatically when their base branch moves; if the rebase fails, the
+    # changeset is conflicted and keeps its previous commit until a later rebase succeeds.
+    conflicted: Boolean!
 }
 
 # A list of changesets.
@@ -1109,6 +1534,14 @@ type Query {
         viewerCanAdminister: Boolean
     ): CampaignConnection!
 
+    # A list of campaign templates.
+    campaignTemplates(
+        # Returns the first n campaign templates from the list.
+        first: Int
+        # Only return campaign templates in this namespace.
+        namespace: ID
+    ): CampaignTemplateConnection!
+
     # Looks up a repository by either name or cloneURL.
     repository(
         # Query the repository by name, for example "github.com/gorilla/mux".
@@ -1254,6 +1687,8 @@ type Query {
     repoGroups: [RepoGroup!]!
     # (experimental) All version contexts.
     versionContexts: [VersionContext!]!
+    # (experimental) The code insights series configured in the site configuration.
+    insights: [InsightSeries!]!
     # The current site.
     site: Site!
     # Retrieve responses to surveys.
@@ -1397,7 +1832,7 @@ type SearchFilterSuggestions {
 }
 
 # A search result.
-union SearchResult = FileMatch | CommitSearchResult | Repository | CodemodResult
+union SearchResult = FileMatch | CommitSearchResult | Repository | CodemodResult | CommitAuthorSearchResult
 
 # An object representing a markdown string.
 type Markdown {
@@ -1548,7 +1983,7 @@ type Language {
 }
 
 # A search suggestion.
-union SearchSuggestion = Repository | File | Symbol | Language
+union SearchSuggestion = Repository | File | Symbol | Language | SearchHistoryEntry
 
 # A search-related alert message.
 type SearchAlert {
@@ -1627,6 +2062,14 @@ type CommitSearchResult implements GenericSearchResultInterface {
     diffPreview: HighlightedString
 }
 
+# A commit author, returned by a search query containing select:commit.author.
+type CommitAuthorSearchResult {
+    # The person who authored the matching commits.
+    person: Person!
+    # The number of matching commits authored by this person.
+    commitCount: Int!
+}
+
 # The result of a code modification query.
 type CodemodResult implements GenericSearchResultInterface {
     # URL to an icon that is displayed with every search result.
@@ -1686,8 +2129,10 @@ type ExternalServiceConnection {
 # A specific kind of external service.
 enum ExternalServiceKind {
     AWSCODECOMMIT
+    AZUREDEVOPS
     BITBUCKETCLOUD
     BITBUCKETSERVER
+    GERRIT
     GITHUB
     GITLAB
     GITOLITE
@@ -1717,6 +2162,29 @@ type ExternalService implements Node {
     # It is a field on ExternalService instead of a separate thing in order to
     # not break the API and stay backwards compatible.
     warning: String
+    # The last sync of the external service's repositories, or null if they haven't been synced yet.
+    lastSync: ExternalServiceSync
+    # When the external service's repositories are synced next, or null if unknown.
+    nextSyncAt: DateTime
+}
+
+# A sync of the repositories of an external service.
+type ExternalServiceSync {
+    # When the sync started.
+    startedAt: DateTime!
+    # When the sync finished.
+    finishedAt: DateTime!
+    # The error the sync failed with, or null if it succeeded.
+    error: String
+    # The number of repositories yielded by the external service. Syncs which only list the
+    # repositories that were updated since the previous sync yield fewer repositories.
+    reposSourced: Int!
+    # The number of repositories added by the sync.
+    reposAdded: Int!
+    # The number of repositories modified by the sync.
+    reposModified: Int!
+    # The number of repositories deleted by the sync.
+    reposDeleted: Int!
 }
 
 # A list of repositories.
@@ -1753,6 +2221,14 @@ type Repository implements Node & GenericSearchResultInterface {
     description: String!
     # The primary programming language in the repository.
     language: String!
+    # The topics (or tags) of the repository on the code host.
+    topics: [String!]!
+    # The number of stars of the repository on the code host, or 0 if the code host doesn't have stars.
+    stars: Int!
+    # The date when the repository was last pushed to on the code host, or null if unknown.
+    pushedAt: DateTime
+    # The SPDX identifier of the repository's license as detected by the code host, or null if unknown.
+    license: String
     # DEPRECATED: This field is unused in known clients.
     #
     # The date when this repository was created on Sourcegraph.
@@ -2594,6 +3070,15 @@ type GitCommit implements Node {
     #
     # See "File" documentation for the difference between this field and the "blob" field.
     file(path: String!): File2
+    # Finds the files in this commit whose paths best match the fuzzy query, ordered by descending score. All
+    # characters of each whitespace-separated term of the query must occur in a matching path in order. Matching
+    # is case-insensitive unless the query contains an uppercase character. An empty query matches all files.
+    fuzzyFiles(
+        # The fuzzy query, such as "gqlbe/schema".
+        query: String!
+        # Returns the first n files (at most 1000).
+        first: Int = 50
+    ): [FuzzyFileMatch!]!
     # Lists the programming languages present in the tree at this commit.
     languages: [String!]!
     # List statistics for each language present in the repository.
@@ -2623,6 +3108,17 @@ type GitCommit implements Node {
     ): SymbolConnection!
 }
 
+# A file matched by a fuzzy file path query.
+type FuzzyFileMatch {
+    # The file.
+    file: GitBlob!
+    # The score of the match. Files with higher scores match the query better.
+    score: Int!
+    # The indexes of the characters (Unicode code points) of the file's path that matched the characters of the
+    # query, in ascending order.
+    positions: [Int!]!
+}
+
 # A set of Git behind/ahead counts for one commit relative to another.
 type BehindAheadCounts {
     # The number of commits behind the other commit.
@@ -3035,6 +3531,9 @@ type FileMatch {
     lineMatches: [LineMatch!]!
     # Whether or not the limit was hit.
     limitHit: Boolean!
+    # The owners of the file (such as "@org/team" or "alice@example.com") according to the
+    # CODEOWNERS file of the repository at the searched revision.
+    owners: [String!]!
 }
 
 # A line match.
@@ -3163,6 +3662,25 @@ type User implements Node & SettingsSubject & Namespace {
         # Returns the first n access tokens from the list.
         first: Int
     ): AccessTokenConnection!
+    # The search exports requested by the user, most recent first.
+    #
+    # Only the user and site admins can access this field.
+    searchExports(
+        # Returns the first n search exports from the list.
+        first: Int
+    ): SearchExportConnection!
+    # The queries that the user searched for, which are used to suggest their recent and frequent queries and
+    # repositories. Searches are only saved if the user has not turned off the search.history.enabled setting.
+    #
+    # Only the user and site admins can access this field.
+    searchHistory(
+        # Returns the first n queries from the list.
+        first: Int
+        # Returns only queries that contain this string (case-insensitively).
+        query: String
+        # The order in which queries are returned.
+        orderBy: SearchHistoryOrderBy = RECENT
+    ): SearchHistoryEntryConnection!
     # A list of external accounts that are associated with the user.
     externalAccounts(
         # Returns the first n external accounts from the list.
@@ -3221,6 +3739,10 @@ type AccessToken implements Node {
     createdAt: DateTime!
     # The date when the access token was last used to authenticate a request.
     lastUsedAt: DateTime
+    # The date after which the access token can't be used anymore, or null if it never expires.
+    expiresAt: DateTime
+    # Whether the access token has expired.
+    expired: Boolean!
 }
 
 # A list of access tokens.
@@ -3234,6 +3756,168 @@ type AccessTokenConnection {
     pageInfo: PageInfo!
 }
 
+# An entry in the audit log of security-sensitive actions. Entries can't be modified or deleted.
+type AuditLogEntry {
+    # The ID of the user who performed the action, or null if it was performed by an internal service
+    # or an unauthenticated client.
+    actorID: ID
+    # The user who performed the action, or null if the user has been deleted since or there is no
+    # such user (see actorID).
+    actor: User
+    # The IP address from which the action was performed. If Sourcegraph is deployed behind a proxy,
+    # this is the IP address of the proxy.
+    actorIP: String!
+    # The X-Forwarded-For header of the request that performed the action, if any. It can only be
+    # trusted if the proxies in front of Sourcegraph overwrite it.
+    actorForwardedFor: String
+    # The action, such as "updateSiteConfiguration".
+    action: String!
+    # The ID of the object on which the action was performed, if any.
+    target: ID
+    # Details of the action. Secrets, such as the contents of the site configuration, aren't recorded.
+    arguments: JSONValue!
+    # The time when the action was performed.
+    createdAt: DateTime!
+}
+
+# A list of audit log entries.
+type AuditLogEntryConnection {
+    # A list of audit log entries.
+    nodes: [AuditLogEntry!]!
+    # The total count of audit log entries in the connection. This total count may be larger than the
+    # number of nodes in this object when the result is paginated.
+    totalCount: Int!
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# The format of a search export file.
+enum SearchExportFormat {
+    # Comma-separated values with a header row.
+    CSV
+    # One JSON object per line.
+    JSON_LINES
+}
+
+# The state of a search export.
+enum SearchExportState {
+    # The export is waiting to be run.
+    QUEUED
+    # The export is running.
+    PROCESSING
+    # The export finished and its file can be downloaded.
+    COMPLETED
+    # The export failed.
+    ERRORED
+    # The export was canceled.
+    CANCELED
+}
+
+# An export of all results of a search query to a downloadable file. Each match is a row with its
+# repository, revision, path, line, column and a preview of the matching line. Exports are deleted
+# after 7 days.
+type SearchExport implements Node {
+    # The unique ID for the search export.
+    id: ID!
+    # The user who requested the export. The search is run with their permissions.
+    user: User!
+    # The search query.
+    query: String!
+    # The format of the exported file.
+    format: SearchExportFormat!
+    # The state of the export.
+    state: SearchExportState!
+    # The number of matches written so far.
+    resultCount: Int!
+    # The error that caused the export to fail, if its state is ERRORED.
+    error: String
+    # The date when the export was requested.
+    createdAt: DateTime!
+    # The date when the export started running, if it has.
+    startedAt: DateTime
+    # The date when the export completed, failed or was canceled.
+    finishedAt: DateTime
+    # The URL where the exported file can be downloaded, if the export is COMPLETED.
+    downloadURL: String
+}
+
+# A list of search exports.
+type SearchExportConnection {
+    # A list of search exports.
+    nodes: [SearchExport!]!
+    # The total count of search exports in the connection. This total count may be larger than the number of
+    # nodes in this object when the result is paginated.
+    totalCount: Int!
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# The order of a user's search history.
+enum SearchHistoryOrderBy {
+    # The most recently searched queries first.
+    RECENT
+    # The most frequently searched queries first.
+    FREQUENT
+}
+
+# A distinct query in a user's search history.
+type SearchHistoryEntry {
+    # The search query.
+    query: String!
+    # The number of times the user searched for the query.
+    searchCount: Int!
+    # The date when the user first searched for the query.
+    firstSearchedAt: DateTime!
+    # The date when the user last searched for the query.
+    lastSearchedAt: DateTime!
+}
+
+# A list of search history entries.
+type SearchHistoryEntryConnection {
+    # A list of search history entries.
+    nodes: [SearchHistoryEntry!]!
+    # The total count of search history entries in the connection. This total count may be larger than the number
+    # of nodes in this object when the result is paginated.
+    totalCount: Int!
+    # Pagination information.
+    pageInfo: PageInfo!
+}
+
+# The interval between the points of a code insights series.
+enum InsightSeriesInterval {
+    DAY
+    WEEK
+    MONTH
+}
+
+# A code insights series: the number of matches of a search query over time.
+type InsightSeries {
+    # The ID of the series in the site configuration.
+    id: String!
+    # The title of the series.
+    title: String!
+    # The search query whose matches are counted.
+    query: String!
+    # The interval between the points of the series.
+    interval: InsightSeriesInterval!
+    # The recorded points of the series, in ascending order of time. Each point only counts the matches in
+    # repositories that the viewer may access.
+    points(
+        # Only return points recorded at or after this date.
+        from: DateTime
+        # Only return points recorded at or before this date.
+        to: DateTime
+    ): [InsightDataPoint!]!
+}
+
+# A point of a code insights series.
+type InsightDataPoint {
+    # The date of the point. Matches are counted in the latest commit before this date.
+    dateTime: DateTime!
+    # The number of matches.
+    value: Int!
+}
+
 # A list of authentication providers.
 type AuthProviderConnection {
     # A list of authentication providers.
@@ -3442,6 +4126,7 @@ enum RepositoryOrderBy {
     REPOSITORY_NAME
     REPO_CREATED_AT # deprecated (use the equivalent REPOSITORY_CREATED_AT)
     REPOSITORY_CREATED_AT
+    REPOSITORY_STARS
 }
 
 # The default settings for the Sourcegraph instance. This is hardcoded in
@@ -3499,11 +4184,28 @@ type Site implements SettingsSubject {
     canReloadSite: Boolean!
     # Whether the viewer can modify the subject's settings.
     viewerCanAdminister: Boolean!
-    # A list of all access tokens on this site.
+    # A list of all access tokens on this site. Only site admins can access this field.
     accessTokens(
         # Returns the first n access tokens from the list.
         first: Int
+        # Returns only the access tokens with this scope.
+        scope: String
     ): AccessTokenConnection!
+    # The audit log of security-sensitive actions, such as changes to the site configuration, external
+    # services, site admins, access tokens, repository permissions and organization memberships.
+    # Entries are listed newest first. Only site admins can access this field.
+    auditLog(
+        # Returns the first n entries from the list.
+        first: Int
+        # Returns only the entries of actions performed by this user.
+        actor: ID
+        # Returns only the entries of this action, such as "updateSiteConfiguration".
+        action: String
+        # Returns only the entries created at or after this time.
+        since: DateTime
+        # Returns only the entries created before this time.
+        until: DateTime
+    ): AuditLogEntryConnection!
     # A list of all authentication providers. This information is visible to all viewers and does not contain any
     # secret information.
     authProviders: AuthProviderConnection!
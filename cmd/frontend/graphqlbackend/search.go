@@ -475,6 +475,12 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 
 	commitAfter, _ := r.query.StringValue(query.FieldRepoHasCommitAfter)
 
+	orderStr, _ := r.query.StringValue(query.FieldOrder)
+	order, err := query.ParseRepoOrder(orderStr)
+	if err != nil {
+		return nil, nil, nil, false, &badRequestError{err}
+	}
+
 	var versionContextName string
 	if r.versionContext != nil {
 		versionContextName = *r.versionContext
@@ -493,6 +499,7 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 		onlyPrivate:        visibility == query.Private,
 		onlyPublic:         visibility == query.Public,
 		commitAfter:        commitAfter,
+		orderByStars:       order == query.RepoOrderStars,
 		query:              r.query,
 	}
 	repoRevs, missingRepoRevs, overLimit, excludedRepos, err = resolveRepositories(ctx, options)
@@ -507,6 +514,31 @@ func (r *searchResolver) resolveRepositories(ctx context.Context, effectiveRepoF
 	return repoRevs, missingRepoRevs, excludedRepos, overLimit, err
 }
 
+// addRepoPredicates adds the conditions of the given repo: field predicates,
+// such as repo:has.topic(go), to the given options.
+func addRepoPredicates(options *db.ReposListOptions, include, exclude []query.RepoPredicate) {
+	for _, p := range include {
+		switch p.Name {
+		case query.RepoPredicateTopic:
+			options.Topics = append(options.Topics, p.Value)
+		case query.RepoPredicateLanguage:
+			options.Languages = append(options.Languages, p.Value)
+		case query.RepoPredicateLicense:
+			options.Licenses = append(options.Licenses, p.Value)
+		}
+	}
+	for _, p := range exclude {
+		switch p.Name {
+		case query.RepoPredicateTopic:
+			options.ExcludeTopics = append(options.ExcludeTopics, p.Value)
+		case query.RepoPredicateLanguage:
+			options.ExcludeLanguages = append(options.ExcludeLanguages, p.Value)
+		case query.RepoPredicateLicense:
+			options.ExcludeLicenses = append(options.ExcludeLicenses, p.Value)
+		}
+	}
+}
+
 // a patternRevspec maps an include pattern to a list of revisions
 // for repos matching that pattern. "map" in this case does not mean
 // an actual map, because we want regexp matches, not identity matches.
@@ -612,6 +644,7 @@ type resolveRepoOp struct {
 	commitAfter        string
 	onlyPrivate        bool
 	onlyPublic         bool
+	orderByStars       bool
 	query              query.QueryInfo
 }
 
@@ -622,13 +655,17 @@ func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions,
 		tr.Finish()
 	}()
 
-	includePatterns := op.repoFilters
-	if includePatterns != nil {
-		// Copy to avoid race condition.
-		includePatterns = append([]string{}, includePatterns...)
+	// Splitting the repo predicates off copies the include patterns, which
+	// avoids a race condition when they are mutated below.
+	includePatterns, includePredicates, err := query.SplitRepoPredicates(op.repoFilters)
+	if err != nil {
+		return nil, nil, false, nil, &badRequestError{err}
 	}
 
-	excludePatterns := op.minusRepoFilters
+	excludePatterns, excludePredicates, err := query.SplitRepoPredicates(op.minusRepoFilters)
+	if err != nil {
+		return nil, nil, false, nil, &badRequestError{err}
+	}
 
 	maxRepoListSize := maxReposToSearch()
 
@@ -678,7 +715,7 @@ func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions,
 	}
 
 	var defaultRepos []*types.Repo
-	if envvar.SourcegraphDotComMode() && len(includePatterns) == 0 {
+	if envvar.SourcegraphDotComMode() && len(includePatterns) == 0 && len(includePredicates) == 0 {
 		getIndexedRepos := func(ctx context.Context, revs []*search.RepositoryRevisions) (indexed, unindexed []*search.RepositoryRevisions, err error) {
 			return zoektIndexedRepos(ctx, search.Indexed(), revs, nil)
 		}
@@ -710,6 +747,15 @@ func resolveRepositories(ctx context.Context, op resolveRepoOp) (repoRevisions,
 			NoPrivate:    op.onlyPublic,
 			OnlyPrivate:  op.onlyPrivate,
 		}
+		if op.orderByStars {
+			// Search the most starred repositories first, in case we hit
+			// the repo limit.
+			options.OrderBy = db.RepoListOrderBy{
+				{Field: db.RepoListStars, Descending: true},
+				{Field: db.RepoListID},
+			}
+		}
+		addRepoPredicates(&options, includePredicates, excludePredicates)
 		excludedRepos = computeExcludedRepositories(ctx, op.query, options)
 		repos, err = db.Repos.List(ctx, options)
 		tr.LazyPrintf("Repos.List - done")
@@ -1080,11 +1126,16 @@ func (r *searchSuggestionResolver) ToLanguage() (*languageResolver, bool) {
 	return res, ok
 }
 
+func (r *searchSuggestionResolver) ToSearchHistoryEntry() (*searchHistoryEntryResolver, bool) {
+	res, ok := r.result.(*searchHistoryEntryResolver)
+	return res, ok
+}
+
 // newSearchSuggestionResolver returns a new searchSuggestionResolver wrapping the
 // given result.
 //
 // A panic occurs if the type of result is not a *RepositoryResolver, *GitTreeEntryResolver,
-// *searchSymbolResult or *languageResolver.
+// *searchSymbolResult, *languageResolver or *searchHistoryEntryResolver.
 func newSearchSuggestionResolver(result interface{}, score int) *searchSuggestionResolver {
 	switch r := result.(type) {
 	case *RepositoryResolver:
@@ -1099,6 +1150,9 @@ func newSearchSuggestionResolver(result interface{}, score int) *searchSuggestio
 	case *languageResolver:
 		return &searchSuggestionResolver{result: r, score: score, length: len(r.Name()), label: r.Name()}
 
+	case *searchHistoryEntryResolver:
+		return &searchSuggestionResolver{result: r, score: score, length: len(r.Query()), label: r.Query()}
+
 	default:
 		panic("never here")
 	}
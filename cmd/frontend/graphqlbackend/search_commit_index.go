@@ -0,0 +1,308 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/commitindex"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+const (
+	// commitIndexPageSize is the number of indexed commits that are read at a
+	// time when their diffs must be filtered after they are read.
+	commitIndexPageSize = 500
+
+	// commitIndexMaxScanned is the maximum number of indexed commits that are
+	// filtered by their diffs per search.
+	commitIndexMaxScanned = 20 * commitIndexPageSize
+)
+
+// partitionCommitIndexedRepos splits the repositories into those whose commits
+// can be searched in the commit index and those that must be searched with
+// `git log`. Only searches of the default branch of indexed repositories use
+// the index, and only if the query's before: and after: values are supported
+// by the index. Repositories whose history is only partially indexed use the
+// index only if the query's after: value is within the indexed history.
+func partitionCommitIndexedRepos(ctx context.Context, repos []*search.RepositoryRevisions, q query.QueryInfo) (indexed, unindexed []*search.RepositoryRevisions, err error) {
+	if !commitindex.Enabled() {
+		return nil, repos, nil
+	}
+	_, after, ok := commitDateRange(q, time.Now())
+	if !ok {
+		return nil, repos, nil
+	}
+
+	var ids []api.RepoID
+	for _, r := range repos {
+		if searchesDefaultBranch(r) {
+			ids = append(ids, r.Repo.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, repos, nil
+	}
+	horizons, err := db.CommitIndex.IndexedRepos(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, r := range repos {
+		horizon, isIndexed := horizons[r.Repo.ID]
+		coversQuery := horizon.IsZero() || (after != nil && !after.Before(horizon))
+		if isIndexed && coversQuery && searchesDefaultBranch(r) {
+			indexed = append(indexed, r)
+		} else {
+			unindexed = append(unindexed, r)
+		}
+	}
+	return indexed, unindexed, nil
+}
+
+func searchesDefaultBranch(r *search.RepositoryRevisions) bool {
+	if len(r.Revs) == 0 {
+		return true
+	}
+	return len(r.Revs) == 1 && (r.Revs[0] == search.RevisionSpecifier{} || r.Revs[0] == search.RevisionSpecifier{RevSpec: "HEAD"})
+}
+
+// searchCommitIndex searches the commit index for the commits (or diffs, if
+// diff is true) in the repositories that match the query.
+//
+// 🚨 SECURITY: The repositories must be ones that the viewer may access, such
+// as the repositories that a search resolved.
+func searchCommitIndex(ctx context.Context, repos []*search.RepositoryRevisions, args *search.TextParametersForCommitParameters, diff bool) (results []*commitSearchResultResolver, limitHit bool, err error) {
+	tr, ctx := trace.New(ctx, "searchCommitIndex", fmt.Sprintf("pattern: %+v, numRepos: %d, diff: %v", args.PatternInfo, len(repos), diff))
+	defer func() {
+		tr.LazyPrintf("%d results, limitHit=%v", len(results), limitHit)
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	opt, err := commitIndexSearchOptions(ctx, repos, args, diff)
+	if err != nil {
+		return nil, false, err
+	}
+
+	info := args.PatternInfo
+	textOptions := git.TextSearchOptions{
+		Pattern:         info.Pattern,
+		IsRegExp:        info.IsRegExp,
+		IsCaseSensitive: info.IsCaseSensitive,
+	}
+	if !diff {
+		// The pattern of commit searches matches commit messages, not diffs.
+		textOptions = git.TextSearchOptions{}
+	}
+	pathOptions := git.PathOptions{
+		IncludePatterns: info.IncludePatterns,
+		ExcludePattern:  info.ExcludePattern,
+		IsCaseSensitive: info.PathPatternsAreCaseSensitive,
+		IsRegExp:        info.PathPatternsAreRegExps,
+	}
+	// Diffs are filtered and highlighted after they are read from the index.
+	filterDiffs := diff || len(info.IncludePatterns) > 0 || info.ExcludePattern != ""
+
+	maxResults := int(info.FileMatchLimit)
+	pageSize := maxResults + 1
+	if filterDiffs {
+		pageSize = commitIndexPageSize
+	}
+
+	var entries []*db.CommitIndexEntry
+	var rawResults []*git.LogCommitSearchResult
+	for opt.Offset = 0; opt.Offset < commitIndexMaxScanned && len(rawResults) <= maxResults; opt.Offset += pageSize {
+		opt.Limit = pageSize
+		page, err := db.CommitIndex.Search(ctx, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, e := range page {
+			r := commitIndexEntryToResult(e)
+			if filterDiffs {
+				d, highlights, err := git.FilterDiff([]byte(e.Diff), textOptions, pathOptions)
+				if err != nil {
+					return nil, false, err
+				}
+				if d == nil {
+					continue
+				}
+				if diff {
+					r.Diff, r.DiffHighlights = d, highlights
+				}
+			}
+			entries = append(entries, e)
+			rawResults = append(rawResults, r)
+		}
+		if len(page) < pageSize {
+			break
+		}
+		if opt.Offset+pageSize >= commitIndexMaxScanned {
+			limitHit = true
+		}
+	}
+	if len(rawResults) > maxResults {
+		limitHit = true
+		entries, rawResults = entries[:maxResults], rawResults[:maxResults]
+	}
+
+	reposByID := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, r := range repos {
+		reposByID[r.Repo.ID] = r.Repo
+	}
+	op := search.CommitParameters{
+		PatternInfo: info,
+		Query:       args.Query,
+		Diff:        diff,
+	}
+	if !diff && info.Pattern != "" {
+		op.ExtraMessageValues = []string{info.Pattern}
+	}
+	for i, r := range rawResults {
+		repoResults, err := toCommitSearchResults(reposByID[entries[i].RepoID], []*git.LogCommitSearchResult{r}, op)
+		if err != nil {
+			return nil, false, err
+		}
+		results = append(results, repoResults...)
+	}
+	return results, limitHit, nil
+}
+
+// commitIndexSearchOptions returns the options for searching the commit index
+// with the filters of the query.
+func commitIndexSearchOptions(ctx context.Context, repos []*search.RepositoryRevisions, args *search.TextParametersForCommitParameters, diff bool) (db.CommitIndexSearchOptions, error) {
+	opt := db.CommitIndexSearchOptions{IsCaseSensitive: args.Query.IsCaseSensitive()}
+	for _, r := range repos {
+		opt.RepoIDs = append(opt.RepoIDs, r.Repo.ID)
+	}
+
+	var err error
+	opt.MessagePatterns, opt.NegatedMessagePatterns = args.Query.RegexpPatterns(query.FieldMessage)
+	for _, f := range []struct {
+		field             string
+		values, negValues *[]string
+	}{
+		{query.FieldAuthor, &opt.AuthorPatterns, &opt.NegatedAuthorPatterns},
+		{query.FieldCommitter, &opt.CommitterPatterns, &opt.NegatedCommitterPatterns},
+	} {
+		values, negValues := args.Query.RegexpPatterns(f.field)
+		if *f.values, err = expandUsernamesToEmails(ctx, values); err != nil {
+			return opt, errors.WithMessage(err, fmt.Sprintf("expanding usernames in field %s", f.field))
+		}
+		if *f.negValues, err = expandUsernamesToEmails(ctx, negValues); err != nil {
+			return opt, errors.WithMessage(err, fmt.Sprintf("expanding usernames in field -%s", f.field))
+		}
+	}
+
+	if pattern := args.PatternInfo.Pattern; pattern != "" {
+		if !args.PatternInfo.IsRegExp {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		if diff {
+			opt.DiffPattern = pattern
+		} else {
+			opt.MessagePatterns = append(opt.MessagePatterns, pattern)
+		}
+	}
+
+	opt.Before, opt.After, _ = commitDateRange(args.Query, time.Now())
+	return opt, nil
+}
+
+func commitIndexEntryToResult(e *db.CommitIndexEntry) *git.LogCommitSearchResult {
+	return &git.LogCommitSearchResult{
+		Commit: git.Commit{
+			ID:        e.CommitID,
+			Author:    git.Signature{Name: e.AuthorName, Email: e.AuthorEmail, Date: e.AuthorDate},
+			Committer: &git.Signature{Name: e.CommitterName, Email: e.CommitterEmail, Date: e.CommitterDate},
+			Message:   e.Message,
+		},
+	}
+}
+
+// commitDateRange returns the time range of the query's before: and after:
+// values. It returns false if a value is not a date that parseCommitDate
+// understands.
+func commitDateRange(q query.QueryInfo, now time.Time) (before, after *time.Time, ok bool) {
+	beforeValues, _ := q.StringValues(query.FieldBefore)
+	for _, s := range beforeValues {
+		t, ok := parseCommitDate(s, now)
+		if !ok {
+			return nil, nil, false
+		}
+		if before == nil || t.Before(*before) {
+			before = &t
+		}
+	}
+	afterValues, _ := q.StringValues(query.FieldAfter)
+	for _, s := range afterValues {
+		t, ok := parseCommitDate(s, now)
+		if !ok {
+			return nil, nil, false
+		}
+		if after == nil || t.After(*after) {
+			after = &t
+		}
+	}
+	return before, after, true
+}
+
+var relativeCommitDateRx = regexp.MustCompile(`^(\d+|a|an|one)\s*(second|minute|hour|day|week|month|year)s?\s+ago$`)
+
+// parseCommitDate parses the common forms of the dates that `git log --since`
+// and `--until` accept: absolute dates (such as "2020-06-01" or RFC 3339
+// times), "now", "today", "yesterday" and relative dates (such as "2 weeks
+// ago").
+func parseCommitDate(s string, now time.Time) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, true
+		}
+	}
+
+	s = strings.ToLower(s)
+
+	switch s {
+	case "now", "today":
+		return now, true
+	case "yesterday":
+		return now.AddDate(0, 0, -1), true
+	}
+
+	m := relativeCommitDateRx.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n := 1
+	if v, err := strconv.Atoi(m[1]); err == nil {
+		n = v
+	}
+	switch m[2] {
+	case "second":
+		return now.Add(-time.Duration(n) * time.Second), true
+	case "minute":
+		return now.Add(-time.Duration(n) * time.Minute), true
+	case "hour":
+		return now.Add(-time.Duration(n) * time.Hour), true
+	case "day":
+		return now.AddDate(0, 0, -n), true
+	case "week":
+		return now.AddDate(0, 0, -7*n), true
+	case "month":
+		return now.AddDate(0, -n, 0), true
+	default: // year
+		return now.AddDate(-n, 0, 0), true
+	}
+}
@@ -0,0 +1,241 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseCommitDate(t *testing.T) {
+	now := time.Date(2020, 6, 15, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"2020-06-01":           time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+		"2020-06-01T10:00:00Z": time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+		"2020-06-01 10:00:00":  time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+		"now":                  now,
+		"yesterday":            now.AddDate(0, 0, -1),
+		"3 hours ago":          now.Add(-3 * time.Hour),
+		"1 week ago":           now.AddDate(0, 0, -7),
+		"2 weeks ago":          now.AddDate(0, 0, -14),
+		"a month ago":          now.AddDate(0, -1, 0),
+		" 2 Years Ago ":        now.AddDate(-2, 0, 0),
+	}
+	for s, want := range tests {
+		got, ok := parseCommitDate(s, now)
+		if !ok || !got.Equal(want) {
+			t.Errorf("%q: got %s, %v, want %s", s, got, ok, want)
+		}
+	}
+
+	for _, s := range []string{"last thursday", "2 fortnights ago", "ago", ""} {
+		if got, ok := parseCommitDate(s, now); ok {
+			t.Errorf("%q: got %s, want unparseable", s, got)
+		}
+	}
+}
+
+func TestPartitionCommitIndexedRepos(t *testing.T) {
+	repoRevs := func(id api.RepoID, revs ...search.RevisionSpecifier) *search.RepositoryRevisions {
+		return &search.RepositoryRevisions{Repo: &types.Repo{ID: id}, Revs: revs}
+	}
+	repos := []*search.RepositoryRevisions{
+		repoRevs(1),
+		repoRevs(2, search.RevisionSpecifier{RevSpec: "HEAD"}),
+		repoRevs(3, search.RevisionSpecifier{RevSpec: "branch"}),
+		repoRevs(4),
+		repoRevs(5),
+	}
+	// Only the commits of repository 5 of the last 30 days are indexed.
+	horizon := time.Now().AddDate(0, 0, -30)
+	db.Mocks.CommitIndex.IndexedRepos = func(repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
+		if want := []api.RepoID{1, 2, 4, 5}; !reflect.DeepEqual(repoIDs, want) {
+			t.Errorf("got repo IDs %v, want %v", repoIDs, want)
+		}
+		return map[api.RepoID]time.Time{1: {}, 2: {}, 3: {}, 5: horizon}, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	ids := func(repos []*search.RepositoryRevisions) (ids []api.RepoID) {
+		for _, r := range repos {
+			ids = append(ids, r.Repo.ID)
+		}
+		return ids
+	}
+	partition := func(q string) (indexed, unindexed []api.RepoID) {
+		t.Helper()
+		parsed, err := query.ParseAndCheck(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		i, u, err := partitionCommitIndexedRepos(context.Background(), repos, parsed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ids(i), ids(u)
+	}
+
+	if indexed, unindexed := partition("type:diff foo"); indexed != nil || len(unindexed) != 5 {
+		t.Errorf("got indexed %v, unindexed %v while commit indexing is disabled, want none indexed", indexed, unindexed)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{SearchIndexCommits: true}})
+	defer conf.Mock(nil)
+
+	tests := []struct {
+		query              string
+		indexed, unindexed []api.RepoID
+	}{
+		{`type:diff foo after:"1 week ago"`, []api.RepoID{1, 2, 5}, []api.RepoID{3, 4}},
+		// The partially indexed repository is searched with `git log` if the
+		// query reaches past its horizon.
+		{`type:diff foo after:"2 months ago"`, []api.RepoID{1, 2}, []api.RepoID{3, 4, 5}},
+		{`type:diff foo before:"1 week ago"`, []api.RepoID{1, 2}, []api.RepoID{3, 4, 5}},
+	}
+	for _, test := range tests {
+		indexed, unindexed := partition(test.query)
+		if !reflect.DeepEqual(indexed, test.indexed) {
+			t.Errorf("%s: got indexed %v, want %v", test.query, indexed, test.indexed)
+		}
+		if !reflect.DeepEqual(unindexed, test.unindexed) {
+			t.Errorf("%s: got unindexed %v, want %v", test.query, unindexed, test.unindexed)
+		}
+	}
+
+	if indexed, _ := partition(`type:diff foo after:"last thursday"`); indexed != nil {
+		t.Errorf("got indexed %v for an unsupported date, want none", indexed)
+	}
+}
+
+func TestSearchCommitIndex(t *testing.T) {
+	date := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(repoID api.RepoID, commitID, diff string) *db.CommitIndexEntry {
+		return &db.CommitIndexEntry{
+			RepoID:        repoID,
+			CommitID:      api.CommitID(commitID),
+			AuthorName:    "a",
+			AuthorDate:    date,
+			CommitterName: "a",
+			CommitterDate: date,
+			Message:       "m " + commitID,
+			Diff:          diff,
+		}
+	}
+	const (
+		diffFoo = "diff --git a.go a.go\n--- a.go\n+++ a.go\n@@ -1 +1 @@\n-x\n+foo\n"
+		diffBar = "diff --git b.txt b.txt\n--- b.txt\n+++ b.txt\n@@ -1 +1 @@\n-x\n+foo bar\n"
+		// matches the pattern only in the file header, not in a changed line
+		diffHeader = "diff --git foo.go foo.go\n--- foo.go\n+++ foo.go\n@@ -1 +1 @@\n-x\n+y\n"
+	)
+
+	var gotOpts []db.CommitIndexSearchOptions
+	db.Mocks.CommitIndex.Search = func(opt db.CommitIndexSearchOptions) ([]*db.CommitIndexEntry, error) {
+		gotOpts = append(gotOpts, opt)
+		if opt.Offset > 0 {
+			return nil, nil
+		}
+		return []*db.CommitIndexEntry{entry(1, "c1", diffFoo), entry(2, "c2", diffHeader), entry(2, "c3", diffBar)}, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	repos := []*search.RepositoryRevisions{
+		{Repo: &types.Repo{ID: 1, Name: "r1"}},
+		{Repo: &types.Repo{ID: 2, Name: "r2"}},
+	}
+	searchIndex := func(q string, diff bool, info *search.CommitPatternInfo) []string {
+		t.Helper()
+		gotOpts = nil
+		parsed, err := query.ParseAndCheck(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, _, err := searchCommitIndex(context.Background(), repos, &search.TextParametersForCommitParameters{
+			PatternInfo: info,
+			Query:       parsed,
+		}, diff)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range results {
+			got = append(got, string(r.commit.repoResolver.Name())+"@"+string(r.commit.oid))
+		}
+		return got
+	}
+
+	t.Run("diff", func(t *testing.T) {
+		got := searchIndex("type:diff author:alice -message:wip foo", true, &search.CommitPatternInfo{Pattern: "foo", FileMatchLimit: 10})
+		if want := []string{"r1@c1", "r2@c3"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		opt := gotOpts[0]
+		if opt.DiffPattern != "foo" || !reflect.DeepEqual(opt.AuthorPatterns, []string{"alice"}) || !reflect.DeepEqual(opt.NegatedMessagePatterns, []string{"wip"}) || opt.IsCaseSensitive {
+			t.Errorf("got unexpected options %+v", opt)
+		}
+		if !reflect.DeepEqual(opt.RepoIDs, []api.RepoID{1, 2}) {
+			t.Errorf("got repo IDs %v, want [1 2]", opt.RepoIDs)
+		}
+	})
+
+	t.Run("diff with path filter", func(t *testing.T) {
+		got := searchIndex("type:diff foo", true, &search.CommitPatternInfo{Pattern: "foo", FileMatchLimit: 10, IncludePatterns: []string{`\.txt$`}, PathPatternsAreRegExps: true})
+		if want := []string{"r2@c3"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		got := searchIndex(`type:commit a.b before:2020-07-01`, false, &search.CommitPatternInfo{Pattern: "a.b", FileMatchLimit: 2})
+		if want := []string{"r1@c1", "r2@c2"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		opt := gotOpts[0]
+		if !reflect.DeepEqual(opt.MessagePatterns, []string{`a\.b`}) || opt.DiffPattern != "" || opt.Limit != 3 {
+			t.Errorf("got unexpected options %+v", opt)
+		}
+		if want := time.Date(2020, 7, 1, 0, 0, 0, 0, time.Local); opt.Before == nil || !opt.Before.Equal(want) {
+			t.Errorf("got before %v, want %s", opt.Before, want)
+		}
+	})
+}
+
+func TestAlertOnSearchLimit_commitIndex(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{SearchIndexCommits: true}})
+	defer conf.Mock(nil)
+	db.Mocks.CommitIndex.IndexedRepos = func(repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
+		indexed := map[api.RepoID]time.Time{}
+		for _, id := range repoIDs[:150] {
+			indexed[id] = time.Time{}
+		}
+		return indexed, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	repoRevs := make([]*search.RepositoryRevisions, 200)
+	for i := range repoRevs {
+		repoRevs[i] = &search.RepositoryRevisions{Repo: &types.Repo{ID: api.RepoID(i)}}
+	}
+	q, err := query.ParseAndCheck("type:diff foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the 50 unindexed repositories are searched with `git log`.
+	resultTypes, alert := alertOnSearchLimit(context.Background(), []string{"diff"}, &search.TextParameters{Repos: repoRevs, Query: q})
+	if alert != nil || !reflect.DeepEqual(resultTypes, []string{"diff"}) {
+		t.Errorf("got result types %v and alert %v, want diff search without an alert", resultTypes, alert)
+	}
+
+	resultTypes, alert = alertOnSearchLimit(context.Background(), []string{"diff"}, &search.TextParameters{Repos: append(repoRevs, &search.RepositoryRevisions{Repo: &types.Repo{ID: 200}}), Query: q})
+	if alert == nil || len(resultTypes) != 0 {
+		t.Errorf("got result types %v and no alert, want an alert for 51 unindexed repositories", resultTypes)
+	}
+}
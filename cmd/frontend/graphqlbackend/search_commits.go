@@ -73,6 +73,10 @@ func (r *commitSearchResultResolver) ToCodemodResult() (*codemodResultResolver,
 	return nil, false
 }
 
+func (r *commitSearchResultResolver) ToCommitAuthorSearchResult() (*commitAuthorSearchResultResolver, bool) {
+	return nil, false
+}
+
 func (r *commitSearchResultResolver) searchResultURIs() (string, string) {
 	// Diffs aren't going to be returned with other types of results
 	// and are already ordered in the desired order, so we'll just leave them in place.
@@ -242,8 +246,18 @@ func searchCommitsInRepo(ctx context.Context, op search.CommitParameters) (resul
 		rawResults = rawResults[:maxResults]
 	}
 
+	results, err = toCommitSearchResults(repo, rawResults, op)
+	if err != nil {
+		return nil, false, false, err
+	}
+	return results, limitHit, timedOut, nil
+}
+
+// toCommitSearchResults returns the search results for the commits of the
+// repository that matched the commit or diff search.
+func toCommitSearchResults(repo *types.Repo, rawResults []*git.LogCommitSearchResult, op search.CommitParameters) ([]*commitSearchResultResolver, error) {
 	repoResolver := &RepositoryResolver{repo: repo}
-	results = make([]*commitSearchResultResolver, len(rawResults))
+	results := make([]*commitSearchResultResolver, len(rawResults))
 	for i, rawResult := range rawResults {
 		commit := rawResult.Commit
 		commitResolver := toGitCommitResolver(repoResolver, &commit)
@@ -289,9 +303,10 @@ func searchCommitsInRepo(ctx context.Context, op search.CommitParameters) (resul
 		}
 
 		commitIcon := "data:image/svg+xml;base64,PD94bWwgdmVyc2lvbj0iMS4wIiBlbmNvZGluZz0iVVRGLTgiPz48IURPQ1RZUEUgc3ZnIFBVQkxJQyAiLS8vVzNDLy9EVEQgU1ZHIDEuMS8vRU4iICJodHRwOi8vd3d3LnczLm9yZy9HcmFwaGljcy9TVkcvMS4xL0RURC9zdmcxMS5kdGQiPjxzdmcgeG1sbnM9Imh0dHA6Ly93d3cudzMub3JnLzIwMDAvc3ZnIiB4bWxuczp4bGluaz0iaHR0cDovL3d3dy53My5vcmcvMTk5OS94bGluayIgdmVyc2lvbj0iMS4xIiB3aWR0aD0iMjQiIGhlaWdodD0iMjQiIHZpZXdCb3g9IjAgMCAyNCAyNCI+PHBhdGggZD0iTTE3LDEyQzE3LDE0LjQyIDE1LjI4LDE2LjQ0IDEzLDE2LjlWMjFIMTFWMTYuOUM4LjcyLDE2LjQ0IDcsMTQuNDIgNywxMkM3LDkuNTggOC43Miw3LjU2IDExLDcuMVYzSDEzVjcuMUMxNS4yOCw3LjU2IDE3LDkuNTggMTcsMTJNMTIsOUEzLDMgMCAwLDAgOSwxMkEzLDMgMCAwLDAgMTIsMTVBMywzIDAgMCwwIDE1LDEyQTMsMyAwIDAsMCAxMiw5WiIgLz48L3N2Zz4="
+		var err error
 		results[i].label, err = createLabel(rawResult, commitResolver)
 		if err != nil {
-			return nil, false, false, err
+			return nil, err
 		}
 		commitHash := string(rawResult.Commit.ID)
 		if len(rawResult.Commit.ID) > 7 {
@@ -301,7 +316,7 @@ func searchCommitsInRepo(ctx context.Context, op search.CommitParameters) (resul
 
 		url, err := commitResolver.URL()
 		if err != nil {
-			return nil, false, false, err
+			return nil, err
 		}
 
 		results[i].detail = fmt.Sprintf("[`%v` %v](%v)", commitHash, timeagoConfig.Format(rawResult.Commit.Author.Date), url)
@@ -312,7 +327,7 @@ func searchCommitsInRepo(ctx context.Context, op search.CommitParameters) (resul
 		results[i].matches = matches
 	}
 
-	return results, limitHit, timedOut, nil
+	return results, nil
 }
 
 func cleanDiffPreview(highlights []*highlightedRange, rawDiffResult string) (string, []*highlightedRange) {
@@ -440,7 +455,38 @@ func searchCommitDiffsInRepos(ctx context.Context, args *search.TextParametersFo
 	for i, repo := range args.Repos {
 		common.repos[i] = repo.Repo
 	}
-	for _, repoRev := range args.Repos {
+
+	indexedRepos, repos, err := partitionCommitIndexedRepos(ctx, args.Repos, args.Query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(indexedRepos) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, limitHit, searchErr := searchCommitIndex(ctx, indexedRepos, args, true)
+			if ctx.Err() == context.Canceled {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if searchErr != nil {
+				err = errors.Wrap(searchErr, "failed to search commit diffs in the commit index")
+				cancel()
+				return
+			}
+			for _, repoRev := range indexedRepos {
+				common.searched = append(common.searched, repoRev.Repo)
+				common.indexed = append(common.indexed, repoRev.Repo)
+			}
+			common.limitHit = common.limitHit || limitHit
+			if len(results) > 0 {
+				unflattened = append(unflattened, results)
+			}
+		}()
+	}
+
+	for _, repoRev := range repos {
 		wg.Add(1)
 		go func(repoRev *search.RepositoryRevisions) {
 			defer wg.Done()
@@ -511,7 +557,38 @@ func searchCommitLogInRepos(ctx context.Context, args *search.TextParametersForC
 	for i, repo := range args.Repos {
 		common.repos[i] = repo.Repo
 	}
-	for _, repoRev := range args.Repos {
+
+	indexedRepos, repos, err := partitionCommitIndexedRepos(ctx, args.Repos, args.Query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(indexedRepos) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results, limitHit, searchErr := searchCommitIndex(ctx, indexedRepos, args, false)
+			if ctx.Err() == context.Canceled {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if searchErr != nil {
+				err = errors.Wrap(searchErr, "failed to search commit log in the commit index")
+				cancel()
+				return
+			}
+			for _, repoRev := range indexedRepos {
+				common.searched = append(common.searched, repoRev.Repo)
+				common.indexed = append(common.indexed, repoRev.Repo)
+			}
+			common.limitHit = common.limitHit || limitHit
+			if len(results) > 0 {
+				unflattened = append(unflattened, results)
+			}
+		}()
+	}
+
+	for _, repoRev := range repos {
 		wg.Add(1)
 		go func(repoRev *search.RepositoryRevisions) {
 			defer wg.Done()
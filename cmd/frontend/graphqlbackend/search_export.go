@@ -0,0 +1,255 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	querytypes "github.com/sourcegraph/sourcegraph/internal/search/query/types"
+)
+
+// searchExportFormats maps the GraphQL SearchExportFormat enum values to the
+// formats stored in the database.
+var searchExportFormats = map[string]string{
+	"CSV":        db.SearchExportFormatCSV,
+	"JSON_LINES": db.SearchExportFormatJSONL,
+}
+
+type createSearchExportArgs struct {
+	Query       string
+	Version     string
+	PatternType *string
+	Format      string
+}
+
+func (r *schemaResolver) CreateSearchExport(ctx context.Context, args *createSearchExportArgs) (*searchExportResolver, error) {
+	// 🚨 SECURITY: Search exports are run as the user who requested them, so
+	// only signed-in users may request them.
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	format, ok := searchExportFormats[args.Format]
+	if !ok {
+		return nil, fmt.Errorf("unknown search export format %q", args.Format)
+	}
+
+	// Fail early on queries that the worker would not be able to run.
+	if _, err := newSearchExportResolver(&db.SearchExport{Query: args.Query, Version: args.Version, PatternType: args.PatternType}, nil); err != nil {
+		return nil, err
+	}
+
+	e, err := db.SearchExports.Create(ctx, a.UID, args.Query, args.Version, args.PatternType, format)
+	if err != nil {
+		return nil, err
+	}
+	return &searchExportResolver{export: e}, nil
+}
+
+func (r *schemaResolver) CancelSearchExport(ctx context.Context, args *struct {
+	SearchExport graphql.ID
+}) (*searchExportResolver, error) {
+	// 🚨 SECURITY: searchExportByID checks that the actor may access the export.
+	e, err := searchExportByID(ctx, args.SearchExport)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.SearchExports.Cancel(ctx, e.export.ID); err != nil {
+		return nil, err
+	}
+	return searchExportByID(ctx, args.SearchExport)
+}
+
+func (r *UserResolver) SearchExports(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+}) (*searchExportConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins and the user can list a user's search exports.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	opt := db.SearchExportsListOptions{UserID: r.user.ID}
+	args.ConnectionArgs.Set(&opt.LimitOffset)
+	return &searchExportConnectionResolver{opt: opt}, nil
+}
+
+// searchExportConnectionResolver resolves a list of search exports.
+//
+// 🚨 SECURITY: When instantiating a searchExportConnectionResolver value, the caller MUST check
+// permissions.
+type searchExportConnectionResolver struct {
+	opt db.SearchExportsListOptions
+
+	// cache results because they are used by multiple fields
+	once    sync.Once
+	exports []*db.SearchExport
+	err     error
+}
+
+func (r *searchExportConnectionResolver) compute(ctx context.Context) ([]*db.SearchExport, error) {
+	r.once.Do(func() {
+		opt2 := r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.exports, r.err = db.SearchExports.List(ctx, opt2)
+	})
+	return r.exports, r.err
+}
+
+func (r *searchExportConnectionResolver) Nodes(ctx context.Context) ([]*searchExportResolver, error) {
+	exports, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(exports) > r.opt.LimitOffset.Limit {
+		exports = exports[:r.opt.LimitOffset.Limit]
+	}
+
+	l := make([]*searchExportResolver, 0, len(exports))
+	for _, e := range exports {
+		l = append(l, &searchExportResolver{export: e})
+	}
+	return l, nil
+}
+
+func (r *searchExportConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := db.SearchExports.Count(ctx, r.opt)
+	return int32(count), err
+}
+
+func (r *searchExportConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	exports, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(exports) > r.opt.Limit), nil
+}
+
+// searchExportResolver resolves a search export.
+type searchExportResolver struct {
+	export *db.SearchExport
+}
+
+func searchExportByID(ctx context.Context, id graphql.ID) (*searchExportResolver, error) {
+	exportID, err := unmarshalSearchExportID(id)
+	if err != nil {
+		return nil, err
+	}
+	e, err := db.SearchExports.GetByID(ctx, exportID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only the user who requested the export and site admins may view it.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, e.UserID); err != nil {
+		return nil, err
+	}
+	return &searchExportResolver{export: e}, nil
+}
+
+func marshalSearchExportID(id int64) graphql.ID { return relay.MarshalID("SearchExport", id) }
+
+func unmarshalSearchExportID(id graphql.ID) (exportID int64, err error) {
+	err = relay.UnmarshalSpec(id, &exportID)
+	return
+}
+
+func (r *searchExportResolver) ID() graphql.ID { return marshalSearchExportID(r.export.ID) }
+
+func (r *searchExportResolver) User(ctx context.Context) (*UserResolver, error) {
+	return UserByIDInt32(ctx, r.export.UserID)
+}
+
+func (r *searchExportResolver) Query() string { return r.export.Query }
+
+func (r *searchExportResolver) Format() string {
+	for enum, format := range searchExportFormats {
+		if format == r.export.Format {
+			return enum
+		}
+	}
+	return strings.ToUpper(r.export.Format)
+}
+
+func (r *searchExportResolver) State() string { return strings.ToUpper(r.export.State) }
+
+func (r *searchExportResolver) ResultCount() int32 { return r.export.ResultCount }
+
+func (r *searchExportResolver) Error() *string { return r.export.Error }
+
+func (r *searchExportResolver) CreatedAt() DateTime { return DateTime{Time: r.export.CreatedAt} }
+
+func (r *searchExportResolver) StartedAt() *DateTime { return DateTimeOrNil(r.export.StartedAt) }
+
+func (r *searchExportResolver) FinishedAt() *DateTime { return DateTimeOrNil(r.export.FinishedAt) }
+
+func (r *searchExportResolver) DownloadURL() *string {
+	if r.export.State != db.SearchExportStateCompleted {
+		return nil
+	}
+	u := fmt.Sprintf("/-/search-exports/%d", r.export.ID)
+	return &u
+}
+
+// newSearchExportResolver returns the searchResolver that fetches the page of
+// results of the search export after the given cursor.
+func newSearchExportResolver(e *db.SearchExport, after *string) (*searchResolver, error) {
+	first := int32(maxSearchResultsPerPaginatedRequest)
+	impl, err := NewSearchImplementer(&SearchArgs{
+		Version:     e.Version,
+		PatternType: e.PatternType,
+		Query:       e.Query,
+		First:       &first,
+		After:       after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch r := impl.(type) {
+	case *searchAlert:
+		return nil, searchAlertError(r)
+	case *searchResolver:
+		q, ok := r.query.(*query.OrdinaryQuery)
+		if !ok {
+			return nil, errors.New("search exports do not support queries with and/or expressions")
+		}
+		// Exports use paginated search, which only returns text matches, so
+		// search them unless the query asks for something else (in which
+		// case the search fails with a descriptive error).
+		if _, ok := q.Fields()[query.FieldType]; !ok {
+			fileValue := "file"
+			q.Fields()[query.FieldType] = []*querytypes.Value{{String: &fileValue}}
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unexpected search implementation %T", impl)
+	}
+}
+
+// searchAlertError returns an error describing the alert that a search
+// returned instead of results.
+func searchAlertError(a *searchAlert) error {
+	if a.description == "" {
+		return errors.New(a.title)
+	}
+	return fmt.Errorf("%s: %s", a.title, a.description)
+}
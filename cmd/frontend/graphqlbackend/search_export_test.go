@@ -0,0 +1,153 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/gqltesting"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestSearchExportWriter(t *testing.T) {
+	rev := "main"
+	matches := []*FileMatchResolver{
+		{
+			JPath: "a.go",
+			JLineMatches: []*lineMatch{
+				{JPreview: `x := "foo", foo`, JLineNumber: 9, JOffsetAndLengths: [][2]int32{{6, 3}, {12, 3}}},
+			},
+			Repo:     &RepositoryResolver{repo: &types.Repo{Name: "r"}},
+			CommitID: "deadbeef",
+		},
+		{
+			JPath:    "foo.txt",
+			Repo:     &RepositoryResolver{repo: &types.Repo{Name: "r"}},
+			CommitID: "deadbeef",
+			InputRev: &rev,
+		},
+	}
+
+	for _, tc := range []struct {
+		format string
+		want   string
+	}{
+		{
+			format: db.SearchExportFormatCSV,
+			want: `repository,revision,path,line,column,preview
+r,deadbeef,a.go,10,7,"x := ""foo"", foo"
+r,deadbeef,a.go,10,13,"x := ""foo"", foo"
+r,main,foo.txt,0,0,
+`,
+		},
+		{
+			format: db.SearchExportFormatJSONL,
+			want: `{"repository":"r","revision":"deadbeef","path":"a.go","line":10,"column":7,"preview":"x := \"foo\", foo"}
+{"repository":"r","revision":"deadbeef","path":"a.go","line":10,"column":13,"preview":"x := \"foo\", foo"}
+{"repository":"r","revision":"main","path":"foo.txt","line":0,"column":0,"preview":""}
+`,
+		},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			w, err := newSearchExportWriter(tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var count int32
+			for _, fm := range matches {
+				count += w.writeFileMatch(fm)
+			}
+			if count != 3 {
+				t.Errorf("got count %d, want 3", count)
+			}
+			data, err := w.flush()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := string(data); have != tc.want {
+				t.Errorf("got\n%s\nwant\n%s", have, tc.want)
+			}
+
+			// Only the first page of a CSV export has a header.
+			data, err = w.flush()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(data) != 0 {
+				t.Errorf("got %q after flushing twice, want nothing", data)
+			}
+		})
+	}
+}
+
+func TestMutation_CreateSearchExport(t *testing.T) {
+	resetMocks()
+	db.Mocks.SearchExports.Create = func(userID int32, query, version string, patternType *string, format string) (*db.SearchExport, error) {
+		if userID != 1 || query != "foo" || version != "V2" || format != db.SearchExportFormatJSONL {
+			t.Errorf("unexpected Create(%d, %q, %q, %v, %q)", userID, query, version, patternType, format)
+		}
+		return &db.SearchExport{ID: 1, UserID: userID, Query: query, Version: version, Format: format, State: db.SearchExportStateQueued}, nil
+	}
+	defer func() { db.Mocks.SearchExports = db.MockSearchExports{} }()
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Context: actor.WithActor(context.Background(), &actor.Actor{UID: 1}),
+			Schema:  mustParseGraphQLSchema(t),
+			Query: `
+				mutation {
+					createSearchExport(query: "foo", format: JSON_LINES) {
+						id
+						format
+						state
+						downloadURL
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"createSearchExport": {
+						"id": "U2VhcmNoRXhwb3J0OjE=",
+						"format": "JSON_LINES",
+						"state": "QUEUED",
+						"downloadURL": null
+					}
+				}
+			`,
+		},
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		_, err := (&schemaResolver{}).CreateSearchExport(context.Background(), &createSearchExportArgs{Query: "foo", Version: "V2", Format: "CSV"})
+		if err == nil {
+			t.Error("got nil error, want an error for an unauthenticated user")
+		}
+	})
+}
+
+// 🚨 SECURITY: This tests that users can't cancel or view other users' search exports.
+func TestMutation_CancelSearchExport(t *testing.T) {
+	resetMocks()
+	db.Mocks.SearchExports.GetByID = func(id int64) (*db.SearchExport, error) {
+		return &db.SearchExport{ID: id, UserID: 2, State: db.SearchExportStateProcessing}, nil
+	}
+	db.Mocks.SearchExports.Cancel = func(id int64) error {
+		t.Error("Cancel should not be called")
+		return nil
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: "u2"}, nil
+	}
+	defer func() { db.Mocks.SearchExports = db.MockSearchExports{} }()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	_, err := (&schemaResolver{}).CancelSearchExport(ctx, &struct{ SearchExport graphql.ID }{SearchExport: marshalSearchExportID(1)})
+	if err == nil {
+		t.Error("got nil error, want an error for another user's export")
+	}
+}
@@ -0,0 +1,251 @@
+package graphqlbackend
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+const (
+	// searchExportPollInterval is how often the worker checks for queued
+	// search exports when there are none.
+	searchExportPollInterval = 5 * time.Second
+
+	// searchExportHeartbeatInterval is how often the worker records that it
+	// is still processing a search export. It must be well below the time
+	// after which the database considers a processing search export stale.
+	searchExportHeartbeatInterval = time.Minute
+
+	// searchExportRetention is how long search exports and their files are
+	// kept after they are requested.
+	searchExportRetention = 7 * 24 * time.Hour
+)
+
+// RunSearchExportWorker processes queued search exports, one at a time, until
+// ctx is canceled. It is safe to run a worker in every frontend replica.
+func RunSearchExportWorker(ctx context.Context) {
+	var lastCleanup time.Time
+	for ctx.Err() == nil {
+		if time.Since(lastCleanup) > time.Hour {
+			if err := db.SearchExports.DeleteOlderThan(ctx, time.Now().Add(-searchExportRetention)); err != nil {
+				log15.Error("deleting old search exports", "error", err)
+			}
+			lastCleanup = time.Now()
+		}
+
+		e, err := db.SearchExports.Dequeue(ctx)
+		if err != nil {
+			log15.Error("dequeueing search export", "error", err)
+		}
+		if e == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(searchExportPollInterval):
+			}
+			continue
+		}
+
+		err = runSearchExport(ctx, e)
+		if err == db.ErrSearchExportNotProcessing {
+			// The export was canceled while we were working on it.
+			continue
+		}
+		var errMsg *string
+		if err != nil {
+			msg := err.Error()
+			errMsg = &msg
+		}
+		if err := db.SearchExports.Finish(ctx, e.ID, e.Attempt, errMsg); err != nil && err != db.ErrSearchExportNotProcessing {
+			log15.Error("finishing search export", "id", e.ID, "error", err)
+		}
+	}
+}
+
+// runSearchExport pages through all results of the search export and appends
+// them to its file.
+func runSearchExport(ctx context.Context, e *db.SearchExport) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go heartbeatSearchExport(ctx, cancel, e)
+
+	// 🚨 SECURITY: Run the search as the user who requested the export, so
+	// that it only contains results from repositories they may access.
+	ctx = actor.WithActor(ctx, &actor.Actor{UID: e.UserID})
+
+	w, err := newSearchExportWriter(e.Format)
+	if err != nil {
+		return err
+	}
+
+	var after *string
+	for {
+		r, err := newSearchExportResolver(e, after)
+		if err != nil {
+			return err
+		}
+		rr, err := r.Results(ctx)
+		if err != nil {
+			return err
+		}
+		if rr.alert != nil {
+			return searchAlertError(rr.alert)
+		}
+
+		var count int32
+		for _, result := range rr.SearchResults {
+			if fm, ok := result.ToFileMatch(); ok {
+				count += w.writeFileMatch(fm)
+			}
+		}
+		data, err := w.flush()
+		if err != nil {
+			return err
+		}
+
+		// Appending also tells us whether the export was canceled or
+		// restarted by another worker, so we do it even for empty pages.
+		if err := db.SearchExports.AppendChunk(ctx, e.ID, e.Attempt, data, count); err != nil {
+			return err
+		}
+
+		if rr.cursor == nil || rr.cursor.Finished {
+			return nil
+		}
+		cursor := marshalSearchCursor(rr.cursor)
+		after = &cursor
+	}
+}
+
+// heartbeatSearchExport periodically records that the search export is still
+// being processed until ctx is done, so that other workers don't restart it
+// while a slow search runs. It calls cancel if the search export is no longer
+// processed by this attempt.
+func heartbeatSearchExport(ctx context.Context, cancel context.CancelFunc, e *db.SearchExport) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(searchExportHeartbeatInterval):
+		}
+
+		err := db.SearchExports.Heartbeat(ctx, e.ID, e.Attempt)
+		if err == db.ErrSearchExportNotProcessing {
+			cancel()
+			return
+		}
+		if err != nil && ctx.Err() == nil {
+			log15.Error("recording search export heartbeat", "id", e.ID, "error", err)
+		}
+	}
+}
+
+// searchExportRow is a single match in a search export.
+type searchExportRow struct {
+	Repository string `json:"repository"`
+	Revision   string `json:"revision"`
+	Path       string `json:"path"`
+	Line       int32  `json:"line"`   // 1-based, or 0 if the file matched without a line match
+	Column     int32  `json:"column"` // 1-based byte offset, or 0 if unknown
+	Preview    string `json:"preview"`
+}
+
+var searchExportCSVHeader = []string{"repository", "revision", "path", "line", "column", "preview"}
+
+// searchExportWriter buffers the rows of one page of a search export in the
+// export's format.
+type searchExportWriter struct {
+	buf bytes.Buffer
+	csv *csv.Writer
+	enc *json.Encoder
+	err error
+}
+
+func newSearchExportWriter(format string) (*searchExportWriter, error) {
+	w := &searchExportWriter{}
+	switch format {
+	case db.SearchExportFormatCSV:
+		w.csv = csv.NewWriter(&w.buf)
+		w.err = w.csv.Write(searchExportCSVHeader)
+	case db.SearchExportFormatJSONL:
+		w.enc = json.NewEncoder(&w.buf)
+	default:
+		return nil, fmt.Errorf("unknown search export format %q", format)
+	}
+	return w, nil
+}
+
+// writeFileMatch writes a row for each match in fm and returns the number of
+// rows written.
+func (w *searchExportWriter) writeFileMatch(fm *FileMatchResolver) int32 {
+	row := searchExportRow{
+		Repository: fm.Repo.Name(),
+		Revision:   string(fm.CommitID),
+		Path:       fm.JPath,
+	}
+	if fm.InputRev != nil && *fm.InputRev != "" {
+		row.Revision = *fm.InputRev
+	}
+
+	if len(fm.JLineMatches) == 0 {
+		w.writeRow(row)
+		return 1
+	}
+
+	var count int32
+	for _, lm := range fm.JLineMatches {
+		row.Line = lm.JLineNumber + 1
+		row.Preview = lm.JPreview
+		if len(lm.JOffsetAndLengths) == 0 {
+			row.Column = 0
+			w.writeRow(row)
+			count++
+		}
+		for _, ol := range lm.JOffsetAndLengths {
+			row.Column = ol[0] + 1
+			w.writeRow(row)
+			count++
+		}
+	}
+	return count
+}
+
+func (w *searchExportWriter) writeRow(row searchExportRow) {
+	if w.err != nil {
+		return
+	}
+	if w.csv != nil {
+		w.err = w.csv.Write([]string{
+			row.Repository,
+			row.Revision,
+			row.Path,
+			fmt.Sprint(row.Line),
+			fmt.Sprint(row.Column),
+			row.Preview,
+		})
+		return
+	}
+	w.err = w.enc.Encode(row)
+}
+
+// flush returns the rows written since the last flush.
+func (w *searchExportWriter) flush() ([]byte, error) {
+	if w.csv != nil {
+		w.csv.Flush()
+		if w.err == nil {
+			w.err = w.csv.Error()
+		}
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	data := append([]byte(nil), w.buf.Bytes()...)
+	w.buf.Reset()
+	return data, nil
+}
@@ -0,0 +1,349 @@
+package graphqlbackend
+
+import (
+	"context"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+const (
+	// defaultSearchHistoryMaxEntriesPerUser and defaultSearchHistoryMaxAgeDays
+	// are the retention limits used when the search.history site
+	// configuration does not specify them.
+	defaultSearchHistoryMaxEntriesPerUser = 500
+	defaultSearchHistoryMaxAgeDays        = 90
+
+	// searchHistorySuggestionScore is the score of the queries and
+	// repositories suggested from the user's search history. It ranks them
+	// above symbol and file suggestions, but below repositories that match
+	// the query.
+	searchHistorySuggestionScore = 1000
+
+	// maxSearchHistorySuggestions is the maximum number of recent and of
+	// frequent queries that are suggested.
+	maxSearchHistorySuggestions = 5
+)
+
+// searchHistoryLimits returns the retention limits of search history.
+func searchHistoryLimits() (maxEntriesPerUser int, maxAge time.Duration) {
+	maxEntriesPerUser, maxAgeDays := defaultSearchHistoryMaxEntriesPerUser, defaultSearchHistoryMaxAgeDays
+	if c := conf.Get().SearchHistory; c != nil {
+		if c.MaxEntriesPerUser != nil {
+			maxEntriesPerUser = *c.MaxEntriesPerUser
+		}
+		if c.MaxAgeDays != nil {
+			maxAgeDays = *c.MaxAgeDays
+		}
+	}
+	return maxEntriesPerUser, time.Duration(maxAgeDays) * 24 * time.Hour
+}
+
+// searchHistoryEnabled reports whether the viewer has not turned off their
+// search history with the search.history.enabled setting.
+func searchHistoryEnabled(ctx context.Context) (bool, error) {
+	settings, err := decodedViewerFinalSettings(ctx)
+	if err != nil {
+		return false, err
+	}
+	return settings.SearchHistoryEnabled == nil || *settings.SearchHistoryEnabled, nil
+}
+
+// recordSearchHistory adds the query of a search that the viewer ran from
+// their browser to their search history. Searches run by API clients and by
+// background workers on behalf of users are not recorded.
+func (r *searchResolver) recordSearchHistory(ctx context.Context) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() || trace.RequestSource(ctx) != trace.SourceBrowser {
+		return
+	}
+	q := strings.TrimSpace(r.rawQuery())
+	if q == "" {
+		return
+	}
+
+	enabled, err := searchHistoryEnabled(ctx)
+	if err != nil || !enabled {
+		return
+	}
+	maxEntriesPerUser, _ := searchHistoryLimits()
+	if err := db.SearchHistory.Record(ctx, a.UID, q, maxEntriesPerUser); err != nil {
+		log15.Warn("Could not record search history", "err", err)
+	}
+}
+
+// RunSearchHistoryPruner deletes search history entries that are older than
+// the configured retention period, once an hour, until ctx is canceled.
+func RunSearchHistoryPruner(ctx context.Context) {
+	for {
+		_, maxAge := searchHistoryLimits()
+		if err := db.SearchHistory.DeleteOlderThan(ctx, time.Now().Add(-maxAge)); err != nil {
+			log15.Error("deleting old search history", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Hour):
+		}
+	}
+}
+
+func (r *UserResolver) SearchHistory(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+	Query   *string
+	OrderBy string
+}) (*searchHistoryConnectionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins and the user can list a user's search history.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
+		return nil, err
+	}
+
+	opt := db.SearchHistoryListOptions{
+		UserID:           r.user.ID,
+		OrderByFrequency: args.OrderBy == "FREQUENT",
+	}
+	if args.Query != nil {
+		opt.Query = *args.Query
+	}
+	args.ConnectionArgs.Set(&opt.LimitOffset)
+	return &searchHistoryConnectionResolver{opt: opt}, nil
+}
+
+func (r *schemaResolver) ClearSearchHistory(ctx context.Context, args *struct {
+	User graphql.ID
+}) (*EmptyResponse, error) {
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only the user and site admins can clear a user's search history.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := db.SearchHistory.DeleteByUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// searchHistoryConnectionResolver resolves a list of search history entries.
+//
+// 🚨 SECURITY: When instantiating a searchHistoryConnectionResolver value, the caller MUST check
+// permissions.
+type searchHistoryConnectionResolver struct {
+	opt db.SearchHistoryListOptions
+
+	// cache results because they are used by multiple fields
+	once    sync.Once
+	entries []*db.SearchHistoryEntry
+	err     error
+}
+
+func (r *searchHistoryConnectionResolver) compute(ctx context.Context) ([]*db.SearchHistoryEntry, error) {
+	r.once.Do(func() {
+		opt2 := r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.entries, r.err = db.SearchHistory.List(ctx, opt2)
+	})
+	return r.entries, r.err
+}
+
+func (r *searchHistoryConnectionResolver) Nodes(ctx context.Context) ([]*searchHistoryEntryResolver, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(entries) > r.opt.LimitOffset.Limit {
+		entries = entries[:r.opt.LimitOffset.Limit]
+	}
+
+	l := make([]*searchHistoryEntryResolver, 0, len(entries))
+	for _, e := range entries {
+		l = append(l, &searchHistoryEntryResolver{entry: e})
+	}
+	return l, nil
+}
+
+func (r *searchHistoryConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := db.SearchHistory.Count(ctx, r.opt)
+	return int32(count), err
+}
+
+func (r *searchHistoryConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(entries) > r.opt.Limit), nil
+}
+
+// searchHistoryEntryResolver resolves an entry of a user's search history.
+type searchHistoryEntryResolver struct {
+	entry *db.SearchHistoryEntry
+}
+
+func (r *searchHistoryEntryResolver) Query() string { return r.entry.Query }
+
+func (r *searchHistoryEntryResolver) SearchCount() int32 { return r.entry.SearchCount }
+
+func (r *searchHistoryEntryResolver) FirstSearchedAt() DateTime {
+	return DateTime{Time: r.entry.FirstSearchedAt}
+}
+
+func (r *searchHistoryEntryResolver) LastSearchedAt() DateTime {
+	return DateTime{Time: r.entry.LastSearchedAt}
+}
+
+// searchHistorySuggestions returns the viewer's recent and frequent queries
+// that contain the query being typed, followed by the repositories that they
+// searched most often whose names contain its last term.
+func (r *searchResolver) searchHistorySuggestions(ctx context.Context) ([]*searchSuggestionResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, nil
+	}
+	if enabled, err := searchHistoryEnabled(ctx); err != nil || !enabled {
+		return nil, err
+	}
+
+	typed := strings.TrimSpace(r.rawQuery())
+	if typed == "" {
+		return nil, nil
+	}
+
+	var suggestions []*searchSuggestionResolver
+	seen := map[string]bool{typed: true}
+	for _, orderByFrequency := range []bool{false, true} {
+		entries, err := db.SearchHistory.List(ctx, db.SearchHistoryListOptions{
+			UserID:           a.UID,
+			Query:            typed,
+			OrderByFrequency: orderByFrequency,
+			LimitOffset:      &db.LimitOffset{Limit: maxSearchHistorySuggestions + 1},
+		})
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		for _, e := range entries {
+			if seen[e.Query] || n == maxSearchHistorySuggestions {
+				continue
+			}
+			seen[e.Query] = true
+			n++
+			suggestions = append(suggestions, newSearchSuggestionResolver(
+				&searchHistoryEntryResolver{entry: e},
+				searchHistorySuggestionScore+int(e.SearchCount),
+			))
+		}
+	}
+
+	// Suggest the repositories that the viewer searched with repo: filters
+	// in their recent searches, weighted by how often they searched them.
+	entries, err := db.SearchHistory.List(ctx, db.SearchHistoryListOptions{
+		UserID:      a.UID,
+		LimitOffset: &db.LimitOffset{Limit: 100},
+	})
+	if err != nil {
+		return nil, err
+	}
+	term := strings.ToLower(lastSearchTerm(typed))
+	repoCounts := map[api.RepoName]int{}
+	for _, e := range entries {
+		for _, name := range searchHistoryRepoNames(e.Query) {
+			if strings.Contains(strings.ToLower(string(name)), term) {
+				repoCounts[name] += int(e.SearchCount)
+			}
+		}
+	}
+	repoNames := make([]api.RepoName, 0, len(repoCounts))
+	for name := range repoCounts {
+		repoNames = append(repoNames, name)
+	}
+	sort.Slice(repoNames, func(i, j int) bool {
+		if repoCounts[repoNames[i]] != repoCounts[repoNames[j]] {
+			return repoCounts[repoNames[i]] > repoCounts[repoNames[j]]
+		}
+		return repoNames[i] < repoNames[j]
+	})
+	if len(repoNames) > maxSearchHistorySuggestions {
+		repoNames = repoNames[:maxSearchHistorySuggestions]
+	}
+	for _, name := range repoNames {
+		// 🚨 SECURITY: backend.Repos.GetByName only returns repositories
+		// that the viewer can still access.
+		repo, err := backend.Repos.GetByName(ctx, name)
+		if err != nil {
+			continue
+		}
+		suggestions = append(suggestions, newSearchSuggestionResolver(
+			&RepositoryResolver{repo: repo},
+			searchHistorySuggestionScore+repoCounts[name],
+		))
+	}
+
+	return suggestions, nil
+}
+
+// lastSearchTerm returns the last whitespace-separated term of the query,
+// without a repo: prefix or regexp anchors, which is the part of the query
+// that the user is most likely still typing.
+func lastSearchTerm(q string) string {
+	fields := strings.Fields(q)
+	if len(fields) == 0 {
+		return ""
+	}
+	term := fields[len(fields)-1]
+	term = strings.TrimPrefix(term, query.FieldRepo+":")
+	return strings.TrimSuffix(strings.TrimPrefix(term, "^"), "$")
+}
+
+// searchHistoryRepoNames returns the names of the repositories that the
+// repo: filters of the query match exactly, such as repo:^github\.com/a/b$.
+// Filters that are not literal names, such as repo:github, are skipped.
+func searchHistoryRepoNames(q string) []api.RepoName {
+	info, err := query.ParseAndCheck(q)
+	if err != nil {
+		return nil
+	}
+	includes, _ := info.RegexpPatterns(query.FieldRepo)
+
+	var names []api.RepoName
+	for _, pattern := range includes {
+		if i := strings.LastIndexByte(pattern, '@'); i > -1 {
+			pattern = pattern[:i] // strip the @revision suffix
+		}
+		pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+		re, err := syntax.Parse(pattern, syntax.Perl)
+		if err != nil || re.Op != syntax.OpLiteral || re.Flags&syntax.FoldCase != 0 {
+			continue
+		}
+		names = append(names, api.RepoName(string(re.Rune)))
+	}
+	return names
+}
@@ -0,0 +1,139 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSearchHistoryRepoNames(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		want  []api.RepoName
+	}{
+		{query: "foo", want: nil},
+		{query: `repo:^github\.com/a/b$ foo`, want: []api.RepoName{"github.com/a/b"}},
+		{query: `repo:^github\.com/a/b$@main`, want: []api.RepoName{"github.com/a/b"}},
+		{query: `repo:^github\.com/a/b$ repo:c/d -repo:e/f`, want: []api.RepoName{"github.com/a/b", "c/d"}},
+		{query: "repo:github.com/a", want: nil},
+		{query: "repo:a|b", want: nil},
+		{query: "repo:(?i)a/b", want: nil},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			if got := searchHistoryRepoNames(tc.query); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSearchHistorySuggestions(t *testing.T) {
+	resetMocks()
+	mockDecodedViewerFinalSettings = &schema.Settings{}
+	defer func() { mockDecodedViewerFinalSettings = nil }()
+
+	entries := []*db.SearchHistoryEntry{
+		{Query: `repo:^github\.com/a/foo$ bar`, SearchCount: 1},
+		{Query: "foo", SearchCount: 4},
+		{Query: `repo:^github\.com/a/foo$ foo`, SearchCount: 2},
+		{Query: `repo:^github\.com/b/baz$ qux`, SearchCount: 9},
+	}
+	db.Mocks.SearchHistory.List = func(opt db.SearchHistoryListOptions) ([]*db.SearchHistoryEntry, error) {
+		if opt.UserID != 1 {
+			t.Errorf("got user ID %d, want 1", opt.UserID)
+		}
+		var matching []*db.SearchHistoryEntry
+		for _, e := range entries {
+			if strings.Contains(e.Query, opt.Query) {
+				matching = append(matching, e)
+			}
+		}
+		if opt.OrderByFrequency {
+			sort.Slice(matching, func(i, j int) bool { return matching[i].SearchCount > matching[j].SearchCount })
+		}
+		return matching, nil
+	}
+	backend.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{Name: name}, nil
+	}
+	defer func() {
+		db.Mocks.SearchHistory = db.MockSearchHistory{}
+		backend.Mocks.Repos.GetByName = nil
+	}()
+
+	getSuggestions := func(ctx context.Context, query string) []string {
+		t.Helper()
+		r, err := (&schemaResolver{}).Search(&SearchArgs{Query: query, Version: "V2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		suggestions, err := r.(*searchResolver).searchHistorySuggestions(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sortSearchSuggestions(suggestions)
+		var got []string
+		for _, s := range suggestions {
+			got = append(got, testStringResult(s))
+		}
+		return got
+	}
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	want := []string{
+		"repo:github.com/a/foo",
+		`history:repo:^github\.com/a/foo$ foo`,
+		`history:repo:^github\.com/a/foo$ bar`,
+	}
+	if got := getSuggestions(ctx, "foo"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got suggestions %q, want %q", got, want)
+	}
+
+	if got := getSuggestions(context.Background(), "foo"); len(got) != 0 {
+		t.Errorf("got suggestions %q for an anonymous user, want none", got)
+	}
+
+	disabled := false
+	mockDecodedViewerFinalSettings = &schema.Settings{SearchHistoryEnabled: &disabled}
+	if got := getSuggestions(ctx, "foo"); len(got) != 0 {
+		t.Errorf("got suggestions %q with search history disabled, want none", got)
+	}
+}
+
+// 🚨 SECURITY: This tests that users can't clear other users' search history.
+func TestMutation_ClearSearchHistory(t *testing.T) {
+	resetMocks()
+	var deleted []int32
+	db.Mocks.SearchHistory.DeleteByUser = func(userID int32) error {
+		deleted = append(deleted, userID)
+		return nil
+	}
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1}, nil
+	}
+	db.Mocks.Users.GetByID = func(_ context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id}, nil
+	}
+	defer func() { db.Mocks.SearchHistory = db.MockSearchHistory{} }()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	if _, err := (&schemaResolver{}).ClearSearchHistory(ctx, &struct{ User graphql.ID }{User: MarshalUserID(2)}); err == nil {
+		t.Error("got nil error, want an error for another user's search history")
+	}
+	if _, err := (&schemaResolver{}).ClearSearchHistory(ctx, &struct{ User graphql.ID }{User: MarshalUserID(1)}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int32{1}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deleted search history of users %v, want %v", deleted, want)
+	}
+}
@@ -0,0 +1,275 @@
+package graphqlbackend
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+)
+
+const (
+	// rankingMaxSymbolLookups is the number of the highest-ranked file matches
+	// that are checked for symbol definitions that match the search pattern.
+	rankingMaxSymbolLookups = 50
+
+	// rankingSymbolsTimeout is how long the symbol definition lookups may take.
+	// Files whose lookups do not finish in time are ranked without them.
+	rankingSymbolsTimeout = 500 * time.Millisecond
+)
+
+// rankingWeights are the weights of the signals that rankResults scores file
+// matches by. They correspond to the "search.ranking" site configuration.
+type rankingWeights struct {
+	repoStars         float64
+	pathDepth         float64
+	testFiles         float64
+	vendoredFiles     float64
+	symbolDefinitions float64
+	matchDensity      float64
+}
+
+var defaultRankingWeights = rankingWeights{
+	repoStars:         1,
+	pathDepth:         0.25,
+	testFiles:         1,
+	vendoredFiles:     2,
+	symbolDefinitions: 2,
+	matchDensity:      1,
+}
+
+// getRankingWeights returns the default ranking weights, overridden by those
+// set in the site configuration.
+func getRankingWeights() rankingWeights {
+	w := defaultRankingWeights
+	c := conf.Get().SearchRanking
+	if c == nil {
+		return w
+	}
+	for _, f := range []struct {
+		dst *float64
+		src *float64
+	}{
+		{&w.repoStars, c.RepoStars},
+		{&w.pathDepth, c.PathDepth},
+		{&w.testFiles, c.TestFiles},
+		{&w.vendoredFiles, c.VendoredFiles},
+		{&w.symbolDefinitions, c.SymbolDefinitions},
+		{&w.matchDensity, c.MatchDensity},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+	return w
+}
+
+// rankingLooksUpSymbols reports whether rankResults looks up the symbols of the
+// highest-ranked file matches, which sends a request to the symbols service
+// for each of their repository revisions. It is disabled by default.
+func rankingLooksUpSymbols() bool {
+	c := conf.Get().SearchRanking
+	return c != nil && c.LookUpSymbols
+}
+
+var (
+	testFilePattern     = regexp.MustCompile(`(^|/)(tests?|__tests__|specs?|testdata)/|_test\.[^/.]+$|\.(test|spec)\.[^/]+$|(^|/)test_[^/]*$`)
+	vendoredFilePattern = regexp.MustCompile(`(^|/)(vendor|node_modules|third_party|bower_components)/|\.min\.(js|css)$`)
+)
+
+// rankingSignals are the signals of a file match that are independent of the
+// other results.
+type rankingSignals struct {
+	stars            int
+	path             string
+	matchCount       int
+	symbolDefinition bool
+}
+
+func (s rankingSignals) score(w rankingWeights) float64 {
+	score := w.repoStars*math.Log10(1+float64(s.stars)) +
+		w.matchDensity*math.Log2(1+float64(s.matchCount)) -
+		w.pathDepth*float64(strings.Count(s.path, "/"))
+	if testFilePattern.MatchString(s.path) {
+		score -= w.testFiles
+	}
+	if vendoredFilePattern.MatchString(s.path) {
+		score -= w.vendoredFiles
+	}
+	if s.symbolDefinition {
+		score += w.symbolDefinitions
+	}
+	return score
+}
+
+// rankResults reorders the file matches among the results so that the most
+// relevant ones come first. The other results keep their positions. File
+// matches with equal scores keep their relative order, so results that are
+// sorted beforehand are ranked deterministically.
+func rankResults(ctx context.Context, results []SearchResultResolver, p *search.TextPatternInfo) {
+	var slots []int
+	var fileMatches []*FileMatchResolver
+	for i, r := range results {
+		if fm, ok := r.ToFileMatch(); ok {
+			slots = append(slots, i)
+			fileMatches = append(fileMatches, fm)
+		}
+	}
+	if len(fileMatches) < 2 {
+		return
+	}
+
+	w := getRankingWeights()
+	signals := make([]rankingSignals, len(fileMatches))
+	for i, fm := range fileMatches {
+		signals[i] = rankingSignals{
+			path:             fm.JPath,
+			matchCount:       int(fm.resultCount()),
+			symbolDefinition: len(fm.symbols) > 0,
+		}
+	}
+
+	if w.repoStars != 0 {
+		stars, err := repoStars(ctx, fileMatches)
+		if err != nil {
+			log15.Warn("Ranking search results without repository stars", "error", err)
+		}
+		for i, fm := range fileMatches {
+			signals[i].stars = stars[fm.Repo.repo.ID]
+		}
+	}
+
+	order := func() []int {
+		scores := make([]float64, len(signals))
+		for i := range signals {
+			scores[i] = signals[i].score(w)
+		}
+		order := make([]int, len(signals))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool { return scores[order[i]] > scores[order[j]] })
+		return order
+	}
+
+	ranked := order()
+	if w.symbolDefinitions != 0 && rankingLooksUpSymbols() && p != nil && p.Pattern != "" && !p.IsStructuralPat {
+		top := ranked
+		if len(top) > rankingMaxSymbolLookups {
+			top = top[:rankingMaxSymbolLookups]
+		}
+		candidates := make([]*FileMatchResolver, len(top))
+		for i, j := range top {
+			candidates[i] = fileMatches[j]
+		}
+		defined := symbolDefinitionFiles(ctx, candidates, p)
+		for i, fm := range fileMatches {
+			if defined[fm] {
+				signals[i].symbolDefinition = true
+			}
+		}
+		ranked = order()
+	}
+
+	for i, j := range ranked {
+		results[slots[i]] = fileMatches[j]
+	}
+}
+
+// repoStars returns the number of stars of the repositories of the file
+// matches.
+func repoStars(ctx context.Context, fileMatches []*FileMatchResolver) (map[api.RepoID]int, error) {
+	seen := make(map[api.RepoID]bool)
+	var ids []api.RepoID
+	for _, fm := range fileMatches {
+		if id := fm.Repo.repo.ID; !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return db.Repos.GetStars(ctx, ids...)
+}
+
+var mockSymbolDefinitionFiles func(fileMatches []*FileMatchResolver, p *search.TextPatternInfo) map[*FileMatchResolver]bool
+
+// symbolDefinitionFiles returns the file matches whose files define a symbol
+// whose name matches the search pattern. The symbols of each repository
+// revision are looked up with one request to the symbols service. Lookups
+// that fail or time out are ignored.
+func symbolDefinitionFiles(ctx context.Context, fileMatches []*FileMatchResolver, p *search.TextPatternInfo) map[*FileMatchResolver]bool {
+	if mockSymbolDefinitionFiles != nil {
+		return mockSymbolDefinitionFiles(fileMatches, p)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rankingSymbolsTimeout)
+	defer cancel()
+
+	type repoCommit struct {
+		repo   api.RepoName
+		commit api.CommitID
+	}
+	byRepoCommit := make(map[repoCommit][]*FileMatchResolver)
+	for _, fm := range fileMatches {
+		if len(fm.symbols) > 0 {
+			continue // symbol results are already definitions
+		}
+		k := repoCommit{repo: fm.Repo.repo.Name, commit: fm.CommitID}
+		byRepoCommit[k] = append(byRepoCommit[k], fm)
+	}
+
+	pattern := p.Pattern
+	if !p.IsRegExp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	pattern = "^(?:" + pattern + ")$"
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		defined = make(map[*FileMatchResolver]bool)
+	)
+	for k, fms := range byRepoCommit {
+		wg.Add(1)
+		go func(k repoCommit, fms []*FileMatchResolver) {
+			defer wg.Done()
+
+			byPath := make(map[string]*FileMatchResolver, len(fms))
+			paths := make([]string, 0, len(fms))
+			for _, fm := range fms {
+				byPath[fm.JPath] = fm
+				paths = append(paths, regexp.QuoteMeta(fm.JPath))
+			}
+			symbols, err := backend.Symbols.ListTags(ctx, search.SymbolsParameters{
+				Repo:            k.repo,
+				CommitID:        k.commit,
+				Query:           pattern,
+				IsCaseSensitive: p.IsCaseSensitive,
+				IsRegExp:        true,
+				IncludePatterns: []string{"^(?:" + strings.Join(paths, "|") + ")$"},
+				First:           500, // the maximum of the symbols service
+			})
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, s := range symbols {
+				if fm, ok := byPath[s.Path]; ok {
+					defined[fm] = true
+				}
+			}
+		}(k, fms)
+	}
+	wg.Wait()
+	return defined
+}
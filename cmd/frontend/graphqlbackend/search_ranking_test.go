@@ -0,0 +1,130 @@
+package graphqlbackend
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRankResults(t *testing.T) {
+	defer func() {
+		db.Mocks = db.MockStores{}
+		mockSymbolDefinitionFiles = nil
+	}()
+
+	popular := &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "popular"}}
+	obscure := &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "obscure"}}
+	db.Mocks.Repos.GetStars = func(ctx context.Context, ids ...api.RepoID) (map[api.RepoID]int, error) {
+		return map[api.RepoID]int{1: 9999, 2: 0}, nil
+	}
+
+	fm := func(repo *RepositoryResolver, path string, matches int) *FileMatchResolver {
+		return &FileMatchResolver{Repo: repo, JPath: path, MatchCount: matches, uri: "git://" + string(repo.repo.Name) + "#" + path}
+	}
+	var (
+		obscureMain   = fm(obscure, "main.go", 1)
+		obscureVendor = fm(obscure, "vendor/a/b.go", 1)
+		popularDeep   = fm(popular, "a/b/c/d/e/f/g/h/x.go", 1)
+		popularMain   = fm(popular, "main.go", 1)
+		popularTest   = fm(popular, "main_test.go", 1)
+		popularDef    = fm(popular, "def.go", 1)
+		repoResult    = &RepositoryResolver{repo: &types.Repo{ID: 3, Name: "repo"}}
+	)
+	lookedUpSymbols := false
+	mockSymbolDefinitionFiles = func(fileMatches []*FileMatchResolver, p *search.TextPatternInfo) map[*FileMatchResolver]bool {
+		lookedUpSymbols = true
+		return map[*FileMatchResolver]bool{popularDef: true}
+	}
+
+	results := []SearchResultResolver{repoResult, obscureMain, obscureVendor, popularDeep, popularMain, popularTest, popularDef}
+	rankResults(context.Background(), results, &search.TextPatternInfo{Pattern: "x"})
+
+	// Symbols are not looked up by default.
+	want := []SearchResultResolver{repoResult, popularMain, popularDef, popularTest, popularDeep, obscureMain, obscureVendor}
+	if lookedUpSymbols {
+		t.Error("got symbol lookup, want none by default")
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("got %v, want %v", resultURIs(results), resultURIs(want))
+	}
+
+	t.Run("symbol lookups", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{SearchRanking: &schema.SearchRanking{LookUpSymbols: true}}})
+		defer conf.Mock(nil)
+
+		results := []SearchResultResolver{popularMain, popularDef}
+		rankResults(context.Background(), results, &search.TextPatternInfo{Pattern: "x"})
+
+		want := []SearchResultResolver{popularDef, popularMain}
+		if !reflect.DeepEqual(results, want) {
+			t.Errorf("got %v, want %v", resultURIs(results), resultURIs(want))
+		}
+	})
+
+	t.Run("weights from site configuration", func(t *testing.T) {
+		zero := 0.0
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{SearchRanking: &schema.SearchRanking{
+			RepoStars:         &zero,
+			PathDepth:         &zero,
+			TestFiles:         &zero,
+			VendoredFiles:     &zero,
+			SymbolDefinitions: &zero,
+		}}})
+		defer conf.Mock(nil)
+
+		many := fm(obscure, "vendor/many.go", 10)
+		results := []SearchResultResolver{obscureMain, popularDef, many}
+		rankResults(context.Background(), results, &search.TextPatternInfo{Pattern: "x"})
+
+		want := []SearchResultResolver{many, obscureMain, popularDef}
+		if !reflect.DeepEqual(results, want) {
+			t.Errorf("got %v, want %v", resultURIs(results), resultURIs(want))
+		}
+	})
+}
+
+func TestRankingPathPatterns(t *testing.T) {
+	tests := []struct {
+		path           string
+		test, vendored bool
+	}{
+		{path: "main.go"},
+		{path: "cmd/latest/main.go"},
+		{path: "contest/main.go"},
+		{path: "main_test.go", test: true},
+		{path: "src/app.test.ts", test: true},
+		{path: "src/app.spec.js", test: true},
+		{path: "test/fixtures.rb", test: true},
+		{path: "pkg/__tests__/a.js", test: true},
+		{path: "test_utils.py", test: true},
+		{path: "vendor/github.com/a/b.go", vendored: true},
+		{path: "web/node_modules/react/index.js", vendored: true},
+		{path: "third_party/x/y.c", vendored: true},
+		{path: "static/jquery.min.js", vendored: true},
+		{path: "vendors.go"},
+	}
+	for _, test := range tests {
+		if got := testFilePattern.MatchString(test.path); got != test.test {
+			t.Errorf("%s: got test %v, want %v", test.path, got, test.test)
+		}
+		if got := vendoredFilePattern.MatchString(test.path); got != test.vendored {
+			t.Errorf("%s: got vendored %v, want %v", test.path, got, test.vendored)
+		}
+	}
+}
+
+func resultURIs(results []SearchResultResolver) []string {
+	uris := make([]string, len(results))
+	for i, r := range results {
+		repo, file := r.searchResultURIs()
+		uris[i] = repo + "/" + file
+	}
+	return uris
+}
@@ -36,6 +36,7 @@ func searchRepositories(ctx context.Context, args *search.TextParameters, limit
 		query.FieldFork:               {},
 		query.FieldArchived:           {},
 		query.FieldVisibility:         {},
+		query.FieldOrder:              {},
 		query.FieldCase:               {},
 		query.FieldRepoHasFile:        {},
 		query.FieldRepoHasCommitAfter: {},
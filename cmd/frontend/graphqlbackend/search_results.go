@@ -42,6 +42,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 // searchResultsCommon contains fields that should be returned by all funcs
@@ -482,7 +483,7 @@ loop:
 				}
 				addPoint(t)
 			})
-		case *codemodResultResolver:
+		case *codemodResultResolver, *commitAuthorSearchResultResolver:
 			continue
 		default:
 			panic("SearchResults.Sparkline unexpected union type state")
@@ -954,22 +955,41 @@ func (r *searchResolver) evaluate(ctx context.Context, q []query.Node) (*SearchR
 }
 
 func (r *searchResolver) Results(ctx context.Context) (*SearchResultsResolver, error) {
+	sel, err := r.selectField()
+	if err != nil {
+		return nil, &badRequestError{err}
+	}
+
+	var rr *SearchResultsResolver
 	switch q := r.query.(type) {
 	case *query.OrdinaryQuery:
-		return r.evaluateLeaf(ctx)
+		rr, err = r.evaluateLeaf(ctx)
 	case *query.AndOrQuery:
 		// Get settings to check if `search.uppercase` is active. If so, run transformer.
-		settings, err := decodedViewerFinalSettings(ctx)
+		var settings *schema.Settings
+		settings, err = decodedViewerFinalSettings(ctx)
 		if err != nil {
 			return nil, err
 		}
 		if v := settings.SearchUppercase; v != nil && *v {
 			q.Query = query.SearchUppercase(q.Query)
 		}
-		return r.evaluate(ctx, q.Query)
+		rr, err = r.evaluate(ctx, q.Query)
+	default:
+		// Unreachable.
+		return nil, fmt.Errorf("unrecognized type %s in searchResolver Results", reflect.TypeOf(r.query).String())
 	}
-	// Unreachable.
-	return nil, fmt.Errorf("unrecognized type %s in searchResolver Results", reflect.TypeOf(r.query).String())
+	if err == nil && rr != nil && (r.pagination == nil || r.pagination.cursor == nil) {
+		// Only record the first page of paginated searches.
+		r.recordSearchHistory(ctx)
+	}
+	if err != nil || rr == nil || sel == nil {
+		return rr, err
+	}
+
+	// Project the results onto the type requested by select:.
+	rr.SearchResults = selectResults(ctx, rr.SearchResults, sel)
+	return rr, nil
 }
 
 // resultsWithTimeoutSuggestion calls doResults, and in case of deadline
@@ -1230,6 +1250,14 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 
 	// Handle file: and -file: filters.
 	includePatterns, excludePatterns := q.RegexpPatterns(query.FieldFile)
+	includePatterns, includeOwners, err := splitFileOwnerPredicates(includePatterns)
+	if err != nil {
+		return nil, err
+	}
+	excludePatterns, excludeOwners, err := splitFileOwnerPredicates(excludePatterns)
+	if err != nil {
+		return nil, err
+	}
 	filePatternsReposMustInclude, filePatternsReposMustExclude := q.RegexpPatterns(query.FieldRepoHasFile)
 
 	if opts.forceFileSearch {
@@ -1257,11 +1285,14 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 		IsRegExp:                     isRegExp,
 		IsStructuralPat:              isStructuralPat,
 		IsCaseSensitive:              q.IsCaseSensitive(),
+		IsMultiline:                  q.BoolValue(query.FieldMultiline),
 		FileMatchLimit:               opts.fileMatchLimit,
 		Pattern:                      pattern,
 		IncludePatterns:              includePatterns,
 		FilePatternsReposMustInclude: filePatternsReposMustInclude,
 		FilePatternsReposMustExclude: filePatternsReposMustExclude,
+		FileOwnersMustInclude:        includeOwners,
+		FileOwnersMustExclude:        excludeOwners,
 		PathPatternsAreRegExps:       true,
 		Languages:                    languages,
 		PathPatternsAreCaseSensitive: q.IsCaseSensitive(),
@@ -1273,6 +1304,21 @@ func getPatternInfo(q query.QueryInfo, opts *getPatternInfoOptions) (*search.Tex
 	return patternInfo, nil
 }
 
+// splitFileOwnerPredicates separates the values of file: filters into file
+// path patterns and the owners of the file:has.owner() predicates.
+func splitFileOwnerPredicates(values []string) (patterns, owners []string, err error) {
+	patterns, predicates, err := query.SplitFilePredicates(values)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, p := range predicates {
+		if p.Name == query.FilePredicateOwner {
+			owners = append(owners, p.Value)
+		}
+	}
+	return patterns, owners, nil
+}
+
 // langIncludeExcludePatterns returns regexps for the include/exclude path patterns given the lang:
 // and -lang: filter values in a search query. For example, a query containing "lang:go" should
 // include files whose paths match /\.go$/.
@@ -1346,6 +1392,9 @@ func (r *searchResolver) determineResultTypes(args search.TextParameters, forceO
 		resultTypes, _ = r.query.StringValues(query.FieldType)
 		if len(resultTypes) == 0 {
 			resultTypes = []string{"file", "path", "repo"}
+			if sel, _ := r.selectField(); sel != nil {
+				resultTypes = selectResultTypes(sel)
+			}
 		}
 	}
 	for _, resultType := range resultTypes {
@@ -1383,7 +1432,7 @@ func (r *searchResolver) determineRepos(ctx context.Context, tr *trace.Trace, st
 
 // Surface an alert if a query exceeds limits that we place on search. Currently limits
 // diff and commit searches where more than repoLimit repos need to be searched.
-func alertOnSearchLimit(resultTypes []string, args *search.TextParameters) ([]string, *searchAlert) {
+func alertOnSearchLimit(ctx context.Context, resultTypes []string, args *search.TextParameters) ([]string, *searchAlert) {
 	var alert *searchAlert
 	repoLimit := 50
 	if len(args.Repos) > repoLimit {
@@ -1391,6 +1440,11 @@ func alertOnSearchLimit(resultTypes []string, args *search.TextParameters) ([]st
 			resultType := resultTypes[0]
 			switch resultType {
 			case "commit", "diff":
+				// Repositories whose commits are indexed don't count towards
+				// the limit.
+				if _, unindexed, err := partitionCommitIndexedRepos(ctx, args.Repos, args.Query); err == nil && len(unindexed) <= repoLimit {
+					break
+				}
 				if _, afterPresent := args.Query.Fields()["after"]; afterPresent {
 					break
 				}
@@ -1479,6 +1533,10 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	resultTypes := r.determineResultTypes(args, forceOnlyResultType)
 	tr.LazyPrintf("resultTypes: %v", resultTypes)
 
+	if err := validateFileOwnerUsage(args.PatternInfo, resultTypes); err != nil {
+		return nil, &badRequestError{err}
+	}
+
 	var (
 		requiredWg sync.WaitGroup
 		optionalWg sync.WaitGroup
@@ -1515,7 +1573,7 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	// Apply search limits and generate warnings before firing off workers.
 	// This currently limits diff and commit search to a set number of
 	// repos, and removes the diff and commit resultTypes if it is breached.
-	resultTypes, alert = alertOnSearchLimit(resultTypes, &args)
+	resultTypes, alert = alertOnSearchLimit(ctx, resultTypes, &args)
 
 	searchedFileContentsOrPaths := false
 	for _, resultType := range resultTypes {
@@ -1788,6 +1846,9 @@ func (r *searchResolver) doResults(ctx context.Context, forceOnlyResultType stri
 	}
 
 	sortResults(results)
+	if !r.query.BoolValue(query.FieldStable) {
+		rankResults(ctx, results, p)
+	}
 
 	resultsResolver := SearchResultsResolver{
 		start:               start,
@@ -1813,6 +1874,7 @@ func isContextError(ctx context.Context, err error) bool {
 //   - *fileMatchResolver          // text match
 //   - *commitSearchResultResolver // diff or commit match
 //   - *codemodResultResolver      // code modification
+//   - *commitAuthorSearchResultResolver // commit author selected by select:commit.author
 //
 // Note: Any new result types added here also need to be handled properly in search_results.go:301 (sparklines)
 type SearchResultResolver interface {
@@ -1820,6 +1882,7 @@ type SearchResultResolver interface {
 	ToFileMatch() (*FileMatchResolver, bool)
 	ToCommitSearchResult() (*commitSearchResultResolver, bool)
 	ToCodemodResult() (*codemodResultResolver, bool)
+	ToCommitAuthorSearchResult() (*commitAuthorSearchResultResolver, bool)
 
 	// SearchResultURIs returns the repo name and file uri respectiveley
 	searchResultURIs() (string, string)
@@ -1856,6 +1919,24 @@ func orderedFuzzyRegexp(pieces []string) string {
 }
 
 // Validates usage of the `repohasfile` filter
+// validateFileOwnerUsage returns an error if the query has file:has.owner()
+// filters but searches result types that the filter can't be applied to.
+// Only file and path results are filtered by owner, and repository results are
+// never returned for queries with file: filters.
+func validateFileOwnerUsage(p *search.TextPatternInfo, resultTypes []string) error {
+	if len(p.FileOwnersMustInclude) == 0 && len(p.FileOwnersMustExclude) == 0 {
+		return nil
+	}
+	for _, resultType := range resultTypes {
+		switch resultType {
+		case "file", "path", "repo":
+		default:
+			return fmt.Errorf("file:has.%s() only filters file and path results, it can't be used with type:%s", query.FilePredicateOwner, resultType)
+		}
+	}
+	return nil
+}
+
 func validateRepoHasFileUsage(q query.QueryInfo) error {
 	// Query only contains "repohasfile:" and "type:symbol"
 	if len(q.Fields()) == 2 && q.Fields()["repohasfile"] != nil && q.Fields()["type"] != nil && len(q.Fields()["type"]) == 1 && q.Fields()["type"][0].Value() == "symbol" {
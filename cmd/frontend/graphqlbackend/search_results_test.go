@@ -414,6 +414,14 @@ func TestSearchResolver_getPatternInfo(t *testing.T) {
 			PathPatternsAreRegExps: true,
 			ExcludePattern:         `f|(\.graphql$|\.gql$|\.graphqls$)`,
 		},
+		"p file:has.owner(@a) -file:has.owner(b) -file:f": {
+			Pattern:                "p",
+			IsRegExp:               true,
+			PathPatternsAreRegExps: true,
+			ExcludePattern:         "f",
+			FileOwnersMustInclude:  []string{"@a"},
+			FileOwnersMustExclude:  []string{"b"},
+		},
 	}
 	for queryStr, want := range tests {
 		t.Run(queryStr, func(t *testing.T) {
@@ -996,7 +1004,7 @@ func TestCommitAndDiffSearchLimits(t *testing.T) {
 			}
 		}
 
-		haveResultTypes, alert := alertOnSearchLimit(test.resultTypes, &search.TextParameters{
+		haveResultTypes, alert := alertOnSearchLimit(context.Background(), test.resultTypes, &search.TextParameters{
 			Repos: repoRevs,
 			Query: &query.OrdinaryQuery{Query: &query.Query{Fields: test.fields}},
 		})
@@ -1127,3 +1135,22 @@ func TestSearchResolver_evaluateWarning(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateFileOwnerUsage(t *testing.T) {
+	owners := &search.TextPatternInfo{FileOwnersMustInclude: []string{"@a"}}
+	for _, tc := range []struct {
+		info        *search.TextPatternInfo
+		resultTypes []string
+		wantErr     string
+	}{
+		{info: &search.TextPatternInfo{}, resultTypes: []string{"diff"}},
+		{info: owners, resultTypes: []string{"file", "path", "repo"}},
+		{info: owners, resultTypes: []string{"symbol"}, wantErr: "file:has.owner() only filters file and path results, it can't be used with type:symbol"},
+		{info: &search.TextPatternInfo{FileOwnersMustExclude: []string{"@a"}}, resultTypes: []string{"file", "diff"}, wantErr: "file:has.owner() only filters file and path results, it can't be used with type:diff"},
+	} {
+		err := validateFileOwnerUsage(tc.info, tc.resultTypes)
+		if got := fmt.Sprint(err); tc.wantErr != "" && got != tc.wantErr || tc.wantErr == "" && err != nil {
+			t.Errorf("%v: got error %v, want %q", tc.resultTypes, err, tc.wantErr)
+		}
+	}
+}
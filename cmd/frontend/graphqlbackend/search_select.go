@@ -0,0 +1,196 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// commitAuthorSearchResultResolver is a resolver for the GraphQL type
+// `CommitAuthorSearchResult`. It is produced by select:commit.author, which
+// collapses commit and diff matches into the people that authored them.
+type commitAuthorSearchResultResolver struct {
+	person      *personResolver
+	commitCount int32
+}
+
+func (r *commitAuthorSearchResultResolver) Person() *personResolver { return r.person }
+func (r *commitAuthorSearchResultResolver) CommitCount() int32      { return r.commitCount }
+
+func (r *commitAuthorSearchResultResolver) ToRepository() (*RepositoryResolver, bool) {
+	return nil, false
+}
+func (r *commitAuthorSearchResultResolver) ToFileMatch() (*FileMatchResolver, bool) {
+	return nil, false
+}
+func (r *commitAuthorSearchResultResolver) ToCommitSearchResult() (*commitSearchResultResolver, bool) {
+	return nil, false
+}
+func (r *commitAuthorSearchResultResolver) ToCodemodResult() (*codemodResultResolver, bool) {
+	return nil, false
+}
+func (r *commitAuthorSearchResultResolver) ToCommitAuthorSearchResult() (*commitAuthorSearchResultResolver, bool) {
+	return r, true
+}
+
+func (r *commitAuthorSearchResultResolver) searchResultURIs() (string, string) {
+	// Like commit results, authors are never mixed with other types of
+	// results, so they sort last and keep the order of their first commit.
+	return "~", "~"
+}
+
+func (r *commitAuthorSearchResultResolver) resultCount() int32 {
+	return 1
+}
+
+// selectField returns the parsed select: field of the query, or nil if the
+// query does not contain one.
+func (r *searchResolver) selectField() (*query.Select, error) {
+	value, _ := r.query.StringValue(query.FieldSelect)
+	if value == "" {
+		return nil, nil
+	}
+	return query.ParseSelect(value)
+}
+
+// selectResultTypes returns the result types to search for sel when the
+// query does not specify any type: field values.
+func selectResultTypes(sel *query.Select) []string {
+	switch sel.Type {
+	case query.SelectSymbol:
+		return []string{"symbol"}
+	case query.SelectCommitAuthor:
+		return []string{"commit"}
+	default:
+		return []string{"file", "path", "repo"}
+	}
+}
+
+// selectResults collapses results into the result type selected by sel,
+// deduplicating them so that each repository, file, symbol or author is
+// returned and counted once. Results that cannot be projected onto the
+// selected type are dropped.
+func selectResults(ctx context.Context, results []SearchResultResolver, sel *query.Select) []SearchResultResolver {
+	switch sel.Type {
+	case query.SelectRepo:
+		return selectRepos(results)
+	case query.SelectFile:
+		return selectFiles(results)
+	case query.SelectSymbol:
+		return selectSymbols(results, sel.SymbolKind)
+	case query.SelectCommitAuthor:
+		return selectCommitAuthors(ctx, results)
+	}
+	return results
+}
+
+func selectRepos(results []SearchResultResolver) []SearchResultResolver {
+	var selected []SearchResultResolver
+	seen := make(map[string]struct{})
+	add := func(repo *RepositoryResolver) {
+		if repo == nil {
+			return
+		}
+		name := repo.Name()
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		selected = append(selected, repo)
+	}
+
+	for _, result := range results {
+		switch r := result.(type) {
+		case *RepositoryResolver:
+			add(r)
+		case *FileMatchResolver:
+			add(r.Repo)
+		case *commitSearchResultResolver:
+			add(r.commit.Repository())
+		case *codemodResultResolver:
+			add(r.commit.Repository())
+		}
+	}
+	return selected
+}
+
+func selectFiles(results []SearchResultResolver) []SearchResultResolver {
+	var selected []SearchResultResolver
+	seen := make(map[string]struct{})
+	for _, result := range results {
+		fm, ok := result.ToFileMatch()
+		if !ok {
+			continue
+		}
+		key := fm.Repo.Name() + "@" + string(fm.CommitID) + "/" + fm.JPath
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		selected = append(selected, &FileMatchResolver{
+			JPath:    fm.JPath,
+			uri:      fm.uri,
+			Repo:     fm.Repo,
+			CommitID: fm.CommitID,
+			InputRev: fm.InputRev,
+		})
+	}
+	return selected
+}
+
+func selectSymbols(results []SearchResultResolver, kind string) []SearchResultResolver {
+	var selected []SearchResultResolver
+	for _, result := range results {
+		fm, ok := result.ToFileMatch()
+		if !ok {
+			continue
+		}
+		var symbols []*searchSymbolResult
+		for _, s := range fm.symbols {
+			if kind != "" && !strings.EqualFold(ctagsKindToLSPSymbolKind(s.symbol.Kind).String(), kind) {
+				continue
+			}
+			symbols = append(symbols, s)
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+		selected = append(selected, &FileMatchResolver{
+			JPath:    fm.JPath,
+			symbols:  symbols,
+			uri:      fm.uri,
+			Repo:     fm.Repo,
+			CommitID: fm.CommitID,
+			InputRev: fm.InputRev,
+		})
+	}
+	return selected
+}
+
+func selectCommitAuthors(ctx context.Context, results []SearchResultResolver) []SearchResultResolver {
+	var selected []SearchResultResolver
+	authors := make(map[string]*commitAuthorSearchResultResolver)
+	for _, result := range results {
+		c, ok := result.ToCommitSearchResult()
+		if !ok {
+			continue
+		}
+		author, err := c.commit.Author(ctx)
+		if err != nil {
+			continue
+		}
+		key := strings.ToLower(author.person.email)
+		if key == "" {
+			key = author.person.name
+		}
+		if a, ok := authors[key]; ok {
+			a.commitCount++
+			continue
+		}
+		a := &commitAuthorSearchResultResolver{person: author.person, commitCount: 1}
+		authors[key] = a
+		selected = append(selected, a)
+	}
+	return selected
+}
@@ -0,0 +1,120 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/symbols/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestSelectResults(t *testing.T) {
+	repoA := &RepositoryResolver{repo: &types.Repo{ID: 1, Name: "a"}}
+	repoB := &RepositoryResolver{repo: &types.Repo{ID: 2, Name: "b"}}
+
+	fileMatch := func(repo *RepositoryResolver, path string, symbolKinds ...string) *FileMatchResolver {
+		fm := &FileMatchResolver{
+			JPath:        path,
+			JLineMatches: []*lineMatch{{JLineNumber: 1}},
+			MatchCount:   1,
+			Repo:         repo,
+		}
+		for _, kind := range symbolKinds {
+			fm.symbols = append(fm.symbols, &searchSymbolResult{symbol: protocol.Symbol{Name: kind, Kind: kind, Path: path}})
+		}
+		return fm
+	}
+	commit := func(repo *RepositoryResolver, email string) *commitSearchResultResolver {
+		return &commitSearchResultResolver{commit: toGitCommitResolver(repo, &git.Commit{
+			Author: git.Signature{Name: email, Email: email, Date: time.Now()},
+		})}
+	}
+
+	results := []SearchResultResolver{
+		repoA,
+		fileMatch(repoA, "main.go", "func", "variable"),
+		fileMatch(repoA, "main.go", "func"),
+		fileMatch(repoB, "README.md"),
+		fileMatch(repoB, "lib.go", "class"),
+		commit(repoB, "alice@example.com"),
+		commit(repoA, "bob@example.com"),
+		commit(repoA, "Alice@example.com"),
+	}
+
+	describe := func(results []SearchResultResolver) []string {
+		var descriptions []string
+		for _, result := range results {
+			switch r := result.(type) {
+			case *RepositoryResolver:
+				descriptions = append(descriptions, "repo:"+r.Name())
+			case *FileMatchResolver:
+				d := fmt.Sprintf("file:%s/%s lines:%d", r.Repo.Name(), r.JPath, len(r.JLineMatches))
+				for _, s := range r.symbols {
+					d += " symbol:" + s.symbol.Name
+				}
+				descriptions = append(descriptions, d)
+			case *commitAuthorSearchResultResolver:
+				descriptions = append(descriptions, fmt.Sprintf("author:%s commits:%d", r.person.email, r.commitCount))
+			default:
+				descriptions = append(descriptions, fmt.Sprintf("unexpected:%T", r))
+			}
+		}
+		return descriptions
+	}
+
+	for _, tc := range []struct {
+		sel  string
+		want []string
+	}{
+		{
+			sel:  "repo",
+			want: []string{"repo:a", "repo:b"},
+		},
+		{
+			sel: "file",
+			want: []string{
+				"file:a/main.go lines:0",
+				"file:b/README.md lines:0",
+				"file:b/lib.go lines:0",
+			},
+		},
+		{
+			sel: "symbol",
+			want: []string{
+				"file:a/main.go lines:0 symbol:func symbol:variable",
+				"file:a/main.go lines:0 symbol:func",
+				"file:b/lib.go lines:0 symbol:class",
+			},
+		},
+		{
+			sel: "symbol.function",
+			want: []string{
+				"file:a/main.go lines:0 symbol:func",
+				"file:a/main.go lines:0 symbol:func",
+			},
+		},
+		{
+			sel: "commit.author",
+			want: []string{
+				"author:alice@example.com commits:2",
+				"author:bob@example.com commits:1",
+			},
+		},
+	} {
+		t.Run(tc.sel, func(t *testing.T) {
+			sel, err := query.ParseSelect(tc.sel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have := describe(selectResults(context.Background(), results, sel))
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("have %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
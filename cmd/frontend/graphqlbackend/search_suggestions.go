@@ -41,6 +41,8 @@ var (
 	mockShowFileSuggestions showSearchSuggestionResolvers
 	mockShowLangSuggestions showSearchSuggestionResolvers
 	mockShowSymbolMatches   showSearchSuggestionResolvers
+
+	mockShowSearchHistorySuggestions showSearchSuggestionResolvers
 )
 
 func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestionsArgs) ([]*searchSuggestionResolver, error) {
@@ -285,6 +287,14 @@ func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestion
 	}
 	suggesters = append(suggesters, showFilesWithTextMatches)
 
+	showSearchHistorySuggestions := func(ctx context.Context) ([]*searchSuggestionResolver, error) {
+		if mockShowSearchHistorySuggestions != nil {
+			return mockShowSearchHistorySuggestions()
+		}
+		return r.searchHistorySuggestions(ctx)
+	}
+	suggesters = append(suggesters, showSearchHistorySuggestions)
+
 	// Run suggesters.
 	var (
 		allSuggestions []*searchSuggestionResolver
@@ -331,6 +341,7 @@ func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestion
 		file     string
 		symbol   string
 		lang     string
+		query    string
 	}
 	seen := make(map[key]struct{}, len(allSuggestions))
 	uniqueSuggestions := allSuggestions[:0]
@@ -356,6 +367,8 @@ func (r *searchResolver) Suggestions(ctx context.Context, args *searchSuggestion
 			k.symbol = s.symbol.Name + s.symbol.Parent
 		case *languageResolver:
 			k.lang = s.name
+		case *searchHistoryEntryResolver:
+			k.query = s.Query()
 		default:
 			panic(fmt.Sprintf("unhandled: %#v", s))
 		}
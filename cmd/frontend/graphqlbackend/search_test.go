@@ -245,6 +245,8 @@ func testStringResult(result *searchSuggestionResolver) string {
 		name = "file:" + r.Path()
 	case *languageResolver:
 		name = "lang:" + r.name
+	case *searchHistoryEntryResolver:
+		name = "history:" + r.Query()
 	default:
 		panic("never here")
 	}
@@ -659,6 +661,97 @@ func TestVersionContext(t *testing.T) {
 	}
 }
 
+func TestRepoPredicates(t *testing.T) {
+	mockDecodedViewerFinalSettings = &schema.Settings{}
+	defer func() { mockDecodedViewerFinalSettings = nil }()
+	db.Mocks.Repos.Count = func(context.Context, db.ReposListOptions) (int, error) { return 0, nil }
+	defer func() { db.Mocks.Repos = db.MockRepos{} }()
+
+	tcs := []struct {
+		name        string
+		searchQuery string
+		want        db.ReposListOptions
+		wantErr     string
+	}{{
+		name:        "topic",
+		searchQuery: "repo:foo repo:has.topic(go) -repo:has.topic(deprecated)",
+		want: db.ReposListOptions{
+			IncludePatterns: []string{"foo"},
+			Topics:          []string{"go"},
+			ExcludeTopics:   []string{"deprecated"},
+		},
+	}, {
+		name:        "language and license",
+		searchQuery: "repo:has.language(Go) repo:has.license(MIT) -repo:has.license(GPL-3.0)",
+		want: db.ReposListOptions{
+			Languages:       []string{"Go"},
+			Licenses:        []string{"MIT"},
+			ExcludeLicenses: []string{"GPL-3.0"},
+		},
+	}, {
+		name:        "unknown predicate is a pattern",
+		searchQuery: "repo:has.owner(foo)",
+		want: db.ReposListOptions{
+			IncludePatterns: []string{"has.owner(foo)"},
+		},
+	}, {
+		name:        "order by stars",
+		searchQuery: "repo:foo order:stars",
+		want: db.ReposListOptions{
+			IncludePatterns: []string{"foo"},
+			OrderBy: db.RepoListOrderBy{
+				{Field: db.RepoListStars, Descending: true},
+				{Field: db.RepoListID},
+			},
+		},
+	}, {
+		name:        "invalid order",
+		searchQuery: "order:forks",
+		wantErr:     "bad request: invalid value \"forks\" for field order, the only valid value is stars",
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			qinfo, err := query.ParseAndCheck(tc.searchQuery)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have db.ReposListOptions
+			db.Mocks.Repos.List = func(ctx context.Context, opts db.ReposListOptions) ([]*types.Repo, error) {
+				have = opts
+				return nil, nil
+			}
+
+			resolver := searchResolver{query: qinfo}
+			_, _, _, _, err = resolver.resolveRepositories(context.Background(), nil)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("error: have %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Only compare the options set from the repo: and order: fields.
+			have = db.ReposListOptions{
+				IncludePatterns:  have.IncludePatterns,
+				Topics:           have.Topics,
+				ExcludeTopics:    have.ExcludeTopics,
+				Languages:        have.Languages,
+				ExcludeLanguages: have.ExcludeLanguages,
+				Licenses:         have.Licenses,
+				ExcludeLicenses:  have.ExcludeLicenses,
+				OrderBy:          have.OrderBy,
+			}
+			if diff := cmp.Diff(tc.want, have, cmpopts.EquateEmpty()); diff != "" {
+				t.Fatalf("mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestComputeExcludedRepositories(t *testing.T) {
 	cases := []struct {
 		Name              string
@@ -27,6 +27,11 @@ type settingsCascade struct {
 var mockSettingsCascadeSubjects func() ([]*settingsSubject, error)
 
 func (r *settingsCascade) Subjects(ctx context.Context) ([]*settingsSubject, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	if mockSettingsCascadeSubjects != nil {
 		return mockSettingsCascadeSubjects()
 	}
@@ -60,6 +60,11 @@ func (r *siteResolver) ID() graphql.ID { return marshalSiteGQLID(r.gqlID) }
 func (r *siteResolver) SiteID() string { return siteid.Get() }
 
 func (r *siteResolver) Configuration(ctx context.Context) (*siteConfigurationResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
 	// so only admins may view it.
 	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
@@ -82,6 +87,11 @@ func (r *siteResolver) settingsSubject() api.SettingsSubject {
 }
 
 func (r *siteResolver) LatestSettings(ctx context.Context) (*settingsResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	settings, err := db.Settings.GetLatest(ctx, r.settingsSubject())
 	if err != nil {
 		return nil, err
@@ -171,6 +181,11 @@ func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *stru
 		return false, fmt.Errorf("site configuration is invalid: %s", strings.Join(problems, ","))
 	}
 
+	// The site configuration contains secrets, so it isn't recorded.
+	if err := db.AuditLogs.Record(ctx, db.AuditActionUpdateSiteConfiguration, "", map[string]int32{"lastID": args.LastID}); err != nil {
+		return false, err
+	}
+
 	prev := globals.ConfigurationServerFrontendOnly.Raw()
 	prev.Site = args.Input
 	// TODO(slimsag): future: actually pass lastID through to prevent race conditions
@@ -137,6 +137,9 @@ func (*schemaResolver) SetUserIsSiteAdmin(ctx context.Context, args *struct {
 		return nil, err
 	}
 
+	if err := db.AuditLogs.Record(ctx, db.AuditActionSetUserIsSiteAdmin, string(args.UserID), map[string]bool{"siteAdmin": args.SiteAdmin}); err != nil {
+		return nil, err
+	}
 	if err := db.Users.SetIsSiteAdmin(ctx, userID, args.SiteAdmin); err != nil {
 		return nil, err
 	}
@@ -144,6 +144,10 @@ func (r *FileMatchResolver) ToCodemodResult() (*codemodResultResolver, bool) {
 	return nil, false
 }
 
+func (r *FileMatchResolver) ToCommitAuthorSearchResult() (*commitAuthorSearchResultResolver, bool) {
+	return nil, false
+}
+
 func (fm *FileMatchResolver) searchResultURIs() (string, string) {
 	return fm.Repo.Name(), fm.JPath
 }
@@ -230,6 +234,9 @@ func textSearch(ctx context.Context, searcherURLs *endpoint.Map, repo gitserver.
 	if p.IsCaseSensitive {
 		q.Set("IsCaseSensitive", "true")
 	}
+	if p.IsMultiline {
+		q.Set("IsMultiline", "true")
+	}
 	if p.PathPatternsAreRegExps {
 		q.Set("PathPatternsAreRegExps", "true")
 	}
@@ -656,6 +663,9 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 					defer done()
 
 					matches, repoLimitHit, err := searchFilesInRepo(ctx, args.SearcherURLs, repoRev.Repo, repoRev.GitserverRepo(), repoRev.RevSpecs()[0], args.PatternInfo, fetchTimeout)
+					if err == nil {
+						matches, err = filterFileMatchesByOwner(ctx, args.PatternInfo, matches)
+					}
 					if err != nil {
 						tr.LogFields(otlog.String("repo", string(repoRev.Repo.Name)), otlog.Error(err), otlog.Bool("timeout", errcode.IsTimeout(err)), otlog.Bool("temporary", errcode.IsTemporary(err)))
 						log15.Warn("searchFilesInRepo failed", "error", err, "repo", repoRev.Repo.Name)
@@ -705,6 +715,9 @@ func searchFilesInRepos(ctx context.Context, args *search.TextParameters) (res [
 		} else {
 			matches, limitHit, reposLimitHit, err = zoektSearchHEADOnlyFiles(ctx, args, zoektRepos, false, time.Since)
 		}
+		if err == nil {
+			matches, err = filterFileMatchesByOwner(ctx, args.PatternInfo, matches)
+		}
 		mu.Lock()
 		defer mu.Unlock()
 		if ctx.Err() == nil {
@@ -94,6 +94,11 @@ func (r *UserResolver) DatabaseID() int32 { return r.user.ID }
 // Email returns the user's oldest email, if one exists.
 // Deprecated: use Emails instead.
 func (r *UserResolver) Email(ctx context.Context) (string, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return "", err
+	}
+
 	// 🚨 SECURITY: Only the user and admins are allowed to access the email address.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 		return "", err
@@ -146,6 +151,11 @@ func (r *UserResolver) settingsSubject() api.SettingsSubject {
 }
 
 func (r *UserResolver) LatestSettings(ctx context.Context) (*settingsResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only the user and admins are allowed to access the user's settings, because they
 	// may contain secrets or other sensitive data.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
@@ -169,6 +179,11 @@ func (r *UserResolver) SettingsCascade() *settingsCascade {
 func (r *UserResolver) ConfigurationCascade() *settingsCascade { return r.SettingsCascade() }
 
 func (r *UserResolver) SiteAdmin(ctx context.Context) (bool, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return false, err
+	}
+
 	// 🚨 SECURITY: Only the user and admins are allowed to determine if the user is a site admin.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 		return false, err
@@ -231,6 +246,11 @@ func CurrentUser(ctx context.Context) (*UserResolver, error) {
 }
 
 func (r *UserResolver) Organizations(ctx context.Context) (*orgConnectionStaticResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	orgs, err := db.Orgs.GetByUserID(ctx, r.user.ID)
 	if err != nil {
 		return nil, err
@@ -243,6 +263,11 @@ func (r *UserResolver) Organizations(ctx context.Context) (*orgConnectionStaticR
 }
 
 func (r *UserResolver) Tags(ctx context.Context) ([]string, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only the user and admins are allowed to access the user's tags.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 		return nil, err
@@ -251,6 +276,11 @@ func (r *UserResolver) Tags(ctx context.Context) ([]string, error) {
 }
 
 func (r *UserResolver) SurveyResponses(ctx context.Context) ([]*surveyResponseResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only the user and admins are allowed to access the user's survey responses.
 	if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 		return nil, err
@@ -290,6 +320,11 @@ func (r *UserResolver) URLForSiteAdminBilling(ctx context.Context) (*string, err
 func (r *UserResolver) NamespaceName() string { return r.user.Username }
 
 func (r *UserResolver) PermissionsInfo(ctx context.Context) (PermissionsInfoResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	return EnterpriseResolvers.authzResolver.UserPermissionsInfo(ctx, r.ID())
 }
 
@@ -9,6 +9,11 @@ import (
 )
 
 func (r *UserResolver) Session(ctx context.Context) (*sessionResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: Only the user can view their session information, because it is retrieved from
 	// the context of this request (and not persisted in a way that is queryable).
 	actor := actor.FromContext(ctx)
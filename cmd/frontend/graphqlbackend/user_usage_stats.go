@@ -15,6 +15,11 @@ import (
 )
 
 func (r *UserResolver) UsageStatistics(ctx context.Context) (*userUsageStatisticsResolver, error) {
+	// 🚨 SECURITY: Restricted access tokens can't read account data, even through nested fields.
+	if err := checkAccessTokenAllowsAccountData(ctx); err != nil {
+		return nil, err
+	}
+
 	if envvar.SourcegraphDotComMode() {
 		if err := backend.CheckSiteAdminOrSameUser(ctx, r.user.ID); err != nil {
 			return nil, err
@@ -1,6 +1,7 @@
 package graphqlbackend
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math"
@@ -87,15 +88,47 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 	// Tell zoekt which repos to search
 	repoSet := &zoektquery.RepoSet{Set: make(map[string]bool, len(repos))}
 	repoMap := make(map[string]*search.RepositoryRevisions, len(repos))
+	repoBranches := make(map[string][]string, len(repos))
+	branchVersions := map[string]string{}
+	indexedRepos, err := args.Zoekt.ListAll(ctx)
+	if err != nil {
+		return nil, false, nil, err
+	}
+	// Repositories may have more indexed branches than their default branch,
+	// in which case we must only search the requested branch of each.
+	restrictBranches := false
 	for _, repoRev := range repos {
-		repoSet.Set[string(repoRev.Repo.Name)] = true
-		repoMap[string(repoRev.Repo.Name)] = repoRev
+		name := string(repoRev.Repo.Name)
+		branch := "HEAD"
+		repo, ok := indexedRepos[name]
+		if ok && len(repo.Branches) > 1 {
+			restrictBranches = true
+		}
+		// zoektIndexedRepos only routes indexed revisions to zoekt, so we fall
+		// back to the default branch if the index changed since.
+		if revs := repoRev.RevSpecs(); ok && len(revs) == 1 && revs[0] != "" && revs[0] != "HEAD" {
+			if b, ok := zoektIndexedBranch(repo, revs[0]); ok && b != "HEAD" {
+				branch = b
+				for _, rb := range repo.Branches {
+					if rb.Name == branch {
+						branchVersions[name] = rb.Version
+					}
+				}
+			}
+		}
+		repoSet.Set[name] = true
+		repoMap[name] = repoRev
+		repoBranches[name] = []string{branch}
 	}
 
 	queryExceptRepos, err := queryToZoektQuery(args.PatternInfo, isSymbol)
 	if err != nil {
 		return nil, false, nil, err
 	}
+
+	if restrictBranches {
+		queryExceptRepos = zoektquery.NewAnd(&zoektquery.RepoBranches{Set: repoBranches}, queryExceptRepos)
+	}
 	finalQuery := zoektquery.NewAnd(repoSet, queryExceptRepos)
 
 	tr, ctx := trace.New(ctx, "zoekt.Search", fmt.Sprintf("%d %+v", len(repoSet.Set), finalQuery.String()))
@@ -150,6 +183,17 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 	if resp.FileCount == 0 && resp.MatchCount == 0 && since(t0) >= searchOpts.MaxWallTime {
 		return nil, false, nil, errNoResultsInTimeout
 	}
+	if restrictBranches {
+		// Zoekt matches branches by substring, so drop the files of other
+		// branches whose names contain the requested branch name.
+		files := resp.Files[:0]
+		for _, file := range resp.Files {
+			if zoektFileOnBranch(&file, repoBranches[file.Repository][0]) {
+				files = append(files, file)
+			}
+		}
+		resp.Files = files
+	}
 	limitHit = resp.FilesSkipped+resp.ShardsSkipped > 0
 	// Repositories that weren't fully evaluated because they hit the Zoekt or Sourcegraph file match limits.
 	reposLimitHit = make(map[string]struct{})
@@ -205,6 +249,16 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 			repoResolvers[repoRev.Repo.Name] = &RepositoryResolver{repo: repoRev.Repo}
 		}
 		inputRev := repoRev.RevSpecs()[0]
+		version := file.Version
+		uriRev := ""
+		var fileInputRev *string
+		if v, ok := branchVersions[file.Repository]; ok {
+			// The file may be the same in several indexed branches, in
+			// which case zoekt reports the version of the first.
+			version = v
+			uriRev = inputRev
+			fileInputRev = &inputRev
+		}
 		baseURI := &gituri.URI{URL: url.URL{Scheme: "git://", Host: string(repoRev.Repo.Name), RawQuery: "?" + url.QueryEscape(inputRev)}}
 		lines := make([]*lineMatch, 0, len(file.LineMatches))
 		symbols := []*searchSymbolResult{}
@@ -215,16 +269,12 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 				if len(l.LineFragments) > maxLineFragmentMatches {
 					l.LineFragments = l.LineFragments[:maxLineFragmentMatches]
 				}
-				offsets := make([][2]int32, len(l.LineFragments))
-				for k, m := range l.LineFragments {
-					offset := utf8.RuneCount(l.Line[:m.LineOffset])
-					length := utf8.RuneCount(l.Line[m.LineOffset : m.LineOffset+m.MatchLength])
-					offsets[k] = [2]int32{int32(offset), int32(length)}
+				for _, m := range l.LineFragments {
 					if isSymbol && m.SymbolInfo != nil {
 						if commit == nil {
 							commit = &GitCommitResolver{
 								repoResolver: repoResolvers[repoRev.Repo.Name],
-								oid:          GitObjectID(file.Version),
+								oid:          GitObjectID(version),
 								inputRev:     &inputRev,
 							}
 						}
@@ -244,12 +294,8 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 					}
 				}
 				if !isSymbol {
-					matchCount += len(offsets)
-					lines = append(lines, &lineMatch{
-						JPreview:          string(l.Line),
-						JLineNumber:       int32(l.LineNumber - 1),
-						JOffsetAndLengths: offsets,
-					})
+					matchCount += len(l.LineFragments)
+					lines = append(lines, zoektLineMatches(l)...)
 				}
 			}
 		}
@@ -258,16 +304,27 @@ func zoektSearchHEAD(ctx context.Context, args *search.TextParameters, repos []*
 			JLineMatches: lines,
 			JLimitHit:    fileLimitHit,
 			MatchCount:   matchCount, // We do not use resp.MatchCount because it counts the number of lines matched, not the number of fragments.
-			uri:          fileMatchURI(repoRev.Repo.Name, "", file.FileName),
+			uri:          fileMatchURI(repoRev.Repo.Name, uriRev, file.FileName),
 			symbols:      symbols,
 			Repo:         repoResolvers[repoRev.Repo.Name],
-			CommitID:     api.CommitID(file.Version),
+			CommitID:     api.CommitID(version),
+			InputRev:     fileInputRev,
 		}
 	}
 
 	return matches, limitHit, reposLimitHit, nil
 }
 
+// zoektFileOnBranch reports whether file is in the indexed branch.
+func zoektFileOnBranch(file *zoekt.FileMatch, branch string) bool {
+	for _, b := range file.Branches {
+		if b == branch {
+			return true
+		}
+	}
+	return false
+}
+
 // createNewRepoSetWithRepoHasFileInputs mutates repoSet such that it accounts
 // for the `repohasfile` and `-repohasfile` flags that may have been passed in
 // the query. As a convenience it returns the mutated RepoSet.
@@ -348,6 +405,49 @@ func createNewRepoSetWithRepoHasFileInputs(ctx context.Context, query *search.Te
 	return repoSet, nil
 }
 
+// zoektLineMatches converts a zoekt line match into line matches. Zoekt
+// returns several lines in a single line match if one of its fragments spans
+// lines, which we split into a line match per line, each highlighting the
+// parts of the fragments on that line. This is the same as how searcher
+// reports multiline matches.
+func zoektLineMatches(l zoekt.LineMatch) []*lineMatch {
+	lines := bytes.Split(l.Line, []byte("\n"))
+	matches := make([]*lineMatch, 0, len(lines))
+	lineStart := 0
+	for i, line := range lines {
+		lineEnd := lineStart + len(line)
+		var offsets [][2]int32
+		for _, m := range l.LineFragments {
+			start, end := m.LineOffset, m.LineOffset+m.MatchLength
+			if start < lineStart {
+				if end <= lineStart {
+					// The fragment ends on a previous line.
+					continue
+				}
+				start = lineStart
+			}
+			if end > lineEnd {
+				end = lineEnd
+			}
+			if start > end {
+				continue
+			}
+			offset := utf8.RuneCount(line[:start-lineStart])
+			length := utf8.RuneCount(line[start-lineStart : end-lineStart])
+			offsets = append(offsets, [2]int32{int32(offset), int32(length)})
+		}
+		if len(offsets) > 0 {
+			matches = append(matches, &lineMatch{
+				JPreview:          string(line),
+				JLineNumber:       int32(l.LineNumber - 1 + i),
+				JOffsetAndLengths: offsets,
+			})
+		}
+		lineStart = lineEnd + 1
+	}
+	return matches
+}
+
 func noOpAnyChar(re *syntax.Regexp) {
 	if re.Op == syntax.OpAnyChar {
 		re.Op = syntax.OpAnyCharNotNL
@@ -358,12 +458,24 @@ func noOpAnyChar(re *syntax.Regexp) {
 }
 
 func parseRe(pattern string, filenameOnly bool, queryIsCaseSensitive bool) (zoektquery.Q, error) {
+	return parseMultilineRe(pattern, false, filenameOnly, queryIsCaseSensitive)
+}
+
+// parseMultilineRe is like parseRe, but if multiline is true . also matches
+// newlines, so that matches can span lines like in searcher.
+func parseMultilineRe(pattern string, multiline, filenameOnly, queryIsCaseSensitive bool) (zoektquery.Q, error) {
 	// these are the flags used by zoekt, which differ to searcher.
-	re, err := syntax.Parse(pattern, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
+	flags := syntax.ClassNL | syntax.PerlX | syntax.UnicodeGroups
+	if multiline {
+		flags |= syntax.DotNL
+	}
+	re, err := syntax.Parse(pattern, flags)
 	if err != nil {
 		return nil, err
 	}
-	noOpAnyChar(re)
+	if !multiline {
+		noOpAnyChar(re)
+	}
 	// zoekt decides to use its literal optimization at the query parser
 	// level, so we check if our regex can just be a literal.
 	if re.Op == syntax.OpLiteral {
@@ -393,7 +505,7 @@ func queryToZoektQuery(query *search.TextPatternInfo, isSymbol bool) (zoektquery
 	var err error
 	if query.IsRegExp {
 		fileNameOnly := query.PatternMatchesPath && !query.PatternMatchesContent
-		q, err = parseRe(query.Pattern, fileNameOnly, query.IsCaseSensitive)
+		q, err = parseMultilineRe(query.Pattern, query.IsMultiline, fileNameOnly, query.IsCaseSensitive)
 		if err != nil {
 			return nil, err
 		}
@@ -481,27 +593,41 @@ func zoektSingleIndexedRepo(ctx context.Context, z *searchbackend.Zoekt, rev *se
 	}
 
 	if len(rev.Revs) == 1 {
-		revSpecToSearch := rev.Revs[0].RevSpec
-		if len(revSpecToSearch) > 0 && len(revSpecToSearch) < 4 {
-			// revSpecToSearch is nonempty but shorter than the
-			// minimum 4 chars expected for a short SHA. It can't
-			// match a commit, maybe it refers to a one-character
-			// branch name.
-			return indexed, append(unindexed, rev), nil
-		}
-		if revSpecToSearch == "" || revSpecToSearch == "HEAD" {
+		if _, ok := zoektIndexedBranch(repo, rev.Revs[0].RevSpec); ok {
 			return append(indexed, rev), unindexed, nil
 		}
-		for _, branch := range repo.Branches {
-			if branch.Name == revSpecToSearch || strings.HasPrefix(branch.Version, revSpecToSearch) {
-				return append(indexed, rev), unindexed, nil
-			}
-		}
 	}
 
 	return indexed, append(unindexed, rev), nil
 }
 
+// zoektIndexedBranch returns the name of the branch of the indexed repository
+// that contains revSpec, which is either a branch name or a commit ID (prefix)
+// of an indexed branch. The default branch is indexed as "HEAD". It returns
+// false if revSpec is not indexed.
+func zoektIndexedBranch(repo *zoekt.Repository, revSpec string) (string, bool) {
+	if revSpec == "" || revSpec == "HEAD" {
+		return "HEAD", true
+	}
+	for _, branch := range repo.Branches {
+		if branch.Name == revSpec {
+			return branch.Name, true
+		}
+	}
+	if len(revSpec) < 4 {
+		// revSpec is shorter than the minimum 4 chars expected for a short
+		// SHA. It can't match a commit, maybe it refers to a one-character
+		// branch name that is not indexed.
+		return "", false
+	}
+	for _, branch := range repo.Branches {
+		if strings.HasPrefix(branch.Version, revSpec) {
+			return branch.Name, true
+		}
+	}
+	return "", false
+}
+
 // zoektIndexedRepos splits the input repo list into two parts: (1) the
 // repositories `indexed` by Zoekt and (2) the repositories that are
 // `unindexed`.
@@ -513,7 +639,7 @@ func zoektIndexedRepos(ctx context.Context, z *searchbackend.Zoekt, revs []*sear
 
 	count := 0
 	for _, r := range revs {
-		if len(r.Revs) > 0 && r.Revs[0].RevSpec == "" {
+		if len(r.Revs) == 1 && r.Revs[0].RefGlob == "" && r.Revs[0].ExcludeRefGlob == "" {
 			count++
 		}
 	}
@@ -547,6 +673,13 @@ func zoektIndexedRepos(ctx context.Context, z *searchbackend.Zoekt, revs []*sear
 			continue
 		}
 
+		if len(rev.Revs) == 1 {
+			if _, ok := zoektIndexedBranch(repo, rev.Revs[0].RevSpec); !ok {
+				unindexed = append(unindexed, rev)
+				continue
+			}
+		}
+
 		indexed = append(indexed, rev)
 	}
 
@@ -45,6 +45,9 @@ func (ss *fakeSearcher) Search(ctx context.Context, q zoektquery.Q, opts *zoekt.
 }
 
 func (ss *fakeSearcher) List(ctx context.Context, q zoektquery.Q) (*zoekt.RepoList, error) {
+	if ss.repos == nil {
+		return &zoekt.RepoList{}, nil
+	}
 	return ss.repos, nil
 }
 
@@ -63,6 +66,10 @@ func (es *errorSearcher) Search(ctx context.Context, q zoektquery.Q, opts *zoekt
 	return nil, es.err
 }
 
+func (es *errorSearcher) List(ctx context.Context, q zoektquery.Q) (*zoekt.RepoList, error) {
+	return &zoekt.RepoList{}, nil
+}
+
 func TestZoektSearchHEAD(t *testing.T) {
 	zeroTimeoutCtx, cancel := context.WithTimeout(context.Background(), 0)
 	defer cancel()
@@ -286,6 +293,12 @@ func TestZoektIndexedRepos(t *testing.T) {
 		},
 	}
 
+	branchRepos := makeRepositoryRevisions(
+		"foo/indexed-three@foobar",
+		"foo/indexed-two@HEAD",
+		"foo/indexed-one@foobar",
+	)
+
 	zoekt := &searchbackend.Zoekt{Client: &fakeSearcher{repos: zoektRepoList}}
 	ctx := context.Background()
 
@@ -321,6 +334,11 @@ func TestZoektIndexedRepos(t *testing.T) {
 		repos:     repos[:1],
 		indexed:   makeIndexed(repos[:1]),
 		unindexed: repos[:0],
+	}, {
+		name:      "indexed branches",
+		repos:     branchRepos,
+		indexed:   makeIndexed(branchRepos[:2]),
+		unindexed: branchRepos[2:],
 	}}
 
 	for _, tc := range cases {
@@ -342,6 +360,42 @@ func TestZoektIndexedRepos(t *testing.T) {
 	}
 }
 
+func TestZoektSearchHEAD_branches(t *testing.T) {
+	searcher := &fakeSearcher{
+		repos: &zoekt.RepoList{Repos: []*zoekt.RepoListEntry{{
+			Repository: zoekt.Repository{
+				Name: "foo/bar",
+				Branches: []zoekt.RepositoryBranch{
+					{Name: "HEAD", Version: "deadbeef"},
+					{Name: "release-1", Version: "c0ffee"},
+					{Name: "release-10", Version: "f00d"},
+				},
+			},
+		}}},
+		result: &zoekt.SearchResult{Files: []zoekt.FileMatch{
+			{Repository: "foo/bar", FileName: "a.go", Version: "deadbeef", Branches: []string{"HEAD", "release-1"}},
+			{Repository: "foo/bar", FileName: "b.go", Version: "f00d", Branches: []string{"release-10"}},
+		}},
+	}
+	args := &search.TextParameters{
+		PatternInfo: &search.TextPatternInfo{PathPatternsAreRegExps: true, FileMatchLimit: 10},
+		Zoekt:       &searchbackend.Zoekt{Client: searcher, DisableCache: true},
+	}
+
+	fms, _, _, err := zoektSearchHEAD(context.Background(), args, makeRepositoryRevisions("foo/bar@release-1"), false, time.Since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// b.go is only in release-10, which zoekt matches because it contains
+	// the name of the searched branch.
+	if len(fms) != 1 {
+		t.Fatalf("got %d file matches, want 1", len(fms))
+	}
+	if fm := fms[0]; fm.JPath != "a.go" || fm.CommitID != "c0ffee" || fm.InputRev == nil || *fm.InputRev != "release-1" {
+		t.Errorf("got file match %s at %s (input rev %v), want a.go at c0ffee (input rev release-1)", fm.JPath, fm.CommitID, fm.InputRev)
+	}
+}
+
 func Benchmark_zoektIndexedRepos(b *testing.B) {
 	repoNames := []string{}
 	zoektRepos := []*zoekt.RepoListEntry{}
@@ -648,6 +702,20 @@ func TestQueryToZoektQuery(t *testing.T) {
 			},
 			Query: "(foo).*?(bar) case:no",
 		},
+		{
+			Name: "multiline",
+			Pattern: &search.TextPatternInfo{
+				IsRegExp:                     true,
+				IsCaseSensitive:              false,
+				IsMultiline:                  true,
+				Pattern:                      "(foo).*?(bar)",
+				IncludePatterns:              nil,
+				ExcludePattern:               "",
+				PathPatternsAreRegExps:       true,
+				PathPatternsAreCaseSensitive: false,
+			},
+			Query: "(?s:(foo).*?(bar)) case:no",
+		},
 		{
 			Name: "path",
 			Pattern: &search.TextPatternInfo{
@@ -720,6 +788,58 @@ func TestQueryToZoektQuery(t *testing.T) {
 	}
 }
 
+func TestZoektLineMatches(t *testing.T) {
+	cases := []struct {
+		name string
+		line zoekt.LineMatch
+		want []*lineMatch
+	}{
+		{
+			name: "single line",
+			line: zoekt.LineMatch{
+				Line:          []byte("héllo world"),
+				LineNumber:    3,
+				LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 1, MatchLength: 5}, {LineOffset: 7, MatchLength: 5}},
+			},
+			want: []*lineMatch{
+				{JPreview: "héllo world", JLineNumber: 2, JOffsetAndLengths: [][2]int32{{1, 4}, {6, 5}}},
+			},
+		},
+		{
+			name: "multiple lines",
+			line: zoekt.LineMatch{
+				Line:          []byte("func a() {\n\treturn 1\n}"),
+				LineNumber:    1,
+				LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 6, MatchLength: 17}},
+			},
+			want: []*lineMatch{
+				{JPreview: "func a() {", JLineNumber: 0, JOffsetAndLengths: [][2]int32{{6, 4}}},
+				{JPreview: "\treturn 1", JLineNumber: 1, JOffsetAndLengths: [][2]int32{{0, 9}}},
+				{JPreview: "}", JLineNumber: 2, JOffsetAndLengths: [][2]int32{{0, 1}}},
+			},
+		},
+		{
+			name: "fragment ending with a newline",
+			line: zoekt.LineMatch{
+				Line:          []byte("foo\nbar foo"),
+				LineNumber:    1,
+				LineFragments: []zoekt.LineFragmentMatch{{LineOffset: 0, MatchLength: 4}, {LineOffset: 8, MatchLength: 3}},
+			},
+			want: []*lineMatch{
+				{JPreview: "foo", JLineNumber: 0, JOffsetAndLengths: [][2]int32{{0, 3}}},
+				{JPreview: "bar foo", JLineNumber: 1, JOffsetAndLengths: [][2]int32{{4, 3}}},
+			},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, zoektLineMatches(tt.line)); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func queryEqual(a, b zoektquery.Q) bool {
 	sortChildren := func(q zoektquery.Q) zoektquery.Q {
 		switch s := q.(type) {
@@ -66,6 +66,8 @@ func NewHandler() http.Handler {
 	// Usage statistics ZIP download
 	r.Get(router.UsageStatsDownload).Handler(trace.TraceRoute(http.HandlerFunc(usageStatsArchiveHandler)))
 
+	r.Get(router.SearchExportDownload).Handler(trace.TraceRoute(http.HandlerFunc(searchExportDownloadHandler)))
+
 	r.Get(router.GDDORefs).Handler(trace.TraceRoute(errorutil.Handler(serveGDDORefs)))
 	r.Get(router.Editor).Handler(trace.TraceRoute(errorutil.Handler(serveEditor)))
 
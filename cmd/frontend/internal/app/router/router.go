@@ -33,6 +33,8 @@ const (
 
 	UsageStatsDownload = "usage-stats.download"
 
+	SearchExportDownload = "search-export.download"
+
 	OldToolsRedirect = "old-tools-redirect"
 	OldTreeRedirect  = "old-tree-redirect"
 
@@ -88,6 +90,8 @@ func newRouter() *mux.Router {
 
 	base.Path("/site-admin/usage-statistics/archive").Methods("GET").Name(UsageStatsDownload)
 
+	base.Path("/-/search-exports/{ID:[0-9]+}").Methods("GET").Name(SearchExportDownload)
+
 	if envvar.SourcegraphDotComMode() {
 		base.PathPrefix("/go/").Methods("GET").Name(GoSymbolURL)
 	}
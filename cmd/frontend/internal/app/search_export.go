@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+)
+
+var searchExportContentTypes = map[string]string{
+	db.SearchExportFormatCSV:   "text/csv; charset=utf-8",
+	db.SearchExportFormatJSONL: "application/x-ndjson",
+}
+
+// searchExportDownloadHandler serves the file of a completed search export.
+func searchExportDownloadHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["ID"], 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	e, err := db.SearchExports.GetByID(r.Context(), id)
+	if err == db.ErrSearchExportNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		log15.Error("db.SearchExports.GetByID", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// 🚨 SECURITY: Only the user who requested the export and site admins may download it.
+	if err := backend.CheckSiteAdminOrSameUser(r.Context(), e.UserID); err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if e.State != db.SearchExportStateCompleted {
+		http.Error(w, fmt.Sprintf("search export is %s", e.State), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", searchExportContentTypes[e.Format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"search-export-%d.%s\"", e.ID, e.Format))
+
+	if err := db.SearchExports.WriteContents(r.Context(), e.ID, w); err != nil {
+		// The response has likely been partially written at this point, so
+		// we can only log the error.
+		log15.Error("db.SearchExports.WriteContents", "id", e.ID, "error", err)
+	}
+}
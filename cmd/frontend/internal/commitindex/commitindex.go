@@ -0,0 +1,273 @@
+// Package commitindex maintains the index of the commits of the default
+// branches of repositories that commit and diff searches use.
+package commitindex
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+const (
+	// batchSize is the number of commits read from gitserver at a time.
+	batchSize = 500
+
+	// maxCommits is the maximum number of commits indexed per repository.
+	// Older commits of repositories with more history are not indexed, and
+	// searches that reach past the newest of them use `git log` instead.
+	maxCommits = 100000
+
+	// maxPatchBytes is the maximum size of the compacted patch indexed per
+	// commit. The diffs of the files after this limit are not indexed.
+	maxPatchBytes = 64 * 1024
+
+	// maxLineBytes is the maximum size of a line of an indexed patch. Longer
+	// lines (such as those of minified files) are truncated.
+	maxLineBytes = 1024
+
+	// pollInterval is how often the queue is checked for repositories to
+	// index.
+	pollInterval = 5 * time.Second
+
+	// sweepInterval is how often all repositories are checked for updates,
+	// in case notifications of repository updates were lost.
+	sweepInterval = time.Hour
+)
+
+// Enabled reports whether commit indexing is enabled in the site
+// configuration.
+func Enabled() bool {
+	return conf.Get().SearchIndexCommits
+}
+
+// Enqueue schedules an update of the index of the repository, for example
+// because the repository was updated.
+func Enqueue(ctx context.Context, repo api.RepoName) error {
+	return db.CommitIndex.Enqueue(ctx, repo)
+}
+
+// Run updates the index of the repositories that are enqueued, and of all
+// repositories every sweepInterval. Only one frontend replica updates the
+// index at a time, and only while commit indexing is enabled.
+func Run(ctx context.Context) {
+	// 🚨 SECURITY: The commits of all repositories are indexed. Searches of
+	// the index are restricted to repositories that the viewer may access.
+	ctx = actor.WithActor(ctx, &actor.Actor{Internal: true})
+
+	for {
+		if lockCtx, release, ok := rcache.TryAcquireMutex(ctx, "commit-index"); ok {
+			processQueue(lockCtx)
+			release()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// processQueue indexes the enqueued repositories until the context is
+// canceled, which happens when this replica loses the lock of the index.
+func processQueue(ctx context.Context) {
+	var lastSweep time.Time
+	for ctx.Err() == nil {
+		if Enabled() && time.Since(lastSweep) >= sweepInterval {
+			if err := db.CommitIndex.EnqueueAll(ctx); err != nil {
+				log15.Error("enqueuing repositories to update the commit index", "error", err)
+			} else {
+				lastSweep = time.Now()
+			}
+		}
+
+		for ctx.Err() == nil && Enabled() {
+			id, ok, err := db.CommitIndex.Dequeue(ctx)
+			if err != nil {
+				log15.Error("dequeuing a repository to update the commit index", "error", err)
+				break
+			}
+			if !ok {
+				break
+			}
+			if err := indexRepo(ctx, id); err != nil {
+				log15.Error("updating commit index", "repo", id, "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// indexRepo indexes the commits of the repository's default branch that are
+// not indexed yet. If the default branch was force-pushed, or too many commits
+// were added to it to index them all, the repository is reindexed.
+func indexRepo(ctx context.Context, id api.RepoID) error {
+	repo, err := db.Repos.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	gitRepo := gitserver.Repo{Name: repo.Name}
+
+	head, err := git.ResolveRevision(ctx, gitRepo, nil, "HEAD", &git.ResolveRevisionOptions{NoEnsureRevision: true})
+	if gitserver.IsRevisionNotFound(err) {
+		return nil // empty repository
+	} else if err != nil {
+		return err
+	}
+
+	prev, horizon, err := db.CommitIndex.Head(ctx, repo.ID)
+	if err != nil || prev == head {
+		return err
+	}
+	if prev != "" {
+		if base, err := git.MergeBase(ctx, gitRepo, prev, head); err == nil && base == prev {
+			truncated, _, err := indexCommits(ctx, repo.ID, gitRepo, string(prev)+".."+string(head))
+			if err != nil {
+				return err
+			}
+			if !truncated {
+				return db.CommitIndex.SetHead(ctx, repo.ID, head, horizon)
+			}
+		}
+		if err := db.CommitIndex.Reset(ctx, repo.ID); err != nil {
+			return err
+		}
+	}
+
+	truncated, oldest, err := indexCommits(ctx, repo.ID, gitRepo, string(head))
+	if err != nil {
+		return err
+	}
+	horizon = nil
+	if truncated {
+		horizon = &oldest
+	}
+	return db.CommitIndex.SetHead(ctx, repo.ID, head, horizon)
+}
+
+// indexCommits indexes up to maxCommits commits of the range. It reports
+// whether the range has more commits, and the commit date of the last commit
+// that it indexed.
+func indexCommits(ctx context.Context, repoID api.RepoID, repo gitserver.Repo, rangeSpec string) (truncated bool, oldest time.Time, err error) {
+	for skip := 0; ; skip += batchSize {
+		if skip >= maxCommits {
+			return true, oldest, nil
+		}
+		patches, err := git.CommitPatches(ctx, repo, git.CommitsOptions{Range: rangeSpec, N: batchSize, Skip: uint(skip)})
+		if err != nil {
+			return false, oldest, err
+		}
+		entries := make([]*db.CommitIndexEntry, len(patches))
+		for i, p := range patches {
+			entries[i] = toEntry(repoID, p)
+			oldest = entries[i].CommitterDate
+		}
+		if err := db.CommitIndex.Insert(ctx, entries); err != nil {
+			return false, oldest, err
+		}
+		if len(patches) < batchSize {
+			return false, oldest, nil
+		}
+	}
+}
+
+func toEntry(repoID api.RepoID, p *git.CommitPatch) *db.CommitIndexEntry {
+	e := &db.CommitIndexEntry{
+		RepoID:      repoID,
+		CommitID:    p.Commit.ID,
+		AuthorName:  sanitize(p.Commit.Author.Name),
+		AuthorEmail: sanitize(p.Commit.Author.Email),
+		AuthorDate:  p.Commit.Author.Date,
+		Message:     sanitize(p.Commit.Message),
+		Diff:        sanitize(string(truncatePatch(compactPatch(p.Patch), maxPatchBytes))),
+	}
+	if c := p.Commit.Committer; c != nil {
+		e.CommitterName, e.CommitterEmail, e.CommitterDate = sanitize(c.Name), sanitize(c.Email), c.Date
+	} else {
+		e.CommitterName, e.CommitterEmail, e.CommitterDate = e.AuthorName, e.AuthorEmail, e.AuthorDate
+	}
+	return e
+}
+
+// sanitize makes s storable in a PostgreSQL text column.
+func sanitize(s string) string {
+	return strings.ReplaceAll(strings.ToValidUTF8(s, "�"), "\x00", "")
+}
+
+// truncatePatch returns the longest prefix of the patch that contains only
+// whole file diffs and is at most max bytes long.
+func truncatePatch(patch []byte, max int) []byte {
+	if len(patch) <= max {
+		return patch
+	}
+	// Find the last file diff that starts at or before max.
+	sep := []byte("\ndiff --git ")
+	end := max - 1 + len(sep)
+	if end > len(patch) {
+		end = len(patch)
+	}
+	i := bytes.LastIndex(patch[:end], sep)
+	if i < 0 {
+		return nil
+	}
+	return patch[:i+1]
+}
+
+// compactPatch returns the patch (as output by `git log --patch --unified=0
+// --no-prefix`) with only the lines that searches need: the "diff --git",
+// "---" and "+++" lines of each file diff, and its hunk headers and added and
+// removed lines. The diffs of files without hunks (such as renames and binary
+// files) are omitted, and long lines are truncated to maxLineBytes.
+func compactPatch(patch []byte) []byte {
+	var (
+		out    bytes.Buffer
+		header []byte // the current file diff's header, until its first hunk
+		inHunk bool
+	)
+	for len(patch) > 0 {
+		line := patch
+		if i := bytes.IndexByte(patch, '\n'); i >= 0 {
+			line, patch = patch[:i+1], patch[i+1:]
+		} else {
+			patch = nil
+		}
+
+		switch {
+		case bytes.HasPrefix(line, []byte("diff --git ")):
+			header, inHunk = append([]byte(nil), line...), false
+		case bytes.HasPrefix(line, []byte("@@ ")):
+			if header != nil {
+				out.Write(header)
+				header = nil
+			}
+			// Omit the name of the enclosing function that git appends.
+			if i := bytes.Index(line[3:], []byte(" @@")); i >= 0 {
+				line = append(line[:i+6:i+6], '\n')
+			}
+			out.Write(line)
+			inHunk = true
+		case inHunk && (line[0] == '+' || line[0] == '-'):
+			if len(line) > maxLineBytes {
+				line = append(line[:maxLineBytes:maxLineBytes], '\n')
+			}
+			out.Write(line)
+		case !inHunk && header != nil && (bytes.HasPrefix(line, []byte("--- ")) || bytes.HasPrefix(line, []byte("+++ "))):
+			header = append(header, line...)
+		}
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,83 @@
+package commitindex
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestTruncatePatch(t *testing.T) {
+	patch := []byte("diff --git a a\n+1\ndiff --git b b\n+2\n")
+	tests := []struct {
+		max  int
+		want string
+	}{
+		{max: 100, want: string(patch)},
+		{max: 20, want: "diff --git a a\n+1\n"},
+		{max: 18, want: "diff --git a a\n+1\n"},
+		{max: 10, want: ""},
+	}
+	for _, test := range tests {
+		if got := string(truncatePatch(patch, test.max)); got != test.want {
+			t.Errorf("max %d: got %q, want %q", test.max, got, test.want)
+		}
+	}
+}
+
+func TestToEntry(t *testing.T) {
+	date := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	p := &git.CommitPatch{
+		Commit: &git.Commit{
+			ID:      "c",
+			Author:  git.Signature{Name: "a\x00", Email: "a@example.com", Date: date},
+			Message: "m\xff",
+		},
+		Patch: []byte("diff --git f f\nindex 1..2 100644\n--- f\n+++ f\n@@ -0,0 +1 @@\n+x\n"),
+	}
+	got := toEntry(1, p)
+	if got.RepoID != 1 || got.CommitID != "c" || got.AuthorName != "a" || got.Message != "m�" || got.Diff != "diff --git f f\n--- f\n+++ f\n@@ -0,0 +1 @@\n+x\n" {
+		t.Errorf("got unexpected entry %+v", got)
+	}
+	if got.CommitterName != "a" || got.CommitterEmail != "a@example.com" || !got.CommitterDate.Equal(date) {
+		t.Errorf("got committer %q <%q> %s, want the author", got.CommitterName, got.CommitterEmail, got.CommitterDate)
+	}
+}
+
+func TestCompactPatch(t *testing.T) {
+	patch := "diff --git a a\n" +
+		"index 1..2 100644\n" +
+		"--- a\n" +
+		"+++ a\n" +
+		"@@ -1 +1 @@ func main() {\n" +
+		"-x\n" +
+		"+--- y\n" +
+		"\\ No newline at end of file\n" +
+		"diff --git b c\n" +
+		"similarity index 100%\n" +
+		"rename from b\n" +
+		"rename to c\n" +
+		"diff --git d d\n" +
+		"new file mode 100644\n" +
+		"--- /dev/null\n" +
+		"+++ d\n" +
+		"@@ -0,0 +1,2 @@\n" +
+		"+" + strings.Repeat("z", maxLineBytes) + "\n" +
+		"+w"
+	want := "diff --git a a\n" +
+		"--- a\n" +
+		"+++ a\n" +
+		"@@ -1 +1 @@\n" +
+		"-x\n" +
+		"+--- y\n" +
+		"diff --git d d\n" +
+		"--- /dev/null\n" +
+		"+++ d\n" +
+		"@@ -0,0 +1,2 @@\n" +
+		"+" + strings.Repeat("z", maxLineBytes-1) + "\n" +
+		"+w"
+	if got := string(compactPatch([]byte(patch))); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
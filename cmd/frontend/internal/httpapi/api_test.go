@@ -20,6 +20,7 @@ func newTest() *httptestutil.Client {
 		nil,
 		enterpriseServices.GithubWebhook,
 		enterpriseServices.BitbucketServerWebhook,
+		enterpriseServices.CampaignReport,
 		enterpriseServices.NewCodeIntelUploadHandler,
 	))
 }
@@ -0,0 +1,117 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+)
+
+// auditLogExportBatchSize is the number of audit log entries that
+// serveAuditLog reads from the database at a time.
+var auditLogExportBatchSize = 1000
+
+// auditLogExportLag is the minimum age of the exported audit log entries. IDs
+// are assigned when an entry is inserted, but the entry only becomes visible
+// when its transaction commits, so entries can become visible out of ID
+// order. Consumers that poll with after_id would skip an entry that becomes
+// visible after an entry with a greater ID, so entries are only exported once
+// every entry inserted before them has had time to commit.
+const auditLogExportLag = time.Minute
+
+// auditLogExportEntry is the JSON representation of an audit log entry in the
+// export.
+type auditLogExportEntry struct {
+	ID                int64           `json:"id"`
+	ActorUserID       int32           `json:"actor_user_id"`
+	ActorIP           string          `json:"actor_ip"`
+	ActorForwardedFor string          `json:"actor_forwarded_for"`
+	Action            string          `json:"action"`
+	Target            string          `json:"target"`
+	Arguments         json.RawMessage `json:"arguments"`
+	CreatedAt         time.Time       `json:"created_at"`
+}
+
+// serveAuditLog exports the audit log as JSON lines, oldest entries first. The
+// entries can be filtered with the query parameters actor_user_id, action,
+// since and until (RFC 3339 timestamps), and after_id, which lets consumers
+// such as SIEMs poll for new entries. Entries are only exported once they're
+// auditLogExportLag old.
+func serveAuditLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Only site admins can view the audit log.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	opt, err := parseAuditLogExportOptions(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	opt.Ascending = true
+	opt.MinAge = auditLogExportLag
+	opt.LimitOffset = &db.LimitOffset{Limit: auditLogExportBatchSize}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache, max-age=0")
+
+	enc := json.NewEncoder(w)
+	for {
+		entries, err := db.AuditLogs.List(ctx, opt)
+		if err != nil {
+			// The response may have been partially written already, so
+			// the status code can't be changed anymore.
+			log15.Error("Exporting audit log failed.", "error", err)
+			return
+		}
+
+		for _, e := range entries {
+			if err := enc.Encode(auditLogExportEntry(*e)); err != nil {
+				return
+			}
+		}
+
+		if len(entries) < auditLogExportBatchSize {
+			return
+		}
+		opt.AfterID = entries[len(entries)-1].ID
+	}
+}
+
+func parseAuditLogExportOptions(r *http.Request) (opt db.AuditLogsListOptions, err error) {
+	q := r.URL.Query()
+
+	if v := q.Get("actor_user_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return opt, err
+		}
+		opt.ActorUserID = int32(id)
+	}
+	opt.Action = q.Get("action")
+	for _, p := range []struct {
+		name string
+		t    **time.Time
+	}{{"since", &opt.Since}, {"until", &opt.Until}} {
+		if v := q.Get(p.name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return opt, err
+			}
+			*p.t = &t
+		}
+	}
+	if v := q.Get("after_id"); v != "" {
+		if opt.AfterID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return opt, err
+		}
+	}
+	return opt, nil
+}
@@ -0,0 +1,100 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestServeAuditLog(t *testing.T) {
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	serve := func(url string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		req = req.WithContext(actor.WithActor(context.Background(), actor.FromUser(1)))
+		rec := httptest.NewRecorder()
+		serveAuditLog(rec, req)
+		return rec
+	}
+
+	t.Run("non-admin", func(t *testing.T) {
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1}, nil
+		}
+		if rec := serve("/.api/audit-log"); rec.Code != http.StatusForbidden {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusForbidden)
+		}
+	})
+
+	db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1, SiteAdmin: true}, nil
+	}
+
+	t.Run("invalid option", func(t *testing.T) {
+		if rec := serve("/.api/audit-log?since=yesterday"); rec.Code != http.StatusBadRequest {
+			t.Errorf("got status %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		defer func(n int) { auditLogExportBatchSize = n }(auditLogExportBatchSize)
+		auditLogExportBatchSize = 2
+
+		createdAt := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+		entries := []*db.AuditLogEntry{
+			{ID: 11, ActorUserID: 1, ActorIP: "192.0.2.1", Action: db.AuditActionSetUserIsSiteAdmin, Target: "VXNlcjoy", Arguments: []byte(`{"siteAdmin":true}`), CreatedAt: createdAt},
+			{ID: 12, ActorUserID: 1, ActorIP: "192.0.2.1", Action: db.AuditActionSetUserIsSiteAdmin, Target: "VXNlcjoz", Arguments: []byte(`{"siteAdmin":true}`), CreatedAt: createdAt},
+			{ID: 13, ActorUserID: 1, ActorIP: "192.0.2.1", ActorForwardedFor: "198.51.100.1", Action: db.AuditActionSetUserIsSiteAdmin, Target: "VXNlcjo0", Arguments: []byte(`{"siteAdmin":false}`), CreatedAt: createdAt},
+		}
+		since := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+		var gotAfterIDs []int64
+		db.Mocks.AuditLogs.List = func(opt db.AuditLogsListOptions) ([]*db.AuditLogEntry, error) {
+			gotAfterIDs = append(gotAfterIDs, opt.AfterID)
+			want := db.AuditLogsListOptions{
+				ActorUserID: 1,
+				Action:      db.AuditActionSetUserIsSiteAdmin,
+				Since:       &since,
+				AfterID:     opt.AfterID,
+				Ascending:   true,
+				MinAge:      auditLogExportLag,
+				LimitOffset: &db.LimitOffset{Limit: 2},
+			}
+			if diff := cmp.Diff(want, opt); diff != "" {
+				t.Errorf("opt: %s", diff)
+			}
+
+			var l []*db.AuditLogEntry
+			for _, e := range entries {
+				if e.ID > opt.AfterID && len(l) < opt.Limit {
+					l = append(l, e)
+				}
+			}
+			return l, nil
+		}
+
+		rec := serve("/.api/audit-log?actor_user_id=1&action=setUserIsSiteAdmin&since=2020-04-01T00:00:00Z&after_id=10")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+		}
+		if got, want := rec.Header().Get("Content-Type"), "application/x-ndjson"; got != want {
+			t.Errorf("got Content-Type %q, want %q", got, want)
+		}
+		want := `{"id":11,"actor_user_id":1,"actor_ip":"192.0.2.1","actor_forwarded_for":"","action":"setUserIsSiteAdmin","target":"VXNlcjoy","arguments":{"siteAdmin":true},"created_at":"2020-05-01T00:00:00Z"}
+{"id":12,"actor_user_id":1,"actor_ip":"192.0.2.1","actor_forwarded_for":"","action":"setUserIsSiteAdmin","target":"VXNlcjoz","arguments":{"siteAdmin":true},"created_at":"2020-05-01T00:00:00Z"}
+{"id":13,"actor_user_id":1,"actor_ip":"192.0.2.1","actor_forwarded_for":"198.51.100.1","action":"setUserIsSiteAdmin","target":"VXNlcjo0","arguments":{"siteAdmin":false},"created_at":"2020-05-01T00:00:00Z"}
+`
+		if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+			t.Errorf("body: %s", diff)
+		}
+		if diff := cmp.Diff([]int64{10, 12}, gotAfterIDs); diff != "" {
+			t.Errorf("after IDs: %s", diff)
+		}
+	})
+}
@@ -60,20 +60,27 @@ func AccessTokenAuthMiddleware(next http.Handler) http.Handler {
 			// Validate access token.
 			//
 			// 🚨 SECURITY: It's important we check for the correct scopes to know what this token
-			// is allowed to do.
-			var requiredScope string
+			// is allowed to do. Tokens without the "user:all" scope are restricted to the
+			// operations allowed by their scopes.
+			var requiredScopes []string
 			if sudoUser == "" {
-				requiredScope = authz.ScopeUserAll
+				requiredScopes = append([]string{authz.ScopeUserAll}, authz.RestrictedScopes...)
 			} else {
-				requiredScope = authz.ScopeSiteAdminSudo
+				requiredScopes = []string{authz.ScopeSiteAdminSudo}
 			}
-			subjectUserID, err := db.AccessTokens.Lookup(r.Context(), token, requiredScope)
+			subjectUserID, scopes, err := db.AccessTokens.Lookup(r.Context(), token, requiredScopes...)
 			if err != nil {
 				log15.Error("Invalid access token.", "token", token, "err", err)
 				http.Error(w, "Invalid access token.", http.StatusUnauthorized)
 				return
 			}
 
+			restrictedScopes := restrictedTokenScopes(scopes)
+			if restrictedScopes != nil && !restrictedTokenAllowsPath(restrictedScopes, r.URL.Path) {
+				http.Error(w, "The access token's scopes don't allow this request.", http.StatusForbidden)
+				return
+			}
+
 			// Determine the actor's user ID.
 			var actorUserID int32
 			if sudoUser == "" {
@@ -105,9 +112,50 @@ func AccessTokenAuthMiddleware(next http.Handler) http.Handler {
 				log15.Debug("HTTP request used sudo token.", "requestURI", r.URL.RequestURI(), "tokenSubjectUserID", subjectUserID, "actorUserID", actorUserID, "actorUsername", user.Username)
 			}
 
-			r = r.WithContext(actor.WithActor(r.Context(), &actor.Actor{UID: actorUserID}))
+			r = r.WithContext(actor.WithActor(r.Context(), &actor.Actor{UID: actorUserID, Scopes: restrictedScopes}))
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// restrictedTokenScopes returns the restricted scopes of an access token with the given scopes, or
+// nil if the access token has the "user:all" scope and is therefore not restricted.
+func restrictedTokenScopes(scopes []string) []string {
+	restricted := []string{}
+	for _, scope := range scopes {
+		if scope == authz.ScopeUserAll {
+			return nil
+		}
+		if authz.IsRestrictedScope(scope) {
+			restricted = append(restricted, scope)
+		}
+	}
+	return restricted
+}
+
+// restrictedTokenPaths are the paths of the API endpoints that access tokens with a restricted
+// scope can be used for. The operations of the GraphQL API are further restricted by
+// graphqlbackend.CheckAccessTokenScopes.
+var restrictedTokenPaths = map[string][]string{
+	authz.ScopeSearchRead:      {"/.api/graphql"},
+	authz.ScopeRepoRead:        {"/.api/graphql"},
+	authz.ScopeCampaignsWrite:  {"/.api/graphql"},
+	authz.ScopeCodeIntelUpload: {"/.api/lsif/upload"},
+}
+
+// restrictedTokenAllowsPath reports whether an access token with the given restricted scopes can be
+// used for a request to path.
+//
+// 🚨 SECURITY: Restricted access tokens must not be accepted for any other paths, since the handlers
+// of those paths don't check the scopes of the actor.
+func restrictedTokenAllowsPath(scopes []string, path string) bool {
+	for _, scope := range scopes {
+		for _, p := range restrictedTokenPaths[scope] {
+			if path == p {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,12 +1,17 @@
 package httpapi
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
@@ -29,6 +34,27 @@ func serveGraphQL(schema *graphql.Schema) func(w http.ResponseWriter, r *http.Re
 
 		r = r.WithContext(trace.WithRequestSource(r.Context(), guessSource(r)))
 
+		// 🚨 SECURITY: Actors authenticated with a restricted access token may only run the
+		// operations that the scopes of the token allow.
+		if actor.FromContext(r.Context()).IsRestricted() {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				return err
+			}
+			var params struct {
+				Query string `json:"query"`
+			}
+			if err := json.Unmarshal(body, &params); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return nil
+			}
+			if err := graphqlbackend.CheckAccessTokenScopes(r.Context(), params.Query); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return nil
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
 		relayHandler.ServeHTTP(w, r)
 		return nil
 	}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 	"strconv"
 
 	"github.com/gorilla/mux"
@@ -16,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/commitindex"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -138,14 +140,25 @@ func serveConfiguration(w http.ResponseWriter, r *http.Request) error {
 // Additionally, it only cares about certain search specific settings so this
 // search specific endpoint is used rather than serving the entire site settings
 // from /.internal/configuration.
+//
+// If the "repo" query parameter is set, the response also contains the
+// branches of that repository to index.
 func serveSearchConfiguration(w http.ResponseWriter, r *http.Request) error {
 	opts := struct {
 		LargeFiles []string
 		Symbols    bool
+		Branches   []indexBranch `json:",omitempty"`
 	}{
 		LargeFiles: conf.Get().SearchLargeFiles,
 		Symbols:    conf.SymbolIndexEnabled(),
 	}
+	if repo := r.URL.Query().Get("repo"); repo != "" {
+		var err error
+		opts.Branches, err = searchIndexBranches(r.Context(), api.RepoName(repo))
+		if err != nil {
+			return errors.Wrap(err, "listing branches to index")
+		}
+	}
 	err := json.NewEncoder(w).Encode(opts)
 	if err != nil {
 		return errors.Wrap(err, "encode")
@@ -153,6 +166,94 @@ func serveSearchConfiguration(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// indexBranch is a branch for zoekt to index. It has the same JSON encoding as
+// zoekt.RepositoryBranch.
+type indexBranch struct {
+	Name    string
+	Version string
+}
+
+// maxIndexBranches is the maximum number of branches zoekt can index per
+// repository.
+const maxIndexBranches = 64
+
+// searchIndexBranches returns the branches of the repository to index: its
+// default branch as "HEAD", followed by the branches matching the patterns
+// returned by searchIndexBranchPatterns.
+func searchIndexBranches(ctx context.Context, name api.RepoName) ([]indexBranch, error) {
+	repo := gitserver.Repo{Name: name}
+	head, err := git.ResolveRevision(ctx, repo, nil, "HEAD", &git.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return nil, err
+	}
+	branches := []indexBranch{{Name: "HEAD", Version: string(head)}}
+
+	patterns, err := searchIndexBranchPatterns(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(patterns) == 0 {
+		return branches, nil
+	}
+	all, err := git.ListBranches(ctx, repo, git.BranchesOptions{})
+	if err != nil {
+		return nil, err
+	}
+	branches = append(branches, matchIndexBranches(patterns, all)...)
+	if len(branches) > maxIndexBranches {
+		log15.Warn("Too many branches to index, only indexing the first ones.", "repo", name, "branches", len(branches), "max", maxIndexBranches)
+		branches = branches[:maxIndexBranches]
+	}
+	return branches, nil
+}
+
+// searchIndexBranchPatterns returns the names and glob patterns of the extra
+// branches of the repository to index. These are the branches listed for the
+// repository in the search.index.branches site configuration and in the
+// indexBranches property of the code host connections it is synced from.
+func searchIndexBranchPatterns(ctx context.Context, name api.RepoName) ([]string, error) {
+	patterns := append([]string(nil), conf.Get().SearchIndexBranches[string(name)]...)
+
+	repo, err := backend.Repos.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	svcs, err := db.ExternalServices.List(ctx, db.ExternalServicesListOptions{RepoID: repo.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range svcs {
+		// All code host connections have the same indexBranches property.
+		var c struct {
+			IndexBranches []string `json:"indexBranches"`
+		}
+		if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+			return nil, errors.Wrapf(err, "parsing config of external service %d", svc.ID)
+		}
+		patterns = append(patterns, c.IndexBranches...)
+	}
+	return patterns, nil
+}
+
+// matchIndexBranches returns the branches whose names are equal to or match
+// one of the glob patterns, sorted by name.
+func matchIndexBranches(patterns []string, branches []*git.Branch) []indexBranch {
+	var matched []indexBranch
+	for _, b := range branches {
+		if b.Name == "HEAD" {
+			continue
+		}
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, b.Name); ok || pattern == b.Name {
+				matched = append(matched, indexBranch{Name: b.Name, Version: string(b.Head)})
+				break
+			}
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched
+}
+
 type reposListServer struct {
 	// SourcegraphDotComMode is true if this instance of Sourcegraph is http://sourcegraph.com
 	SourcegraphDotComMode bool
@@ -307,6 +408,17 @@ func serveReposListEnabled(w http.ResponseWriter, r *http.Request) error {
 	return json.NewEncoder(w).Encode(names)
 }
 
+func serveReposCommitIndexUpdate(w http.ResponseWriter, r *http.Request) error {
+	var repo api.RepoName
+	if err := json.NewDecoder(r.Body).Decode(&repo); err != nil {
+		return err
+	}
+	if !commitindex.Enabled() {
+		return nil
+	}
+	return commitindex.Enqueue(r.Context(), repo)
+}
+
 func serveSavedQueriesListAll(w http.ResponseWriter, r *http.Request) error {
 	// List settings for all users, orgs, etc.
 	settings, err := db.SavedSearches.ListAll(r.Context())
@@ -14,6 +14,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/gorilla/mux"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	apirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi/router"
@@ -299,3 +302,90 @@ func (b suffixIndexers) ReposSubset(ctx context.Context, hostname string, indexe
 func (b suffixIndexers) Enabled() bool {
 	return bool(b)
 }
+
+func TestServeSearchConfiguration(t *testing.T) {
+	git.Mocks.ResolveRevision = func(spec string, opt *git.ResolveRevisionOptions) (api.CommitID, error) {
+		if spec != "HEAD" {
+			t.Errorf("got spec %q, want HEAD", spec)
+		}
+		return "deadbeef", nil
+	}
+	defer git.ResetMocks()
+	db.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{ID: 1, Name: name}, nil
+	}
+	db.Mocks.ExternalServices.List = func(db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	for target, want := range map[string][]indexBranch{
+		"/":                         nil,
+		"/?repo=github.com/foo/bar": {{Name: "HEAD", Version: "deadbeef"}},
+	} {
+		req := httptest.NewRequest("GET", target, nil)
+		w := httptest.NewRecorder()
+		if err := serveSearchConfiguration(w, req); err != nil {
+			t.Fatal(err)
+		}
+		var opts struct{ Branches []indexBranch }
+		if err := json.Unmarshal(w.Body.Bytes(), &opts); err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(want, opts.Branches) {
+			t.Errorf("%s: mismatch (-want +got):\n%s", target, cmp.Diff(want, opts.Branches))
+		}
+	}
+}
+
+func TestSearchIndexBranchPatterns(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		SearchIndexBranches: map[string][]string{
+			"github.com/foo/bar": {"release-1.2"},
+			"github.com/foo/baz": {"release-*"},
+		},
+	}})
+	defer conf.Mock(nil)
+
+	db.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{ID: 1, Name: name}, nil
+	}
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		if opt.RepoID != 1 {
+			t.Errorf("got repo ID %d, want 1", opt.RepoID)
+		}
+		return []*types.ExternalService{
+			{ID: 1, Kind: "GITHUB", Config: `{"indexBranches": ["main", "feature/*"]} // comment`},
+			{ID: 2, Kind: "GITHUB", Config: `{}`},
+		}, nil
+	}
+	defer func() { db.Mocks = db.MockStores{} }()
+
+	got, err := searchIndexBranchPatterns(context.Background(), "github.com/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"release-1.2", "main", "feature/*"}; !cmp.Equal(want, got) {
+		t.Fatalf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestMatchIndexBranches(t *testing.T) {
+	branches := []*git.Branch{
+		{Name: "master", Head: "1"},
+		{Name: "release-1.2", Head: "2"},
+		{Name: "release-1.2.1", Head: "3"},
+		{Name: "release-2.0", Head: "4"},
+		{Name: "feature/x", Head: "5"},
+	}
+
+	got := matchIndexBranches([]string{"release-1.2", "release-2.*", "feature/*", "["}, branches)
+	want := []indexBranch{
+		{Name: "feature/x", Version: "5"},
+		{Name: "release-1.2", Version: "2"},
+		{Name: "release-2.0", Version: "4"},
+	}
+	if !cmp.Equal(want, got) {
+		t.Fatalf("mismatch (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
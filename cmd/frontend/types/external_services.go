@@ -21,6 +21,18 @@ func (c *AWSCodeCommitConnection) SetURN(urn string) {
 	c.URN = urn
 }
 
+var _ CodeHostConnection = (*AzureDevOpsConnection)(nil)
+
+type AzureDevOpsConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.AzureDevOpsConnection
+}
+
+func (c *AzureDevOpsConnection) SetURN(urn string) {
+	c.URN = urn
+}
+
 var _ CodeHostConnection = (*BitbucketCloudConnection)(nil)
 
 type BitbucketCloudConnection struct {
@@ -45,6 +57,18 @@ func (c *BitbucketServerConnection) SetURN(urn string) {
 	c.URN = urn
 }
 
+var _ CodeHostConnection = (*GerritConnection)(nil)
+
+type GerritConnection struct {
+	// The unique resource identifier of the external service.
+	URN string
+	*schema.GerritConnection
+}
+
+func (c *GerritConnection) SetURN(urn string) {
+	c.URN = urn
+}
+
 var _ CodeHostConnection = (*GitHubConnection)(nil)
 
 type GitHubConnection struct {
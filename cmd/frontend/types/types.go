@@ -17,10 +17,9 @@ type RepoFields struct {
 	// Description is a brief description of the repository.
 	Description string
 
-	// DEPRECATED: this field is always empty for new repositories as of
-	// https://github.com/sourcegraph/sourcegraph/issues/2586. Do not use it.
-	//
-	// Language is the primary programming language used in this repository.
+	// Language is the primary programming language used in this repository,
+	// as reported by the code host. It is empty for code hosts that don't
+	// report one.
 	Language string
 
 	// Fork is whether this repository is a fork of another repository.
@@ -28,6 +27,19 @@ type RepoFields struct {
 
 	// Archived is whether this repository has been archived.
 	Archived bool
+
+	// Topics are the topics (or tags) of this repository on the code host.
+	Topics []string
+
+	// Stars is the number of stars of this repository on the code host.
+	Stars int
+
+	// PushedAt is when this repository was last pushed to on the code host.
+	PushedAt time.Time
+
+	// License is the SPDX identifier of the license of this repository, as
+	// detected by the code host.
+	License string
 }
 
 // Repo represents a source code repository.
@@ -75,6 +87,20 @@ func (e *ExternalService) URN() string {
 	return extsvc.URN(e.Kind, e.ID)
 }
 
+// ExternalServiceSyncState is the state of the repository syncs of an
+// external service, as recorded by repo-updater.
+type ExternalServiceSyncState struct {
+	ExternalServiceID  int64
+	LastSyncStartedAt  *time.Time
+	LastSyncFinishedAt *time.Time
+	NextSyncAt         *time.Time
+	LastSyncError      string
+	ReposSourced       int
+	ReposAdded         int
+	ReposModified      int
+	ReposDeleted       int
+}
+
 type GlobalState struct {
 	SiteID      string
 	Initialized bool // whether the initial site admin account has been created
@@ -28,6 +28,7 @@ var (
 	runRepoCleanup, _ = strconv.ParseBool(env.Get("SRC_RUN_REPO_CLEANUP", "", "Periodically remove inactive repositories."))
 	wantPctFree       = env.Get("SRC_REPOS_DESIRED_PERCENT_FREE", "10", "Target percentage of free space on disk.")
 	janitorInterval   = env.Get("SRC_REPOS_JANITOR_INTERVAL", "1m", "Interval between cleanup runs")
+	packCacheSizeMB   = env.Get("SRC_GITSERVER_PACK_CACHE_SIZE_MB", "0", "Maximum size of the cache of packs served to internal clones. 0 disables the cache.")
 )
 
 func main() {
@@ -46,10 +47,15 @@ func main() {
 	if err != nil {
 		log.Fatalf("parsing $SRC_REPOS_DESIRED_PERCENT_FREE: %v", err)
 	}
+	packCacheSizeMB2, err := strconv.ParseInt(packCacheSizeMB, 10, 64)
+	if err != nil {
+		log.Fatalf("parsing $SRC_GITSERVER_PACK_CACHE_SIZE_MB: %v", err)
+	}
 	gitserver := server.Server{
 		ReposDir:                reposDir,
 		DeleteStaleRepositories: runRepoCleanup,
 		DesiredPercentFree:      wantPctFree2,
+		PackCacheSizeMB:         packCacheSizeMB2,
 	}
 	gitserver.RegisterMetrics()
 
@@ -1,18 +1,25 @@
 package server
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/mxk/go-flowrate/flowrate"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
 )
 
 var uploadPackArgs = []string{
@@ -38,8 +45,19 @@ type gitServiceHandler struct {
 	// Dir is a funcion which takes a repository name and returns an absolute
 	// path to the GIT_DIR for it.
 	Dir func(string) string
+
+	// PackCache when non-nil is used to cache the responses of
+	// git-upload-pack requests which are fully negotiated. This allows many
+	// clients fetching the same commits (eg zoekt-indexserver replicas) to
+	// share one generated pack.
+	PackCache *diskcache.Store
 }
 
+// maxCacheableRequestSize is the largest git-upload-pack request body we will
+// consider for the pack cache. Larger requests contain many haves, which are
+// unlikely to be repeated by another client.
+const maxCacheableRequestSize = 64 * 1024
+
 func (s *gitServiceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Only support clones and fetches (git upload-pack). /info/refs sets the
 	// service field.
@@ -92,16 +110,40 @@ func (s *gitServiceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	args = append(args, dir)
 
-	body := r.Body
-	defer body.Close()
+	var body io.Reader = r.Body
+	defer r.Body.Close()
 
-	env := os.Environ()
-	if protocol := r.Header.Get("Git-Protocol"); protocol != "" {
-		env = append(env, "GIT_PROTOCOL="+protocol)
+	// git will gzip large request bodies. upload-pack expects the
+	// uncompressed pkt-lines on stdin.
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, "malformed gzip request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer gzr.Close()
+		body = gzr
 	}
 
-	cmd := exec.CommandContext(r.Context(), "git", args...)
-	cmd.Env = env
+	protocol := r.Header.Get("Git-Protocol")
+
+	if s.PackCache != nil && svc == "/git-upload-pack" {
+		b, err := ioutil.ReadAll(io.LimitReader(body, maxCacheableRequestSize+1))
+		if err != nil {
+			http.Error(w, "failed to read request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(b) <= maxCacheableRequestSize {
+			if key, ok := packCacheKey(repo, protocol, b); ok {
+				s.serveCachedPack(w, r, key, protocol, args, b)
+				return
+			}
+		}
+		metricPackCache.WithLabelValues("uncacheable").Inc()
+		body = io.MultiReader(bytes.NewReader(b), body)
+	}
+
+	cmd := uploadPackCommand(r.Context(), protocol, args)
 	cmd.Stdout = flowrateWriter(w)
 	cmd.Stdin = body
 	if err := cmd.Run(); err != nil {
@@ -109,6 +151,131 @@ func (s *gitServiceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// serveCachedPack writes the response for the git-upload-pack request body
+// req to w. If the response is not in s.PackCache it is generated first, with
+// concurrent requests for the same key waiting on a single upload-pack.
+func (s *gitServiceHandler) serveCachedPack(w http.ResponseWriter, r *http.Request, key, protocol string, args []string, req []byte) {
+	hit := true
+	f, err := s.PackCache.OpenWithPath(r.Context(), key, func(ctx context.Context, path string) error {
+		hit = false
+		out, err := os.OpenFile(path, os.O_WRONLY, 0600)
+		if err != nil {
+			return errors.Wrap(err, "failed to open pack cache item")
+		}
+		defer out.Close()
+
+		var stderr bytes.Buffer
+		cmd := uploadPackCommand(ctx, protocol, args)
+		cmd.Stdin = bytes.NewReader(req)
+		cmd.Stdout = out
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(err, "git upload-pack failed: %s", stderr.String())
+		}
+		return out.Close()
+	})
+	if err != nil {
+		metricPackCache.WithLabelValues("error").Inc()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+
+	if hit {
+		metricPackCache.WithLabelValues("hit").Inc()
+	} else {
+		metricPackCache.WithLabelValues("miss").Inc()
+	}
+
+	_, _ = io.Copy(flowrateWriter(w), f)
+}
+
+func uploadPackCommand(ctx context.Context, protocol string, args []string) *exec.Cmd {
+	env := os.Environ()
+	if protocol != "" {
+		env = append(env, "GIT_PROTOCOL="+protocol)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = env
+	return cmd
+}
+
+// packCacheKey returns the key used to cache the response to the
+// git-upload-pack request body req. ok is false if the response should not be
+// cached.
+//
+// We only cache requests which complete negotiation (contain "done"), since
+// those are the requests which result in a packfile. The response is then
+// determined by the wants, haves and capabilities, so we key on those. The
+// agent and session-id capabilities vary between clients but don't affect
+// the response, so they are excluded from the key. Requests for refs by name
+// (v2 ls-refs and want-ref) depend on the state of the repository, so are
+// never cached.
+func packCacheKey(repo, protocol string, req []byte) (key string, ok bool) {
+	lines, err := readPktLines(req)
+	if err != nil {
+		return "", false
+	}
+
+	var done bool
+	keyLines := make([]string, 0, len(lines))
+	for _, line := range lines {
+		switch {
+		case line == "done":
+			done = true
+		case strings.HasPrefix(line, "command=") && line != "command=fetch":
+			return "", false
+		case strings.HasPrefix(line, "want-ref "):
+			return "", false
+		}
+
+		fields := strings.Fields(line)
+		kept := fields[:0]
+		for _, f := range fields {
+			if strings.HasPrefix(f, "agent=") || strings.HasPrefix(f, "session-id=") {
+				continue
+			}
+			kept = append(kept, f)
+		}
+		if len(kept) > 0 {
+			keyLines = append(keyLines, strings.Join(kept, " "))
+		}
+	}
+	if !done {
+		return "", false
+	}
+
+	sort.Strings(keyLines)
+	return repo + "\x00" + protocol + "\x00" + strings.Join(keyLines, "\n"), true
+}
+
+// readPktLines parses the pkt-line encoded b. Special packets (flush, delim
+// and response-end) are skipped and trailing newlines are trimmed.
+func readPktLines(b []byte) ([]string, error) {
+	var lines []string
+	for len(b) > 0 {
+		if len(b) < 4 {
+			return nil, errors.New("short pkt-line length")
+		}
+		n, err := strconv.ParseUint(string(b[:4]), 16, 16)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid pkt-line length")
+		}
+		if n < 4 {
+			// 0000 flush-pkt, 0001 delim-pkt, 0002 response-end-pkt
+			b = b[4:]
+			continue
+		}
+		if int(n) > len(b) {
+			return nil, errors.New("pkt-line length exceeds request")
+		}
+		lines = append(lines, strings.TrimSuffix(string(b[4:n]), "\n"))
+		b = b[n:]
+	}
+	return lines, nil
+}
+
 func packetWrite(str string) []byte {
 	s := strconv.FormatInt(int64(len(str)+4), 16)
 	if len(s)%4 != 0 {
@@ -147,4 +314,9 @@ var (
 		Name: "src_gitserver_gitservice_running",
 		Help: "A histogram of latencies for the git service (upload-pack for internal clones) endpoint.",
 	}, []string{"type"})
+
+	metricPackCache = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_gitservice_pack_cache_total",
+		Help: "Total number of git-upload-pack requests by pack cache status (hit, miss, uncacheable, error).",
+	}, []string{"status"})
 )
@@ -2,10 +2,13 @@ package server
 
 import (
 	"bytes"
+	"io/ioutil"
 	"net/http/httptest"
 	"os/exec"
 	"path/filepath"
 	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
 )
 
 func TestGitServiceHandler(t *testing.T) {
@@ -75,3 +78,107 @@ func TestGitServiceHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGitServiceHandler_PackCache(t *testing.T) {
+	root := tmpDir(t)
+	repo := filepath.Join(root, "testrepo")
+
+	runCmd(t, root, "git", "init", repo)
+	runCmd(t, repo, "sh", "-c", "echo hello world > hello.txt")
+	runCmd(t, repo, "git", "add", "hello.txt")
+	runCmd(t, repo, "git", "commit", "-m", "hello")
+
+	cacheDir := tmpDir(t)
+	ts := httptest.NewServer(&gitServiceHandler{
+		Dir: func(s string) string {
+			return filepath.Join(root, s, ".git")
+		},
+		PackCache: &diskcache.Store{Dir: cacheDir},
+	})
+	defer ts.Close()
+
+	cloneURL := ts.URL + "/testrepo"
+
+	cacheSize := func() int {
+		fis, err := ioutil.ReadDir(cacheDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(fis)
+	}
+
+	for _, version := range []string{"1", "2"} {
+		for i := 0; i < 2; i++ {
+			dir := tmpDir(t)
+			runCmd(t, dir, "git", "-c", "protocol.version="+version, "clone", cloneURL)
+			if got := runCmd(t, filepath.Join(dir, "testrepo"), "cat", "hello.txt"); got != "hello world\n" {
+				t.Fatalf("unexpected content of hello.txt after clone with protocol v%s: %q", version, got)
+			}
+		}
+	}
+
+	// One pack per protocol version, the second clone of each should be
+	// served from the cache.
+	if got, want := cacheSize(), 2; got != want {
+		t.Fatalf("got %d items in pack cache, want %d", got, want)
+	}
+}
+
+func TestPackCacheKey(t *testing.T) {
+	pkt := func(lines ...string) []byte {
+		var b bytes.Buffer
+		for _, l := range lines {
+			if l == "" {
+				b.WriteString("0000")
+				continue
+			}
+			b.Write(packetWrite(l + "\n"))
+		}
+		return b.Bytes()
+	}
+
+	const sha = "0123456789012345678901234567890123456789"
+
+	cases := []struct {
+		Name string
+		Req  []byte
+		OK   bool
+	}{{
+		Name: "v1 negotiation incomplete",
+		Req:  pkt("want "+sha+" side-band-64k ofs-delta agent=git/2.27.0", ""),
+	}, {
+		Name: "v1 done",
+		Req:  pkt("want "+sha+" side-band-64k ofs-delta agent=git/2.27.0", "", "done"),
+		OK:   true,
+	}, {
+		Name: "v2 ls-refs",
+		Req:  pkt("command=ls-refs", "agent=git/2.27.0", ""),
+	}, {
+		Name: "v2 fetch",
+		Req:  pkt("command=fetch", "agent=git/2.27.0", "ofs-delta", "want "+sha, "done", ""),
+		OK:   true,
+	}, {
+		Name: "v2 want-ref",
+		Req:  pkt("command=fetch", "want-ref refs/heads/master", "done", ""),
+	}, {
+		Name: "malformed",
+		Req:  []byte("zzzzwant"),
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, ok := packCacheKey("repo", "", tc.Req)
+			if ok != tc.OK {
+				t.Fatalf("got ok=%v, want %v", ok, tc.OK)
+			}
+		})
+	}
+
+	t.Run("ignores agent", func(t *testing.T) {
+		a, _ := packCacheKey("repo", "version=2", pkt("command=fetch", "agent=git/2.27.0", "want "+sha, "done", ""))
+		b, _ := packCacheKey("repo", "version=2", pkt("command=fetch", "agent=git/2.28.0", "want "+sha, "done", ""))
+		if a != b {
+			t.Fatalf("expected keys to be equal:\n%q\n%q", a, b)
+		}
+	})
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/diskcache"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
@@ -116,6 +117,10 @@ type Server struct {
 	// DiskSizer tells how much disk is free and how large the disk is.
 	DiskSizer DiskSizer
 
+	// PackCacheSizeMB is the maximum size of the cache of packs generated for
+	// internal clones. The pack cache is disabled if it is zero.
+	PackCacheSizeMB int64
+
 	// skipCloneForTests is set by tests to avoid clones.
 	skipCloneForTests bool
 
@@ -138,6 +143,10 @@ type Server struct {
 
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
+
+	// packCache is the cache used by the git service handler. It is nil if
+	// PackCacheSizeMB is zero.
+	packCache *diskcache.Store
 }
 
 type locks struct {
@@ -200,6 +209,16 @@ func (s *Server) Handler() http.Handler {
 	s.locker = &RepositoryLocker{}
 	s.repoUpdateLocks = make(map[api.RepoName]*locks)
 
+	if s.PackCacheSizeMB > 0 {
+		s.packCache = &diskcache.Store{
+			Dir:       filepath.Join(s.ReposDir, tempDirName, "pack-cache"),
+			Component: "gitserver-pack-cache",
+			// Generating a pack continues if the requesting client goes
+			// away, since other clients may be waiting on it.
+			BackgroundTimeout: longGitCommandTimeout,
+		}
+	}
+
 	// GitMaxConcurrentClones controls the maximum number of clones that
 	// can happen at once on a single gitserver.
 	// Used to prevent throttle limits from a code host. Defaults to 5.
@@ -240,7 +259,8 @@ func (s *Server) Handler() http.Handler {
 	})
 
 	mux.Handle("/git/", http.StripPrefix("/git", &gitServiceHandler{
-		Dir: func(d string) string { return string(s.dir(api.RepoName(d))) },
+		Dir:       func(d string) string { return string(s.dir(api.RepoName(d))) },
+		PackCache: s.packCache,
 	}))
 
 	return mux
@@ -249,6 +269,23 @@ func (s *Server) Handler() http.Handler {
 // Janitor does clean up tasks over s.ReposDir.
 func (s *Server) Janitor() {
 	s.cleanupRepos()
+	s.evictPackCache()
+}
+
+// evictPackCache removes the least recently used packs until the pack cache
+// is smaller than PackCacheSizeMB.
+func (s *Server) evictPackCache() {
+	if s.packCache == nil {
+		return
+	}
+	stats, err := s.packCache.Evict(s.PackCacheSizeMB * 1000 * 1000)
+	if err != nil {
+		log15.Error("failed to evict items from pack cache", "error", err)
+		return
+	}
+	if stats.Evicted > 0 {
+		log15.Debug("evicted items from pack cache", "evicted", stats.Evicted, "cacheSize", stats.CacheSize)
+	}
 }
 
 // Stop cancels the running background jobs and returns when done.
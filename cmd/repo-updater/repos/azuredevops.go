@@ -0,0 +1,357 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps connection configured
+// in Sourcegraph via the external services configuration.
+type AzureDevOpsSource struct {
+	svc     *ExternalService
+	config  *schema.AzureDevOpsConnection
+	exclude excludeFunc
+	baseURL *url.URL
+	client  *azuredevops.Client
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external service.
+func NewAzureDevOpsSource(svc *ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newAzureDevOpsSource(svc, &c, cf)
+}
+
+func newAzureDevOpsSource(svc *ExternalService, c *schema.AzureDevOpsConnection, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Exact(r.Id)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client := azuredevops.NewClient(baseURL, cli)
+	client.Token = c.Token
+
+	return &AzureDevOpsSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		baseURL: baseURL,
+		client:  client,
+	}, nil
+}
+
+// ListRepos returns all Azure DevOps repositories accessible to all connections configured
+// in Sourcegraph via the external services configuration.
+func (s AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	s.listAllRepos(ctx, results)
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s AzureDevOpsSource) ExternalServices() ExternalServices {
+	return ExternalServices{s.svc}
+}
+
+var _ ChangesetSource = AzureDevOpsSource{}
+
+// CreateChangeset creates the given *Changeset in the code host.
+func (s AzureDevOpsSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, false)
+}
+
+// CreateDraftChangeset creates the given *Changeset as a draft pull request
+// on the code host.
+func (s AzureDevOpsSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, true)
+}
+
+func (s AzureDevOpsSource) createChangeset(ctx context.Context, c *Changeset, draft bool) (bool, error) {
+	var exists bool
+
+	repo := c.Repo.Metadata.(*azuredevops.Repository)
+
+	pr, err := s.client.CreatePullRequest(ctx, repo, &azuredevops.CreatePullRequestInput{
+		SourceRefName: git.EnsureRefPrefix(c.HeadRef),
+		TargetRefName: git.EnsureRefPrefix(c.BaseRef),
+		Title:         c.Title,
+		Description:   c.Body,
+		IsDraft:       draft,
+	})
+	if err != nil {
+		ae, ok := err.(*azuredevops.ErrAlreadyExists)
+		if !ok {
+			return exists, err
+		}
+		pr, exists = ae.Existing, true
+	}
+
+	if err = c.SetMetadata(pr); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset abandons the given *Changeset on the code host and updates the
+// Metadata column in the *campaigns.Changeset to the newly abandoned pull request.
+func (s AzureDevOpsSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	abandoned, err := s.client.AbandonPullRequest(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = abandoned
+
+	return nil
+}
+
+// azureDevOpsMergeStrategies maps merge methods to Azure DevOps' merge
+// strategies.
+var azureDevOpsMergeStrategies = map[campaigns.ChangesetMergeMethod]string{
+	campaigns.ChangesetMergeMethodMerge:  "noFastForward",
+	campaigns.ChangesetMergeMethodSquash: "squash",
+	campaigns.ChangesetMergeMethodRebase: "rebase",
+}
+
+// MergeChangeset completes the given *Changeset on the code host and updates
+// the Metadata column in the *campaigns.Changeset to the newly completed pull
+// request.
+func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	completed, err := s.client.CompletePullRequest(ctx, pr, azureDevOpsMergeStrategies[method])
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = completed
+
+	return nil
+}
+
+// UndraftChangeset publishes the given draft *Changeset on the code host and
+// updates the Metadata column in the *campaigns.Changeset.
+func (s AzureDevOpsSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	isDraft := false
+	updated, err := s.client.UpdatePullRequest(ctx, pr, &azuredevops.UpdatePullRequestInput{IsDraft: &isDraft})
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = updated
+	return nil
+}
+
+// LoadChangesets loads the latest state of the given Changesets from the codehost.
+func (s AzureDevOpsSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
+	var notFound []*Changeset
+
+	for i := range cs {
+		repo := cs[i].Repo.Metadata.(*azuredevops.Repository)
+		id, err := strconv.Atoi(cs[i].ExternalID)
+		if err != nil {
+			return errors.Wrap(err, "parsing changeset external id")
+		}
+
+		pr, err := s.client.GetPullRequest(ctx, repo, id)
+		if err != nil {
+			if azuredevops.IsNotFound(err) {
+				notFound = append(notFound, cs[i])
+				if cs[i].Changeset.Metadata == nil {
+					cs[i].Changeset.Metadata = &azuredevops.PullRequest{ID: id, Repository: *repo}
+				}
+				continue
+			}
+
+			return err
+		}
+
+		if err = cs[i].SetMetadata(pr); err != nil {
+			return errors.Wrap(err, "setting changeset metadata")
+		}
+	}
+
+	if len(notFound) > 0 {
+		return ChangesetsNotFoundError{Changesets: notFound}
+	}
+
+	return nil
+}
+
+// UpdateChangeset updates the given *Changeset in the code host.
+func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	update := &azuredevops.UpdatePullRequestInput{
+		Title:       c.Title,
+		Description: c.Body,
+	}
+	if ref := git.EnsureRefPrefix(c.BaseRef); ref != pr.TargetRefName {
+		update.TargetRefName = ref
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, pr, update)
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = updated
+	return nil
+}
+
+func (s AzureDevOpsSource) makeRepo(r *azuredevops.Repository) *Repo {
+	urn := s.svc.URN()
+	organization := strings.Trim(s.baseURL.Path, "/")
+	return &Repo{
+		Name: string(reposource.AzureDevOpsRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			organization,
+			r.Project.Name,
+			r.Name,
+		)),
+		URI: string(reposource.AzureDevOpsRepoName(
+			"",
+			s.baseURL.Hostname(),
+			organization,
+			r.Project.Name,
+			r.Name,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.baseURL.String(),
+		},
+		Description:   r.Project.Description,
+		Fork:          r.IsFork,
+		Archived:      r.IsDisabled,
+		Private:       r.Project.Visibility != azuredevops.ProjectVisibilityPublic,
+		DefaultBranch: strings.TrimPrefix(r.DefaultBranch, "refs/heads/"),
+		Sources: map[string]*SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.authenticatedRemoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// authenticatedRemoteURL returns the repository's Git remote URL with the configured
+// Azure DevOps personal access token inserted in the URL userinfo.
+func (s *AzureDevOpsSource) authenticatedRemoteURL(r *azuredevops.Repository) string {
+	u, err := url.Parse(r.RemoteURL)
+	if err != nil {
+		log15.Warn("Error adding authentication to Azure DevOps repository Git remote URL.", "url", r.RemoteURL, "error", err)
+		return r.RemoteURL
+	}
+
+	// Azure DevOps ignores the username when authenticating with a personal
+	// access token, but Git needs one to send the password.
+	username := "azuredevops"
+	if u.User != nil && u.User.Username() != "" {
+		username = u.User.Username()
+	}
+
+	u.User = url.UserPassword(username, s.config.Token)
+	return u.String()
+}
+
+func (s *AzureDevOpsSource) excludes(r *azuredevops.Repository) bool {
+	return s.exclude(r.NameWithProject()) || s.exclude(r.ID)
+}
+
+func (s *AzureDevOpsSource) listAllRepos(ctx context.Context, results chan SourceResult) {
+	projects := s.config.Projects
+	if len(projects) == 0 {
+		const top = 100
+		for skip := 0; ; skip += top {
+			page, err := s.client.ListProjects(ctx, skip, top)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.projects: skip=%d", skip)}
+				return
+			}
+
+			for _, p := range page {
+				projects = append(projects, p.ID)
+			}
+
+			if len(page) < top {
+				break
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, project := range projects {
+		repos, err := s.client.ListRepositories(ctx, project)
+		if err != nil {
+			if azuredevops.IsNotFound(err) {
+				log15.Warn("skipping missing azuredevops.projects entry:", "name", project, "err", err)
+				continue
+			}
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.repositories: project=%q", project)}
+			continue
+		}
+
+		for _, r := range repos {
+			if !seen[r.ID] && !s.excludes(r) {
+				results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+				seen[r.ID] = true
+			}
+		}
+	}
+}
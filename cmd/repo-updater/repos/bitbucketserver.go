@@ -11,6 +11,7 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
@@ -85,6 +86,8 @@ func (s BitbucketServerSource) ListRepos(ctx context.Context, results chan Sourc
 }
 
 var _ ChangesetSource = BitbucketServerSource{}
+var _ ChangesetSearchSource = BitbucketServerSource{}
+var _ ChangesetActionSource = BitbucketServerSource{}
 
 // CreateChangeset creates the given *Changeset in the code host.
 func (s BitbucketServerSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
@@ -144,7 +147,133 @@ func (s BitbucketServerSource) CloseChangeset(ctx context.Context, c *Changeset)
 	return nil
 }
 
+// ReopenChangeset reopens the given declined *Changeset on the code host and
+// updates the Metadata column in the *campaigns.Changeset.
+func (s BitbucketServerSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	if err := s.client.ReopenPullRequest(ctx, pr); err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
+// CreateComment adds a comment to the given *Changeset on the code host.
+func (s BitbucketServerSource) CreateComment(ctx context.Context, c *Changeset, body string) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	return s.client.CreatePullRequestComment(ctx, pr, body)
+}
+
+// AddLabels returns an error, since Bitbucket Server pull requests have no
+// labels.
+func (s BitbucketServerSource) AddLabels(ctx context.Context, c *Changeset, labels []string) error {
+	return errors.New("Bitbucket Server pull requests have no labels")
+}
+
+// RequestReviewers adds the users with the given usernames as reviewers of
+// the given *Changeset on the code host.
+func (s BitbucketServerSource) RequestReviewers(ctx context.Context, c *Changeset, usernames []string) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	for _, name := range usernames {
+		if err := s.client.AddPullRequestReviewer(ctx, pr, name); err != nil {
+			return errors.Wrapf(err, "adding reviewer %q", name)
+		}
+	}
+
+	return nil
+}
+
+// bitbucketServerMergeStrategies maps merge methods to the IDs of Bitbucket
+// Server's merge strategies.
+var bitbucketServerMergeStrategies = map[campaigns.ChangesetMergeMethod]string{
+	campaigns.ChangesetMergeMethodMerge:  "no-ff",
+	campaigns.ChangesetMergeMethodSquash: "squash",
+	campaigns.ChangesetMergeMethodRebase: "rebase-ff-only",
+}
+
+// MergeChangeset merges the given *Changeset on the code host and updates the
+// Metadata column in the *campaigns.Changeset to the newly merged pull request.
+func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	err := s.client.MergePullRequest(ctx, pr, bitbucketServerMergeStrategies[method])
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
 // LoadChangesets loads the latest state of the given Changesets from the codehost.
+// SearchChangesets returns the pull requests of a single repository matching
+// the given query. The query is the "PROJECT/repo" name of the repository,
+// optionally followed by the filters of Bitbucket Server's pull request
+// listing, e.g. "SG/sourcegraph?state=ALL&filterText=security".
+func (s BitbucketServerSource) SearchChangesets(ctx context.Context, query string) ([]*Changeset, error) {
+	name, filter := query, ""
+	if i := strings.Index(query, "?"); i >= 0 {
+		name, filter = query[:i], query[i:]
+	}
+
+	ps := strings.SplitN(name, "/", 2)
+	if len(ps) != 2 || ps[0] == "" || ps[1] == "" {
+		return nil, errors.Errorf("invalid Bitbucket Server pull request search %q: expected PROJECT/repo", query)
+	}
+
+	host, err := url.Parse(s.config.Url)
+	if err != nil {
+		return nil, err
+	}
+	serviceID := extsvc.NormalizeBaseURL(host).String()
+
+	var (
+		cs   []*Changeset
+		next *bitbucketserver.PageToken
+	)
+	for next.HasMore() {
+		var prs []*bitbucketserver.PullRequest
+		prs, next, err = s.client.PullRequests(ctx, ps[0], ps[1], next, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range prs {
+			cs = append(cs, &Changeset{
+				Changeset: &campaigns.Changeset{
+					ExternalID:          strconv.Itoa(pr.ID),
+					ExternalServiceType: extsvc.TypeBitbucketServer,
+				},
+				Repo: &Repo{ExternalRepo: api.ExternalRepoSpec{
+					ID:          strconv.Itoa(pr.ToRef.Repository.ID),
+					ServiceType: extsvc.TypeBitbucketServer,
+					ServiceID:   serviceID,
+				}},
+			})
+		}
+	}
+
+	return cs, nil
+}
+
 func (s BitbucketServerSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
 	var notFound []*Changeset
 
@@ -0,0 +1,187 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GerritSource yields repositories from a single Gerrit connection configured
+// in Sourcegraph via the external services configuration.
+type GerritSource struct {
+	svc     *ExternalService
+	config  *schema.GerritConnection
+	exclude excludeFunc
+	baseURL *url.URL
+	client  *gerrit.Client
+}
+
+// NewGerritSource returns a new GerritSource from the given external service.
+func NewGerritSource(svc *ExternalService, cf *httpcli.Factory) (*GerritSource, error) {
+	var c schema.GerritConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, fmt.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newGerritSource(svc, &c, cf)
+}
+
+func newGerritSource(svc *ExternalService, c *schema.GerritConnection, cf *httpcli.Factory) (*GerritSource, error) {
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client := gerrit.NewClient(baseURL, cli)
+	client.Username = c.Username
+	client.Password = c.Password
+
+	return &GerritSource{
+		svc:     svc,
+		config:  c,
+		exclude: exclude,
+		baseURL: baseURL,
+		client:  client,
+	}, nil
+}
+
+// ListRepos returns all Gerrit projects accessible to all connections configured
+// in Sourcegraph via the external services configuration.
+func (s GerritSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	s.listAllRepos(ctx, results)
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s GerritSource) ExternalServices() ExternalServices {
+	return ExternalServices{s.svc}
+}
+
+func (s GerritSource) makeRepo(p *gerrit.Project) *Repo {
+	urn := s.svc.URN()
+	return &Repo{
+		Name: string(reposource.GerritRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			p.Name,
+		)),
+		URI: string(reposource.GerritRepoName(
+			"",
+			s.baseURL.Hostname(),
+			p.Name,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          p.ID,
+			ServiceType: extsvc.TypeGerrit,
+			ServiceID:   s.baseURL.String(),
+		},
+		Description: p.Description,
+		Archived:    p.State == gerrit.ProjectStateReadOnly,
+		Sources: map[string]*SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.authenticatedRemoteURL(p),
+			},
+		},
+		Metadata: p,
+	}
+}
+
+// authenticatedRemoteURL returns the project's Git remote URL with the configured
+// Gerrit HTTP credentials inserted in the URL userinfo. Gerrit serves authenticated
+// Git requests under the "/a/" prefix.
+func (s *GerritSource) authenticatedRemoteURL(p *gerrit.Project) string {
+	u := *s.baseURL
+	if !s.client.Authenticated() {
+		u.Path += p.Name
+		return u.String()
+	}
+
+	u.Path += "a/" + p.Name
+	u.User = url.UserPassword(s.config.Username, s.config.Password)
+	return u.String()
+}
+
+func (s *GerritSource) excludes(p *gerrit.Project) bool {
+	return s.exclude(p.Name) || p.State == gerrit.ProjectStateHidden
+}
+
+func (s *GerritSource) listAllRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[string]bool)
+	emit := func(p *gerrit.Project) {
+		if !seen[p.ID] && !s.excludes(p) {
+			results <- SourceResult{Source: s, Repo: s.makeRepo(p)}
+			seen[p.ID] = true
+		}
+	}
+
+	for _, name := range s.config.Projects {
+		p, err := s.client.GetProject(ctx, name)
+		if err != nil {
+			if gerrit.IsNotFound(err) {
+				log15.Warn("skipping missing gerrit.projects entry:", "name", name, "err", err)
+				continue
+			}
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gerrit.projects: item=%q", name)}
+			continue
+		}
+		emit(p)
+	}
+
+	queries := s.config.ProjectQuery
+	if len(queries) == 0 {
+		queries = []string{"state:active"}
+	}
+
+	const perPage = 100
+	for _, q := range queries {
+		if q == "none" {
+			continue
+		}
+
+		for skip := 0; ; {
+			projects, more, err := s.client.ListProjects(ctx, q, skip, perPage)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: errors.Wrapf(err, "gerrit.projectQuery: item=%q, skip=%d", q, skip)}
+				break
+			}
+
+			for _, p := range projects {
+				emit(p)
+			}
+
+			if !more || len(projects) == 0 {
+				break
+			}
+			skip += len(projects)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -127,9 +128,17 @@ type githubResult struct {
 // ListRepos returns all Github repositories accessible to all connections configured
 // in Sourcegraph via the external services configuration.
 func (s GithubSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	s.ListReposSince(ctx, time.Time{}, results)
+}
+
+// ListReposSince is like ListRepos, but only lists the repositories of orgs
+// and affiliated repositories that were updated after the given time. The
+// repositories matched by the `repos` config option and by searches are
+// always listed.
+func (s GithubSource) ListReposSince(ctx context.Context, since time.Time, results chan SourceResult) {
 	unfiltered := make(chan *githubResult)
 	go func() {
-		s.listAllRepositories(ctx, unfiltered)
+		s.listAllRepositories(ctx, since, unfiltered)
 		close(unfiltered)
 	}()
 
@@ -152,9 +161,21 @@ func (s GithubSource) ExternalServices() ExternalServices {
 }
 
 var _ ChangesetSource = GithubSource{}
+var _ ChangesetActionSource = GithubSource{}
+var _ ChangesetSearchSource = GithubSource{}
 
 // CreateChangeset creates the given *Changeset in the code host.
 func (s GithubSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, false)
+}
+
+// CreateDraftChangeset creates the given *Changeset as a draft pull request
+// on the code host.
+func (s GithubSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, true)
+}
+
+func (s GithubSource) createChangeset(ctx context.Context, c *Changeset, draft bool) (bool, error) {
 	var exists bool
 	repo := c.Repo.Metadata.(*github.Repository)
 
@@ -164,6 +185,7 @@ func (s GithubSource) CreateChangeset(ctx context.Context, c *Changeset) (bool,
 		Body:         c.Body,
 		HeadRefName:  git.AbbreviateRef(c.HeadRef),
 		BaseRefName:  git.AbbreviateRef(c.BaseRef),
+		Draft:        draft,
 	})
 
 	if err != nil {
@@ -206,6 +228,113 @@ func (s GithubSource) CloseChangeset(ctx context.Context, c *Changeset) error {
 	return nil
 }
 
+// UndraftChangeset marks the given draft *Changeset as ready for review on
+// the code host and updates the Metadata column in the *campaigns.Changeset.
+func (s GithubSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.MarkPullRequestReadyForReview(ctx, pr); err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
+// ReopenChangeset reopens the given closed *Changeset on the code host and
+// updates the Metadata column in the *campaigns.Changeset.
+func (s GithubSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.ReopenPullRequest(ctx, pr); err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
+// CreateComment adds a comment to the given *Changeset on the code host.
+func (s GithubSource) CreateComment(ctx context.Context, c *Changeset, body string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	return s.client.CreatePullRequestComment(ctx, pr, body)
+}
+
+// AddLabels adds the given labels to the *Changeset on the code host.
+func (s GithubSource) AddLabels(ctx context.Context, c *Changeset, labels []string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	return s.client.AddLabelsToPullRequest(ctx, pr, labels)
+}
+
+// RequestReviewers requests reviews of the given *Changeset from the users
+// with the given logins on the code host.
+func (s GithubSource) RequestReviewers(ctx context.Context, c *Changeset, usernames []string) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	return s.client.RequestPullRequestReviews(ctx, pr, usernames)
+}
+
+// MergeChangeset merges the given *Changeset on the code host and updates the
+// Metadata column in the *campaigns.Changeset to the newly merged pull request.
+func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, method campaigns.ChangesetMergeMethod) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	// GitHub's merge methods have the same names as ours.
+	err := s.client.MergePullRequest(ctx, pr, string(method))
+	if err != nil {
+		return err
+	}
+
+	c.Changeset.Metadata = pr
+
+	return nil
+}
+
+// SearchChangesets returns the pull requests matching the given GitHub search
+// query, e.g. "org:sourcegraph label:security-fix".
+func (s GithubSource) SearchChangesets(ctx context.Context, query string) ([]*Changeset, error) {
+	prs, err := s.client.SearchPullRequests(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := make([]*Changeset, 0, len(prs))
+	for _, pr := range prs {
+		repo := &github.Repository{ID: pr.Repository.ID, NameWithOwner: pr.Repository.NameWithOwner}
+		cs = append(cs, &Changeset{
+			Changeset: &campaigns.Changeset{
+				ExternalID:          strconv.FormatInt(pr.Number, 10),
+				ExternalServiceType: extsvc.TypeGitHub,
+			},
+			Repo: &Repo{ExternalRepo: github.ExternalRepoSpec(repo, *s.baseURL)},
+		})
+	}
+
+	return cs, nil
+}
+
 // LoadChangesets loads the latest state of the given Changesets from the codehost.
 func (s GithubSource) LoadChangesets(ctx context.Context, cs ...*Changeset) error {
 	prs := make([]*github.PullRequest, len(cs))
@@ -282,11 +411,17 @@ func (s GithubSource) makeRepo(r *github.Repository) *Repo {
 			s.originalHostname,
 			r.NameWithOwner,
 		)),
-		ExternalRepo: github.ExternalRepoSpec(r, *s.baseURL),
-		Description:  r.Description,
-		Fork:         r.IsFork,
-		Archived:     r.IsArchived,
-		Private:      r.IsPrivate,
+		ExternalRepo:  github.ExternalRepoSpec(r, *s.baseURL),
+		Description:   r.Description,
+		Fork:          r.IsFork,
+		Archived:      r.IsArchived,
+		Private:       r.IsPrivate,
+		Topics:        r.Topics,
+		Language:      r.Language,
+		Stars:         r.StargazerCount,
+		PushedAt:      r.PushedAt,
+		DefaultBranch: r.DefaultBranch,
+		License:       r.License,
 		Sources: map[string]*SourceInfo{
 			urn: {
 				ID:       urn,
@@ -375,7 +510,9 @@ func (s *GithubSource) paginate(ctx context.Context, results chan *githubResult,
 // by hitting the /orgs/:org/repos endpoint.
 //
 // It returns an error if the request fails on the first page.
-func (s *GithubSource) listOrg(ctx context.Context, org string, results chan *githubResult) {
+//
+// If since is not zero, only the repositories updated after it are listed.
+func (s *GithubSource) listOrg(ctx context.Context, org string, since time.Time, results chan *githubResult) {
 	var oerr error
 	s.paginate(ctx, results, func(page int) (repos []*github.Repository, hasNext bool, cost int, err error) {
 		defer func() {
@@ -397,6 +534,9 @@ func (s *GithubSource) listOrg(ctx context.Context, org string, results chan *gi
 				"retryAfter", retry,
 			)
 		}()
+		if !since.IsZero() {
+			return s.client.ListOrgRepositoriesSince(ctx, org, since, page)
+		}
 		return s.client.ListOrgRepositories(ctx, org, page)
 	})
 
@@ -523,7 +663,9 @@ func (s *GithubSource) listPublic(ctx context.Context, results chan *githubResul
 //
 // Affiliation is present if the user: (1) owns the repo, (2) is apart of an org that
 // the repo belongs to, or (3) is a collaborator.
-func (s *GithubSource) listAffiliated(ctx context.Context, results chan *githubResult) {
+//
+// If since is not zero, only the repositories updated after it are listed.
+func (s *GithubSource) listAffiliated(ctx context.Context, since time.Time, results chan *githubResult) {
 	s.paginate(ctx, results, func(page int) (repos []*github.Repository, hasNext bool, cost int, err error) {
 		defer func() {
 			remaining, reset, retry, _ := s.client.RateLimitMonitor().Get()
@@ -536,6 +678,9 @@ func (s *GithubSource) listAffiliated(ctx context.Context, results chan *githubR
 				"retryAfter", retry,
 			)
 		}()
+		if !since.IsZero() {
+			return s.client.ListAffiliatedRepositoriesSince(ctx, github.VisibilityAll, since, page)
+		}
 		return s.client.ListAffiliatedRepositories(ctx, github.VisibilityAll, page)
 	})
 }
@@ -609,13 +754,13 @@ func matchOrg(q string) string {
 // - `none`: disables `repositoryQuery`
 // Inputs other than these three keywords will be queried using
 // GitHub advanced repository search (endpoint: /search/repositories)
-func (s *GithubSource) listRepositoryQuery(ctx context.Context, query string, results chan *githubResult) {
+func (s *GithubSource) listRepositoryQuery(ctx context.Context, query string, since time.Time, results chan *githubResult) {
 	switch query {
 	case "public":
 		s.listPublic(ctx, results)
 		return
 	case "affiliated":
-		s.listAffiliated(ctx, results)
+		s.listAffiliated(ctx, since, results)
 		return
 	case "none":
 		// nothing
@@ -630,7 +775,7 @@ func (s *GithubSource) listRepositoryQuery(ctx context.Context, query string, re
 	// If the org repo list API fails, we
 	// try the user repo list API.
 	if org := matchOrg(query); org != "" {
-		s.listOrg(ctx, org, results)
+		s.listOrg(ctx, org, since, results)
 		return
 	}
 
@@ -641,17 +786,17 @@ func (s *GithubSource) listRepositoryQuery(ctx context.Context, query string, re
 
 // listAllRepositories returns the repositories from the given `orgs`, `repos`, and
 // `repositoryQuery` config options excluding the ones specified by `exclude`.
-func (s *GithubSource) listAllRepositories(ctx context.Context, results chan *githubResult) {
+func (s *GithubSource) listAllRepositories(ctx context.Context, since time.Time, results chan *githubResult) {
 	s.listRepos(ctx, s.config.Repos, results)
 
 	// Admins normally add to end of lists, so end of list most likely has new
 	// repos => stream them first.
 	for i := len(s.config.RepositoryQuery) - 1; i >= 0; i-- {
-		s.listRepositoryQuery(ctx, s.config.RepositoryQuery[i], results)
+		s.listRepositoryQuery(ctx, s.config.RepositoryQuery[i], since, results)
 	}
 
 	for i := len(s.config.Orgs) - 1; i >= 0; i-- {
-		s.listOrg(ctx, s.config.Orgs[i], results)
+		s.listOrg(ctx, s.config.Orgs[i], since, results)
 	}
 }
 
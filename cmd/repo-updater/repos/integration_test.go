@@ -54,8 +54,10 @@ func TestIntegration(t *testing.T) {
 		{"DBStore/UpsertRepos", testStoreUpsertRepos(store)},
 		{"DBStore/ListRepos", testStoreListRepos(store)},
 		{"DBStore/ListRepos/Pagination", testStoreListReposPagination(store)},
+		{"DBStore/UpsertExternalServiceSyncStates", testStoreUpsertExternalServiceSyncStates(store)},
 		{"DBStore/Syncer/Sync", testSyncerSync(store)},
 		{"DBStore/Syncer/SyncSubset", testSyncSubset(store)},
+		{"DBStore/Syncer/SyncExternalService", testSyncExternalService(store)},
 	} {
 		t.Run(tc.name, tc.test)
 	}
@@ -19,11 +19,15 @@ import (
 // with error logging, Prometheus metrics and tracing.
 func ObservedSource(l logging.ErrorLogger, m SourceMetrics) func(Source) Source {
 	return func(s Source) Source {
-		return &observedSource{
+		o := &observedSource{
 			Source:  s,
 			metrics: m,
 			log:     l,
 		}
+		if inc, ok := s.(IncrementalSource); ok {
+			return &observedIncrementalSource{observedSource: o, inner: inc}
+		}
+		return o
 	}
 }
 
@@ -63,6 +67,12 @@ func NewSourceMetrics() SourceMetrics {
 
 // ListRepos calls into the inner Source registers the observed results.
 func (o *observedSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	o.observe(results, func(uncounted chan SourceResult) {
+		o.Source.ListRepos(ctx, uncounted)
+	})
+}
+
+func (o *observedSource) observe(results chan SourceResult, list func(chan SourceResult)) {
 	var (
 		err   error
 		count float64
@@ -76,7 +86,7 @@ func (o *observedSource) ListRepos(ctx context.Context, results chan SourceResul
 
 	uncounted := make(chan SourceResult)
 	go func() {
-		o.Source.ListRepos(ctx, uncounted)
+		list(uncounted)
 		close(uncounted)
 	}()
 
@@ -90,6 +100,21 @@ func (o *observedSource) ListRepos(ctx context.Context, results chan SourceResul
 	}
 }
 
+// An observedIncrementalSource is an observedSource which wraps an
+// IncrementalSource.
+type observedIncrementalSource struct {
+	*observedSource
+	inner IncrementalSource
+}
+
+// ListReposSince calls into the inner IncrementalSource and registers the
+// observed results.
+func (o *observedIncrementalSource) ListReposSince(ctx context.Context, since time.Time, results chan SourceResult) {
+	o.observe(results, func(uncounted chan SourceResult) {
+		o.inner.ListReposSince(ctx, since, uncounted)
+	})
+}
+
 // NewObservedStore wraps the given Store with error logging,
 // Prometheus metrics and tracing.
 func NewObservedStore(
@@ -126,6 +151,9 @@ type StoreMetrics struct {
 	UpsertExternalServices *metrics.OperationMetrics
 	ListExternalServices   *metrics.OperationMetrics
 	ListAllRepoNames       *metrics.OperationMetrics
+
+	UpsertExternalServiceSyncStates *metrics.OperationMetrics
+	ListExternalServiceSyncStates   *metrics.OperationMetrics
 }
 
 // MustRegister registers all metrics in StoreMetrics in the given
@@ -139,6 +167,8 @@ func (sm StoreMetrics) MustRegister(r prometheus.Registerer) {
 		sm.ListExternalServices,
 		sm.UpsertExternalServices,
 		sm.ListAllRepoNames,
+		sm.ListExternalServiceSyncStates,
+		sm.UpsertExternalServiceSyncStates,
 	} {
 		r.MustRegister(om.Count)
 		r.MustRegister(om.Duration)
@@ -248,6 +278,34 @@ func NewStoreMetrics() StoreMetrics {
 				Help: "Total number of errors when listing repo names",
 			}, []string{}),
 		},
+		UpsertExternalServiceSyncStates: &metrics.OperationMetrics{
+			Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name: "src_repoupdater_store_upsert_external_service_sync_states_duration_seconds",
+				Help: "Time spent upserting external service sync states",
+			}, []string{}),
+			Count: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_upsert_external_service_sync_states_total",
+				Help: "Total number of upserted external service sync states",
+			}, []string{}),
+			Errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_upsert_external_service_sync_states_errors_total",
+				Help: "Total number of errors when upserting external service sync states",
+			}, []string{}),
+		},
+		ListExternalServiceSyncStates: &metrics.OperationMetrics{
+			Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name: "src_repoupdater_store_list_external_service_sync_states_duration_seconds",
+				Help: "Time spent listing external service sync states",
+			}, []string{}),
+			Count: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_list_external_service_sync_states_total",
+				Help: "Total number of listed external service sync states",
+			}, []string{}),
+			Errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_list_external_service_sync_states_errors_total",
+				Help: "Total number of errors when listing external service sync states",
+			}, []string{}),
+		},
 	}
 }
 
@@ -366,6 +424,48 @@ func (o *ObservedStore) UpsertExternalServices(ctx context.Context, svcs ...*Ext
 	return o.store.UpsertExternalServices(ctx, svcs...)
 }
 
+// ListExternalServiceSyncStates calls into the inner Store and registers the observed results.
+func (o *ObservedStore) ListExternalServiceSyncStates(ctx context.Context, args StoreListExternalServiceSyncStatesArgs) (states []*ExternalServiceSyncState, err error) {
+	tr, ctx := o.trace(ctx, "Store.ListExternalServiceSyncStates")
+	tr.LogFields(otlog.Object("args.external_service_ids", args.ExternalServiceIDs))
+
+	defer func(began time.Time) {
+		secs := time.Since(began).Seconds()
+		count := float64(len(states))
+
+		o.metrics.ListExternalServiceSyncStates.Observe(secs, count, &err)
+		logging.Log(o.log, "store.list-external-service-sync-states", &err,
+			"args", fmt.Sprintf("%+v", args),
+			"count", len(states),
+		)
+
+		tr.LogFields(otlog.Int("count", len(states)))
+		tr.SetError(err)
+		tr.Finish()
+	}(time.Now())
+
+	return o.store.ListExternalServiceSyncStates(ctx, args)
+}
+
+// UpsertExternalServiceSyncStates calls into the inner Store and registers the observed results.
+func (o *ObservedStore) UpsertExternalServiceSyncStates(ctx context.Context, states ...*ExternalServiceSyncState) (err error) {
+	tr, ctx := o.trace(ctx, "Store.UpsertExternalServiceSyncStates")
+	tr.LogFields(otlog.Int("count", len(states)))
+
+	defer func(began time.Time) {
+		secs := time.Since(began).Seconds()
+		count := float64(len(states))
+
+		o.metrics.UpsertExternalServiceSyncStates.Observe(secs, count, &err)
+		logging.Log(o.log, "store.upsert-external-service-sync-states", &err, "count", len(states))
+
+		tr.SetError(err)
+		tr.Finish()
+	}(time.Now())
+
+	return o.store.UpsertExternalServiceSyncStates(ctx, states...)
+}
+
 // ListRepos calls into the inner Store and registers the observed results.
 func (o *ObservedStore) ListRepos(ctx context.Context, args StoreListReposArgs) (rs []*Repo, err error) {
 	tr, ctx := o.trace(ctx, "Store.ListRepos")
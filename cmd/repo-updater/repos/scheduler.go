@@ -200,6 +200,11 @@ func (s *updateScheduler) runUpdateLoop(ctx context.Context) {
 					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
 					s.schedule.updateInterval(repo, interval)
 				}
+				if err == nil && conf.Get().SearchIndexCommits {
+					if err := notifyCommitIndex(ctx, repo); err != nil {
+						log15.Warn("error notifying the commit index of a repo update", "uri", repo.Name, "err", err)
+					}
+				}
 			}(ctx, repo, cancel)
 		}
 	}
@@ -210,6 +215,12 @@ var requestRepoUpdate = func(ctx context.Context, repo configuredRepo2, since ti
 	return gitserver.DefaultClient.RequestRepoUpdate(ctx, gitserver.Repo{Name: repo.Name, URL: repo.URL}, since)
 }
 
+// notifyCommitIndex notifies the frontend that the repository was updated,
+// so that it updates the repository's commit index.
+var notifyCommitIndex = func(ctx context.Context, repo configuredRepo2) error {
+	return api.InternalClient.ReposCommitIndexUpdate(ctx, repo.Name)
+}
+
 // configuredLimiter returns a mutable limiter that is
 // configured with the maximum number of concurrent update
 // requests that repo-updater should send to gitserver.
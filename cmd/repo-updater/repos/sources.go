@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
@@ -61,12 +63,16 @@ func NewSource(svc *ExternalService, cf *httpcli.Factory) (Source, error) {
 		return NewBitbucketServerSource(svc, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(svc, cf)
+	case extsvc.KindGerrit:
+		return NewGerritSource(svc, cf)
 	case extsvc.KindGitolite:
 		return NewGitoliteSource(svc, cf)
 	case extsvc.KindPhabricator:
 		return NewPhabricatorSource(svc, cf)
 	case extsvc.KindAWSCodeCommit:
 		return NewAWSCodeCommitSource(svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(svc, cf)
 	case extsvc.KindOther:
 		return NewOtherSource(svc, cf)
 	default:
@@ -98,6 +104,18 @@ type Source interface {
 	ExternalServices() ExternalServices
 }
 
+// An IncrementalSource is a Source that can list only the repositories that
+// were updated on the code host after a given time. Since deleted
+// repositories can't be detected this way, the Syncer still periodically
+// lists all repositories of an IncrementalSource.
+type IncrementalSource interface {
+	Source
+	// ListReposSince sends the repos a source yields that were updated after
+	// the given time over the passed in channel as SourceResults. If since is
+	// the zero time, all repos are sent.
+	ListReposSince(ctx context.Context, since time.Time, results chan SourceResult)
+}
+
 // A ChangesetSource can load the latest state of a list of Changesets.
 type ChangesetSource interface {
 	// LoadChangesets loads the given Changesets from the sources and updates
@@ -112,10 +130,71 @@ type ChangesetSource interface {
 	// means the appropriate final state on the codehost (e.g. "declined" on
 	// Bitbucket Server).
 	CloseChangeset(context.Context, *Changeset) error
+	// MergeChangeset merges the Changeset on the source with the given merge
+	// method and updates it. If the method is empty, the default merge method
+	// of the repository on the codehost is used.
+	MergeChangeset(context.Context, *Changeset, campaigns.ChangesetMergeMethod) error
 	// UpdateChangeset can update Changesets.
 	UpdateChangeset(context.Context, *Changeset) error
 }
 
+// A DraftChangesetSource is a ChangesetSource that can create Changesets as
+// drafts, which are only marked as ready for review later.
+type DraftChangesetSource interface {
+	ChangesetSource
+	// CreateDraftChangeset will create the Changeset on the source as a
+	// draft. If it already exists, *Changeset will be populated and the
+	// return value will be true.
+	CreateDraftChangeset(context.Context, *Changeset) (bool, error)
+	// UndraftChangeset marks the draft Changeset on the source as ready for
+	// review and updates it.
+	UndraftChangeset(context.Context, *Changeset) error
+}
+
+// changesetSources maps external service types to the ChangesetSources of
+// their code hosts.
+var changesetSources = map[string]ChangesetSource{
+	extsvc.TypeAzureDevOps:     AzureDevOpsSource{},
+	extsvc.TypeBitbucketServer: BitbucketServerSource{},
+	extsvc.TypeGitHub:          GithubSource{},
+}
+
+// SupportsDraftChangesets returns true if the ChangesetSource of code hosts of
+// the given external service type is a DraftChangesetSource.
+func SupportsDraftChangesets(serviceType string) bool {
+	_, ok := changesetSources[serviceType].(DraftChangesetSource)
+	return ok
+}
+
+// A ChangesetActionSource is a ChangesetSource that can run the bulk actions
+// of campaigns on Changesets.
+type ChangesetActionSource interface {
+	ChangesetSource
+	// CreateComment adds a comment with the given body to the Changeset on
+	// the source.
+	CreateComment(ctx context.Context, c *Changeset, body string) error
+	// ReopenChangeset reopens the closed Changeset on the source and updates
+	// it.
+	ReopenChangeset(context.Context, *Changeset) error
+	// AddLabels adds the labels with the given names to the Changeset on the
+	// source.
+	AddLabels(ctx context.Context, c *Changeset, labels []string) error
+	// RequestReviewers requests reviews of the Changeset on the source from
+	// the users with the given usernames.
+	RequestReviewers(ctx context.Context, c *Changeset, usernames []string) error
+}
+
+// A ChangesetSearchSource is a ChangesetSource that can search for existing
+// Changesets on the code host, so that they can be imported into campaigns.
+type ChangesetSearchSource interface {
+	ChangesetSource
+	// SearchChangesets returns the Changesets on the source matching the
+	// given code host specific query. Only the ExternalID and
+	// ExternalServiceType of the returned Changesets and the ExternalRepo of
+	// their Repos are set.
+	SearchChangesets(ctx context.Context, query string) ([]*Changeset, error)
+}
+
 // ChangesetsNotFoundError is returned by LoadChangesets if any of the passed
 // Changesets could not be found on the codehost.
 type ChangesetsNotFoundError struct {
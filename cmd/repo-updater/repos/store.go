@@ -14,8 +14,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gerrit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitolite"
@@ -26,6 +28,9 @@ type Store interface {
 	ListExternalServices(context.Context, StoreListExternalServicesArgs) ([]*ExternalService, error)
 	UpsertExternalServices(ctx context.Context, svcs ...*ExternalService) error
 
+	ListExternalServiceSyncStates(context.Context, StoreListExternalServiceSyncStatesArgs) ([]*ExternalServiceSyncState, error)
+	UpsertExternalServiceSyncStates(ctx context.Context, states ...*ExternalServiceSyncState) error
+
 	ListRepos(context.Context, StoreListReposArgs) ([]*Repo, error)
 	UpsertRepos(ctx context.Context, repos ...*Repo) error
 
@@ -43,6 +48,10 @@ type StoreListReposArgs struct {
 	Kinds []string
 	// ExternalRepos of repos to list. When zero-valued, this is omitted from the predicate set.
 	ExternalRepos []api.ExternalRepoSpec
+	// ExternalServiceID of the external service whose repos to list, i.e. the
+	// repos which have it as a source. When zero-valued, this is omitted from
+	// the predicate set.
+	ExternalServiceID int64
 	// Limit the total number of repos returned. Zero means no limit
 	Limit int64
 	// PerPage determines the number of repos returned on each page. Zero means it defaults to 10000.
@@ -65,6 +74,16 @@ type StoreListExternalServicesArgs struct {
 	RepoIDs []api.RepoID
 	// Kinds of external services to list. When zero-valued, this is omitted from the predicate set.
 	Kinds []string
+	// IncludeDeleted makes deleted external services be listed too.
+	IncludeDeleted bool
+}
+
+// StoreListExternalServiceSyncStatesArgs is a query arguments type used by
+// the ListExternalServiceSyncStates method of Store implementations.
+type StoreListExternalServiceSyncStatesArgs struct {
+	// ExternalServiceIDs of the sync states to list. When zero-valued, this is
+	// omitted from the predicate set.
+	ExternalServiceIDs []int64
 }
 
 // ErrNoResults is returned by Store method invocations that yield no result set.
@@ -217,7 +236,9 @@ func listExternalServicesQuery(args StoreListExternalServicesArgs) paginatedQuer
 			sqlf.Sprintf("LOWER(kind) != 'phabricator'"))
 	}
 
-	preds = append(preds, sqlf.Sprintf("deleted_at IS NULL"))
+	if !args.IncludeDeleted {
+		preds = append(preds, sqlf.Sprintf("deleted_at IS NULL"))
+	}
 
 	return func(cursor, limit int64) *sqlf.Query {
 		return sqlf.Sprintf(
@@ -299,6 +320,126 @@ SET
 RETURNING *
 `
 
+// ListExternalServiceSyncStates lists the sync states of the external
+// services matching the given args.
+func (s DBStore) ListExternalServiceSyncStates(ctx context.Context, args StoreListExternalServiceSyncStatesArgs) (states []*ExternalServiceSyncState, _ error) {
+	return states, s.paginate(ctx, 0, 500, listExternalServiceSyncStatesQuery(args),
+		func(sc scanner) (last, count int64, err error) {
+			var st ExternalServiceSyncState
+			if err = scanExternalServiceSyncState(&st, sc); err != nil {
+				return 0, 0, err
+			}
+			states = append(states, &st)
+			return st.ExternalServiceID, 1, nil
+		},
+	)
+}
+
+const listExternalServiceSyncStatesQueryFmtstr = `
+-- source: cmd/repo-updater/repos/store.go:DBStore.ListExternalServiceSyncStates
+SELECT
+  external_service_id,
+  last_sync_started_at,
+  last_sync_finished_at,
+  last_full_sync_at,
+  next_sync_at,
+  last_sync_error,
+  repos_sourced,
+  repos_added,
+  repos_modified,
+  repos_deleted
+FROM external_service_sync_states
+WHERE external_service_id > %s
+AND %s
+ORDER BY external_service_id ASC LIMIT %s
+`
+
+func listExternalServiceSyncStatesQuery(args StoreListExternalServiceSyncStatesArgs) paginatedQuery {
+	var preds []*sqlf.Query
+
+	if len(args.ExternalServiceIDs) > 0 {
+		ids := make([]*sqlf.Query, 0, len(args.ExternalServiceIDs))
+		for _, id := range args.ExternalServiceIDs {
+			ids = append(ids, sqlf.Sprintf("%d", id))
+		}
+		preds = append(preds, sqlf.Sprintf("external_service_id IN (%s)", sqlf.Join(ids, ",")))
+	} else {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return func(cursor, limit int64) *sqlf.Query {
+		return sqlf.Sprintf(
+			listExternalServiceSyncStatesQueryFmtstr,
+			cursor,
+			sqlf.Join(preds, "\n AND "),
+			limit,
+		)
+	}
+}
+
+// UpsertExternalServiceSyncStates updates or inserts the given sync states.
+func (s DBStore) UpsertExternalServiceSyncStates(ctx context.Context, states ...*ExternalServiceSyncState) error {
+	if len(states) == 0 {
+		return nil
+	}
+
+	vals := make([]*sqlf.Query, 0, len(states))
+	for _, st := range states {
+		vals = append(vals, sqlf.Sprintf(
+			upsertExternalServiceSyncStatesQueryValueFmtstr,
+			st.ExternalServiceID,
+			nullTimeColumn(st.LastSyncStartedAt.UTC()),
+			nullTimeColumn(st.LastSyncFinishedAt.UTC()),
+			nullTimeColumn(st.LastFullSyncAt.UTC()),
+			nullTimeColumn(st.NextSyncAt.UTC()),
+			st.LastSyncError,
+			st.ReposSourced,
+			st.ReposAdded,
+			st.ReposModified,
+			st.ReposDeleted,
+		))
+	}
+
+	q := sqlf.Sprintf(upsertExternalServiceSyncStatesQueryFmtstr, sqlf.Join(vals, ",\n"))
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+const upsertExternalServiceSyncStatesQueryValueFmtstr = `
+  (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+`
+
+const upsertExternalServiceSyncStatesQueryFmtstr = `
+-- source: cmd/repo-updater/repos/store.go:DBStore.UpsertExternalServiceSyncStates
+INSERT INTO external_service_sync_states (
+  external_service_id,
+  last_sync_started_at,
+  last_sync_finished_at,
+  last_full_sync_at,
+  next_sync_at,
+  last_sync_error,
+  repos_sourced,
+  repos_added,
+  repos_modified,
+  repos_deleted
+)
+VALUES %s
+ON CONFLICT(external_service_id) DO UPDATE
+SET
+  last_sync_started_at  = excluded.last_sync_started_at,
+  last_sync_finished_at = excluded.last_sync_finished_at,
+  last_full_sync_at     = excluded.last_full_sync_at,
+  next_sync_at          = excluded.next_sync_at,
+  last_sync_error       = excluded.last_sync_error,
+  repos_sourced         = excluded.repos_sourced,
+  repos_added           = excluded.repos_added,
+  repos_modified        = excluded.repos_modified,
+  repos_deleted         = excluded.repos_deleted
+`
+
 // ListRepos lists all stored repos that match the given arguments.
 func (s DBStore) ListRepos(ctx context.Context, args StoreListReposArgs) (repos []*Repo, _ error) {
 	return repos, s.paginate(ctx, args.Limit, args.PerPage, listReposQuery(args),
@@ -330,6 +471,11 @@ SELECT
   archived,
   fork,
   private,
+  topics,
+  stars,
+  pushed_at,
+  default_branch,
+  license,
   sources,
   metadata
 FROM repo
@@ -377,6 +523,13 @@ func listReposQuery(args StoreListReposArgs) paginatedQuery {
 		preds = append(preds, sqlf.Sprintf("(%s)", sqlf.Join(er, "\n OR ")))
 	}
 
+	if args.ExternalServiceID != 0 {
+		preds = append(preds, sqlf.Sprintf(
+			"EXISTS (SELECT 1 FROM jsonb_object_keys(sources) urn WHERE split_part(urn, ':', 3)::bigint = %s)",
+			args.ExternalServiceID,
+		))
+	}
+
 	if args.PrivateOnly {
 		preds = append(preds, sqlf.Sprintf("private = TRUE"))
 	}
@@ -576,6 +729,11 @@ func batchReposQuery(fmtstr string, repos []*Repo) (_ *sqlf.Query, err error) {
 		Archived            bool            `json:"archived"`
 		Fork                bool            `json:"fork"`
 		Private             bool            `json:"private"`
+		Topics              []string        `json:"topics"`
+		Stars               int             `json:"stars"`
+		PushedAt            *time.Time      `json:"pushed_at,omitempty"`
+		DefaultBranch       string          `json:"default_branch"`
+		License             string          `json:"license"`
 		Sources             json.RawMessage `json:"sources"`
 		Metadata            json.RawMessage `json:"metadata"`
 	}
@@ -607,6 +765,11 @@ func batchReposQuery(fmtstr string, repos []*Repo) (_ *sqlf.Query, err error) {
 			Archived:            r.Archived,
 			Fork:                r.Fork,
 			Private:             r.Private,
+			Topics:              topicsColumn(r.Topics),
+			Stars:               r.Stars,
+			PushedAt:            nullTimeColumn(r.PushedAt.UTC()),
+			DefaultBranch:       r.DefaultBranch,
+			License:             r.License,
 			Sources:             sources,
 			Metadata:            metadata,
 		})
@@ -651,6 +814,11 @@ WITH batch AS (
       archived              boolean,
       fork                  boolean,
       private               boolean,
+      topics                jsonb,
+      stars                 integer,
+      pushed_at             timestamptz,
+      default_branch        text,
+      license               text,
       sources               jsonb,
       metadata              jsonb
     )
@@ -674,6 +842,11 @@ SET
   archived              = batch.archived,
   fork                  = batch.fork,
   private               = batch.private,
+  topics                = batch.topics,
+  stars                 = batch.stars,
+  pushed_at             = batch.pushed_at,
+  default_branch        = batch.default_branch,
+  license               = batch.license,
   sources               = batch.sources,
   metadata              = batch.metadata
 FROM batch
@@ -711,6 +884,11 @@ INSERT INTO repo (
   archived,
   fork,
   private,
+  topics,
+  stars,
+  pushed_at,
+  default_branch,
+  license,
   sources,
   metadata
 )
@@ -728,6 +906,11 @@ SELECT
   archived,
   fork,
   private,
+  topics,
+  stars,
+  pushed_at,
+  default_branch,
+  license,
   sources,
   metadata
 FROM batch
@@ -754,6 +937,13 @@ func nullStringColumn(s string) *string {
 	return &s
 }
 
+func topicsColumn(topics []string) []string {
+	if topics == nil {
+		return []string{}
+	}
+	return topics
+}
+
 func metadataColumn(metadata interface{}) (msg json.RawMessage, err error) {
 	switch m := metadata.(type) {
 	case nil:
@@ -812,8 +1002,23 @@ func scanExternalService(svc *ExternalService, s scanner) error {
 	)
 }
 
+func scanExternalServiceSyncState(st *ExternalServiceSyncState, s scanner) error {
+	return s.Scan(
+		&st.ExternalServiceID,
+		&dbutil.NullTime{Time: &st.LastSyncStartedAt},
+		&dbutil.NullTime{Time: &st.LastSyncFinishedAt},
+		&dbutil.NullTime{Time: &st.LastFullSyncAt},
+		&dbutil.NullTime{Time: &st.NextSyncAt},
+		&st.LastSyncError,
+		&st.ReposSourced,
+		&st.ReposAdded,
+		&st.ReposModified,
+		&st.ReposDeleted,
+	)
+}
+
 func scanRepo(r *Repo, s scanner) error {
-	var sources, metadata json.RawMessage
+	var topics, sources, metadata json.RawMessage
 	err := s.Scan(
 		&r.ID,
 		&r.Name,
@@ -829,6 +1034,11 @@ func scanRepo(r *Repo, s scanner) error {
 		&r.Archived,
 		&r.Fork,
 		&r.Private,
+		&topics,
+		&r.Stars,
+		&dbutil.NullTime{Time: &r.PushedAt},
+		&r.DefaultBranch,
+		&r.License,
 		&sources,
 		&metadata,
 	)
@@ -836,6 +1046,13 @@ func scanRepo(r *Repo, s scanner) error {
 		return err
 	}
 
+	if err = json.Unmarshal(topics, &r.Topics); err != nil {
+		return errors.Wrap(err, "scanRepo: failed to unmarshal topics")
+	}
+	if len(r.Topics) == 0 {
+		r.Topics = nil
+	}
+
 	if err = json.Unmarshal(sources, &r.Sources); err != nil {
 		return errors.Wrap(err, "scanRepo: failed to unmarshal sources")
 	}
@@ -853,8 +1070,12 @@ func scanRepo(r *Repo, s scanner) error {
 		r.Metadata = new(bitbucketserver.Repo)
 	case extsvc.TypeBitbucketCloud:
 		r.Metadata = new(bitbucketcloud.Repo)
+	case extsvc.TypeGerrit:
+		r.Metadata = new(gerrit.Project)
 	case extsvc.TypeAWSCodeCommit:
 		r.Metadata = new(awscodecommit.Repository)
+	case extsvc.TypeAzureDevOps:
+		r.Metadata = new(azuredevops.Repository)
 	case extsvc.TypeGitolite:
 		r.Metadata = new(gitolite.Repo)
 	default:
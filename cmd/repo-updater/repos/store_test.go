@@ -41,6 +41,7 @@ func TestFakeStore(t *testing.T) {
 		{"ListRepos", testStoreListRepos},
 		{"ListRepos_Pagination", testStoreListReposPagination},
 		{"UpsertRepos", testStoreUpsertRepos},
+		{"UpsertExternalServiceSyncStates", testStoreUpsertExternalServiceSyncStates},
 	} {
 		t.Run(tc.name, tc.test(repos.NewObservedStore(
 			new(repos.FakeStore),
@@ -447,6 +448,99 @@ func testStoreUpsertExternalServices(store repos.Store) func(*testing.T) {
 	}
 }
 
+func testStoreUpsertExternalServiceSyncStates(store repos.Store) func(*testing.T) {
+	clock := repos.NewFakeClock(time.Now(), 0)
+	now := clock.Now()
+
+	return func(t *testing.T) {
+		t.Helper()
+
+		ctx := context.Background()
+
+		t.Run("no states", transact(ctx, store, func(t testing.TB, tx repos.Store) {
+			if err := tx.UpsertExternalServiceSyncStates(ctx); err != nil {
+				t.Fatalf("UpsertExternalServiceSyncStates error: %s", err)
+			}
+		}))
+
+		t.Run("many states", transact(ctx, store, func(t testing.TB, tx repos.Store) {
+			svcs := repos.ExternalServices{
+				{
+					Kind:        extsvc.KindGitHub,
+					DisplayName: "Github - Test",
+					Config:      `{"url": "https://github.com"}`,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				},
+				{
+					Kind:        extsvc.KindGitLab,
+					DisplayName: "GitLab - Test",
+					Config:      `{"url": "https://gitlab.com"}`,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				},
+			}
+
+			if err := tx.UpsertExternalServices(ctx, svcs...); err != nil {
+				t.Fatalf("UpsertExternalServices error: %s", err)
+			}
+
+			want := []*repos.ExternalServiceSyncState{
+				{
+					ExternalServiceID:  svcs[0].ID,
+					LastSyncStartedAt:  now,
+					LastSyncFinishedAt: now.Add(time.Minute),
+					LastFullSyncAt:     now,
+					NextSyncAt:         now.Add(time.Hour),
+					ReposSourced:       3,
+					ReposAdded:         2,
+					ReposModified:      1,
+				},
+				{
+					ExternalServiceID:  svcs[1].ID,
+					LastSyncStartedAt:  now,
+					LastSyncFinishedAt: now.Add(time.Minute),
+					NextSyncAt:         now.Add(time.Hour),
+					LastSyncError:      "boom",
+				},
+			}
+
+			if err := tx.UpsertExternalServiceSyncStates(ctx, want...); err != nil {
+				t.Fatalf("UpsertExternalServiceSyncStates error: %s", err)
+			}
+
+			have, err := tx.ListExternalServiceSyncStates(ctx, repos.StoreListExternalServiceSyncStatesArgs{})
+			if err != nil {
+				t.Fatalf("ListExternalServiceSyncStates error: %s", err)
+			}
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatalf("ListExternalServiceSyncStates:\n%s", diff)
+			}
+
+			// Update one state and list only that one.
+			want[1].LastSyncError = ""
+			want[1].LastFullSyncAt = now.Add(2 * time.Hour)
+			want[1].ReposSourced = 10
+
+			if err := tx.UpsertExternalServiceSyncStates(ctx, want[1]); err != nil {
+				t.Fatalf("UpsertExternalServiceSyncStates error: %s", err)
+			}
+
+			args := repos.StoreListExternalServiceSyncStatesArgs{
+				ExternalServiceIDs: []int64{svcs[1].ID},
+			}
+			if have, err = tx.ListExternalServiceSyncStates(ctx, args); err != nil {
+				t.Fatalf("ListExternalServiceSyncStates error: %s", err)
+			}
+
+			if diff := cmp.Diff(have, want[1:]); diff != "" {
+				t.Fatalf("ListExternalServiceSyncStates:\n%s", diff)
+			}
+		}))
+	}
+}
+
 func testStoreUpsertRepos(store repos.Store) func(*testing.T) {
 	clock := repos.NewFakeClock(time.Now(), 0)
 	now := clock.Now()
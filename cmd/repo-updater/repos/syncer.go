@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
 	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
@@ -25,8 +26,8 @@ type Syncer struct {
 	// sourced repositories into the store.
 	DisableStreaming bool
 
-	// FailFullSync prevents Sync from running. This should only be true for
-	// Sourcegraph.com
+	// FailFullSync prevents Sync and SyncExternalService from running. This
+	// should only be true for Sourcegraph.com
 	FailFullSync bool
 
 	// Synced is sent a collection of Repos that were synced by Sync (only if Synced is non-nil)
@@ -47,52 +48,165 @@ type Syncer struct {
 	lastSyncErr   error
 	lastSyncErrMu sync.Mutex
 
+	// svcSyncErrs contains the last error returned by SyncExternalService for
+	// each external service synced by Run. It's protected by lastSyncErrMu.
+	svcSyncErrs map[int64]error
+
+	// runningMu protects the fields below, which Run uses to keep track of
+	// the external services it's currently syncing.
+	runningMu sync.Mutex
+	running   map[int64]bool
+	resync    map[int64]bool
+	kindMus   map[string]*sync.Mutex
+
 	syncSignal signal
 }
 
-// Run runs the Sync at the specified interval.
-func (s *Syncer) Run(pctx context.Context, interval func() time.Duration) error {
-	for pctx.Err() == nil {
-		ctx, cancel := contextWithSignalCancel(pctx, s.syncSignal.Watch())
-
-		if err := s.Sync(ctx); err != nil && s.Logger != nil {
+// syncPollInterval is how often Run checks which external services are due
+// to be synced.
+const syncPollInterval = 10 * time.Second
+
+// fullSyncInterval is the maximum time between two syncs of an external
+// service that list all of its repositories. Only full syncs detect the
+// repositories that were deleted on the code host, so in between, the syncs of
+// IncrementalSources only list the repositories updated since the previous
+// sync.
+const fullSyncInterval = time.Hour
+
+// Run syncs the repositories of each external service independently, the
+// specified interval after its previous sync finished. This way, an external
+// service that is slow or failing doesn't delay the syncs of the others.
+// External services of the same kind are synced one at a time to comply with
+// code host rate limits.
+func (s *Syncer) Run(ctx context.Context, interval func() time.Duration) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	triggered := false
+	for ctx.Err() == nil {
+		if err := s.syncDue(ctx, &wg, interval(), triggered); err != nil && s.Logger != nil {
 			s.Logger.Error("Syncer", "error", err)
 		}
 
-		sleep(ctx, interval())
-
-		cancel()
+		triggered = false
+		select {
+		case <-ctx.Done():
+		case <-s.syncSignal.Watch():
+			triggered = true
+		case <-time.After(syncPollInterval):
+		}
 	}
 
-	return pctx.Err()
+	return ctx.Err()
 }
 
-// contextWithSignalCancel will return a context which will be cancelled if
-// signal fires. Callers need to call cancel when done.
-func contextWithSignalCancel(ctx context.Context, signal <-chan struct{}) (context.Context, context.CancelFunc) {
-	ctx, cancel := context.WithCancel(ctx)
+// syncDue starts a SyncExternalService goroutine for each external service
+// which is due to be synced and isn't currently being synced. If triggered
+// is true, all external services are due.
+func (s *Syncer) syncDue(ctx context.Context, wg *sync.WaitGroup, interval time.Duration, triggered bool) error {
+	svcs, err := s.Store.ListExternalServices(ctx, StoreListExternalServicesArgs{})
+	if err != nil {
+		return errors.Wrap(err, "syncer.sync-due.store.list-external-services")
+	}
+
+	states, err := s.Store.ListExternalServiceSyncStates(ctx, StoreListExternalServiceSyncStatesArgs{})
+	if err != nil {
+		return errors.Wrap(err, "syncer.sync-due.store.list-sync-states")
+	}
 
-	go func() {
-		select {
-		case <-ctx.Done():
-		case <-signal:
-			cancel()
+	stateBySvc := make(map[int64]*ExternalServiceSyncState, len(states))
+	for _, st := range states {
+		stateBySvc[st.ExternalServiceID] = st
+	}
+
+	// External services which were synced before but aren't listed anymore
+	// were deleted. They are synced one last time to remove them from the
+	// sources of their repositories, which are identified by the kind of the
+	// external service, so they are loaded with it.
+	listed := make(map[int64]bool, len(svcs))
+	for _, svc := range svcs {
+		listed[svc.ID] = true
+	}
+	var deletedIDs []int64
+	for _, st := range states {
+		if !listed[st.ExternalServiceID] && !st.NextSyncAt.IsZero() {
+			deletedIDs = append(deletedIDs, st.ExternalServiceID)
 		}
-	}()
+	}
+	if len(deletedIDs) > 0 {
+		deleted, err := s.Store.ListExternalServices(ctx, StoreListExternalServicesArgs{
+			IDs:            deletedIDs,
+			IncludeDeleted: true,
+		})
+		if err != nil {
+			return errors.Wrap(err, "syncer.sync-due.store.list-deleted-external-services")
+		}
+		for _, svc := range deleted {
+			if svc.IsDeleted() {
+				svcs = append(svcs, svc)
+			}
+		}
+	}
 
-	return ctx, cancel
-}
+	now := s.Now()
 
-// sleep is a context aware time.Sleep
-func sleep(ctx context.Context, d time.Duration) {
-	select {
-	case <-ctx.Done():
-	case <-time.After(d):
+	s.runningMu.Lock()
+	defer s.runningMu.Unlock()
+
+	if s.running == nil {
+		s.running = make(map[int64]bool)
+		s.resync = make(map[int64]bool)
+		s.kindMus = make(map[string]*sync.Mutex)
+	}
+
+	for _, svc := range svcs {
+		st := stateBySvc[svc.ID]
+		due := triggered || s.resync[svc.ID] || st == nil || !st.NextSyncAt.After(now)
+		if !due {
+			continue
+		}
+
+		if s.running[svc.ID] {
+			if triggered {
+				s.resync[svc.ID] = true
+			}
+			continue
+		}
+
+		kind := strings.ToLower(svc.Kind)
+		if s.kindMus[kind] == nil {
+			s.kindMus[kind] = &sync.Mutex{}
+		}
+		mu := s.kindMus[kind]
+
+		s.running[svc.ID] = true
+		delete(s.resync, svc.ID)
+
+		wg.Add(1)
+		go func(svc *ExternalService) {
+			defer wg.Done()
+
+			mu.Lock()
+			err := s.SyncExternalService(ctx, svc, interval)
+			mu.Unlock()
+
+			if err != nil && s.Logger != nil {
+				s.Logger.Error("Syncer", "external-service", svc.ID, "error", err)
+			}
+
+			s.setOrResetExternalServiceSyncErr(svc, err)
+
+			s.runningMu.Lock()
+			delete(s.running, svc.ID)
+			s.runningMu.Unlock()
+		}(svc)
 	}
+
+	return nil
 }
 
-// TriggerSync will run Sync now. If a sync is currently running it is
-// cancelled.
+// TriggerSync will sync all external services now. External services that
+// are currently being synced are synced again once their sync finishes.
 func (s *Syncer) TriggerSync() {
 	s.syncSignal.Trigger()
 }
@@ -153,6 +267,202 @@ func (s *Syncer) Sync(ctx context.Context) (err error) {
 	return nil
 }
 
+// SyncExternalService synchronizes the stored repositories with the ones
+// yielded by the given external service, leaving the sources of the stored
+// repositories that belong to other external services untouched. The next
+// sync of the external service is scheduled after the given interval.
+//
+// If the Source of the external service is an IncrementalSource and it was
+// fully synced less than fullSyncInterval ago, only the repositories updated
+// since the previous sync are listed. Otherwise, the external service is
+// removed from the sources of the stored repositories it didn't yield, and
+// repositories left without sources are deleted.
+//
+// If the external service is deleted, it's removed from the sources of all
+// stored repositories.
+func (s *Syncer) SyncExternalService(ctx context.Context, svc *ExternalService, interval time.Duration) (err error) {
+	var diff Diff
+
+	ctx, save := s.observe(ctx, "Syncer.SyncExternalService", svc.DisplayName)
+	defer save(&diff, &err)
+
+	if s.FailFullSync {
+		return errors.New("Syncer is not enabled")
+	}
+
+	states, err := s.Store.ListExternalServiceSyncStates(ctx, StoreListExternalServiceSyncStatesArgs{
+		ExternalServiceIDs: []int64{svc.ID},
+	})
+	if err != nil {
+		return errors.Wrap(err, "syncer.sync-external-service.store.list-sync-states")
+	}
+
+	state := &ExternalServiceSyncState{ExternalServiceID: svc.ID}
+	if len(states) > 0 {
+		state = states[0]
+	}
+
+	prev := state.Clone()
+	began := s.Now()
+	state.LastSyncStartedAt = began
+
+	var sourced Repos
+	var streamed int
+	full := true
+
+	defer func() {
+		state.LastSyncFinishedAt = s.Now()
+		state.NextSyncAt = state.LastSyncFinishedAt.Add(interval)
+		if svc.IsDeleted() && err == nil {
+			state.NextSyncAt = time.Time{}
+		}
+
+		state.LastSyncError = ""
+		state.ReposSourced, state.ReposAdded, state.ReposModified, state.ReposDeleted = 0, 0, 0, 0
+		if err != nil {
+			state.LastSyncError = err.Error()
+		} else {
+			state.ReposSourced = len(sourced)
+			state.ReposAdded = len(diff.Added) + streamed
+			state.ReposModified = len(diff.Modified)
+			state.ReposDeleted = len(diff.Deleted)
+			if full {
+				state.LastFullSyncAt = began
+			}
+		}
+
+		if uerr := s.Store.UpsertExternalServiceSyncStates(ctx, state); uerr != nil && err == nil {
+			err = errors.Wrap(uerr, "syncer.sync-external-service.store.upsert-sync-states")
+		}
+	}()
+
+	srcs, err := s.Sourcer(svc)
+	if err != nil {
+		return err
+	}
+
+	// Only list the repositories updated since the previous sync if it
+	// succeeded, the external service config didn't change since and the
+	// last full sync is recent enough.
+	incremental := !prev.LastSyncStartedAt.IsZero() &&
+		prev.LastSyncError == "" &&
+		svc.UpdatedAt.Before(prev.LastSyncStartedAt) &&
+		began.Sub(prev.LastFullSyncAt) < fullSyncInterval
+
+	for i, src := range srcs {
+		if inc, ok := src.(IncrementalSource); ok && incremental {
+			srcs[i] = sinceSource{IncrementalSource: inc, since: prev.LastSyncStartedAt}
+			full = false
+		}
+	}
+
+	var observe []func(*Repo)
+	if len(states) == 0 && !s.DisableStreaming {
+		// Stream in the repositories of newly added external services, so
+		// that they become available before the sync finishes.
+		streamingInserter, err := s.makeNewRepoInserter(ctx, func(*Repo) { streamed++ })
+		if err != nil {
+			return errors.Wrap(err, "syncer.sync-external-service.streaming")
+		}
+		observe = append(observe, streamingInserter)
+	}
+
+	if sourced, err = listAll(ctx, srcs, observe...); err != nil {
+		return errors.Wrap(err, "syncer.sync-external-service.sourced")
+	}
+
+	store := s.Store
+	if tr, ok := s.Store.(Transactor); ok {
+		var txs TxStore
+		if txs, err = tr.Transact(ctx); err != nil {
+			return errors.Wrap(err, "syncer.sync-external-service.transact")
+		}
+		defer txs.Done(&err)
+		store = txs
+	}
+
+	// Besides the stored repositories of the external service, we list the
+	// ones which are related to the sourced repositories to merge their
+	// sources and to resolve name conflicts like Sync does.
+	var stored Repos
+	args := StoreListReposArgs{ExternalServiceID: svc.ID}
+	if len(sourced) > 0 {
+		args.Names = sourced.Names()
+		args.ExternalRepos = sourced.ExternalRepos()
+		args.UseOr = true
+	}
+	if stored, err = store.ListRepos(ctx, args); err != nil {
+		return errors.Wrap(err, "syncer.sync-external-service.store.list-repos")
+	}
+
+	diff = NewDiff(externalServiceSourced(svc, full, sourced, stored), stored)
+	upserts := s.upserts(diff)
+
+	if err = store.UpsertRepos(ctx, upserts...); err != nil {
+		return errors.Wrap(err, "syncer.sync-external-service.store.upsert-repos")
+	}
+
+	if s.Synced != nil {
+		s.Synced <- diff
+	}
+
+	return nil
+}
+
+// externalServiceSourced returns what the set of sourced repositories of all
+// external services would be, given the repositories sourced from the given
+// external service and the related stored repositories, so that the result
+// can be diffed against the stored repositories.
+func externalServiceSourced(svc *ExternalService, full bool, sourced, stored Repos) Repos {
+	urn := svc.URN()
+
+	byID := make(map[api.ExternalRepoSpec]*Repo, len(sourced))
+	for _, r := range sourced {
+		byID[r.ExternalRepo] = r
+	}
+
+	all := make(Repos, 0, len(sourced)+len(stored))
+	for _, old := range stored {
+		r := old.Clone()
+		if src, ok := byID[old.ExternalRepo]; ok {
+			delete(byID, old.ExternalRepo)
+			sources := r.Sources
+			if sources == nil {
+				sources = make(map[string]*SourceInfo, len(src.Sources))
+			}
+			r = src.Clone()
+			r.Sources = sources
+			delete(r.Sources, urn)
+			for k, info := range src.Sources {
+				r.Sources[k] = info
+			}
+		} else if full {
+			delete(r.Sources, urn)
+		}
+
+		if len(r.Sources) > 0 {
+			all = append(all, r)
+		}
+	}
+
+	for _, r := range byID {
+		all = append(all, r)
+	}
+
+	return all
+}
+
+// sinceSource adapts an IncrementalSource to a Source which only lists the
+// repositories updated after since.
+type sinceSource struct {
+	IncrementalSource
+	since time.Time
+}
+
+func (s sinceSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	s.ListReposSince(ctx, s.since, results)
+}
+
 // SyncSubset runs the syncer on a subset of the stored repositories. It will
 // only sync the repositories with the same name or external service spec as
 // sourcedSubset repositories.
@@ -172,14 +482,11 @@ func (s *Syncer) SyncSubset(ctx context.Context, sourcedSubset ...*Repo) (err er
 
 // insertIfNew is a specialization of SyncSubset. It will insert sourcedRepo
 // if there are no related repositories, otherwise does nothing.
-func (s *Syncer) insertIfNew(ctx context.Context, sourcedRepo *Repo) (err error) {
-	var diff Diff
-
+func (s *Syncer) insertIfNew(ctx context.Context, sourcedRepo *Repo) (diff Diff, err error) {
 	ctx, save := s.observe(ctx, "Syncer.InsertIfNew", sourcedRepo.Name)
 	defer save(&diff, &err)
 
-	diff, err = s.syncSubset(ctx, true, sourcedRepo)
-	return err
+	return s.syncSubset(ctx, true, sourcedRepo)
 }
 
 func (s *Syncer) syncSubset(ctx context.Context, insertOnly bool, sourcedSubset ...*Repo) (diff Diff, err error) {
@@ -365,7 +672,10 @@ func (s *Syncer) sourced(ctx context.Context, observe ...func(*Repo)) ([]*Repo,
 	return listAll(ctx, srcs, observe...)
 }
 
-func (s *Syncer) makeNewRepoInserter(ctx context.Context) (func(*Repo), error) {
+// makeNewRepoInserter returns a function which inserts the given repository
+// if it's new. The given onInsert functions are called with each inserted
+// repository.
+func (s *Syncer) makeNewRepoInserter(ctx context.Context, onInsert ...func(*Repo)) (func(*Repo), error) {
 	// syncSubset requires querying the store for related repositories, and
 	// will do nothing if `insertOnly` is set and there are any related repositories. Most
 	// repositories will already have related repos, so to avoid that cost we
@@ -382,11 +692,17 @@ func (s *Syncer) makeNewRepoInserter(ctx context.Context) (func(*Repo), error) {
 			return
 		}
 
-		err := s.insertIfNew(ctx, r)
+		diff, err := s.insertIfNew(ctx, r)
 		if err != nil && s.Logger != nil {
 			// Best-effort, final syncer will handle this repo if this failed.
 			s.Logger.Warn("streaming insert failed", "external_id", r.ExternalRepo, "error", err)
 		}
+
+		for _, added := range diff.Added {
+			for _, f := range onInsert {
+				f(added)
+			}
+		}
 	}, nil
 }
 
@@ -413,8 +729,44 @@ func (s *Syncer) setOrResetLastSyncErr(perr *error) {
 	s.lastSyncErrMu.Unlock()
 }
 
-// LastSyncError returns the error that was produced in the last Sync run. If
-// no error was produced, this returns nil.
+// setOrResetExternalServiceSyncErr records the error returned by the last
+// SyncExternalService of the given external service, and sets the last sync
+// error to the combination of the errors of all external services.
+func (s *Syncer) setOrResetExternalServiceSyncErr(svc *ExternalService, err error) {
+	s.lastSyncErrMu.Lock()
+	defer s.lastSyncErrMu.Unlock()
+
+	if s.svcSyncErrs == nil {
+		s.svcSyncErrs = make(map[int64]error)
+	}
+
+	if err == nil || svc.IsDeleted() {
+		delete(s.svcSyncErrs, svc.ID)
+	} else {
+		s.svcSyncErrs[svc.ID] = err
+	}
+
+	ids := make([]int64, 0, len(s.svcSyncErrs))
+	for id := range s.svcSyncErrs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var errs *multierror.Error
+	for _, id := range ids {
+		err := s.svcSyncErrs[id]
+		if _, ok := errors.Cause(err).(*multierror.Error); !ok {
+			err = &SourceError{Err: err, ExtSvc: &ExternalService{ID: id}}
+		}
+		errs = multierror.Append(errs, errors.Cause(err))
+	}
+
+	s.lastSyncErr = errs.ErrorOrNil()
+}
+
+// LastSyncError returns the error that was produced in the last Sync run, or
+// the errors produced by the last sync of each external service when run by
+// Run. If no error was produced, this returns nil.
 func (s *Syncer) LastSyncError() error {
 	s.lastSyncErrMu.Lock()
 	defer s.lastSyncErrMu.Unlock()
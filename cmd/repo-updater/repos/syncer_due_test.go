@@ -0,0 +1,95 @@
+package repos
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestSyncer_syncDue_deletedExternalService(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	store := new(FakeStore)
+	deleted := &ExternalService{Kind: extsvc.KindGitHub, DisplayName: "Deleted", Config: `{}`}
+	other := &ExternalService{Kind: extsvc.KindGitHub, DisplayName: "Other", Config: `{}`}
+	if err := store.UpsertExternalServices(ctx, deleted, other); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := (&Repo{
+		Name:     "github.com/org/a",
+		Metadata: &github.Repository{},
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "a-external-id",
+			ServiceID:   "https://github.com/",
+			ServiceType: extsvc.TypeGitHub,
+		},
+	}).With(Opt.RepoSources(deleted.URN(), other.URN()))
+	if err := store.UpsertRepos(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	// The deleted external service was synced before, and its next sync is
+	// due.
+	if err := store.UpsertExternalServiceSyncStates(ctx, &ExternalServiceSyncState{
+		ExternalServiceID: deleted.ID,
+		NextSyncAt:        now.Add(-time.Minute),
+	}, &ExternalServiceSyncState{
+		ExternalServiceID: other.ID,
+		NextSyncAt:        now.Add(time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted.DeletedAt = now
+	if err := store.UpsertExternalServices(ctx, deleted); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := NewFakeClock(now, time.Second)
+	syncer := &Syncer{
+		Store:   store,
+		Sourcer: NewFakeSourcer(nil),
+		Now:     clock.Now,
+	}
+
+	var wg sync.WaitGroup
+	if err := syncer.syncDue(ctx, &wg, time.Hour, false); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+
+	stored, err := store.ListRepos(ctx, StoreListReposArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 {
+		t.Fatalf("have %d repos, want 1", len(stored))
+	}
+	var urns []string
+	for urn := range stored[0].Sources {
+		urns = append(urns, urn)
+	}
+	sort.Strings(urns)
+	if diff := cmp.Diff([]string{other.URN()}, urns); diff != "" {
+		t.Errorf("repo sources mismatch (-want +have):\n%s", diff)
+	}
+
+	states, err := store.ListExternalServiceSyncStates(ctx, StoreListExternalServiceSyncStatesArgs{
+		ExternalServiceIDs: []int64{deleted.ID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 1 || states[0].LastSyncError != "" || !states[0].NextSyncAt.IsZero() {
+		t.Errorf("have sync states %+v, want one without error and next sync", states)
+	}
+}
@@ -712,6 +712,311 @@ func testSyncSubset(s repos.Store) func(*testing.T) {
 	}
 }
 
+func TestSyncer_SyncExternalService(t *testing.T) {
+	t.Parallel()
+
+	testSyncExternalService(new(repos.FakeStore))(t)
+}
+
+func testSyncExternalService(s repos.Store) func(*testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	repo := func(name string, svcs ...*repos.ExternalService) *repos.Repo {
+		urns := make([]string, 0, len(svcs))
+		for _, svc := range svcs {
+			urns = append(urns, svc.URN())
+		}
+		return (&repos.Repo{
+			Name:     "github.com/org/" + name,
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          name + "-external-id",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+		}).With(repos.Opt.RepoSources(urns...))
+	}
+
+	// summary returns the names of the given repos with their sorted source
+	// URNs and description, which is what these tests care about.
+	summary := func(rs repos.Repos) []string {
+		lines := make([]string, 0, len(rs))
+		for _, r := range rs {
+			urns := make([]string, 0, len(r.Sources))
+			for urn := range r.Sources {
+				urns = append(urns, urn)
+			}
+			sort.Strings(urns)
+			lines = append(lines, fmt.Sprintf("%s %v %q", r.Name, urns, r.Description))
+		}
+		sort.Strings(lines)
+		return lines
+	}
+
+	type testCase struct {
+		name string
+		// deleted makes the synced external service deleted.
+		deleted bool
+		// incremental makes the source of the synced external service an
+		// IncrementalSource.
+		incremental bool
+		// state is the sync state of the synced external service before the
+		// sync, if any.
+		state *repos.ExternalServiceSyncState
+		// stored returns the stored repos, given the synced external
+		// service and another one.
+		stored func(svc, other *repos.ExternalService) repos.Repos
+		// sourced returns the repos yielded by the synced external service.
+		sourced func(svc *repos.ExternalService) repos.Repos
+		err     string
+		want    func(svc, other *repos.ExternalService) repos.Repos
+		// wantState contains the expected repo counts and error of the sync
+		// state after the sync.
+		wantState repos.ExternalServiceSyncState
+		wantFull  bool
+	}
+
+	recentFullSync := &repos.ExternalServiceSyncState{
+		LastSyncStartedAt:  now.Add(-time.Minute),
+		LastSyncFinishedAt: now.Add(-time.Minute),
+		LastFullSyncAt:     now.Add(-time.Minute),
+	}
+
+	testCases := []testCase{
+		{
+			name: "new external service adds its repos",
+			sourced: func(svc *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a"), repo("b")}
+			},
+			want: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc), repo("b", svc)}
+			},
+			wantState: repos.ExternalServiceSyncState{ReposSourced: 2, ReposAdded: 2},
+			wantFull:  true,
+		},
+		{
+			name: "sources of other external services are kept",
+			stored: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", other), repo("c", other)}
+			},
+			sourced: func(svc *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a")}
+			},
+			want: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc, other), repo("c", other)}
+			},
+			wantState: repos.ExternalServiceSyncState{ReposSourced: 1, ReposModified: 1},
+			wantFull:  true,
+		},
+		{
+			name: "full sync removes external service from repos it didn't yield",
+			stored: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc, other), repo("b", svc)}
+			},
+			sourced: func(svc *repos.ExternalService) repos.Repos {
+				return repos.Repos{}
+			},
+			want: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", other)}
+			},
+			wantState: repos.ExternalServiceSyncState{ReposModified: 1, ReposDeleted: 1},
+			wantFull:  true,
+		},
+		{
+			name:        "incremental sync only lists updated repos",
+			incremental: true,
+			state:       recentFullSync,
+			stored: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc), repo("b", svc)}
+			},
+			sourced: func(svc *repos.ExternalService) repos.Repos {
+				return repos.Repos{
+					repo("a").With(repos.Opt.RepoModifiedAt(now.Add(-time.Hour))),
+					repo("b").With(
+						repos.Opt.RepoModifiedAt(now),
+						func(r *repos.Repo) { r.Description = "updated" },
+					),
+				}
+			},
+			want: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{
+					repo("a", svc),
+					repo("b", svc).With(func(r *repos.Repo) { r.Description = "updated" }),
+				}
+			},
+			wantState: repos.ExternalServiceSyncState{ReposSourced: 1, ReposModified: 1},
+			wantFull:  false,
+		},
+		{
+			name:        "incremental source is fully synced after a failed sync",
+			incremental: true,
+			state: &repos.ExternalServiceSyncState{
+				LastSyncStartedAt:  now.Add(-time.Minute),
+				LastSyncFinishedAt: now.Add(-time.Minute),
+				LastFullSyncAt:     now.Add(-2 * time.Minute),
+				LastSyncError:      "boom",
+			},
+			stored: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc), repo("b", svc)}
+			},
+			sourced: func(svc *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a").With(repos.Opt.RepoModifiedAt(now.Add(-time.Hour)))}
+			},
+			want: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc)}
+			},
+			wantState: repos.ExternalServiceSyncState{ReposSourced: 1, ReposDeleted: 1},
+			wantFull:  true,
+		},
+		{
+			name:    "deleted external service is removed from all repos",
+			deleted: true,
+			state:   recentFullSync,
+			stored: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc, other), repo("b", svc)}
+			},
+			want: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", other)}
+			},
+			wantState: repos.ExternalServiceSyncState{ReposModified: 1, ReposDeleted: 1},
+			wantFull:  true,
+		},
+		{
+			name: "source error aborts sync and is recorded",
+			stored: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc)}
+			},
+			err: "syncer.sync-external-service.sourced: 1 error occurred:\n\t* boom\n\n",
+			want: func(svc, other *repos.ExternalService) repos.Repos {
+				return repos.Repos{repo("a", svc)}
+			},
+			wantState: repos.ExternalServiceSyncState{
+				LastSyncError: "syncer.sync-external-service.sourced: 1 error occurred:\n\t* boom\n\n",
+			},
+		},
+	}
+
+	return func(t *testing.T) {
+		t.Helper()
+
+		for _, tc := range testCases {
+			tc := tc
+			ctx := context.Background()
+
+			t.Run(tc.name, transact(ctx, s, func(t testing.TB, st repos.Store) {
+				svc := &repos.ExternalService{
+					Kind:        extsvc.KindGitHub,
+					DisplayName: "Synced",
+					Config:      `{}`,
+					CreatedAt:   now.Add(-time.Hour),
+					UpdatedAt:   now.Add(-time.Hour),
+				}
+				other := &repos.ExternalService{
+					Kind:        extsvc.KindGitHub,
+					DisplayName: "Other",
+					Config:      `{}`,
+					CreatedAt:   now.Add(-time.Hour),
+					UpdatedAt:   now.Add(-time.Hour),
+				}
+				if err := st.UpsertExternalServices(ctx, svc, other); err != nil {
+					t.Fatalf("failed to prepare store: %v", err)
+				}
+
+				if tc.stored != nil {
+					if err := st.UpsertRepos(ctx, tc.stored(svc, other)...); err != nil {
+						t.Fatalf("failed to prepare store: %v", err)
+					}
+				}
+
+				if tc.state != nil {
+					state := tc.state.Clone()
+					state.ExternalServiceID = svc.ID
+					if err := st.UpsertExternalServiceSyncStates(ctx, state); err != nil {
+						t.Fatalf("failed to prepare store: %v", err)
+					}
+				}
+
+				if tc.deleted {
+					svc.DeletedAt = now
+				}
+
+				var sourced repos.Repos
+				if tc.sourced != nil {
+					sourced = tc.sourced(svc)
+				}
+
+				var src repos.Source
+				switch {
+				case tc.sourced == nil && !tc.deleted:
+					src = repos.NewFakeSource(svc, errors.New("boom"))
+				case tc.incremental:
+					src = repos.NewFakeIncrementalSource(svc, nil, sourced...)
+				default:
+					src = repos.NewFakeSource(svc, nil, sourced...)
+				}
+
+				sourcer := repos.NewFakeSourcer(nil, src)
+				if tc.deleted {
+					sourcer = repos.NewFakeSourcer(nil)
+				}
+
+				clock := repos.NewFakeClock(now, time.Second)
+				syncer := &repos.Syncer{
+					Store:   st,
+					Sourcer: sourcer,
+					Now:     clock.Now,
+				}
+
+				err := syncer.SyncExternalService(ctx, svc, time.Hour)
+				if have, want := fmt.Sprint(err), tc.err; tc.err != "" && have != want {
+					t.Errorf("have error %q, want %q", have, want)
+				} else if tc.err == "" && err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				have, err := st.ListRepos(ctx, repos.StoreListReposArgs{})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(summary(tc.want(svc, other)), summary(have)); diff != "" {
+					t.Errorf("stored repos mismatch (-want +have):\n%s", diff)
+				}
+
+				states, err := st.ListExternalServiceSyncStates(ctx, repos.StoreListExternalServiceSyncStatesArgs{
+					ExternalServiceIDs: []int64{svc.ID},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(states) != 1 {
+					t.Fatalf("have %d sync states, want 1", len(states))
+				}
+
+				state := states[0]
+				if have, want := state.LastSyncError, tc.wantState.LastSyncError; have != want {
+					t.Errorf("have sync error %q, want %q", have, want)
+				}
+
+				counts := func(st *repos.ExternalServiceSyncState) [4]int {
+					return [4]int{st.ReposSourced, st.ReposAdded, st.ReposModified, st.ReposDeleted}
+				}
+				if have, want := counts(state), counts(&tc.wantState); have != want {
+					t.Errorf("have sourced, added, modified, deleted counts %v, want %v", have, want)
+				}
+
+				if have, want := state.LastFullSyncAt.Equal(state.LastSyncStartedAt), tc.wantFull; have != want {
+					t.Errorf("have full sync %t, want %t", have, want)
+				}
+
+				if have, want := state.NextSyncAt.IsZero(), tc.deleted; have != want {
+					t.Errorf("have no next sync %t, want %t", have, want)
+				}
+			}))
+		}
+	}
+}
+
 func TestDiff(t *testing.T) {
 	t.Parallel()
 
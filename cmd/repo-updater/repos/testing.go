@@ -65,6 +65,34 @@ func (s FakeSource) ExternalServices() ExternalServices {
 	return ExternalServices{s.svc}
 }
 
+// FakeIncrementalSource is a FakeSource which implements IncrementalSource.
+// Its ListReposSince only yields the repos updated after the given time.
+type FakeIncrementalSource struct {
+	FakeSource
+}
+
+// NewFakeIncrementalSource returns an instance of FakeIncrementalSource with
+// the given urn, error and repos.
+func NewFakeIncrementalSource(svc *ExternalService, err error, rs ...*Repo) *FakeIncrementalSource {
+	return &FakeIncrementalSource{FakeSource: FakeSource{svc: svc, err: err, repos: rs}}
+}
+
+// ListReposSince returns the Repos that FakeIncrementalSource was
+// instantiated with whose UpdatedAt is after since, as well as the error, if
+// any.
+func (s FakeIncrementalSource) ListReposSince(ctx context.Context, since time.Time, results chan SourceResult) {
+	if s.err != nil {
+		results <- SourceResult{Source: s, Err: s.err}
+		return
+	}
+
+	for _, r := range s.repos {
+		if r.UpdatedAt.After(since) {
+			results <- SourceResult{Source: s, Repo: r.With(Opt.RepoSources(s.svc.URN()))}
+		}
+	}
+}
+
 // FakeStore is a fake implementation of Store to be used in tests.
 type FakeStore struct {
 	ListExternalServicesError   error // error to be returned in ListExternalServices
@@ -74,11 +102,14 @@ type FakeStore struct {
 	UpsertReposError            error // error to be returned in UpsertRepos
 	ListAllRepoNamesError       error // error to be returned in ListAllRepoNames
 
-	svcIDSeq  int64
-	repoIDSeq api.RepoID
-	svcByID   map[int64]*ExternalService
-	repoByID  map[api.RepoID]*Repo
-	parent    *FakeStore
+	UpsertExternalServiceSyncStatesError error // error to be returned in UpsertExternalServiceSyncStates
+
+	svcIDSeq   int64
+	repoIDSeq  api.RepoID
+	svcByID    map[int64]*ExternalService
+	repoByID   map[api.RepoID]*Repo
+	stateBySvc map[int64]*ExternalServiceSyncState
+	parent     *FakeStore
 }
 
 // Transact returns a TxStore whose methods operate within the context of a transaction.
@@ -98,6 +129,11 @@ func (s *FakeStore) Transact(ctx context.Context) (TxStore, error) {
 		repoByID[r.ID] = clone
 	}
 
+	stateBySvc := make(map[int64]*ExternalServiceSyncState, len(s.stateBySvc))
+	for id, st := range s.stateBySvc {
+		stateBySvc[id] = st.Clone()
+	}
+
 	return &FakeStore{
 		ListExternalServicesError:   s.ListExternalServicesError,
 		UpsertExternalServicesError: s.UpsertExternalServicesError,
@@ -106,11 +142,14 @@ func (s *FakeStore) Transact(ctx context.Context) (TxStore, error) {
 		UpsertReposError:            s.UpsertReposError,
 		ListAllRepoNamesError:       s.ListAllRepoNamesError,
 
-		svcIDSeq:  s.svcIDSeq,
-		svcByID:   svcByID,
-		repoIDSeq: s.repoIDSeq,
-		repoByID:  repoByID,
-		parent:    s,
+		UpsertExternalServiceSyncStatesError: s.UpsertExternalServiceSyncStatesError,
+
+		svcIDSeq:   s.svcIDSeq,
+		svcByID:    svcByID,
+		repoIDSeq:  s.repoIDSeq,
+		repoByID:   repoByID,
+		stateBySvc: stateBySvc,
+		parent:     s,
 	}, nil
 }
 
@@ -155,7 +194,7 @@ func (s FakeStore) ListExternalServices(ctx context.Context, args StoreListExter
 		if !set[svc] &&
 			((len(kinds) == 0 && k != extsvc.TypePhabricator) || kinds[k]) &&
 			(len(ids) == 0 || ids[svc.ID]) &&
-			!svc.IsDeleted() {
+			(args.IncludeDeleted || !svc.IsDeleted()) {
 
 			svcs = append(svcs, svc)
 			set[svc] = true
@@ -235,6 +274,15 @@ func (s FakeStore) ListRepos(ctx context.Context, args StoreListReposArgs) ([]*R
 		externalRepos[spec] = true
 	}
 
+	hasSource := func(r *Repo, svcID int64) bool {
+		for _, id := range r.ExternalServiceIDs() {
+			if id == svcID {
+				return true
+			}
+		}
+		return false
+	}
+
 	set := make(map[*Repo]bool, len(s.repoByID))
 	repos := make(Repos, 0, len(s.repoByID))
 	for _, r := range s.repoByID {
@@ -259,6 +307,9 @@ func (s FakeStore) ListRepos(ctx context.Context, args StoreListReposArgs) ([]*R
 		if len(externalRepos) > 0 {
 			preds = append(preds, externalRepos[r.ExternalRepo])
 		}
+		if args.ExternalServiceID != 0 {
+			preds = append(preds, hasSource(r, args.ExternalServiceID))
+		}
 		if args.PrivateOnly {
 			preds = append(preds, r.Private)
 		}
@@ -295,6 +346,44 @@ func (s FakeStore) ListAllRepoNames(ctx context.Context) ([]api.RepoName, error)
 	return names, nil
 }
 
+// ListExternalServiceSyncStates lists the sync states in the store matching the given args.
+func (s FakeStore) ListExternalServiceSyncStates(ctx context.Context, args StoreListExternalServiceSyncStatesArgs) ([]*ExternalServiceSyncState, error) {
+	ids := make(map[int64]bool, len(args.ExternalServiceIDs))
+	for _, id := range args.ExternalServiceIDs {
+		ids[id] = true
+	}
+
+	states := make([]*ExternalServiceSyncState, 0, len(s.stateBySvc))
+	for id, st := range s.stateBySvc {
+		if len(ids) == 0 || ids[id] {
+			states = append(states, st.Clone())
+		}
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].ExternalServiceID < states[j].ExternalServiceID
+	})
+
+	return states, nil
+}
+
+// UpsertExternalServiceSyncStates updates or inserts the given sync states.
+func (s *FakeStore) UpsertExternalServiceSyncStates(ctx context.Context, states ...*ExternalServiceSyncState) error {
+	if s.UpsertExternalServiceSyncStatesError != nil {
+		return s.UpsertExternalServiceSyncStatesError
+	}
+
+	if s.stateBySvc == nil {
+		s.stateBySvc = make(map[int64]*ExternalServiceSyncState, len(states))
+	}
+
+	for _, st := range states {
+		s.stateBySvc[st.ExternalServiceID] = st.Clone()
+	}
+
+	return nil
+}
+
 func evalOr(bs ...bool) bool {
 	if len(bs) == 0 {
 		return true
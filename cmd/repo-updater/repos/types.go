@@ -572,6 +572,18 @@ type Repo struct {
 	Archived bool
 	// Private is whether the repository is private.
 	Private bool
+	// Topics are the topics (or tags) of this repository on the code host.
+	Topics []string
+	// Stars is the number of stars of this repository on the code host.
+	Stars int
+	// PushedAt is when this repository was last pushed to on the code host.
+	PushedAt time.Time
+	// DefaultBranch is the name of the default branch of this repository on the
+	// code host.
+	DefaultBranch string
+	// License is the SPDX identifier of the license of this repository, as
+	// detected by the code host.
+	License string
 	// CreatedAt is when this repository was created on Sourcegraph.
 	CreatedAt time.Time
 	// UpdatedAt is when this repository's metadata was last updated on Sourcegraph.
@@ -669,6 +681,26 @@ func (r *Repo) Update(n *Repo) (modified bool) {
 		r.Private, modified = n.Private, true
 	}
 
+	if !equalStrings(r.Topics, n.Topics) {
+		r.Topics, modified = n.Topics, true
+	}
+
+	if r.Stars != n.Stars {
+		r.Stars, modified = n.Stars, true
+	}
+
+	if !r.PushedAt.Equal(n.PushedAt) {
+		r.PushedAt, modified = n.PushedAt, true
+	}
+
+	if r.DefaultBranch != n.DefaultBranch {
+		r.DefaultBranch, modified = n.DefaultBranch, true
+	}
+
+	if r.License != n.License {
+		r.License, modified = n.License, true
+	}
+
 	if !reflect.DeepEqual(r.Sources, n.Sources) {
 		r.Sources, modified = n.Sources, true
 	}
@@ -686,6 +718,9 @@ func (r *Repo) Clone() *Repo {
 		return nil
 	}
 	clone := *r
+	if r.Topics != nil {
+		clone.Topics = append([]string(nil), r.Topics...)
+	}
 	if r.Sources != nil {
 		clone.Sources = make(map[string]*SourceInfo, len(r.Sources))
 		for k, v := range r.Sources {
@@ -749,6 +784,20 @@ func (r *Repo) String() string {
 	return fmt.Sprintf("Repo{ID: %d, Name: %q, EID: %s}", r.ID, r.Name, eid)
 }
 
+// equalStrings reports whether a and b contain the same strings in the same
+// order, treating nil and empty slices as equal.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func sourcesKeys(m map[string]*SourceInfo) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -953,6 +1002,36 @@ func (es ExternalServices) With(opts ...func(*ExternalService)) ExternalServices
 	return clone
 }
 
+// ExternalServiceSyncState is the state of the last sync of an
+// ExternalService by the Syncer.
+type ExternalServiceSyncState struct {
+	ExternalServiceID int64
+
+	LastSyncStartedAt  time.Time
+	LastSyncFinishedAt time.Time
+	// LastFullSyncAt is when the last sync which listed all repositories of
+	// the external service started. Syncs in between only list the
+	// repositories updated since the previous sync, if supported by the
+	// Source.
+	LastFullSyncAt time.Time
+	NextSyncAt     time.Time
+	// LastSyncError is the error of the last sync, or empty if it succeeded.
+	LastSyncError string
+
+	// ReposSourced is the number of repos listed by the last sync. The other
+	// counts are the number of repos added, modified and deleted by it.
+	ReposSourced  int
+	ReposAdded    int
+	ReposModified int
+	ReposDeleted  int
+}
+
+// Clone returns a clone of the given sync state.
+func (s *ExternalServiceSyncState) Clone() *ExternalServiceSyncState {
+	clone := *s
+	return &clone
+}
+
 type externalServiceLister interface {
 	ListExternalServices(context.Context, StoreListExternalServicesArgs) ([]*ExternalService, error)
 }
@@ -81,6 +81,11 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 			// We don't do the search line by line, therefore we want the
 			// regex engine to consider newlines for anchors (^$).
 			expr = "(?m:" + expr + ")"
+			if p.IsMultiline {
+				// Let . match newlines too, so that patterns like
+				// foo\(.*bar can match across lines.
+				expr = "(?s:" + expr + ")"
+			}
 		}
 		if !p.IsCaseSensitive {
 			// We don't just use (?i) because regexp library doesn't seem
@@ -152,10 +157,13 @@ func (rg *readerGrep) matchString(s string) bool {
 	return rg.re.MatchString(s)
 }
 
-// Find returns a LineMatch for each line that matches rg in reader.
-// LimitHit is true if some matches may not have been included in the result.
+// Find returns a LineMatch for each line that matches rg in reader. A match
+// that spans multiple lines results in a LineMatch for each of its lines.
+// matchCount is the number of matches, which may be smaller than the number
+// of LineMatches. LimitHit is true if some matches may not have been included
+// in the result.
 // NOTE: This is not safe to use concurrently.
-func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []protocol.LineMatch, limitHit bool, err error) {
+func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []protocol.LineMatch, matchCount int, limitHit bool, err error) {
 	// fileMatchBuf is what we run match on, fileBuf is the original
 	// data (for Preview).
 	fileBuf := zf.DataFor(f)
@@ -182,7 +190,7 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []proto
 	// per-line. Additionally if we have a non-empty literalSubstring, we use
 	// that to prune out files since doing bytes.Index is very fast.
 	if !bytes.Contains(fileMatchBuf, rg.literalSubstring) {
-		return nil, false, nil
+		return nil, 0, false, nil
 	}
 
 	locs := rg.re.FindAllIndex(fileMatchBuf, maxLineMatches+1)
@@ -217,6 +225,7 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []proto
 		lastMatchIndex = matchIndex
 		lastLineNumber = lineNumber
 		matches = appendMatches(matches, fileBuf[lineStart:lineEnd], fileMatchBuf[lineStart:lineEnd], lineNumber, start-lineStart, end-lineStart)
+		matchCount++
 
 		if len(matches) > maxLineMatches {
 			matches = matches[:maxLineMatches]
@@ -224,7 +233,7 @@ func (rg *readerGrep) Find(zf *store.ZipFile, f *store.SrcFile) (matches []proto
 			break
 		}
 	}
-	return matches, limitHit, nil
+	return matches, matchCount, limitHit, nil
 }
 
 func hydrateLineNumbers(fileBuf []byte, lastLineNumber, lastMatchIndex, lineStart int, match []int) (lineNumber, matchIndex int) {
@@ -232,6 +241,11 @@ func hydrateLineNumbers(fileBuf []byte, lastLineNumber, lastMatchIndex, lineStar
 	return lineNumber, lineStart
 }
 
+// appendMatches appends a LineMatch for each line of the match from start to
+// end. The offset and length of each LineMatch only cover the part of the
+// match on its line, excluding the newline, in the same way that
+// highlightMultipleLines reports structural search matches.
+//
 // matchLineBuf is a byte slice that contains the full line(s) that the match appears on.
 func appendMatches(matches []protocol.LineMatch, fileBuf []byte, matchLineBuf []byte, lineNumber, start, end int) []protocol.LineMatch {
 	// If any newlines appear between start and end, we need to append multiple LineMatch.
@@ -254,12 +268,17 @@ func appendMatches(matches []protocol.LineMatch, fileBuf []byte, matchLineBuf []
 			e = len(line)
 		}
 
-		offset := utf8.RuneCount(line[:start])
-		length := utf8.RuneCount(line[start:e])
 		limit := eol
 		if limit < 0 {
 			limit = len(fileBuf)
 		}
+		// Don't highlight the newline at the end of the line.
+		lengthEnd := e
+		if eol >= 0 && lengthEnd > eol {
+			lengthEnd = eol
+		}
+		offset := utf8.RuneCount(line[:start])
+		length := utf8.RuneCount(line[start:lengthEnd])
 		matches = append(matches, protocol.LineMatch{
 			// we are not allowed to use the fileBuf data after the ZipFile has been Closed,
 			// which currently occurs before Preview has been serialized.
@@ -285,11 +304,11 @@ func appendMatches(matches []protocol.LineMatch, fileBuf []byte, matchLineBuf []
 
 // FindZip is a convenience function to run Find on f.
 func (rg *readerGrep) FindZip(zf *store.ZipFile, f *store.SrcFile) (protocol.FileMatch, error) {
-	lm, limitHit, err := rg.Find(zf, f)
+	lm, matchCount, limitHit, err := rg.Find(zf, f)
 	return protocol.FileMatch{
 		Path:        f.Name,
 		LineMatches: lm,
-		MatchCount:  len(lm),
+		MatchCount:  matchCount,
 		LimitHit:    limitHit,
 	}, err
 }
@@ -475,6 +475,40 @@ func TestMaxMatches(t *testing.T) {
 	}
 }
 
+func TestFindMultiline(t *testing.T) {
+	zipData, err := testutil.CreateZip(map[string]string{
+		"a.go": "func a() {\n\treturn 1\n}\nfunc b() {}\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	zf, err := testutil.MockZipFile(zipData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rg, err := compile(&protocol.PatternInfo{Pattern: `\(\).*?}`, IsRegExp: true, IsMultiline: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fm, err := rg.FindZip(zf, &zf.Files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []protocol.LineMatch{
+		{Preview: "func a() {", LineNumber: 0, OffsetAndLengths: [][2]int{{6, 4}}},
+		{Preview: "\treturn 1", LineNumber: 1, OffsetAndLengths: [][2]int{{0, 9}}},
+		{Preview: "}", LineNumber: 2, OffsetAndLengths: [][2]int{{0, 1}}},
+		{Preview: "func b() {}", LineNumber: 3, OffsetAndLengths: [][2]int{{6, 5}}},
+	}
+	if !reflect.DeepEqual(fm.LineMatches, want) {
+		t.Errorf("got line matches %+v, want %+v", fm.LineMatches, want)
+	}
+	if fm.MatchCount != 2 {
+		t.Errorf("got match count %d, want 2", fm.MatchCount)
+	}
+}
+
 // Tests that:
 //
 // - IncludePatterns can match the path in any order
@@ -71,6 +71,12 @@ main.go:6:	fmt.Println("Hello world")
 
 		{protocol.PatternInfo{Pattern: "func.*main", IsRegExp: true}, `
 main.go:5:func main() {
+`},
+
+		{protocol.PatternInfo{Pattern: "func.*Println", IsRegExp: true}, ""},
+		{protocol.PatternInfo{Pattern: "func.*Println", IsRegExp: true, IsMultiline: true}, `
+main.go:5:func main() {
+main.go:6:	fmt.Println("Hello world")
 `},
 
 		// https://github.com/sourcegraph/sourcegraph/issues/8155
@@ -366,6 +372,9 @@ func doSearch(u string, p *protocol.Request) ([]protocol.FileMatch, error) {
 	if p.IsCaseSensitive {
 		form.Set("IsCaseSensitive", "true")
 	}
+	if p.IsMultiline {
+		form.Set("IsMultiline", "true")
+	}
 	if p.PathPatternsAreRegExps {
 		form.Set("PathPatternsAreRegExps", "true")
 	}
@@ -106,6 +106,10 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 		pendingBindIDs = append(pendingBindIDs, id)
 	}
 
+	if err := db.AuditLogs.Record(ctx, db.AuditActionSetRepositoryPermissionsForUsers, string(args.Repository), map[string][]string{"bindIDs": bindIDs}); err != nil {
+		return nil, err
+	}
+
 	txs, err := r.store.Transact(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "start transaction")
@@ -79,6 +79,7 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 		gqlTests           []*gqltesting.Test
 		expUserIDs         []uint32
 		expAccounts        *extsvc.Accounts
+		expAuditLogEntry   *db.AuditLogEntry
 	}{
 		{
 			name: "set permissions via email",
@@ -121,6 +122,11 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 				ServiceID:   authz.SourcegraphServiceID,
 				AccountIDs:  []string{"bob"},
 			},
+			expAuditLogEntry: &db.AuditLogEntry{
+				Action:    db.AuditActionSetRepositoryPermissionsForUsers,
+				Target:    "UmVwb3NpdG9yeTox",
+				Arguments: []byte(`{"bindIDs":["alice@example.com","bob"]}`),
+			},
 		},
 		{
 			name: "set permissions via username",
@@ -163,6 +169,11 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 				ServiceID:   authz.SourcegraphServiceID,
 				AccountIDs:  []string{"bob"},
 			},
+			expAuditLogEntry: &db.AuditLogEntry{
+				Action:    db.AuditActionSetRepositoryPermissionsForUsers,
+				Target:    "UmVwb3NpdG9yeTox",
+				Arguments: []byte(`{"bindIDs":["alice","bob"]}`),
+			},
 		},
 	}
 	for _, test := range tests {
@@ -197,14 +208,24 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 				}
 				return nil
 			}
+			var auditLogEntry *db.AuditLogEntry
+			db.Mocks.AuditLogs.Insert = func(e *db.AuditLogEntry) error {
+				auditLogEntry = e
+				return nil
+			}
 			defer func() {
 				db.Mocks.UserEmails = db.MockUserEmails{}
 				db.Mocks.Users = db.MockUsers{}
 				db.Mocks.Repos = db.MockRepos{}
+				db.Mocks.AuditLogs = db.MockAuditLogs{}
 				edb.Mocks.Perms = edb.MockPerms{}
 			}()
 
 			gqltesting.RunTests(t, test.gqlTests)
+
+			if diff := cmp.Diff(test.expAuditLogEntry, auditLogEntry); diff != "" {
+				t.Errorf("audit log entry: %v", diff)
+			}
 		})
 	}
 }
@@ -163,6 +163,7 @@ func initCampaigns(ctx context.Context, enterpriseServices *enterprise.Services)
 		msResolutionClock,
 		"sourcegraph-"+globalState.SiteID,
 	)
+	enterpriseServices.CampaignReport = campaigns.NewCampaignReportHandler(campaignsStore)
 }
 
 var bundleManagerURL = env.Get("PRECISE_CODE_INTEL_BUNDLE_MANAGER_URL", "", "HTTP address for internal LSIF bundle manager server.")
@@ -105,6 +105,14 @@ func (s *mockReposStore) ListAllRepoNames(context.Context) ([]api.RepoName, erro
 	return nil, nil
 }
 
+func (s *mockReposStore) ListExternalServiceSyncStates(context.Context, repos.StoreListExternalServiceSyncStatesArgs) ([]*repos.ExternalServiceSyncState, error) {
+	return nil, nil
+}
+
+func (s *mockReposStore) UpsertExternalServiceSyncStates(context.Context, ...*repos.ExternalServiceSyncState) error {
+	return nil
+}
+
 func TestPermsSyncer_syncUserPerms(t *testing.T) {
 	p := &mockProvider{
 		serviceType: extsvc.TypeGitLab,
@@ -12,6 +12,7 @@ import (
 	ossAuthz "github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	ossDB "github.com/sourcegraph/sourcegraph/cmd/frontend/db"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repoupdater"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/shared"
@@ -64,6 +65,25 @@ func enterpriseInit(
 	sourcer := repos.NewSourcer(cf)
 	go campaigns.RunWorkers(ctx, campaignsStore, clock, gitserver.DefaultClient, sourcer, 5*time.Second)
 
+	patchSetExecutor := &campaigns.WorkspaceExecutor{
+		Archiver:    gitserver.DefaultClient,
+		ReplacerURL: graphqlbackend.ReplacerURL,
+	}
+	go campaigns.RunPatchSetWorkers(ctx, campaignsStore, clock, patchSetExecutor, 5*time.Second)
+	go campaigns.RunChangesetBulkWorkers(ctx, campaignsStore, clock, sourcer, 5*time.Second)
+
+	rebaser := &campaigns.Rebaser{Store: campaignsStore, GitClient: gitserver.DefaultClient}
+	go rebaser.Run(ctx, 10*time.Minute)
+
+	autoMerger := &campaigns.AutoMerger{Store: campaignsStore, HTTPFactory: cf}
+	go autoMerger.Run(ctx, 5*time.Minute)
+
+	changesetImporter := &campaigns.ChangesetImporter{Store: campaignsStore, HTTPFactory: cf}
+	go changesetImporter.Run(ctx, 15*time.Minute)
+
+	reportDigester := &campaigns.ReportDigester{Store: campaignsStore}
+	go reportDigester.Run(ctx, time.Hour)
+
 	// Set up expired patch set deletion
 	go func() {
 		for {
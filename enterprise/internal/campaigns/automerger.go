@@ -0,0 +1,212 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// An AutoMerger merges the changesets of open campaigns that have auto-merge
+// enabled once they've been approved and their checks passed, as last synced
+// from their code hosts.
+//
+// Changesets are only merged within the merge window of their campaign, and
+// only while fewer than their campaign's AutoMerge.MaxMergesPerHour changesets
+// were merged on their code host in the last hour. The merges of the last
+// hour are only tracked in memory, so a restart resets the limit.
+//
+// Draft changesets and changesets that the Rebaser updated since they were
+// last synced are skipped, since their reviews and checks are not known yet.
+// The changesets are merged with the version of their pull requests that was
+// last synced, so that code hosts reject the merge if the pull request
+// changed since.
+type AutoMerger struct {
+	Store       *Store
+	HTTPFactory *httpcli.Factory
+
+	// sourcer is used in tests to replace the sources of the code hosts.
+	sourcer repos.Sourcer
+
+	// merges holds the times of the merges of the last hour per code host
+	// (identified by its external service ID).
+	merges map[string][]time.Time
+}
+
+// Run merges the mergeable changesets every interval until ctx is canceled.
+func (m *AutoMerger) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := m.MergeChangesets(ctx); err != nil {
+			log15.Error("Auto-merging changesets", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// MergeChangesets merges the approved changesets with passing checks of all
+// open campaigns with auto-merge enabled. Errors of single changesets don't
+// stop the others from being merged and are returned together.
+func (m *AutoMerger) MergeChangesets(ctx context.Context) (err error) {
+	tr, ctx := trace.New(ctx, "AutoMerger.MergeChangesets", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	cs, _, err := m.Store.ListCampaigns(ctx, ListCampaignsOpts{
+		State:         campaigns.CampaignStateOpen,
+		OnlyAutoMerge: true,
+		Limit:         -1,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing campaigns")
+	}
+	tr.LazyPrintf("%d campaigns", len(cs))
+
+	now := m.Store.Clock()()
+
+	var errs *multierror.Error
+	for _, c := range cs {
+		if !c.AutoMerge.InWindow(now) {
+			continue
+		}
+		if err := m.mergeCampaignChangesets(ctx, c, now); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "merging changesets of campaign %d", c.ID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (m *AutoMerger) mergeCampaignChangesets(ctx context.Context, c *campaigns.Campaign, now time.Time) error {
+	var (
+		open     = campaigns.ChangesetStateOpen
+		approved = campaigns.ChangesetReviewStateApproved
+		passed   = campaigns.ChangesetCheckStatePassed
+	)
+
+	cs, _, err := m.Store.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID:          c.ID,
+		Limit:               -1,
+		WithoutDeleted:      true,
+		ExternalState:       &open,
+		ExternalReviewState: &approved,
+		ExternalCheckState:  &passed,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+
+	jobs, _, err := m.Store.ListChangesetJobs(ctx, ListChangesetJobsOpts{CampaignID: c.ID, Limit: -1})
+	if err != nil {
+		return errors.Wrap(err, "listing changeset jobs")
+	}
+	jobsByChangeset := make(map[int64]*campaigns.ChangesetJob, len(jobs))
+	for _, j := range jobs {
+		jobsByChangeset[j.ChangesetID] = j
+	}
+
+	patches, _, err := m.Store.ListPatches(ctx, ListPatchesOpts{PatchSetID: c.PatchSetID, Limit: -1, NoDiff: true})
+	if err != nil {
+		return errors.Wrap(err, "listing patches")
+	}
+	patchesByID := make(map[int64]*campaigns.Patch, len(patches))
+	for _, p := range patches {
+		patchesByID[p.ID] = p
+	}
+
+	cs = cs.Filter(func(ch *campaigns.Changeset) bool {
+		// Changesets that were only added to the campaign are owned by their
+		// authors, so we only merge the ones the campaign created.
+		j, ok := jobsByChangeset[ch.ID]
+		if !ch.CreatedByCampaign || !ok {
+			return false
+		}
+		// Drafts can't be merged before they're marked as ready for review.
+		if j.Draft {
+			return false
+		}
+		// The Rebaser updates the Patch when it pushes new commits to the
+		// changeset, so the synced reviews and checks are outdated until the
+		// changeset is synced again.
+		if p, ok := patchesByID[j.PatchID]; ok && p.UpdatedAt.After(ch.UpdatedAt) {
+			return false
+		}
+		return true
+	})
+	if len(cs) == 0 {
+		return nil
+	}
+
+	reposStore := repos.NewDBStore(m.Store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, m.HTTPFactory, m.sourcer, cs...)
+	if err != nil {
+		return err
+	}
+
+	var (
+		errs   *multierror.Error
+		merged []*SourceChangesets
+	)
+	for _, group := range bySource {
+		var done []*repos.Changeset
+		for _, ch := range group.Changesets {
+			key := ch.Repo.ExternalRepo.ServiceID
+			if !m.allowMerge(key, c.AutoMerge.MaxMergesPerHour, now) {
+				continue
+			}
+
+			if err := group.MergeChangeset(ctx, ch, c.AutoMerge.Method); err != nil {
+				errs = multierror.Append(errs, errors.Wrapf(err, "merging changeset %d", ch.Changeset.ID))
+				continue
+			}
+			m.merges[key] = append(m.merges[key], now)
+			done = append(done, ch)
+		}
+
+		if len(done) > 0 {
+			merged = append(merged, &SourceChangesets{ChangesetSource: group.ChangesetSource, Changesets: done})
+		}
+	}
+
+	// As in CloseOpenChangesets, we sync the merged changesets right away so
+	// that their state and events are up to date before the next run of
+	// campaigns.Syncer.
+	if len(merged) > 0 {
+		if err := syncChangesetsWithSources(ctx, m.Store, merged); err != nil {
+			errs = multierror.Append(errs, errors.Wrap(err, "syncing merged changesets"))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// allowMerge returns true if fewer than limit changesets were merged on the
+// code host with the given external service ID in the hour before now. A
+// limit of zero allows any number of merges.
+func (m *AutoMerger) allowMerge(serviceID string, limit int32, now time.Time) bool {
+	if m.merges == nil {
+		m.merges = make(map[string][]time.Time)
+	}
+
+	var recent []time.Time
+	for _, t := range m.merges[serviceID] {
+		if now.Sub(t) < time.Hour {
+			recent = append(recent, t)
+		}
+	}
+	m.merges[serviceID] = recent
+
+	return limit == 0 || len(recent) < int(limit)
+}
@@ -0,0 +1,129 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestAutoMergerMergeChangesets(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	dbtesting.SetupGlobalTestDB(t)
+
+	tx := dbtest.NewTx(t, dbconn.Global)
+	s := NewStoreWithClock(tx, clock)
+
+	repo, extSvc := createGitHubRepo(t, ctx, now, s)
+	campaign, _ := createCampaignPatch(t, ctx, now, s, repo)
+	campaign.ClosedAt = time.Time{}
+	campaign.AutoMerge = cmpgn.AutoMerge{
+		Enabled:          true,
+		Method:           cmpgn.ChangesetMergeMethodSquash,
+		MaxMergesPerHour: 1,
+		WindowStart:      "09:00",
+		WindowEnd:        "17:00",
+	}
+	if err := s.UpdateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	newChangeset := func(externalID string, createdByCampaign bool, check cmpgn.ChangesetCheckState, draft bool) (*cmpgn.Changeset, *cmpgn.Patch) {
+		c := &cmpgn.Changeset{
+			RepoID:              repo.ID,
+			CampaignIDs:         []int64{campaign.ID},
+			ExternalID:          externalID,
+			ExternalServiceType: repo.ExternalRepo.ServiceType,
+			ExternalState:       cmpgn.ChangesetStateOpen,
+			ExternalReviewState: cmpgn.ChangesetReviewStateApproved,
+			ExternalCheckState:  check,
+			CreatedByCampaign:   createdByCampaign,
+		}
+		if err := s.CreateChangesets(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+
+		p := &cmpgn.Patch{RepoID: repo.ID, PatchSetID: campaign.PatchSetID, Diff: testDiff, Rev: "f00b4r", BaseRef: "refs/heads/master"}
+		if err := s.CreatePatch(ctx, p); err != nil {
+			t.Fatal(err)
+		}
+		j := &cmpgn.ChangesetJob{CampaignID: campaign.ID, PatchID: p.ID, ChangesetID: c.ID, Draft: draft}
+		if err := s.CreateChangesetJob(ctx, j); err != nil {
+			t.Fatal(err)
+		}
+		return c, p
+	}
+
+	mergeable1, _ := newChangeset("1", true, cmpgn.ChangesetCheckStatePassed, false)
+	mergeable2, _ := newChangeset("2", true, cmpgn.ChangesetCheckStatePassed, false)
+	newChangeset("3", true, cmpgn.ChangesetCheckStatePending, false)
+	newChangeset("4", false, cmpgn.ChangesetCheckStatePassed, false)
+	newChangeset("5", true, cmpgn.ChangesetCheckStatePassed, true)
+
+	// The Rebaser updated the patch of this changeset after it was last
+	// synced.
+	_, rebased := newChangeset("6", true, cmpgn.ChangesetCheckStatePassed, false)
+	now = now.Add(time.Minute)
+	if err := s.UpdatePatch(ctx, rebased); err != nil {
+		t.Fatal(err)
+	}
+
+	source := &FakeChangesetSource{
+		Svc:          extSvc,
+		FakeMetadata: &github.PullRequest{State: "MERGED"},
+	}
+	merger := &AutoMerger{Store: s, sourcer: repos.NewFakeSourcer(nil, source)}
+
+	merge := func(wantMerged ...*cmpgn.Changeset) {
+		t.Helper()
+
+		source.MergedChangesets = nil
+		if err := merger.MergeChangesets(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := len(source.MergedChangesets), len(wantMerged); have != want {
+			t.Fatalf("have %d merged changesets, want %d", have, want)
+		}
+		for i, c := range wantMerged {
+			if have, want := source.MergedChangesets[i].Changeset.ID, c.ID; have != want {
+				t.Fatalf("have merged changeset %d, want %d", have, want)
+			}
+		}
+	}
+
+	// Outside of the merge window nothing is merged.
+	now = time.Date(2020, 4, 1, 18, 0, 0, 0, time.UTC)
+	merge()
+
+	// Within the merge window only one changeset is merged per hour.
+	now = time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	merge(mergeable1)
+	if have, want := source.MergeMethods[0], cmpgn.ChangesetMergeMethodSquash; have != want {
+		t.Fatalf("have merge method %q, want %q", have, want)
+	}
+
+	merged, err := s.GetChangeset(ctx, GetChangesetOpts{ID: mergeable1.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := merged.ExternalState, cmpgn.ChangesetStateMerged; have != want {
+		t.Fatalf("have state %q, want %q", have, want)
+	}
+
+	now = now.Add(30 * time.Minute)
+	merge()
+
+	now = now.Add(30 * time.Minute)
+	merge(mergeable2)
+}
@@ -0,0 +1,155 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// RunChangesetBulkWorkers should be executed in a background goroutine and
+// is responsible for running the ChangesetBulkJobs of the bulk actions on
+// campaign changesets.
+func RunChangesetBulkWorkers(ctx context.Context, s *Store, clock func() time.Time, sourcer repos.Sourcer, backoffDuration time.Duration) {
+	// process is executed inside a database transaction that's opened by
+	// ProcessPendingChangesetBulkJobs.
+	process := func(ctx context.Context, s *Store, job campaigns.ChangesetBulkJob) error {
+		return execJobInTransaction("ExecChangesetBulkJob", job.ID, func() error {
+			return ExecChangesetBulkJob(ctx, &job, ExecChangesetBulkJobOpts{
+				Clock:   clock,
+				Store:   s,
+				Sourcer: sourcer,
+			})
+		})
+	}
+	runWorkers(ctx, "changeset bulk job", backoffDuration, func(ctx context.Context) (bool, error) {
+		return s.ProcessPendingChangesetBulkJobs(ctx, process)
+	})
+}
+
+type ExecChangesetBulkJobOpts struct {
+	Clock   func() time.Time
+	Store   *Store
+	Sourcer repos.Sourcer
+}
+
+// ExecChangesetBulkJob runs the ChangesetBulkAction of the given
+// ChangesetBulkJob on the job's Changeset on its code host and syncs the
+// Changeset afterwards.
+// Like ExecChangesetJob, it must be executed inside a transaction and it is
+// idempotent: once the action has been run, the job is completed even if
+// syncing the Changeset fails.
+func ExecChangesetBulkJob(ctx context.Context, job *campaigns.ChangesetBulkJob, opts ExecChangesetBulkJobOpts) (err error) {
+	tr, ctx := trace.New(ctx, "service.ExecChangesetBulkJob", fmt.Sprintf("job_id: %d", job.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	tr.LogFields(log.Int64("job_id", job.ID), log.Int64("bulk_action_id", job.BulkActionID))
+
+	if !job.FinishedAt.IsZero() && job.Error == "" {
+		log15.Info("ChangesetBulkJob already completed", "id", job.ID)
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			job.Error = err.Error()
+		} else {
+			job.Error = ""
+		}
+		job.FinishedAt = opts.Clock()
+
+		if e := opts.Store.UpdateChangesetBulkJob(ctx, job); e != nil {
+			if err == nil {
+				err = e
+			} else {
+				err = multierror.Append(err, e)
+			}
+		}
+	}()
+
+	job.StartedAt = opts.Clock()
+
+	action, err := opts.Store.GetChangesetBulkAction(ctx, GetChangesetBulkActionOpts{ID: job.BulkActionID})
+	if err != nil {
+		return errors.Wrap(err, "getting bulk action")
+	}
+
+	changeset, err := opts.Store.GetChangeset(ctx, GetChangesetOpts{ID: job.ChangesetID})
+	if err != nil {
+		return errors.Wrap(err, "getting changeset")
+	}
+
+	reposStore := repos.NewDBStore(opts.Store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, nil, opts.Sourcer, changeset)
+	if err != nil {
+		return err
+	}
+
+	var group *SourceChangesets
+	for _, g := range bySource {
+		if len(g.Changesets) > 0 {
+			group = g
+			break
+		}
+	}
+	if group == nil {
+		return errors.Errorf("no external service found for changeset %d", changeset.ID)
+	}
+
+	if err := runChangesetBulkAction(ctx, group.ChangesetSource, group.Changesets[0], action); err != nil {
+		return err
+	}
+
+	// The action has been run on the code host, so from here on the job must
+	// be marked as completed: a failed job is run again and actions such as
+	// comments aren't idempotent on the code host.
+	//
+	// As in CloseOpenChangesets, we sync the changeset right away so that the
+	// events created by the action show up before the next run of
+	// campaigns.Syncer. If that fails, the Syncer picks the events up later.
+	if err := syncChangesetsWithSources(ctx, opts.Store, []*SourceChangesets{group}); err != nil {
+		log15.Warn("Syncing changeset after bulk action failed", "jobID", job.ID, "changesetID", changeset.ID, "err", err)
+		tr.LogFields(log.String("sync_error", err.Error()))
+	}
+	return nil
+}
+
+func runChangesetBulkAction(ctx context.Context, src repos.ChangesetSource, c *repos.Changeset, a *campaigns.ChangesetBulkAction) error {
+	if a.Type == campaigns.ChangesetBulkActionClose {
+		if c.ExternalState != campaigns.ChangesetStateOpen {
+			return nil
+		}
+		return src.CloseChangeset(ctx, c)
+	}
+
+	as, ok := src.(repos.ChangesetActionSource)
+	if !ok {
+		return errors.Errorf("%s changesets don't support bulk action %q", c.ExternalServiceType, a.Type)
+	}
+
+	switch a.Type {
+	case campaigns.ChangesetBulkActionComment:
+		return as.CreateComment(ctx, c, a.Body)
+	case campaigns.ChangesetBulkActionReopen:
+		if c.ExternalState != campaigns.ChangesetStateClosed {
+			return nil
+		}
+		return as.ReopenChangeset(ctx, c)
+	case campaigns.ChangesetBulkActionAddLabels:
+		return as.AddLabels(ctx, c, a.Labels)
+	case campaigns.ChangesetBulkActionRequestReviewers:
+		return as.RequestReviewers(ctx, c, a.Reviewers)
+	default:
+		return errors.Errorf("invalid bulk action %q", a.Type)
+	}
+}
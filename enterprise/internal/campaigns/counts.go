@@ -11,14 +11,14 @@ import (
 // ChangesetCounts represents the states in which a given set of Changesets was
 // at a given point in time
 type ChangesetCounts struct {
-	Time                 time.Time
-	Total                int32
-	Merged               int32
-	Closed               int32
-	Open                 int32
-	OpenApproved         int32
-	OpenChangesRequested int32
-	OpenPending          int32
+	Time                 time.Time `json:"date"`
+	Total                int32     `json:"total"`
+	Merged               int32     `json:"merged"`
+	Closed               int32     `json:"closed"`
+	Open                 int32     `json:"open"`
+	OpenApproved         int32     `json:"openApproved"`
+	OpenChangesRequested int32     `json:"openChangesRequested"`
+	OpenPending          int32     `json:"openPending"`
 }
 
 func (cc *ChangesetCounts) String() string {
@@ -0,0 +1,212 @@
+package campaigns
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+// defaultReportDigestIntervalDays is the number of days between two campaign
+// report digests if campaigns.reportDigest.intervalDays isn't set.
+const defaultReportDigestIntervalDays = 7
+
+// A ReportDigester periodically emails a digest of the progress of all open
+// campaigns to the recipients configured in campaigns.reportDigest in the
+// site configuration.
+//
+// The time at which the last digest was sent is stored in the database, so
+// that restarts don't cause duplicate digests.
+type ReportDigester struct {
+	Store *Store
+
+	// send is used in tests to replace api.InternalClient.SendEmail.
+	send func(ctx context.Context, msg txtypes.Message) error
+}
+
+// Run sends the digest every interval, if it's due, until ctx is canceled.
+func (d *ReportDigester) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := d.SendDigest(ctx); err != nil {
+			log15.Error("Sending campaign report digest", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// A campaignDigest is the progress of a single campaign in the digest.
+type campaignDigest struct {
+	Name      string
+	URL       string
+	ReportURL string
+
+	Total                int32
+	Open                 int32
+	OpenApproved         int32
+	OpenChangesRequested int32
+	Merged               int32
+	Closed               int32
+
+	// MergedInPeriod is the number of changesets merged since the start of
+	// the digest period.
+	MergedInPeriod int32
+}
+
+// SendDigest sends the digest to the configured recipients if no digest was
+// sent within the configured interval. It does nothing if
+// campaigns.reportDigest isn't configured.
+func (d *ReportDigester) SendDigest(ctx context.Context) (err error) {
+	tr, ctx := trace.New(ctx, "ReportDigester.SendDigest", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	cfg := conf.Get().CampaignsReportDigest
+	if cfg == nil || len(cfg.Recipients) == 0 {
+		return nil
+	}
+
+	days := cfg.IntervalDays
+	if days <= 0 {
+		days = defaultReportDigestIntervalDays
+	}
+
+	now := d.Store.Clock()()
+	start := now.AddDate(0, 0, -days)
+
+	lastSentAt, err := d.Store.GetLatestCampaignReportDigest(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting latest digest")
+	}
+	if lastSentAt.After(start) {
+		return nil
+	}
+
+	digests, err := d.campaignDigests(ctx, start, now)
+	if err != nil {
+		return err
+	}
+	tr.LazyPrintf("%d campaigns", len(digests))
+
+	send := d.send
+	if send == nil {
+		canSend, err := api.InternalClient.CanSendEmail(ctx)
+		if err != nil {
+			return errors.Wrap(err, "InternalClient.CanSendEmail")
+		}
+		if !canSend {
+			return errors.New("SMTP server not set in site configuration")
+		}
+		send = api.InternalClient.SendEmail
+	}
+
+	if err := send(ctx, txtypes.Message{
+		To:       cfg.Recipients,
+		Template: reportDigestEmailTemplates,
+		Data: struct {
+			IntervalDays int
+			Campaigns    []*campaignDigest
+		}{
+			IntervalDays: days,
+			Campaigns:    digests,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "sending digest")
+	}
+
+	return d.Store.CreateCampaignReportDigest(ctx, cfg.Recipients)
+}
+
+func (d *ReportDigester) campaignDigests(ctx context.Context, start, end time.Time) ([]*campaignDigest, error) {
+	cs, _, err := d.Store.ListCampaigns(ctx, ListCampaignsOpts{
+		State: campaigns.CampaignStateOpen,
+		Limit: -1,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing campaigns")
+	}
+
+	externalURL := strings.TrimSuffix(conf.Get().ExternalURL, "/")
+
+	digests := make([]*campaignDigest, 0, len(cs))
+	for _, c := range cs {
+		changesets, _, err := d.Store.ListChangesets(ctx, ListChangesetsOpts{CampaignID: c.ID, Limit: -1})
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing changesets of campaign %d", c.ID)
+		}
+
+		events, _, err := d.Store.ListChangesetEvents(ctx, ListChangesetEventsOpts{ChangesetIDs: changesets.IDs(), Limit: -1})
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing changeset events of campaign %d", c.ID)
+		}
+
+		counts, err := CalcCounts(start, end, changesets, events...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "computing counts of campaign %d", c.ID)
+		}
+
+		id := string(campaigns.MarshalCampaignID(c.ID))
+		digest := &campaignDigest{
+			Name:      c.Name,
+			URL:       externalURL + path.Join("/campaigns", id),
+			ReportURL: externalURL + "/.api/campaigns/report?" + url.Values{"campaign": {id}}.Encode(),
+		}
+
+		if len(counts) > 0 {
+			first, last := counts[0], counts[len(counts)-1]
+			digest.Total = last.Total
+			digest.Open = last.Open
+			digest.OpenApproved = last.OpenApproved
+			digest.OpenChangesRequested = last.OpenChangesRequested
+			digest.Merged = last.Merged
+			digest.Closed = last.Closed
+			digest.MergedInPeriod = last.Merged - first.Merged
+		}
+
+		digests = append(digests, digest)
+	}
+
+	return digests, nil
+}
+
+var reportDigestEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Campaign progress of the last {{.IntervalDays}} days`,
+	Text: `
+Progress of the open campaigns in the last {{.IntervalDays}} days:
+{{range .Campaigns}}
+{{.Name}} ({{.URL}})
+  {{.MergedInPeriod}} merged in the last {{$.IntervalDays}} days
+  {{.Total}} total: {{.Open}} open ({{.OpenApproved}} approved, {{.OpenChangesRequested}} changes requested), {{.Merged}} merged, {{.Closed}} closed
+  Report: {{.ReportURL}}
+{{else}}
+There are no open campaigns.
+{{end}}`,
+	HTML: `
+<p>Progress of the open campaigns in the last {{.IntervalDays}} days:</p>
+{{range .Campaigns}}
+<p>
+  <a href="{{.URL}}"><strong>{{.Name}}</strong></a><br>
+  <strong>{{.MergedInPeriod}}</strong> merged in the last {{$.IntervalDays}} days<br>
+  {{.Total}} total: {{.Open}} open ({{.OpenApproved}} approved, {{.OpenChangesRequested}} changes requested), {{.Merged}} merged, {{.Closed}} closed<br>
+  <a href="{{.ReportURL}}">Download the report</a>
+</p>
+{{else}}
+<p>There are no open campaigns.</p>
+{{end}}`,
+})
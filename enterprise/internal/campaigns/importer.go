@@ -0,0 +1,259 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// A ChangesetImporter periodically runs the searches of the ChangesetImports
+// on their code hosts and adds the matching changesets to their campaigns,
+// so that new changesets matching a search are imported automatically.
+type ChangesetImporter struct {
+	Store       *Store
+	HTTPFactory *httpcli.Factory
+
+	// sourcer is used in tests to replace the sources of the code hosts.
+	sourcer repos.Sourcer
+}
+
+// Run checks for ChangesetImports that didn't run within the last interval
+// every minute and runs them, until ctx is canceled.
+func (im *ChangesetImporter) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := im.ImportChangesets(ctx, interval); err != nil {
+			log15.Error("Importing changesets", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Minute):
+		}
+	}
+}
+
+// ImportChangesets runs the ChangesetImports of open campaigns that didn't run
+// within the last interval. The error of each run is stored in its
+// ChangesetImport, and errors of single imports don't stop the others.
+func (im *ChangesetImporter) ImportChangesets(ctx context.Context, interval time.Duration) (err error) {
+	tr, ctx := trace.New(ctx, "ChangesetImporter.ImportChangesets", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	now := im.Store.Clock()()
+
+	is, _, err := im.Store.ListChangesetImports(ctx, ListChangesetImportsOpts{
+		LastRunBefore:     now.Add(-interval),
+		OnlyOpenCampaigns: true,
+		Limit:             -1,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changeset imports")
+	}
+	tr.LazyPrintf("%d changeset imports", len(is))
+
+	var errs *multierror.Error
+	for _, i := range is {
+		runErr := im.runImport(ctx, i)
+
+		i.LastRunAt = now
+		i.Error = ""
+		if runErr != nil {
+			i.Error = runErr.Error()
+			errs = multierror.Append(errs, errors.Wrapf(runErr, "running changeset import %d", i.ID))
+		}
+
+		if err := im.Store.UpdateChangesetImport(ctx, i); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "updating changeset import %d", i.ID))
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (im *ChangesetImporter) runImport(ctx context.Context, i *campaigns.ChangesetImport) (err error) {
+	// The search runs before the transaction is opened, since it can take a
+	// while on the code host.
+	found, err := im.searchChangesets(ctx, repos.NewDBStore(im.Store.DB(), sql.TxOptions{}), i)
+	if err != nil {
+		return err
+	}
+
+	tx, err := im.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Done(&err)
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: i.CampaignID})
+	if err != nil {
+		return errors.Wrap(err, "getting campaign")
+	}
+
+	// The campaign could have been closed while the search ran.
+	if !campaign.ClosedAt.IsZero() {
+		return nil
+	}
+
+	reposStore := repos.NewDBStore(tx.DB(), sql.TxOptions{})
+
+	cs, err := changesetsInRepos(ctx, reposStore, found)
+	if err != nil {
+		return err
+	}
+	if len(cs) == 0 {
+		return nil
+	}
+
+	existing := map[int64]bool{}
+	if err = tx.CreateChangesets(ctx, cs...); err != nil {
+		alreadyExist, ok := err.(AlreadyExistError)
+		if !ok {
+			return errors.Wrap(err, "creating changesets")
+		}
+		for _, id := range alreadyExist.ChangesetIDs {
+			existing[id] = true
+		}
+	}
+
+	// The changesets that weren't tracked yet need to be synced to load their
+	// metadata from the code host, like in the createChangesets mutation.
+	var created []*campaigns.Changeset
+	for _, c := range cs {
+		if !existing[c.ID] {
+			created = append(created, c)
+		}
+	}
+	if len(created) > 0 {
+		bySource, err := groupChangesetsBySource(ctx, reposStore, im.HTTPFactory, im.sourcer, created...)
+		if err != nil {
+			return err
+		}
+		if err := syncChangesetsWithSources(ctx, tx, bySource); err != nil {
+			return errors.Wrap(err, "syncing imported changesets")
+		}
+	}
+
+	var added []*campaigns.Changeset
+	for _, c := range cs {
+		if inCampaign(c, campaign.ID) {
+			continue
+		}
+		c.CampaignIDs = append(c.CampaignIDs, campaign.ID)
+		c.AddedToCampaign = true
+		added = append(added, c)
+		campaign.ChangesetIDs = append(campaign.ChangesetIDs, c.ID)
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	if err = tx.UpdateChangesets(ctx, added...); err != nil {
+		return err
+	}
+
+	return tx.UpdateCampaign(ctx, campaign)
+}
+
+// searchChangesets runs the search of the given ChangesetImport on the code
+// host of its external service.
+func (im *ChangesetImporter) searchChangesets(ctx context.Context, reposStore repos.Store, i *campaigns.ChangesetImport) ([]*repos.Changeset, error) {
+	svcs, err := reposStore.ListExternalServices(ctx, repos.StoreListExternalServicesArgs{
+		IDs: []int64{i.ExternalServiceID},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting external service")
+	}
+	if len(svcs) != 1 {
+		return nil, errors.Errorf("external service %d not found", i.ExternalServiceID)
+	}
+
+	sourcer := im.sourcer
+	if sourcer == nil {
+		sourcer = repos.NewSourcer(im.HTTPFactory)
+	}
+
+	srcs, err := sourcer(svcs...)
+	if err != nil {
+		return nil, err
+	}
+	if len(srcs) != 1 {
+		return nil, errors.Errorf("no source found for external service %d", i.ExternalServiceID)
+	}
+
+	src, ok := srcs[0].(repos.ChangesetSearchSource)
+	if !ok {
+		return nil, fmt.Errorf("changesets of external service %d can't be searched", i.ExternalServiceID)
+	}
+
+	return src.SearchChangesets(ctx, i.Query)
+}
+
+// changesetsInRepos returns new Changesets for the found changesets whose
+// repositories exist on Sourcegraph. The other ones are ignored, since they
+// can't be tracked.
+func changesetsInRepos(ctx context.Context, reposStore repos.Store, found []*repos.Changeset) ([]*campaigns.Changeset, error) {
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	specs := make([]api.ExternalRepoSpec, 0, len(found))
+	for _, c := range found {
+		specs = append(specs, c.Repo.ExternalRepo)
+	}
+
+	rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{ExternalRepos: specs})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing repositories")
+	}
+
+	bySpec := make(map[api.ExternalRepoSpec]*repos.Repo, len(rs))
+	for _, r := range rs {
+		bySpec[r.ExternalRepo] = r
+	}
+
+	seen := map[string]bool{}
+	cs := make([]*campaigns.Changeset, 0, len(found))
+	for _, c := range found {
+		r, ok := bySpec[c.Repo.ExternalRepo]
+		if !ok {
+			continue
+		}
+
+		key := fmt.Sprintf("%d:%s", r.ID, c.ExternalID)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		cs = append(cs, &campaigns.Changeset{
+			RepoID:              r.ID,
+			ExternalID:          c.ExternalID,
+			ExternalServiceType: c.ExternalServiceType,
+		})
+	}
+
+	return cs, nil
+}
+
+func inCampaign(c *campaigns.Changeset, campaignID int64) bool {
+	for _, id := range c.CampaignIDs {
+		if id == campaignID {
+			return true
+		}
+	}
+	return false
+}
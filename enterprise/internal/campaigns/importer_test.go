@@ -0,0 +1,132 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestChangesetImporterImportChangesets(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	dbtesting.SetupGlobalTestDB(t)
+
+	tx := dbtest.NewTx(t, dbconn.Global)
+	s := NewStoreWithClock(tx, clock)
+
+	repo, extSvc := createGitHubRepo(t, ctx, now, s)
+	campaign, _ := createCampaignPatch(t, ctx, now, s, repo)
+	campaign.ClosedAt = time.Time{}
+	if err := s.UpdateCampaign(ctx, campaign); err != nil {
+		t.Fatal(err)
+	}
+
+	i := &cmpgn.ChangesetImport{
+		CampaignID:        campaign.ID,
+		UserID:            campaign.AuthorID,
+		ExternalServiceID: extSvc.ID,
+		Query:             "org:sourcegraph label:migration",
+	}
+	if err := s.CreateChangesetImport(ctx, i); err != nil {
+		t.Fatal(err)
+	}
+
+	found := func(externalID string, spec api.ExternalRepoSpec) *repos.Changeset {
+		return &repos.Changeset{
+			Changeset: &cmpgn.Changeset{
+				ExternalID:          externalID,
+				ExternalServiceType: extsvc.TypeGitHub,
+			},
+			Repo: &repos.Repo{ExternalRepo: spec},
+		}
+	}
+
+	source := &FakeChangesetSource{
+		Svc: extSvc,
+		FakeSearchResults: []*repos.Changeset{
+			found("1", repo.ExternalRepo),
+			// Duplicates are only imported once.
+			found("1", repo.ExternalRepo),
+			// Changesets in repositories that don't exist on Sourcegraph are ignored.
+			found("2", api.ExternalRepoSpec{ID: "unknown", ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"}),
+		},
+	}
+	importer := &ChangesetImporter{Store: s, sourcer: repos.NewFakeSourcer(nil, source)}
+
+	if err := importer.ImportChangesets(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := source.SearchQueries, []string{i.Query}; len(have) != 1 || have[0] != want[0] {
+		t.Fatalf("have search queries %v, want %v", have, want)
+	}
+
+	cs, _, err := s.ListChangesets(ctx, ListChangesetsOpts{CampaignID: campaign.ID, Limit: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cs) != 1 {
+		t.Fatalf("have %d changesets in campaign, want 1", len(cs))
+	}
+	if have, want := cs[0].ExternalID, "1"; have != want {
+		t.Fatalf("have external ID %q, want %q", have, want)
+	}
+	if !cs[0].AddedToCampaign {
+		t.Fatal("imported changeset not marked as added to campaign")
+	}
+
+	campaign, err = s.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !inCampaign(cs[0], campaign.ID) || len(campaign.ChangesetIDs) != 1 || campaign.ChangesetIDs[0] != cs[0].ID {
+		t.Fatalf("have campaign changeset IDs %v, want [%d]", campaign.ChangesetIDs, cs[0].ID)
+	}
+
+	i, err = s.GetChangesetImport(ctx, GetChangesetImportOpts{ID: i.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !i.LastRunAt.Equal(now) {
+		t.Fatalf("have last run at %v, want %v", i.LastRunAt, now)
+	}
+	if i.Error != "" {
+		t.Fatalf("have error %q, want none", i.Error)
+	}
+
+	// Imports that ran within the interval aren't run again.
+	now = now.Add(30 * time.Minute)
+	if err := importer.ImportChangesets(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if have := len(source.SearchQueries); have != 1 {
+		t.Fatalf("have %d searches, want 1", have)
+	}
+
+	// Running again after the interval doesn't add the changeset twice.
+	now = now.Add(time.Hour)
+	if err := importer.ImportChangesets(ctx, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if have := len(source.SearchQueries); have != 2 {
+		t.Fatalf("have %d searches, want 2", have)
+	}
+	campaign, err = s.GetCampaign(ctx, GetCampaignOpts{ID: campaign.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := len(campaign.ChangesetIDs); have != 1 {
+		t.Fatalf("have %d changesets in campaign, want 1", have)
+	}
+}
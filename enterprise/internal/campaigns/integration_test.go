@@ -31,6 +31,11 @@ func TestIntegration(t *testing.T) {
 		t.Run("PatchSets_DeleteExpired", storeTest(db, testStorePatchSetsDeleteExpired))
 		t.Run("Patches", storeTest(db, testStorePatches))
 		t.Run("ChangesetJobs", storeTest(db, testStoreChangesetJobs))
+		t.Run("PatchSetJobs", storeTest(db, testStorePatchSetJobs))
+		t.Run("ChangesetBulkActions", storeTest(db, testStoreChangesetBulkActions))
+		t.Run("CampaignReportDigests", storeTest(db, testStoreCampaignReportDigests))
+		t.Run("ChangesetImports", storeTest(db, testStoreChangesetImports))
+		t.Run("CampaignTemplates", storeTest(db, testStoreCampaignTemplates))
 	})
 
 	t.Run("GitHubWebhook", testGitHubWebhook(db, userID))
@@ -0,0 +1,459 @@
+package campaigns
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/tar"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+const (
+	// patchSetJobTimeout is the maximum duration of the execution of the
+	// steps of a campaign spec in a single repository.
+	patchSetJobTimeout = 10 * time.Minute
+
+	// commandStepTimeout is the maximum duration of a single command step.
+	commandStepTimeout = 5 * time.Minute
+
+	// commandStepWorkdir is the path of the copy of the workspace in the
+	// containers of command steps.
+	commandStepWorkdir = "/work/repo"
+)
+
+// commandStepLimits are the `docker create` flags that limit the resources
+// and privileges of the containers of command steps.
+var commandStepLimits = []string{
+	"--memory", "2g",
+	"--cpus", "2",
+	"--pids-limit", "1024",
+	"--read-only",
+	"--tmpfs", "/tmp",
+	"--cap-drop", "ALL",
+	"--security-opt", "no-new-privileges",
+}
+
+// A PatchSetJobExecutor computes the diff of a PatchSetJob by executing the
+// steps of a campaign spec in a repository.
+type PatchSetJobExecutor interface {
+	// ResolveRevision resolves the given revision of the repository to a
+	// commit and the ref the Patch should be based on. An empty rev refers to
+	// the default branch.
+	ResolveRevision(ctx context.Context, repo api.RepoName, rev string) (commit api.CommitID, baseRef string, err error)
+	// Diff executes the steps in the repository at the given commit and
+	// returns the resulting diff, without a/ and b/ filename prefixes.
+	Diff(ctx context.Context, repo api.RepoName, commit api.CommitID, steps []CampaignSpecStep) (string, error)
+}
+
+// ArchiveClient is the subset of the gitserver client used by the
+// WorkspaceExecutor.
+type ArchiveClient interface {
+	Archive(ctx context.Context, repo gitserver.Repo, opt gitserver.ArchiveOptions) (io.ReadCloser, error)
+}
+
+// WorkspaceExecutor is the PatchSetJobExecutor used in production. It
+// extracts the archive of the repository into a temporary workspace, applies
+// the steps to it and diffs the workspace against the archive. The git
+// directory that tracks the workspace is kept outside of it, so that steps
+// cannot change the git configuration that the diffing uses.
+//
+// Comby steps are computed by the replacer service against the original
+// commit, which means that they are applied with `git apply` and fail if an
+// earlier step changed the same lines.
+type WorkspaceExecutor struct {
+	Archiver    ArchiveClient
+	ReplacerURL string
+	HTTPClient  httpcli.Doer
+}
+
+var _ PatchSetJobExecutor = &WorkspaceExecutor{}
+
+// ResolveRevision implements PatchSetJobExecutor.
+func (e *WorkspaceExecutor) ResolveRevision(ctx context.Context, repo api.RepoName, rev string) (api.CommitID, string, error) {
+	gitserverRepo := gitserver.Repo{Name: repo}
+
+	spec, baseRef := rev, git.EnsureRefPrefix(rev)
+	if rev == "" {
+		spec = "HEAD"
+		stdout, stderr, exitCode, err := git.ExecSafe(ctx, gitserverRepo, []string{"symbolic-ref", "HEAD"})
+		if err != nil {
+			return "", "", errors.Wrap(err, "resolving default branch")
+		}
+		if exitCode != 0 {
+			return "", "", errors.Errorf("resolving default branch of %q: %s", repo, stderr)
+		}
+		baseRef = strings.TrimSpace(string(stdout))
+	}
+
+	commit, err := git.ResolveRevision(ctx, gitserverRepo, nil, spec, &git.ResolveRevisionOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	return commit, baseRef, nil
+}
+
+// Diff implements PatchSetJobExecutor.
+func (e *WorkspaceExecutor) Diff(ctx context.Context, repo api.RepoName, commit api.CommitID, steps []CampaignSpecStep) (_ string, err error) {
+	root, err := ioutil.TempDir("", "campaign-spec-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(root)
+	w := workspace{dir: filepath.Join(root, "repo"), gitDir: filepath.Join(root, "git")}
+	if err := os.Mkdir(w.dir, 0700); err != nil {
+		return "", err
+	}
+
+	archive, err := e.Archiver.Archive(ctx, gitserver.Repo{Name: repo}, gitserver.ArchiveOptions{
+		Treeish: string(commit),
+		Format:  "tar",
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "fetching archive")
+	}
+	err = tar.Extract(w.dir, archive)
+	archive.Close()
+	if err != nil {
+		return "", errors.Wrap(err, "extracting archive")
+	}
+
+	// We track the extracted archive in a throwaway git repository, so that
+	// the changes made by the steps can be diffed at the end.
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "--all"},
+		{"-c", "user.name=Sourcegraph", "-c", "user.email=campaigns@sourcegraph.com", "-c", "commit.gpgsign=false",
+			"commit", "--quiet", "--allow-empty", "--no-verify", "--message", "base"},
+	} {
+		if _, err := w.git(ctx, nil, args...); err != nil {
+			return "", err
+		}
+	}
+
+	for i, step := range steps {
+		switch step.Type {
+		case CampaignSpecStepComby:
+			diff, err := e.combyDiff(ctx, repo, commit, step)
+			if err != nil {
+				return "", errors.Wrapf(err, "step %d", i+1)
+			}
+			if diff == "" {
+				continue
+			}
+			if _, err := w.git(ctx, strings.NewReader(diff), "apply", "-p0", "--whitespace=nowarn", "-"); err != nil {
+				return "", errors.Wrapf(err, "step %d: applying comby diff", i+1)
+			}
+
+		case CampaignSpecStepCommand:
+			if out, err := runCommandStep(ctx, w.dir, step); err != nil {
+				return "", errors.Errorf("step %d: running %q in container %q failed: %s\n%s", i+1, step.Run, step.Container, err, out)
+			}
+
+		default:
+			return "", errors.Errorf("step %d: unknown step type %q", i+1, step.Type)
+		}
+	}
+
+	if _, err := w.git(ctx, nil, "add", "--all"); err != nil {
+		return "", err
+	}
+	diff, err := w.git(ctx, nil, "diff", "--cached", "--no-prefix", "--binary")
+	if err != nil {
+		return "", err
+	}
+	return string(diff), nil
+}
+
+// combyDiff returns the diff of the given comby step in the repository at the
+// given commit, as computed by the replacer service.
+func (e *WorkspaceExecutor) combyDiff(ctx context.Context, repo api.RepoName, commit api.CommitID, step CampaignSpecStep) (string, error) {
+	u, err := url.Parse(e.ReplacerURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("repo", string(repo))
+	q.Set("commit", string(commit))
+	q.Set("matchtemplate", step.MatchTemplate)
+	q.Set("rewritetemplate", step.RewriteTemplate)
+	q.Set("fileextension", step.FileExtension)
+	q.Set("directoryexclude", step.DirectoryExclude)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(ctx)
+
+	var httpClient httpcli.Doer = http.DefaultClient
+	if e.HTTPClient != nil {
+		httpClient = e.HTTPClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrap(err, "replacer request failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", errors.Errorf("replacer request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	// The replacer responds with one JSON object per line.
+	var diff strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 100), 10*bufio.MaxScanTokenSize)
+	for scanner.Scan() {
+		var result struct {
+			URI  string `json:"uri"`
+			Diff string `json:"diff"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			return "", errors.Wrap(err, "decoding replacer response")
+		}
+		diff.WriteString(result.Diff)
+		if !strings.HasSuffix(result.Diff, "\n") {
+			diff.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return diff.String(), nil
+}
+
+// runCommandStep executes the given command step in a container with a copy
+// of the workspace dir, and replaces the workspace with the container's copy
+// once the step succeeded. The workspace is copied with `docker cp` instead of
+// being mounted, because the Docker daemon does not share the file system of
+// repo-updater if it runs in a container. The container has no network
+// access, limited resources and a read-only file system apart from the
+// workspace and /tmp. It runs as the current user, so that the files it
+// writes can be diffed and removed.
+var runCommandStep = func(ctx context.Context, dir string, step CampaignSpecStep) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandStepTimeout)
+	defer cancel()
+
+	args := []string{"create",
+		"--network", "none",
+		"--user", strconv.Itoa(os.Getuid()) + ":" + strconv.Itoa(os.Getgid()),
+		"--mount", "type=volume,target=" + path.Dir(commandStepWorkdir),
+		"--workdir", commandStepWorkdir,
+	}
+	args = append(append(args, commandStepLimits...), step.Container, "sh", "-c", step.Run)
+	out, err := runDocker(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	container := strings.TrimSpace(string(out))
+	defer func() {
+		// Removing the container also stops it if the step timed out.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if _, err := runDocker(ctx, "rm", "--force", "--volumes", container); err != nil {
+			log15.Error("Removing container of command step", "container", container, "err", err)
+		}
+	}()
+
+	if _, err := runDocker(ctx, "cp", "--archive", dir, container+":"+commandStepWorkdir); err != nil {
+		return nil, err
+	}
+	out, err = exec.CommandContext(ctx, "docker", "start", "--attach", container).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return out, errors.Errorf("timed out after %s", commandStepTimeout)
+	}
+	if err != nil {
+		return out, err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if _, err := runDocker(ctx, "cp", container+":"+commandStepWorkdir, dir); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func runDocker(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "docker", args...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Errorf("docker %s failed: %s: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// workspace is a directory whose files are tracked in a git repository that
+// is stored outside of it.
+type workspace struct {
+	dir    string
+	gitDir string
+}
+
+func (w workspace) git(ctx context.Context, stdin io.Reader, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = w.dir
+	cmd.Stdin = stdin
+	cmd.Env = append(os.Environ(),
+		"GIT_DIR="+w.gitDir,
+		"GIT_WORK_TREE="+w.dir,
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_TERMINAL_PROMPT=0",
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Errorf("git %s failed: %s: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// RunPatchSetWorkers should be executed in a background goroutine and is
+// responsible for finding pending PatchSetJobs and executing them.
+// ctx should be canceled to terminate the function.
+func RunPatchSetWorkers(ctx context.Context, s *Store, clock func() time.Time, executor PatchSetJobExecutor, backoffDuration time.Duration) {
+	// process is executed inside a database transaction that's opened by
+	// ProcessPendingPatchSetJobs.
+	process := func(ctx context.Context, s *Store, job campaigns.PatchSetJob) error {
+		return execJobInTransaction("ExecPatchSetJob", job.ID, func() error {
+			return ExecPatchSetJob(ctx, &job, ExecPatchSetJobOpts{
+				Clock:    clock,
+				Store:    s,
+				Executor: executor,
+			})
+		})
+	}
+	runWorkers(ctx, "patch set job", backoffDuration, func(ctx context.Context) (bool, error) {
+		return s.ProcessPendingPatchSetJobs(ctx, process)
+	})
+}
+
+type ExecPatchSetJobOpts struct {
+	Clock    func() time.Time
+	Store    *Store
+	Executor PatchSetJobExecutor
+}
+
+// ExecPatchSetJob executes the steps of the spec of the PatchSet of the given
+// PatchSetJob in the job's repository and, if they produce a non-empty diff,
+// creates a Patch with it.
+// Like ExecChangesetJob, it must be executed inside a transaction and it is
+// idempotent.
+func ExecPatchSetJob(ctx context.Context, job *campaigns.PatchSetJob, opts ExecPatchSetJobOpts) (err error) {
+	tr, ctx := trace.New(ctx, "service.ExecPatchSetJob", fmt.Sprintf("job_id: %d", job.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	tr.LogFields(log.Int64("job_id", job.ID), log.Int64("patch_set_id", job.PatchSetID))
+
+	if !job.FinishedAt.IsZero() && job.Error == "" {
+		log15.Info("PatchSetJob already completed", "id", job.ID)
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			job.Error = err.Error()
+		} else {
+			job.Error = ""
+		}
+		job.FinishedAt = opts.Clock()
+
+		if e := opts.Store.UpdatePatchSetJob(ctx, job); e != nil {
+			if err == nil {
+				err = e
+			} else {
+				err = multierror.Append(err, e)
+			}
+		}
+	}()
+
+	job.StartedAt = opts.Clock()
+
+	patchSet, err := opts.Store.GetPatchSet(ctx, GetPatchSetOpts{ID: job.PatchSetID})
+	if err != nil {
+		return err
+	}
+	spec, err := ParseCampaignSpec(patchSet.Spec)
+	if err != nil {
+		return err
+	}
+
+	reposStore := repos.NewDBStore(opts.Store.DB(), sql.TxOptions{})
+	rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{IDs: []api.RepoID{job.RepoID}})
+	if err != nil {
+		return err
+	}
+	if len(rs) != 1 {
+		return errors.Errorf("repo not found: %d", job.RepoID)
+	}
+	repo := rs[0]
+
+	execCtx, cancel := context.WithTimeout(ctx, patchSetJobTimeout)
+	defer cancel()
+
+	commit, baseRef, err := opts.Executor.ResolveRevision(execCtx, api.RepoName(repo.Name), job.Rev)
+	if err != nil {
+		return errors.Wrapf(err, "resolving revision %q of repository %q", job.Rev, repo.Name)
+	}
+
+	diff, err := opts.Executor.Diff(execCtx, api.RepoName(repo.Name), commit, spec.Steps)
+	if err != nil {
+		return errors.Wrapf(err, "executing campaign spec in repository %q", repo.Name)
+	}
+	if diff == "" {
+		// Nothing to change in this repository.
+		return nil
+	}
+
+	patch := &campaigns.Patch{
+		PatchSetID: job.PatchSetID,
+		RepoID:     repo.ID,
+		Rev:        commit,
+		BaseRef:    baseRef,
+		Diff:       diff,
+	}
+	if err := patch.ComputeDiffStat(); err != nil {
+		return errors.Wrap(err, "computing diff stat")
+	}
+	if err := opts.Store.CreatePatch(ctx, patch); err != nil {
+		return err
+	}
+	job.PatchID = patch.ID
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package campaigns
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+)
+
+type fakeArchiver map[string]string
+
+func (a fakeArchiver) Archive(ctx context.Context, repo gitserver.Repo, opt gitserver.ArchiveOptions) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, contents := range a {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(contents)),
+		}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(&buf), nil
+}
+
+func TestWorkspaceExecutorDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	replacer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("repo") != "github.com/sourcegraph/foo" || q.Get("commit") != "deadbeef" || q.Get("matchtemplate") != "hello" {
+			http.Error(w, "unexpected query "+r.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"uri":  "main.go",
+			"diff": "--- main.go\n+++ main.go\n@@ -1 +1 @@\n-hello\n+goodbye\n",
+		})
+	}))
+	defer replacer.Close()
+
+	defer func(orig func(context.Context, string, CampaignSpecStep) ([]byte, error)) {
+		runCommandStep = orig
+	}(runCommandStep)
+	runCommandStep = func(ctx context.Context, dir string, step CampaignSpecStep) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, "sh", "-c", step.Run)
+		cmd.Dir = dir
+		return cmd.CombinedOutput()
+	}
+
+	e := &WorkspaceExecutor{
+		Archiver: fakeArchiver{
+			"main.go":   "hello\n",
+			"README.md": "# foo\n",
+		},
+		ReplacerURL: replacer.URL,
+	}
+
+	tests := []struct {
+		name  string
+		steps []CampaignSpecStep
+		want  string
+	}{
+		{
+			name:  "comby",
+			steps: []CampaignSpecStep{{Type: CampaignSpecStepComby, MatchTemplate: "hello", RewriteTemplate: "goodbye"}},
+			want: "diff --git main.go main.go\n" +
+				"index ce01362..dd7e1c6 100644\n" +
+				"--- main.go\n" +
+				"+++ main.go\n" +
+				"@@ -1 +1 @@\n" +
+				"-hello\n" +
+				"+goodbye\n",
+		},
+		{
+			name: "comby and command",
+			steps: []CampaignSpecStep{
+				{Type: CampaignSpecStepComby, MatchTemplate: "hello", RewriteTemplate: "goodbye"},
+				{Type: CampaignSpecStepCommand, Container: "alpine", Run: "rm README.md"},
+			},
+			want: "diff --git README.md README.md\n" +
+				"deleted file mode 100644\n" +
+				"index c2a8392..0000000\n" +
+				"--- README.md\n" +
+				"+++ /dev/null\n" +
+				"@@ -1 +0,0 @@\n" +
+				"-# foo\n" +
+				"diff --git main.go main.go\n" +
+				"index ce01362..dd7e1c6 100644\n" +
+				"--- main.go\n" +
+				"+++ main.go\n" +
+				"@@ -1 +1 @@\n" +
+				"-hello\n" +
+				"+goodbye\n",
+		},
+		{
+			// Steps must not be able to change the configuration of the git
+			// repository that tracks the workspace.
+			name:  "git directory outside of the workspace",
+			steps: []CampaignSpecStep{{Type: CampaignSpecStepCommand, Container: "alpine", Run: "test ! -e .git"}},
+			want:  "",
+		},
+		{
+			name:  "no changes",
+			steps: []CampaignSpecStep{{Type: CampaignSpecStepCommand, Container: "alpine", Run: "cat main.go"}},
+			want:  "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := e.Diff(context.Background(), api.RepoName("github.com/sourcegraph/foo"), api.CommitID("deadbeef"), tc.steps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	t.Run("failing command", func(t *testing.T) {
+		steps := []CampaignSpecStep{{Type: CampaignSpecStepCommand, Container: "alpine", Run: "exit 3"}}
+		if _, err := e.Diff(context.Background(), api.RepoName("github.com/sourcegraph/foo"), api.CommitID("deadbeef"), steps); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package campaigns
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/inconshreveable/log15"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// A Rebaser keeps the changesets created by campaigns up to date with their
+// base branch: when the base branch has moved past the base revision of a
+// changeset's Patch, it recreates the commit of the Patch on top of the new
+// head of the base branch and force-pushes the campaign branch.
+//
+// If the Patch no longer applies, the ChangesetJob is marked as Conflicted
+// and the changeset is left untouched until a later rebase succeeds.
+type Rebaser struct {
+	Store     *Store
+	GitClient GitserverClient
+
+	// ResolveRevision resolves a revision of a repository to a commit. If
+	// nil, git.ResolveRevision is used.
+	ResolveRevision func(ctx context.Context, repo api.RepoName, spec string) (api.CommitID, error)
+
+	// conflicts maps the IDs of conflicted ChangesetJobs to the head of the
+	// base branch their Patch didn't apply to, so that we only try again once
+	// the base branch moves.
+	conflicts map[int64]api.CommitID
+}
+
+// Run rebases the outdated changesets every interval until ctx is canceled.
+func (r *Rebaser) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := r.RebaseOutdatedChangesets(ctx); err != nil {
+			log15.Error("Rebasing outdated changesets", "err", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// RebaseOutdatedChangesets rebases all open changesets of open campaigns
+// whose base branch has moved. Errors of single changesets don't stop the
+// others from being rebased and are returned together.
+func (r *Rebaser) RebaseOutdatedChangesets(ctx context.Context) (err error) {
+	tr, ctx := trace.New(ctx, "Rebaser.RebaseOutdatedChangesets", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	jobs, err := r.Store.ListChangesetJobsToRebase(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing changeset jobs")
+	}
+	tr.LazyPrintf("%d changeset jobs", len(jobs))
+
+	var errs *multierror.Error
+	for _, job := range jobs {
+		if err := r.rebase(ctx, job); err != nil {
+			errs = multierror.Append(errs, errors.Wrapf(err, "rebasing changeset %d", job.ChangesetID))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+func (r *Rebaser) rebase(ctx context.Context, job *campaigns.ChangesetJob) (err error) {
+	patch, err := r.Store.GetPatch(ctx, GetPatchOpts{ID: job.PatchID})
+	if err != nil {
+		return err
+	}
+
+	reposStore := repos.NewDBStore(r.Store.DB(), sql.TxOptions{})
+	rs, err := reposStore.ListRepos(ctx, repos.StoreListReposArgs{IDs: []api.RepoID{patch.RepoID}})
+	if err != nil {
+		return err
+	}
+	if len(rs) != 1 {
+		return errors.Errorf("repo not found: %d", patch.RepoID)
+	}
+	repo := rs[0]
+
+	baseRef := "refs/heads/master"
+	if patch.BaseRef != "" {
+		baseRef = git.EnsureRefPrefix(patch.BaseRef)
+	}
+
+	head, err := r.resolveRevision(ctx, api.RepoName(repo.Name), baseRef)
+	if err != nil {
+		return errors.Wrapf(err, "resolving %q", baseRef)
+	}
+	if head == patch.Rev || (job.Conflicted && r.conflicts[job.ID] == head) {
+		return nil
+	}
+
+	c, err := r.Store.GetCampaign(ctx, GetCampaignOpts{ID: job.CampaignID})
+	if err != nil {
+		return errors.Wrap(err, "getting campaign")
+	}
+
+	// The rebased commit must have the same message as the one created by
+	// ExecChangesetJob. The body isn't needed, so no external URL is passed.
+	message, _, err := renderChangesetTemplates(ctx, r.Store, c, patch, repo.Name, "")
+	if err != nil {
+		return errors.Wrap(err, "rendering commit message")
+	}
+
+	_, err = r.GitClient.CreateCommitFromPatch(ctx, protocol.CreateCommitFromPatchRequest{
+		Repo:       api.RepoName(repo.Name),
+		BaseCommit: head,
+		// IMPORTANT: See ExecChangesetJob for why we add a trailing newline
+		// and use -p0.
+		Patch:     patch.Diff + "\n",
+		TargetRef: job.Branch,
+		UniqueRef: false,
+		CommitInfo: protocol.PatchCommitInfo{
+			Message:     message,
+			AuthorName:  "Sourcegraph Bot",
+			AuthorEmail: "campaigns@sourcegraph.com",
+			Date:        job.CreatedAt,
+		},
+		GitApplyArgs: []string{"-p0"},
+		Push:         true,
+	})
+	if err != nil {
+		diffErr, ok := err.(*protocol.CreateCommitFromPatchError)
+		if !ok || !strings.HasPrefix(diffErr.Command, "git apply") {
+			return err
+		}
+
+		// The patch doesn't apply to the new head of the base branch. We
+		// leave the changeset as is and flag it.
+		if r.conflicts == nil {
+			r.conflicts = make(map[int64]api.CommitID)
+		}
+		r.conflicts[job.ID] = head
+		if job.Conflicted {
+			return nil
+		}
+		log15.Info("Campaign changeset conflicts with its base branch", "changeset", job.ChangesetID, "repo", repo.Name, "base", head)
+		job.Conflicted = true
+		return r.Store.UpdateChangesetJob(ctx, job)
+	}
+
+	tx, err := r.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Done(&err)
+
+	patch.Rev = head
+	if err = tx.UpdatePatch(ctx, patch); err != nil {
+		return err
+	}
+
+	delete(r.conflicts, job.ID)
+	if job.Conflicted {
+		job.Conflicted = false
+		if err = tx.UpdateChangesetJob(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Rebaser) resolveRevision(ctx context.Context, repo api.RepoName, spec string) (api.CommitID, error) {
+	if r.ResolveRevision != nil {
+		return r.ResolveRevision(ctx, repo, spec)
+	}
+	return git.ResolveRevision(ctx, gitserver.Repo{Name: repo}, nil, spec, &git.ResolveRevisionOptions{})
+}
@@ -0,0 +1,168 @@
+package campaigns
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+type recordingGitserverClient struct {
+	requests []protocol.CreateCommitFromPatchRequest
+	err      error
+}
+
+func (c *recordingGitserverClient) CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error) {
+	c.requests = append(c.requests, req)
+	if c.err != nil {
+		return "", c.err
+	}
+	return req.TargetRef, nil
+}
+
+func TestRebaserRebaseOutdatedChangesets(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	clock := func() time.Time { return now.UTC().Truncate(time.Microsecond) }
+
+	dbtesting.SetupGlobalTestDB(t)
+
+	tests := []struct {
+		name      string
+		head      api.CommitID
+		gitErr    error
+		conflicts bool
+
+		wantRequest    bool
+		wantRev        api.CommitID
+		wantConflicted bool
+	}{
+		{
+			name:    "base branch did not move",
+			head:    "f00b4r",
+			wantRev: "f00b4r",
+		},
+		{
+			name:        "base branch moved",
+			head:        "b4rf00",
+			wantRequest: true,
+			wantRev:     "b4rf00",
+		},
+		{
+			name:           "patch does not apply",
+			head:           "b4rf00",
+			gitErr:         &protocol.CreateCommitFromPatchError{Command: "git apply --cached -p0"},
+			wantRequest:    true,
+			wantRev:        "f00b4r",
+			wantConflicted: true,
+		},
+		{
+			name:        "conflicted patch applies again",
+			head:        "b4rf00",
+			conflicts:   true,
+			wantRequest: true,
+			wantRev:     "b4rf00",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := dbtest.NewTx(t, dbconn.Global)
+			s := NewStoreWithClock(tx, clock)
+
+			repo, _ := createGitHubRepo(t, ctx, now, s)
+			campaign, patch := createCampaignPatch(t, ctx, now, s, repo)
+			campaign.ClosedAt = time.Time{}
+			if err := s.UpdateCampaign(ctx, campaign); err != nil {
+				t.Fatal(err)
+			}
+
+			changeset := &cmpgn.Changeset{
+				RepoID:              repo.ID,
+				CampaignIDs:         []int64{campaign.ID},
+				ExternalID:          "1234",
+				ExternalServiceType: repo.ExternalRepo.ServiceType,
+				ExternalState:       cmpgn.ChangesetStateOpen,
+				CreatedByCampaign:   true,
+			}
+			if err := s.CreateChangesets(ctx, changeset); err != nil {
+				t.Fatal(err)
+			}
+
+			job := &cmpgn.ChangesetJob{
+				CampaignID:  campaign.ID,
+				PatchID:     patch.ID,
+				ChangesetID: changeset.ID,
+				Branch:      "refs/heads/" + campaign.Branch,
+				Conflicted:  tc.conflicts,
+				StartedAt:   now,
+				FinishedAt:  now,
+			}
+			if err := s.CreateChangesetJob(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			gitClient := &recordingGitserverClient{err: tc.gitErr}
+			rebaser := &Rebaser{
+				Store:     s,
+				GitClient: gitClient,
+				ResolveRevision: func(ctx context.Context, repo api.RepoName, spec string) (api.CommitID, error) {
+					if spec != patch.BaseRef {
+						t.Fatalf("resolved %q, want %q", spec, patch.BaseRef)
+					}
+					return tc.head, nil
+				},
+			}
+			if err := rebaser.RebaseOutdatedChangesets(ctx); err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := len(gitClient.requests) == 1, tc.wantRequest; have != want {
+				t.Fatalf("have %d requests, want request: %t", len(gitClient.requests), want)
+			}
+			if tc.wantRequest {
+				req := gitClient.requests[0]
+				if req.BaseCommit != tc.head || req.TargetRef != job.Branch || req.UniqueRef || !req.Push {
+					t.Fatalf("unexpected request %+v", req)
+				}
+				if have, want := req.CommitInfo.Message, campaign.Name; have != want {
+					t.Fatalf("have commit message %q, want %q", have, want)
+				}
+			}
+
+			havePatch, err := s.GetPatch(ctx, GetPatchOpts{ID: patch.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if havePatch.Rev != tc.wantRev {
+				t.Fatalf("have patch rev %q, want %q", havePatch.Rev, tc.wantRev)
+			}
+
+			haveJob, err := s.GetChangesetJob(ctx, GetChangesetJobOpts{ID: job.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if haveJob.Conflicted != tc.wantConflicted {
+				t.Fatalf("have conflicted %t, want %t", haveJob.Conflicted, tc.wantConflicted)
+			}
+
+			// A second run doesn't retry a conflicted patch against the same
+			// head of the base branch.
+			if tc.wantConflicted {
+				if err := rebaser.RebaseOutdatedChangesets(ctx); err != nil {
+					t.Fatal(err)
+				}
+				if len(gitClient.requests) != 1 {
+					t.Fatalf("have %d requests after second run, want 1", len(gitClient.requests))
+				}
+			}
+		})
+	}
+}
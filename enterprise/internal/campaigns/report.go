@@ -0,0 +1,270 @@
+package campaigns
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// A CampaignReport is the export of the burndown of a campaign and of the
+// details of its changesets.
+type CampaignReport struct {
+	Burndown   []*ChangesetCounts    `json:"burndown"`
+	Changesets []*ChangesetReportRow `json:"changesets"`
+}
+
+// A ChangesetReportRow holds the details of a single changeset in a
+// CampaignReport.
+type ChangesetReportRow struct {
+	Repository     string                         `json:"repository"`
+	URL            string                         `json:"url"`
+	State          campaigns.ChangesetState       `json:"state"`
+	ReviewState    campaigns.ChangesetReviewState `json:"reviewState"`
+	CheckState     campaigns.ChangesetCheckState  `json:"checkState"`
+	LastActivityAt time.Time                      `json:"lastActivityAt"`
+	Assignees      []string                       `json:"assignees"`
+}
+
+// NewCampaignReport computes the CampaignReport of the given campaign, with
+// the burndown in 1-day intervals between start and end.
+//
+// The burndown counts all changesets of the campaign, like
+// Campaign.changesetCountsOverTime in the GraphQL API, but the report only
+// contains the details of the changesets in repositories the actor in ctx can
+// access.
+func NewCampaignReport(ctx context.Context, s *Store, c *campaigns.Campaign, start, end time.Time) (*CampaignReport, error) {
+	cs, _, err := s.ListChangesets(ctx, ListChangesetsOpts{CampaignID: c.ID, Limit: -1})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+
+	es, _, err := s.ListChangesetEvents(ctx, ListChangesetEventsOpts{ChangesetIDs: cs.IDs(), Limit: -1})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changeset events")
+	}
+
+	counts, err := CalcCounts(start, end, cs, es...)
+	if err != nil {
+		return nil, errors.Wrap(err, "computing burndown")
+	}
+
+	// 🚨 SECURITY: accessibleRepos filters out the repositories the actor
+	// doesn't have access to.
+	accessibleReposByID, err := accessibleRepos(ctx, cs.RepoIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*ChangesetReportRow, 0, len(cs))
+	for _, c := range cs {
+		repo, ok := accessibleReposByID[c.RepoID]
+		if !ok {
+			continue
+		}
+
+		url, err := c.URL()
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting URL of changeset %d", c.ID)
+		}
+
+		rows = append(rows, &ChangesetReportRow{
+			Repository:     string(repo.Name),
+			URL:            url,
+			State:          c.ExternalState,
+			ReviewState:    c.ExternalReviewState,
+			CheckState:     c.ExternalCheckState,
+			LastActivityAt: c.ExternalUpdatedAt,
+			Assignees:      c.Assignees(),
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Repository != rows[j].Repository {
+			return rows[i].Repository < rows[j].Repository
+		}
+		return rows[i].URL < rows[j].URL
+	})
+
+	return &CampaignReport{Burndown: counts, Changesets: rows}, nil
+}
+
+// WriteBurndownCSV writes the burndown of the report as CSV, with one row per
+// day.
+func (r *CampaignReport) WriteBurndownCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"date", "total", "merged", "closed", "open", "open_approved", "open_changes_requested", "open_pending"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	itoa := func(i int32) string { return strconv.Itoa(int(i)) }
+	for _, c := range r.Burndown {
+		record := []string{
+			c.Time.UTC().Format("2006-01-02"),
+			itoa(c.Total),
+			itoa(c.Merged),
+			itoa(c.Closed),
+			itoa(c.Open),
+			itoa(c.OpenApproved),
+			itoa(c.OpenChangesRequested),
+			itoa(c.OpenPending),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteChangesetsCSV writes the details of the changesets of the report as
+// CSV, with one row per changeset. Multiple assignees are separated by
+// spaces.
+func (r *CampaignReport) WriteChangesetsCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"repository", "url", "state", "review_state", "check_state", "last_activity_at", "assignees"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, c := range r.Changesets {
+		var lastActivity string
+		if !c.LastActivityAt.IsZero() {
+			lastActivity = c.LastActivityAt.UTC().Format(time.RFC3339)
+		}
+
+		record := []string{
+			c.Repository,
+			c.URL,
+			string(c.State),
+			string(c.ReviewState),
+			string(c.CheckState),
+			lastActivity,
+			strings.Join(c.Assignees, " "),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// NewCampaignReportHandler returns the http.Handler of the campaign report
+// endpoint of the HTTP API. It expects the following query parameters:
+//
+//	campaign: the GraphQL ID of the campaign (required)
+//	format:   "csv" (default) or "json"
+//	report:   "changesets" (default) or "burndown", for the CSV format
+//	from, to: the RFC 3339 bounds of the burndown (optional)
+//
+// The JSON format contains both the burndown and the changesets.
+func NewCampaignReportHandler(s *Store) http.Handler {
+	return &campaignReportHandler{store: s}
+}
+
+type campaignReportHandler struct {
+	store *Store
+}
+
+func (h *campaignReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may
+	// access campaigns.
+	if !conf.CampaignsReadAccessEnabled() {
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+			respond(w, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	q := r.URL.Query()
+
+	id, err := campaigns.UnmarshalCampaignID(graphql.ID(q.Get("campaign")))
+	if err != nil || id == 0 {
+		respond(w, http.StatusBadRequest, errors.New("invalid campaign ID"))
+		return
+	}
+
+	c, err := h.store.GetCampaign(ctx, GetCampaignOpts{ID: id})
+	if err == ErrNoResults {
+		respond(w, http.StatusNotFound, errors.Errorf("campaign %q not found", q.Get("campaign")))
+		return
+	} else if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	// The defaults of the burndown match those of
+	// Campaign.changesetCountsOverTime.
+	now := h.store.Clock()()
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	start := c.CreatedAt.UTC()
+	if start.After(weekAgo) {
+		start = weekAgo
+	}
+	end := now.UTC()
+
+	for param, t := range map[string]*time.Time{"from": &start, "to": &end} {
+		if v := q.Get(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				respond(w, http.StatusBadRequest, errors.Wrapf(err, "invalid %q parameter", param))
+				return
+			}
+			*t = parsed.UTC()
+		}
+	}
+	if end.After(now) {
+		end = now.UTC()
+	}
+
+	report, err := NewCampaignReport(ctx, h.store, c, start, end)
+	if err != nil {
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	switch format := q.Get("format"); format {
+	case "json":
+		respond(w, http.StatusOK, report)
+		return
+	case "", "csv":
+	default:
+		respond(w, http.StatusBadRequest, errors.Errorf("invalid format %q", format))
+		return
+	}
+
+	write := report.WriteChangesetsCSV
+	name := "changesets"
+	switch kind := q.Get("report"); kind {
+	case "", "changesets":
+	case "burndown":
+		write = report.WriteBurndownCSV
+		name = kind
+	default:
+		respond(w, http.StatusBadRequest, errors.Errorf("invalid report %q", kind))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"campaign-%d-%s.csv\"", c.ID, name))
+	if err := write(w); err != nil {
+		respond(w, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,72 @@
+package campaigns
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestCampaignReportCSV(t *testing.T) {
+	day := time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	r := &CampaignReport{
+		Burndown: []*ChangesetCounts{
+			{Time: day, Total: 2, Open: 2, OpenPending: 2},
+			{Time: day.AddDate(0, 0, 1), Total: 2, Merged: 1, Open: 1, OpenApproved: 1},
+		},
+		Changesets: []*ChangesetReportRow{
+			{
+				Repository:     "github.com/sourcegraph/sourcegraph",
+				URL:            "https://github.com/sourcegraph/sourcegraph/pull/1",
+				State:          campaigns.ChangesetStateOpen,
+				ReviewState:    campaigns.ChangesetReviewStateApproved,
+				CheckState:     campaigns.ChangesetCheckStatePassed,
+				LastActivityAt: day,
+				Assignees:      []string{"alice", "bob"},
+			},
+			{
+				Repository:  "github.com/sourcegraph/src-cli",
+				URL:         "https://github.com/sourcegraph/src-cli/pull/2",
+				State:       campaigns.ChangesetStateMerged,
+				ReviewState: campaigns.ChangesetReviewStatePending,
+				CheckState:  campaigns.ChangesetCheckStateUnknown,
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		want  string
+	}{
+		{
+			name:  "burndown",
+			write: func(b *bytes.Buffer) error { return r.WriteBurndownCSV(b) },
+			want: `date,total,merged,closed,open,open_approved,open_changes_requested,open_pending
+2020-04-01,2,0,0,2,0,0,2
+2020-04-02,2,1,0,1,1,0,0
+`,
+		},
+		{
+			name:  "changesets",
+			write: func(b *bytes.Buffer) error { return r.WriteChangesetsCSV(b) },
+			want: `repository,url,state,review_state,check_state,last_activity_at,assignees
+github.com/sourcegraph/sourcegraph,https://github.com/sourcegraph/sourcegraph/pull/1,OPEN,APPROVED,PASSED,2020-04-01T12:00:00Z,alice bob
+github.com/sourcegraph/src-cli,https://github.com/sourcegraph/src-cli/pull/2,MERGED,PENDING,UNKNOWN,,
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := tc.write(&b); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, b.String()); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
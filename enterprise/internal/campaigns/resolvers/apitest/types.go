@@ -115,6 +115,15 @@ type Campaign struct {
 	ChangesetCountsOverTime []ChangesetCounts
 	DiffStat                DiffStat
 	PatchSet                PatchSet
+	AutoMerge               CampaignAutoMerge
+}
+
+type CampaignAutoMerge struct {
+	Enabled          bool
+	Method           string
+	MaxMergesPerHour int32
+	WindowStart      string
+	WindowEnd        string
 }
 
 type CampaignConnection struct {
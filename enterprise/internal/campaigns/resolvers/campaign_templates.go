@@ -0,0 +1,166 @@
+package resolvers
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const campaignTemplateIDKind = "CampaignTemplate"
+
+func marshalCampaignTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(campaignTemplateIDKind, id)
+}
+
+func unmarshalCampaignTemplateID(id graphql.ID) (templateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &templateID)
+	return
+}
+
+type campaignTemplatesConnectionResolver struct {
+	store *ee.Store
+	opts  ee.ListCampaignTemplatesOpts
+
+	// cache results because they are used by multiple fields
+	once      sync.Once
+	templates []*campaigns.CampaignTemplate
+	next      int64
+	err       error
+}
+
+func (r *campaignTemplatesConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.CampaignTemplateResolver, error) {
+	templates, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.CampaignTemplateResolver, 0, len(templates))
+	for _, t := range templates {
+		resolvers = append(resolvers, &campaignTemplateResolver{CampaignTemplate: t})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignTemplatesConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	opts := ee.CountCampaignTemplatesOpts{NamespaceUserID: r.opts.NamespaceUserID, NamespaceOrgID: r.opts.NamespaceOrgID}
+	count, err := r.store.CountCampaignTemplates(ctx, opts)
+	return int32(count), err
+}
+
+func (r *campaignTemplatesConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(next != 0), nil
+}
+
+func (r *campaignTemplatesConnectionResolver) compute(ctx context.Context) ([]*campaigns.CampaignTemplate, int64, error) {
+	r.once.Do(func() {
+		r.templates, r.next, r.err = r.store.ListCampaignTemplates(ctx, r.opts)
+	})
+	return r.templates, r.next, r.err
+}
+
+var _ graphqlbackend.CampaignTemplateResolver = &campaignTemplateResolver{}
+
+type campaignTemplateResolver struct {
+	*campaigns.CampaignTemplate
+}
+
+func (r *campaignTemplateResolver) ID() graphql.ID {
+	return marshalCampaignTemplateID(r.CampaignTemplate.ID)
+}
+
+func (r *campaignTemplateResolver) Name() string {
+	return r.CampaignTemplate.Name
+}
+
+func (r *campaignTemplateResolver) Description() string {
+	return r.CampaignTemplate.Description
+}
+
+func (r *campaignTemplateResolver) Spec() string {
+	return r.CampaignTemplate.Spec
+}
+
+func (r *campaignTemplateResolver) Parameters() ([]graphqlbackend.CampaignTemplateParameterResolver, error) {
+	spec, err := ee.ParseCampaignTemplateSpec(r.CampaignTemplate.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.CampaignTemplateParameterResolver, 0, len(spec.Parameters))
+	for _, p := range spec.Parameters {
+		resolvers = append(resolvers, &campaignTemplateParameterResolver{p: p})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignTemplateResolver) Version() int32 {
+	return r.CampaignTemplate.Version
+}
+
+func (r *campaignTemplateResolver) Namespace(ctx context.Context) (n graphqlbackend.NamespaceResolver, err error) {
+	if r.NamespaceUserID != 0 {
+		n.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.NamespaceUserID)
+	} else {
+		n.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.NamespaceOrgID)
+	}
+
+	return n, err
+}
+
+func (r *campaignTemplateResolver) Author(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.AuthorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *campaignTemplateResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.CampaignTemplate.CreatedAt}
+}
+
+func (r *campaignTemplateResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.CampaignTemplate.UpdatedAt}
+}
+
+type campaignTemplateParameterResolver struct {
+	p ee.CampaignTemplateParameter
+}
+
+func (r *campaignTemplateParameterResolver) Name() string        { return r.p.Name }
+func (r *campaignTemplateParameterResolver) Description() string { return r.p.Description }
+func (r *campaignTemplateParameterResolver) Default() *string    { return r.p.Default }
+
+type campaignTemplateArgumentResolver struct {
+	name, value string
+}
+
+func (r *campaignTemplateArgumentResolver) Name() string  { return r.name }
+func (r *campaignTemplateArgumentResolver) Value() string { return r.value }
+
+// campaignTemplateArgumentResolvers returns resolvers for the given
+// arguments, sorted by name.
+func campaignTemplateArgumentResolvers(args map[string]string) []graphqlbackend.CampaignTemplateArgumentResolver {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolvers := make([]graphqlbackend.CampaignTemplateArgumentResolver, 0, len(names))
+	for _, name := range names {
+		resolvers = append(resolvers, &campaignTemplateArgumentResolver{name: name, value: args[name]})
+	}
+	return resolvers
+}
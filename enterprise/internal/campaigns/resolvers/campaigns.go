@@ -272,6 +272,42 @@ func (r *campaignResolver) RepositoryDiffs(
 	return &changesetDiffsConnectionResolver{changesetsConnection}, nil
 }
 
+func (r *campaignResolver) AutoMerge() *graphqlbackend.CampaignAutoMergeResolver {
+	return &graphqlbackend.CampaignAutoMergeResolver{AutoMerge: r.Campaign.AutoMerge}
+}
+
+func (r *campaignResolver) ChangesetBulkActions(ctx context.Context, args *graphqlutil.ConnectionArgs) ([]graphqlbackend.ChangesetBulkActionResolver, error) {
+	as, _, err := r.store.ListChangesetBulkActions(ctx, ee.ListChangesetBulkActionsOpts{
+		CampaignID: r.Campaign.ID,
+		Limit:      int(args.GetFirst()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetBulkActionResolver, 0, len(as))
+	for _, a := range as {
+		resolvers = append(resolvers, &changesetBulkActionResolver{store: r.store, httpFactory: r.httpFactory, ChangesetBulkAction: a})
+	}
+	return resolvers, nil
+}
+
+func (r *campaignResolver) ChangesetImports(ctx context.Context) ([]graphqlbackend.ChangesetImportResolver, error) {
+	is, _, err := r.store.ListChangesetImports(ctx, ee.ListChangesetImportsOpts{
+		CampaignID: r.Campaign.ID,
+		Limit:      -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetImportResolver, 0, len(is))
+	for _, i := range is {
+		resolvers = append(resolvers, &changesetImportResolver{ChangesetImport: i})
+	}
+	return resolvers, nil
+}
+
 func (r *campaignResolver) DiffStat(ctx context.Context) (*graphqlbackend.DiffStat, error) {
 	changesetsConnection := &changesetsConnectionResolver{
 		store: r.store,
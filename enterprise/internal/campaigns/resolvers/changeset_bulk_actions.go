@@ -0,0 +1,69 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	ee "github.com/sourcegraph/sourcegraph/enterprise/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const changesetBulkActionIDKind = "ChangesetBulkAction"
+
+func marshalChangesetBulkActionID(id int64) graphql.ID {
+	return relay.MarshalID(changesetBulkActionIDKind, id)
+}
+
+var _ graphqlbackend.ChangesetBulkActionResolver = &changesetBulkActionResolver{}
+
+type changesetBulkActionResolver struct {
+	store       *ee.Store
+	httpFactory *httpcli.Factory
+	*campaigns.ChangesetBulkAction
+}
+
+func (r *changesetBulkActionResolver) ID() graphql.ID {
+	return marshalChangesetBulkActionID(r.ChangesetBulkAction.ID)
+}
+
+func (r *changesetBulkActionResolver) Type() campaigns.ChangesetBulkActionType {
+	return r.ChangesetBulkAction.Type
+}
+
+func (r *changesetBulkActionResolver) Body() *string {
+	if r.ChangesetBulkAction.Body == "" {
+		return nil
+	}
+	return &r.ChangesetBulkAction.Body
+}
+
+func (r *changesetBulkActionResolver) Labels() []string {
+	return r.ChangesetBulkAction.Labels
+}
+
+func (r *changesetBulkActionResolver) Reviewers() []string {
+	return r.ChangesetBulkAction.Reviewers
+}
+
+func (r *changesetBulkActionResolver) Author(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *changesetBulkActionResolver) Status(ctx context.Context) (graphqlbackend.BackgroundProcessStatus, error) {
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: GetChangesetBulkActionStatus filters out the errors of
+	// repositories the current user can't access.
+	return svc.GetChangesetBulkActionStatus(ctx, r.ChangesetBulkAction)
+}
+
+func (r *changesetBulkActionResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.ChangesetBulkAction.CreatedAt}
+}
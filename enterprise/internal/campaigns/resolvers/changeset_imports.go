@@ -0,0 +1,80 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const changesetImportIDKind = "ChangesetImport"
+
+func marshalChangesetImportID(id int64) graphql.ID {
+	return relay.MarshalID(changesetImportIDKind, id)
+}
+
+func unmarshalChangesetImportID(id graphql.ID) (importID int64, err error) {
+	err = relay.UnmarshalSpec(id, &importID)
+	return
+}
+
+var _ graphqlbackend.ChangesetImportResolver = &changesetImportResolver{}
+
+type changesetImportResolver struct {
+	*campaigns.ChangesetImport
+}
+
+func (r *changesetImportResolver) ID() graphql.ID {
+	return marshalChangesetImportID(r.ChangesetImport.ID)
+}
+
+func (r *changesetImportResolver) Query() string {
+	return r.ChangesetImport.Query
+}
+
+func (r *changesetImportResolver) ExternalService(ctx context.Context) (*graphqlbackend.ExternalServiceResolver, error) {
+	// 🚨 SECURITY: Only site admins are allowed to read external services.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, nil
+	}
+
+	svc, err := db.ExternalServices.GetByID(ctx, r.ExternalServiceID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return graphqlbackend.NewExternalServiceResolver(svc), nil
+}
+
+func (r *changesetImportResolver) Author(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *changesetImportResolver) LastRunAt() *graphqlbackend.DateTime {
+	if r.ChangesetImport.LastRunAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.ChangesetImport.LastRunAt}
+}
+
+func (r *changesetImportResolver) Error() *string {
+	if r.ChangesetImport.Error == "" {
+		return nil
+	}
+	return &r.ChangesetImport.Error
+}
+
+func (r *changesetImportResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.ChangesetImport.CreatedAt}
+}
@@ -324,6 +324,20 @@ func (r *changesetResolver) CheckState(ctx context.Context) (*campaigns.Changese
 	return &state, nil
 }
 
+func (r *changesetResolver) Conflicted(ctx context.Context) (bool, error) {
+	if !r.CreatedByCampaign {
+		return false, nil
+	}
+	job, err := r.store.GetChangesetJob(ctx, ee.GetChangesetJobOpts{ChangesetID: r.Changeset.ID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return false, nil
+		}
+		return false, err
+	}
+	return job.Conflicted, nil
+}
+
 func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.ChangesetLabelResolver, error) {
 	// Only GitHub supports labels on pull requests so don't make a DB call unless we need to
 	if _, ok := r.Changeset.Metadata.(*github.PullRequest); !ok {
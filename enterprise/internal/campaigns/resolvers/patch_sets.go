@@ -121,6 +121,13 @@ func patchSetDiffStat(ctx context.Context, store *ee.Store, opts ee.ListPatchesO
 	return total, nil
 }
 
+func (r *patchSetResolver) Status(ctx context.Context) (graphqlbackend.BackgroundProcessStatus, error) {
+	svc := ee.NewService(r.store, nil)
+	// 🚨 SECURITY: GetPatchSetStatus filters out the errors of repositories
+	// the current user can't access.
+	return svc.GetPatchSetStatus(ctx, r.patchSet)
+}
+
 func (r *patchSetResolver) PreviewURL() string {
 	u := globals.ExternalURL().ResolveReference(&url.URL{Path: "/campaigns/new"})
 	q := url.Values{}
@@ -129,6 +136,37 @@ func (r *patchSetResolver) PreviewURL() string {
 	return u.String()
 }
 
+func (r *patchSetResolver) CampaignTemplate(ctx context.Context) (graphqlbackend.CampaignTemplateResolver, error) {
+	if r.patchSet.CampaignTemplateID == 0 {
+		return nil, nil
+	}
+
+	t, err := r.store.GetCampaignTemplate(ctx, ee.GetCampaignTemplateOpts{ID: r.patchSet.CampaignTemplateID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &campaignTemplateResolver{CampaignTemplate: t}, nil
+}
+
+func (r *patchSetResolver) CampaignTemplateVersion() *int32 {
+	if r.patchSet.CampaignTemplateID == 0 {
+		return nil
+	}
+	return &r.patchSet.CampaignTemplateVersion
+}
+
+func (r *patchSetResolver) CampaignTemplateArguments() *[]graphqlbackend.CampaignTemplateArgumentResolver {
+	if r.patchSet.CampaignTemplateID == 0 {
+		return nil
+	}
+	args := campaignTemplateArgumentResolvers(r.patchSet.CampaignTemplateArguments)
+	return &args
+}
+
 type patchesConnectionResolver struct {
 	store *ee.Store
 	opts  ee.ListPatchesOpts
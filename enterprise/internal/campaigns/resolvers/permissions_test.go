@@ -330,6 +330,24 @@ func TestPermissionLevels(t *testing.T) {
 					)
 				},
 			},
+			{
+				name: "markCampaignChangesetsReadyForReview",
+				mutationFunc: func(campaignID string, changesetID string, patchID string) string {
+					return fmt.Sprintf(
+						`mutation { markCampaignChangesetsReadyForReview(campaign: %q) { id } }`,
+						campaignID,
+					)
+				},
+			},
+			{
+				name: "runChangesetBulkAction",
+				mutationFunc: func(campaignID string, changesetID string, patchID string) string {
+					return fmt.Sprintf(
+						`mutation { runChangesetBulkAction(campaign: %q, action: {type: COMMENT, body: "test"}) { id } }`,
+						campaignID,
+					)
+				},
+			},
 			{
 				name: "syncChangeset",
 				mutationFunc: func(campaignID string, changesetID string, patchID string) string {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -259,6 +260,10 @@ func (r *Resolver) CreateCampaign(ctx context.Context, args *graphqlbackend.Crea
 		campaign.PatchSetID = patchSetID
 	}
 
+	if args.Input.AutoMerge != nil {
+		campaign.AutoMerge = args.Input.AutoMerge.AutoMerge()
+	}
+
 	switch relay.UnmarshalKind(args.Input.Namespace) {
 	case "User":
 		err = relay.UnmarshalSpec(args.Input.Namespace, &campaign.NamespaceUserID)
@@ -310,6 +315,11 @@ func (r *Resolver) UpdateCampaign(ctx context.Context, args *graphqlbackend.Upda
 		updateArgs.PatchSet = &patchSetID
 	}
 
+	if args.Input.AutoMerge != nil {
+		autoMerge := args.Input.AutoMerge.AutoMerge()
+		updateArgs.AutoMerge = &autoMerge
+	}
+
 	svc := ee.NewService(r.store, r.httpFactory)
 
 	// 🚨 SECURITY: UpdateCampaign checks whether current user is authorized.
@@ -607,6 +617,51 @@ func (r *Resolver) CreatePatchSetFromPatches(ctx context.Context, args graphqlba
 	return &patchSetResolver{store: r.store, patchSet: patchSet}, nil
 }
 
+func (r *Resolver) CreatePatchSetFromSpec(ctx context.Context, args graphqlbackend.CreatePatchSetFromSpecArgs) (graphqlbackend.PatchSetResolver, error) {
+	var err error
+	tr, ctx := trace.New(ctx, "Resolver.CreatePatchSetFromSpec", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may create patch sets for now. This also
+	// guards the execution of arbitrary commands in command steps.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := backend.CurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+	if user == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	spec, err := ee.ParseCampaignSpec(args.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	repoRevs, err := graphqlbackend.RepositoryRevisionsMatchingQuery(ctx, spec.RepositoriesMatchingQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving repositories matching query")
+	}
+	specRepos := make([]ee.CampaignSpecRepo, len(repoRevs))
+	for i, rr := range repoRevs {
+		specRepos[i] = ee.CampaignSpecRepo{RepoID: rr.Repo.ID, Rev: rr.Rev}
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	patchSet, err := svc.CreatePatchSetFromSpec(ctx, args.Spec, specRepos, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &patchSetResolver{store: r.store, patchSet: patchSet}, nil
+}
+
 func (r *Resolver) CloseCampaign(ctx context.Context, args *graphqlbackend.CloseCampaignArgs) (_ graphqlbackend.CampaignResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CloseCampaign", fmt.Sprintf("Campaign: %q", args.Campaign))
 	defer func() {
@@ -649,15 +704,22 @@ func (r *Resolver) PublishCampaignChangesets(ctx context.Context, args *graphqlb
 		return nil, ErrIDIsZero
 	}
 
+	opts := ee.PublishChangesetsOpts{Draft: args.Draft}
+	if args.Rollout != nil {
+		opts.WaveSize = int(args.Rollout.WaveSize)
+		opts.WaveInterval = time.Duration(args.Rollout.WaveIntervalHours) * time.Hour
+	}
+
 	svc := ee.NewService(r.store, r.httpFactory)
 	// 🚨 SECURITY: EnqueueChangesetJobs checks whether current user is authorized.
-	campaign, err := svc.EnqueueChangesetJobs(ctx, campaignID)
+	campaign, err := svc.EnqueueChangesetJobs(ctx, campaignID, opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "publishing campaign changesets")
 	}
 
 	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
 }
+
 func (r *Resolver) PublishChangeset(ctx context.Context, args *graphqlbackend.PublishChangesetArgs) (_ *graphqlbackend.EmptyResponse, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.PublishChangeset", fmt.Sprintf("Patch: %q", args.Patch))
 	defer func() {
@@ -676,7 +738,167 @@ func (r *Resolver) PublishChangeset(ctx context.Context, args *graphqlbackend.Pu
 
 	// 🚨 SECURITY: EnqueueChangesetJobForPatch checks whether current user is authorized.
 	svc := ee.NewService(r.store, r.httpFactory)
-	if err = svc.EnqueueChangesetJobForPatch(ctx, patchID); err != nil {
+	if err = svc.EnqueueChangesetJobForPatch(ctx, patchID, args.Draft); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) MarkCampaignChangesetsReadyForReview(ctx context.Context, args *graphqlbackend.MarkCampaignChangesetsReadyForReviewArgs) (_ graphqlbackend.CampaignResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.MarkCampaignChangesetsReadyForReview", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: UndraftChangesets checks whether current user is authorized.
+	campaign, err := svc.UndraftChangesets(ctx, campaignID)
+	if err != nil {
+		return nil, errors.Wrap(err, "marking campaign changesets as ready for review")
+	}
+
+	return &campaignResolver{store: r.store, httpFactory: r.httpFactory, Campaign: campaign}, nil
+}
+
+func (r *Resolver) RunChangesetBulkAction(ctx context.Context, args *graphqlbackend.RunChangesetBulkActionArgs) (_ graphqlbackend.ChangesetBulkActionResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RunChangesetBulkAction", fmt.Sprintf("Campaign: %q, Type: %q", args.Campaign, args.Action.Type))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	user, err := backend.CurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+	if user == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	action := &campaigns.ChangesetBulkAction{
+		CampaignID: campaignID,
+		UserID:     user.ID,
+		Type:       args.Action.Type,
+	}
+	if args.Action.Body != nil {
+		action.Body = *args.Action.Body
+	}
+	if args.Action.Labels != nil {
+		action.Labels = *args.Action.Labels
+	}
+	if args.Action.Reviewers != nil {
+		action.Reviewers = *args.Action.Reviewers
+	}
+
+	var filter ee.ChangesetBulkFilter
+	if f := args.Filter; f != nil {
+		filter.ExternalState = f.State
+		filter.ExternalReviewState = f.ReviewState
+		filter.ExternalCheckState = f.CheckState
+		if f.Repositories != nil {
+			for _, id := range *f.Repositories {
+				repoID, err := graphqlbackend.UnmarshalRepositoryID(id)
+				if err != nil {
+					return nil, err
+				}
+				filter.RepoIDs = append(filter.RepoIDs, repoID)
+			}
+		}
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: RunChangesetBulkAction checks whether current user is authorized.
+	if err = svc.RunChangesetBulkAction(ctx, action, filter); err != nil {
+		return nil, errors.Wrap(err, "running changeset bulk action")
+	}
+
+	return &changesetBulkActionResolver{store: r.store, httpFactory: r.httpFactory, ChangesetBulkAction: action}, nil
+}
+
+func (r *Resolver) ImportChangesets(ctx context.Context, args *graphqlbackend.ImportChangesetsArgs) (_ graphqlbackend.ChangesetImportResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ImportChangesets", fmt.Sprintf("Campaign: %q, ExternalService: %q", args.Campaign, args.ExternalService))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	externalServiceID, err := graphqlbackend.UnmarshalExternalServiceID(args.ExternalService)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling external service id")
+	}
+
+	user, err := backend.CurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+	if user == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	i := &campaigns.ChangesetImport{
+		CampaignID:        campaignID,
+		UserID:            user.ID,
+		ExternalServiceID: externalServiceID,
+		Query:             args.Query,
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: CreateChangesetImport checks whether current user is authorized.
+	if err = svc.CreateChangesetImport(ctx, i); err != nil {
+		return nil, errors.Wrap(err, "creating changeset import")
+	}
+
+	return &changesetImportResolver{ChangesetImport: i}, nil
+}
+
+func (r *Resolver) DeleteChangesetImport(ctx context.Context, args *graphqlbackend.DeleteChangesetImportArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteChangesetImport", fmt.Sprintf("ChangesetImport: %q", args.ChangesetImport))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	importID, err := unmarshalChangesetImportID(args.ChangesetImport)
+	if err != nil {
+		return nil, err
+	}
+
+	if importID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: DeleteChangesetImport checks whether current user is authorized.
+	if err = svc.DeleteChangesetImport(ctx, importID); err != nil {
 		return nil, err
 	}
 
@@ -708,6 +930,286 @@ func (r *Resolver) SyncChangeset(ctx context.Context, args *graphqlbackend.SyncC
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
+func (r *Resolver) CreateCampaignTemplate(ctx context.Context, args *graphqlbackend.CreateCampaignTemplateArgs) (_ graphqlbackend.CampaignTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateCampaignTemplate", args.Input.Name)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	user, err := backend.CurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+	if user == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	t := &campaigns.CampaignTemplate{
+		Name:     args.Input.Name,
+		Spec:     args.Input.Spec,
+		AuthorID: user.ID,
+	}
+
+	if args.Input.Description != nil {
+		t.Description = *args.Input.Description
+	}
+
+	switch relay.UnmarshalKind(args.Input.Namespace) {
+	case "User":
+		err = relay.UnmarshalSpec(args.Input.Namespace, &t.NamespaceUserID)
+	case "Org":
+		err = relay.UnmarshalSpec(args.Input.Namespace, &t.NamespaceOrgID)
+	default:
+		err = errors.Errorf("Invalid namespace %q", args.Input.Namespace)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: CreateCampaignTemplate checks whether current user is authorized.
+	if err = svc.CreateCampaignTemplate(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return &campaignTemplateResolver{CampaignTemplate: t}, nil
+}
+
+func (r *Resolver) UpdateCampaignTemplate(ctx context.Context, args *graphqlbackend.UpdateCampaignTemplateArgs) (_ graphqlbackend.CampaignTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.UpdateCampaignTemplate", fmt.Sprintf("CampaignTemplate: %q", args.Input.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	templateID, err := unmarshalCampaignTemplateID(args.Input.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign template id")
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: UpdateCampaignTemplate checks whether current user is authorized.
+	t, err := svc.UpdateCampaignTemplate(ctx, ee.UpdateCampaignTemplateArgs{
+		CampaignTemplate: templateID,
+		Name:             args.Input.Name,
+		Description:      args.Input.Description,
+		Spec:             args.Input.Spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &campaignTemplateResolver{CampaignTemplate: t}, nil
+}
+
+func (r *Resolver) DeleteCampaignTemplate(ctx context.Context, args *graphqlbackend.DeleteCampaignTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteCampaignTemplate", fmt.Sprintf("CampaignTemplate: %q", args.CampaignTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	templateID, err := unmarshalCampaignTemplateID(args.CampaignTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign template id")
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	// 🚨 SECURITY: DeleteCampaignTemplate checks whether current user is authorized.
+	if err = svc.DeleteCampaignTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) CampaignTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.CampaignTemplateResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign templates.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalCampaignTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, nil
+	}
+
+	t, err := r.store.GetCampaignTemplate(ctx, ee.GetCampaignTemplateOpts{ID: templateID})
+	if err != nil {
+		if err == ee.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &campaignTemplateResolver{CampaignTemplate: t}, nil
+}
+
+func (r *Resolver) CampaignTemplates(ctx context.Context, args *graphqlbackend.ListCampaignTemplatesArgs) (graphqlbackend.CampaignTemplatesConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins or users when read-access is enabled may access campaign templates.
+	if err := allowReadAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	var opts ee.ListCampaignTemplatesOpts
+	if args.First != nil {
+		opts.Limit = int(*args.First)
+	}
+
+	if args.Namespace != nil {
+		var err error
+		switch relay.UnmarshalKind(*args.Namespace) {
+		case "User":
+			err = relay.UnmarshalSpec(*args.Namespace, &opts.NamespaceUserID)
+		case "Org":
+			err = relay.UnmarshalSpec(*args.Namespace, &opts.NamespaceOrgID)
+		default:
+			err = errors.Errorf("Invalid namespace %q", *args.Namespace)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &campaignTemplatesConnectionResolver{store: r.store, opts: opts}, nil
+}
+
+func (r *Resolver) CreatePatchSetFromCampaignTemplate(ctx context.Context, args *graphqlbackend.CreatePatchSetFromCampaignTemplateArgs) (_ graphqlbackend.PatchSetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreatePatchSetFromCampaignTemplate", fmt.Sprintf("CampaignTemplate: %q", args.CampaignTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	templateID, err := unmarshalCampaignTemplateID(args.CampaignTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign template id")
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	templateArgs := map[string]string{}
+	if args.Arguments != nil {
+		for _, a := range *args.Arguments {
+			if _, ok := templateArgs[a.Name]; ok {
+				return nil, errors.Errorf("duplicate argument %q", a.Name)
+			}
+			templateArgs[a.Name] = a.Value
+		}
+	}
+
+	return r.createPatchSetFromCampaignTemplate(ctx, templateID, templateArgs)
+}
+
+func (r *Resolver) ReapplyCampaignTemplate(ctx context.Context, args *graphqlbackend.ReapplyCampaignTemplateArgs) (_ graphqlbackend.PatchSetResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ReapplyCampaignTemplate", fmt.Sprintf("Campaign: %q", args.Campaign))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaignID, err := campaigns.UnmarshalCampaignID(args.Campaign)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshaling campaign id")
+	}
+
+	if campaignID == 0 {
+		return nil, ErrIDIsZero
+	}
+
+	// 🚨 SECURITY: Only site admins may create patch sets for now.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	campaign, err := r.store.GetCampaign(ctx, ee.GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting campaign")
+	}
+
+	if campaign.PatchSetID == 0 {
+		return nil, errors.New("campaign was not created from a campaign template")
+	}
+
+	patchSet, err := r.store.GetPatchSet(ctx, ee.GetPatchSetOpts{ID: campaign.PatchSetID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting patch set")
+	}
+
+	if patchSet.CampaignTemplateID == 0 {
+		return nil, errors.New("campaign was not created from a campaign template")
+	}
+
+	return r.createPatchSetFromCampaignTemplate(ctx, patchSet.CampaignTemplateID, patchSet.CampaignTemplateArguments)
+}
+
+// createPatchSetFromCampaignTemplate renders the campaign template with the
+// given ID and arguments and creates a patch set from it, in the repositories
+// matching the query of the rendered spec.
+func (r *Resolver) createPatchSetFromCampaignTemplate(ctx context.Context, templateID int64, args map[string]string) (graphqlbackend.PatchSetResolver, error) {
+	// 🚨 SECURITY: Only site admins may create patch sets for now. This also
+	// guards the execution of arbitrary commands in command steps.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := backend.CurrentUser(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", backend.ErrNotAuthenticated)
+	}
+	if user == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	t, err := r.store.GetCampaignTemplate(ctx, ee.GetCampaignTemplateOpts{ID: templateID})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting campaign template")
+	}
+
+	templateSpec, err := ee.ParseCampaignTemplateSpec(t.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := templateSpec.Render(args)
+	if err != nil {
+		return nil, err
+	}
+
+	repoRevs, err := graphqlbackend.RepositoryRevisionsMatchingQuery(ctx, spec.RepositoriesMatchingQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving repositories matching query")
+	}
+	specRepos := make([]ee.CampaignSpecRepo, len(repoRevs))
+	for i, rr := range repoRevs {
+		specRepos[i] = ee.CampaignSpecRepo{RepoID: rr.Repo.ID, Rev: rr.Rev}
+	}
+
+	svc := ee.NewService(r.store, r.httpFactory)
+	patchSet, err := svc.CreatePatchSetFromCampaignTemplate(ctx, t, args, specRepos, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &patchSetResolver{store: r.store, patchSet: patchSet}, nil
+}
+
 func parseCampaignState(s *string) (campaigns.CampaignState, error) {
 	if s == nil {
 		return campaigns.CampaignStateAny, nil
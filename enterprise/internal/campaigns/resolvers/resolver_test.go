@@ -184,11 +184,25 @@ func TestCampaigns(t *testing.T) {
 
 	campaigns.Admin.Name = "Updated Admin Campaign Name"
 	campaigns.Admin.Description = "Updated Admin Campaign Description"
+	campaigns.Admin.AutoMerge = apitest.CampaignAutoMerge{
+		Enabled:          true,
+		Method:           "SQUASH",
+		MaxMergesPerHour: 3,
+		WindowStart:      "09:00",
+		WindowEnd:        "17:00",
+	}
 	updateInput := map[string]interface{}{
 		"input": map[string]interface{}{
 			"id":          campaigns.Admin.ID,
 			"name":        campaigns.Admin.Name,
 			"description": campaigns.Admin.Description,
+			"autoMerge": map[string]interface{}{
+				"enabled":          true,
+				"method":           "SQUASH",
+				"maxMergesPerHour": 3,
+				"windowStart":      "09:00",
+				"windowEnd":        "17:00",
+			},
 		},
 	}
 	var updated struct{ UpdateCampaign apitest.Campaign }
@@ -203,6 +217,7 @@ func TestCampaigns(t *testing.T) {
 				... on User { ...u }
 				... on Org  { ...o }
 			}
+			autoMerge { enabled, method, maxMergesPerHour, windowStart, windowEnd }
 		}
 		mutation($input: UpdateCampaignInput!){
 			updateCampaign(input: $input) { ...c }
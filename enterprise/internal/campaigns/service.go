@@ -3,7 +3,10 @@ package campaigns
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -15,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -96,6 +100,158 @@ func (s *Service) CreatePatchSetFromPatches(ctx context.Context, patches []*camp
 	return patchSet, nil
 }
 
+// CampaignSpecRepo is a repository, and the revision in it, in which the steps
+// of a campaign spec are executed.
+type CampaignSpecRepo struct {
+	RepoID api.RepoID
+	// Rev is the revision to execute the steps at. If empty, the default
+	// branch is used.
+	Rev string
+}
+
+// CreatePatchSetFromSpec creates a PatchSet with the given campaign spec and
+// enqueues a PatchSetJob for each of the given repositories. The Patches are
+// then computed in the background by the workers started with
+// RunPatchSetWorkers.
+func (s *Service) CreatePatchSetFromSpec(ctx context.Context, spec string, specRepos []CampaignSpecRepo, userID int32) (patchSet *campaigns.PatchSet, err error) {
+	tr, ctx := trace.New(ctx, "service.CreatePatchSetFromSpec", fmt.Sprintf("user: %d", userID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if userID == 0 {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	if _, err := ParseCampaignSpec(spec); err != nil {
+		return nil, err
+	}
+
+	return s.createPatchSetFromSpec(ctx, &campaigns.PatchSet{UserID: userID, Spec: spec}, specRepos)
+}
+
+// CreatePatchSetFromCampaignTemplate renders the CampaignTemplate with the
+// given arguments and creates a PatchSet from the rendered campaign spec, like
+// CreatePatchSetFromSpec. The PatchSet records the version of the template
+// and the arguments, so that it can be rendered again once the template
+// changes.
+func (s *Service) CreatePatchSetFromCampaignTemplate(ctx context.Context, t *campaigns.CampaignTemplate, args map[string]string, specRepos []CampaignSpecRepo, userID int32) (patchSet *campaigns.PatchSet, err error) {
+	tr, ctx := trace.New(ctx, "service.CreatePatchSetFromCampaignTemplate", fmt.Sprintf("template: %d, user: %d", t.ID, userID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if userID == 0 {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	templateSpec, err := ParseCampaignTemplateSpec(t.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := templateSpec.Render(args)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createPatchSetFromSpec(ctx, &campaigns.PatchSet{
+		UserID:                    userID,
+		Spec:                      string(data),
+		CampaignTemplateID:        t.ID,
+		CampaignTemplateVersion:   t.Version,
+		CampaignTemplateArguments: args,
+	}, specRepos)
+}
+
+func (s *Service) createPatchSetFromSpec(ctx context.Context, patchSet *campaigns.PatchSet, specRepos []CampaignSpecRepo) (_ *campaigns.PatchSet, err error) {
+	repoIDs := make([]api.RepoID, len(specRepos))
+	for i, r := range specRepos {
+		repoIDs[i] = r.RepoID
+	}
+	// 🚨 SECURITY: We use db.Repos.GetByIDs to check for which the user has access.
+	repos, err := db.Repos.GetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	reposByID := make(map[api.RepoID]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByID[repo.ID] = repo
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Done(&err)
+
+	if err = tx.CreatePatchSet(ctx, patchSet); err != nil {
+		return nil, err
+	}
+
+	for _, r := range specRepos {
+		repo, ok := reposByID[r.RepoID]
+		if !ok {
+			return nil, &db.RepoNotFoundErr{ID: r.RepoID}
+		}
+		if !campaigns.IsRepoSupported(&repo.ExternalRepo) {
+			continue
+		}
+
+		job := &campaigns.PatchSetJob{
+			PatchSetID: patchSet.ID,
+			RepoID:     repo.ID,
+			Rev:        r.Rev,
+		}
+		if err = tx.CreatePatchSetJob(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	return patchSet, nil
+}
+
+// GetPatchSetStatus returns the BackgroundProcessStatus of the computation of
+// the Patches of the given PatchSet. Error messages of repositories the user
+// doesn't have access to are filtered out.
+func (s *Service) GetPatchSetStatus(ctx context.Context, patchSet *campaigns.PatchSet) (status *campaigns.BackgroundProcessStatus, err error) {
+	traceTitle := fmt.Sprintf("patchSet: %d", patchSet.ID)
+	tr, ctx := trace.New(ctx, "service.GetPatchSetStatus", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	repoIDs, err := s.store.GetRepoIDsForFailedPatchSetJobs(ctx, patchSet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessibleRepoIDs, err := accessibleRepos(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var excludedRepos []api.RepoID
+	for _, id := range repoIDs {
+		if _, ok := accessibleRepoIDs[id]; !ok {
+			excludedRepos = append(excludedRepos, id)
+		}
+	}
+
+	return s.store.GetPatchSetStatus(ctx, GetPatchSetStatusOpts{
+		ID:                   patchSet.ID,
+		ExcludeErrorsInRepos: excludedRepos,
+	})
+}
+
 // CreateCampaign creates the Campaign. When a PatchSetID is set on the
 // Campaign it validates that the PatchSet contains Patches.
 func (s *Service) CreateCampaign(ctx context.Context, c *campaigns.Campaign) error {
@@ -110,6 +266,10 @@ func (s *Service) CreateCampaign(ctx context.Context, c *campaigns.Campaign) err
 		return ErrCampaignNameBlank
 	}
 
+	if err = c.AutoMerge.Validate(); err != nil {
+		return err
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return err
@@ -550,10 +710,39 @@ func (s *Service) RetryPublishCampaign(ctx context.Context, id int64) (campaign
 	return campaign, nil
 }
 
+// PublishChangesetsOpts are the options with which the changesets of a
+// Campaign are published.
+type PublishChangesetsOpts struct {
+	// Draft publishes the changesets as drafts, e.g. draft pull requests on
+	// GitHub, that can later be marked as ready for review with
+	// UndraftChangesets.
+	Draft bool
+
+	// WaveSize is the number of changesets that are published at a time. If
+	// zero, all changesets are published right away.
+	WaveSize int
+	// WaveInterval is the time between the publication of two waves.
+	WaveInterval time.Duration
+}
+
+// Validate returns an error if the options are invalid.
+func (o PublishChangesetsOpts) Validate() error {
+	if o.WaveSize < 0 {
+		return errors.New("wave size must not be negative")
+	}
+	if o.WaveSize > 0 && o.WaveInterval <= 0 {
+		return errors.New("wave interval must be positive")
+	}
+	return nil
+}
+
 // EnqueueChangesetJobs enqueues a ChangesetJob for each Patch associated with
 // the PatchSet in the given Campaign, creating it if necessary. The Patch has
 // to belong to a PatchSet
-func (s *Service) EnqueueChangesetJobs(ctx context.Context, campaignID int64) (_ *campaigns.Campaign, err error) {
+//
+// If opts.WaveSize is set, the ChangesetJobs are ordered by the name of their
+// repository and scheduled in waves of that size, opts.WaveInterval apart.
+func (s *Service) EnqueueChangesetJobs(ctx context.Context, campaignID int64, opts PublishChangesetsOpts) (_ *campaigns.Campaign, err error) {
 	traceTitle := fmt.Sprintf("campaign: %d", campaignID)
 	tr, ctx := trace.New(ctx, "service.EnqueueChangesetJobs", traceTitle)
 	defer func() {
@@ -561,6 +750,10 @@ func (s *Service) EnqueueChangesetJobs(ctx context.Context, campaignID int64) (_
 		tr.Finish()
 	}()
 
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
 	campaign, err := s.store.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
 	if err != nil {
 		return nil, err
@@ -615,6 +808,7 @@ func (s *Service) EnqueueChangesetJobs(ctx context.Context, campaignID int64) (_
 		jobsByPatchID[j.PatchID] = j
 	}
 
+	var publish []*campaigns.Patch
 	for _, p := range patches {
 		if _, ok := jobsByPatchID[p.ID]; ok {
 			continue
@@ -624,7 +818,32 @@ func (s *Service) EnqueueChangesetJobs(ctx context.Context, campaignID int64) (_
 			continue
 		}
 
-		j := &campaigns.ChangesetJob{CampaignID: campaign.ID, PatchID: p.ID}
+		publish = append(publish, p)
+	}
+
+	sort.SliceStable(publish, func(i, j int) bool {
+		return accessibleRepoIDs[publish[i].RepoID].Name < accessibleRepoIDs[publish[j].RepoID].Name
+	})
+
+	if opts.Draft {
+		rs := make([]*types.Repo, len(publish))
+		for i, p := range publish {
+			rs[i] = accessibleRepoIDs[p.RepoID]
+		}
+		if err := checkDraftChangesetsSupported(rs...); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.clock()
+	for i, p := range publish {
+		j := &campaigns.ChangesetJob{CampaignID: campaign.ID, PatchID: p.ID, Draft: opts.Draft}
+		// The first wave is published right away.
+		if opts.WaveSize > 0 {
+			if wave := i / opts.WaveSize; wave > 0 {
+				j.PublishAt = now.Add(time.Duration(wave) * opts.WaveInterval)
+			}
+		}
 		if err := tx.CreateChangesetJob(ctx, j); err != nil {
 			return nil, err
 		}
@@ -635,8 +854,9 @@ func (s *Service) EnqueueChangesetJobs(ctx context.Context, campaignID int64) (_
 
 // EnqueueChangesetJobForPatch queues a ChangesetJob for the Patch with the
 // given ID, creating it if necessary. The Patch has to belong to a PatchSet
-// that was attached to a Campaign.
-func (s *Service) EnqueueChangesetJobForPatch(ctx context.Context, patchID int64) (err error) {
+// that was attached to a Campaign. If draft is true, the changeset is
+// published as a draft.
+func (s *Service) EnqueueChangesetJobForPatch(ctx context.Context, patchID int64, draft bool) (err error) {
 	traceTitle := fmt.Sprintf("patch: %d", patchID)
 	tr, ctx := trace.New(ctx, "service.EnqueueChangesetJobForPatch", traceTitle)
 	defer func() {
@@ -661,10 +881,17 @@ func (s *Service) EnqueueChangesetJobForPatch(ctx context.Context, patchID int64
 
 	// 🚨 SECURITY: We use db.Repos.Get to check whether the user has access to
 	// the repository or not.
-	if _, err = db.Repos.Get(ctx, job.RepoID); err != nil {
+	repo, err := db.Repos.Get(ctx, job.RepoID)
+	if err != nil {
 		return err
 	}
 
+	if draft {
+		if err := checkDraftChangesetsSupported(repo); err != nil {
+			return err
+		}
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return err
@@ -683,6 +910,14 @@ func (s *Service) EnqueueChangesetJobForPatch(ctx context.Context, patchID int64
 		// ProcessPendingChangesetJobs can try to publish it again.
 		if existing.UnsuccessfullyCompleted() {
 			existing.Reset()
+			existing.Draft = draft
+			return tx.UpdateChangesetJob(ctx, existing)
+		}
+
+		// A ChangesetJob scheduled for a later wave is published right away.
+		if existing.Scheduled(s.clock()) {
+			existing.PublishAt = time.Time{}
+			existing.Draft = draft
 			return tx.UpdateChangesetJob(ctx, existing)
 		}
 
@@ -692,10 +927,127 @@ func (s *Service) EnqueueChangesetJobForPatch(ctx context.Context, patchID int64
 	changesetJob := &campaigns.ChangesetJob{
 		CampaignID: campaign.ID,
 		PatchID:    job.ID,
+		Draft:      draft,
 	}
 	return tx.CreateChangesetJob(ctx, changesetJob)
 }
 
+// UndraftChangesets marks the open draft changesets of the given Campaign as
+// ready for review on their code hosts. ChangesetJobs that didn't publish
+// their changeset yet publish it as ready for review.
+func (s *Service) UndraftChangesets(ctx context.Context, campaignID int64) (campaign *campaigns.Campaign, err error) {
+	traceTitle := fmt.Sprintf("campaign: %d", campaignID)
+	tr, ctx := trace.New(ctx, "service.UndraftChangesets", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	campaign, err = s.store.GetCampaign(ctx, GetCampaignOpts{ID: campaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := backend.CheckSiteAdminOrSameUser(ctx, campaign.AuthorID); err != nil {
+		return nil, err
+	}
+
+	jobs, _, err := s.store.ListChangesetJobs(ctx, ListChangesetJobsOpts{
+		CampaignID: campaign.ID,
+		Limit:      -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobsByChangesetID := make(map[int64]*campaigns.ChangesetJob)
+	for _, j := range jobs {
+		if !j.Draft {
+			continue
+		}
+
+		if j.SuccessfullyCompleted() {
+			jobsByChangesetID[j.ChangesetID] = j
+			continue
+		}
+
+		j.Draft = false
+		if err := s.store.UpdateChangesetJob(ctx, j); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(jobsByChangesetID) == 0 {
+		return campaign, nil
+	}
+
+	ids := make([]int64, 0, len(jobsByChangesetID))
+	for id := range jobsByChangesetID {
+		ids = append(ids, id)
+	}
+
+	cs, _, err := s.store.ListChangesets(ctx, ListChangesetsOpts{IDs: ids, Limit: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	cs = cs.Filter(func(c *campaigns.Changeset) bool {
+		return c.ExternalState == campaigns.ChangesetStateOpen
+	})
+
+	if len(cs) == 0 {
+		return campaign, nil
+	}
+
+	accessibleReposByID, err := accessibleRepos(ctx, cs.RepoIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	reposStore := repos.NewDBStore(s.store.DB(), sql.TxOptions{})
+	bySource, err := groupChangesetsBySource(ctx, reposStore, s.cf, s.sourcer, cs...)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := &multierror.Error{}
+	for _, group := range bySource {
+		dcs, ok := group.ChangesetSource.(repos.DraftChangesetSource)
+		if !ok {
+			continue
+		}
+
+		for _, c := range group.Changesets {
+			if _, ok := accessibleReposByID[c.RepoID]; !ok {
+				continue
+			}
+
+			if err := dcs.UndraftChangeset(ctx, c); err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+
+			j := jobsByChangesetID[c.Changeset.ID]
+			j.Draft = false
+			if err := s.store.UpdateChangesetJob(ctx, j); err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
+	}
+
+	if len(errs.Errors) != 0 {
+		return nil, errs
+	}
+
+	// As in CloseOpenChangesets, we sync the changesets right away so that
+	// their events are up to date before the next run of campaigns.Syncer.
+	if err := syncChangesetsWithSources(ctx, s.store, bySource); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
 // GetCampaignStatus returns the BackgroundProcessStatus for the given campaign.
 func (s *Service) GetCampaignStatus(ctx context.Context, c *campaigns.Campaign) (status *campaigns.BackgroundProcessStatus, err error) {
 	traceTitle := fmt.Sprintf("campaign: %d", c.ID)
@@ -757,6 +1109,332 @@ func (s *Service) GetCampaignStatus(ctx context.Context, c *campaigns.Campaign)
 	})
 }
 
+// ChangesetBulkFilter selects the changesets of a campaign a
+// ChangesetBulkAction is run on. Zero values don't filter.
+type ChangesetBulkFilter struct {
+	ExternalState       *campaigns.ChangesetState
+	ExternalReviewState *campaigns.ChangesetReviewState
+	ExternalCheckState  *campaigns.ChangesetCheckState
+	RepoIDs             []api.RepoID
+}
+
+// ErrNoChangesetsMatchFilter is returned by RunChangesetBulkAction if none of
+// the changesets of the campaign match the filter.
+var ErrNoChangesetsMatchFilter = errors.New("no changesets of the campaign match the filter")
+
+// RunChangesetBulkAction creates the given ChangesetBulkAction with one
+// ChangesetBulkJob for each changeset of the action's campaign that matches
+// the filter and is in a repository the actor in ctx can access. The jobs are
+// run in the background by RunChangesetBulkWorkers.
+func (s *Service) RunChangesetBulkAction(ctx context.Context, a *campaigns.ChangesetBulkAction, filter ChangesetBulkFilter) (err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, type: %s", a.CampaignID, a.Type)
+	tr, ctx := trace.New(ctx, "service.RunChangesetBulkAction", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Done(&err)
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: a.CampaignID})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only site admins or the authors of a campaign can run bulk
+	// actions on its changesets.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, campaign.AuthorID); err != nil {
+		return err
+	}
+
+	cs, _, err := tx.ListChangesets(ctx, ListChangesetsOpts{
+		CampaignID:          campaign.ID,
+		Limit:               -1,
+		WithoutDeleted:      true,
+		ExternalState:       filter.ExternalState,
+		ExternalReviewState: filter.ExternalReviewState,
+		ExternalCheckState:  filter.ExternalCheckState,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(filter.RepoIDs) > 0 {
+		repoIDs := make(map[api.RepoID]struct{}, len(filter.RepoIDs))
+		for _, id := range filter.RepoIDs {
+			repoIDs[id] = struct{}{}
+		}
+		cs = cs.Filter(func(c *campaigns.Changeset) bool {
+			_, ok := repoIDs[c.RepoID]
+			return ok
+		})
+	}
+
+	accessibleReposByID, err := accessibleRepos(ctx, cs.RepoIDs())
+	if err != nil {
+		return err
+	}
+	cs = cs.Filter(func(c *campaigns.Changeset) bool {
+		_, ok := accessibleReposByID[c.RepoID]
+		return ok
+	})
+
+	if len(cs) == 0 {
+		return ErrNoChangesetsMatchFilter
+	}
+
+	if err := tx.CreateChangesetBulkAction(ctx, a); err != nil {
+		return err
+	}
+
+	for _, c := range cs {
+		job := &campaigns.ChangesetBulkJob{BulkActionID: a.ID, ChangesetID: c.ID}
+		if err := tx.CreateChangesetBulkJob(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetChangesetBulkActionStatus returns the BackgroundProcessStatus for the
+// given ChangesetBulkAction, without the errors of the changesets in
+// repositories the actor in ctx can't access.
+func (s *Service) GetChangesetBulkActionStatus(ctx context.Context, a *campaigns.ChangesetBulkAction) (status *campaigns.BackgroundProcessStatus, err error) {
+	traceTitle := fmt.Sprintf("bulk action: %d", a.ID)
+	tr, ctx := trace.New(ctx, "service.GetChangesetBulkActionStatus", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	repoIDs, err := s.store.GetRepoIDsForFailedChangesetBulkJobs(ctx, a.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessibleReposByID, err := accessibleRepos(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var excludedRepos []api.RepoID
+	for _, id := range repoIDs {
+		if _, ok := accessibleReposByID[id]; !ok {
+			excludedRepos = append(excludedRepos, id)
+		}
+	}
+
+	return s.store.GetChangesetBulkActionStatus(ctx, GetChangesetBulkActionStatusOpts{
+		ID:                   a.ID,
+		ExcludeErrorsInRepos: excludedRepos,
+	})
+}
+
+// ErrChangesetImportUnsupported is returned by CreateChangesetImport if the
+// code host of the external service can't be searched for changesets.
+var ErrChangesetImportUnsupported = errors.New("changesets can only be imported from GitHub and Bitbucket Server")
+
+// CreateChangesetImport creates the given ChangesetImport, whose search is
+// then periodically run by the ChangesetImporter to import the matching
+// changesets into its campaign.
+func (s *Service) CreateChangesetImport(ctx context.Context, i *campaigns.ChangesetImport) (err error) {
+	traceTitle := fmt.Sprintf("campaign: %d, external service: %d", i.CampaignID, i.ExternalServiceID)
+	tr, ctx := trace.New(ctx, "service.CreateChangesetImport", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may import changesets for now, since the
+	// search runs with the credentials of the external service.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(i.Query) == "" {
+		return errors.New("changeset import query is empty")
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Done(&err)
+
+	campaign, err := tx.GetCampaign(ctx, GetCampaignOpts{ID: i.CampaignID})
+	if err != nil {
+		return errors.Wrap(err, "getting campaign")
+	}
+
+	if !campaign.ClosedAt.IsZero() {
+		return ErrUpdateClosedCampaign
+	}
+
+	reposStore := repos.NewDBStore(tx.DB(), sql.TxOptions{})
+	svcs, err := reposStore.ListExternalServices(ctx, repos.StoreListExternalServicesArgs{
+		IDs: []int64{i.ExternalServiceID},
+	})
+	if err != nil {
+		return errors.Wrap(err, "getting external service")
+	}
+	if len(svcs) != 1 {
+		return errors.Errorf("external service %d not found", i.ExternalServiceID)
+	}
+
+	switch svcs[0].Kind {
+	case extsvc.KindGitHub, extsvc.KindBitbucketServer:
+	default:
+		return ErrChangesetImportUnsupported
+	}
+
+	return tx.CreateChangesetImport(ctx, i)
+}
+
+// DeleteChangesetImport deletes the ChangesetImport with the given ID. The
+// changesets it already imported stay in its campaign.
+func (s *Service) DeleteChangesetImport(ctx context.Context, id int64) (err error) {
+	traceTitle := fmt.Sprintf("changeset import: %d", id)
+	tr, ctx := trace.New(ctx, "service.DeleteChangesetImport", traceTitle)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may delete changeset imports, like they
+	// are the only ones who may create them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.store.GetChangesetImport(ctx, GetChangesetImportOpts{ID: id}); err != nil {
+		return err
+	}
+
+	return s.store.DeleteChangesetImport(ctx, id)
+}
+
+// ErrCampaignTemplateNameBlank is returned by CreateCampaignTemplate or
+// UpdateCampaignTemplate if the name of the CampaignTemplate is blank.
+var ErrCampaignTemplateNameBlank = errors.New("Campaign template name cannot be blank")
+
+// CreateCampaignTemplate validates the spec of the CampaignTemplate and
+// creates it.
+func (s *Service) CreateCampaignTemplate(ctx context.Context, t *campaigns.CampaignTemplate) (err error) {
+	tr, ctx := trace.New(ctx, "service.CreateCampaignTemplate", fmt.Sprintf("Name: %q", t.Name))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may create campaign templates, since they
+	// may contain command steps.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrCampaignTemplateNameBlank
+	}
+
+	if _, err := ParseCampaignTemplateSpec(t.Spec); err != nil {
+		return err
+	}
+
+	t.Version = 1
+	return s.store.CreateCampaignTemplate(ctx, t)
+}
+
+// UpdateCampaignTemplateArgs are the arguments of UpdateCampaignTemplate.
+// Nil fields are left unchanged.
+type UpdateCampaignTemplateArgs struct {
+	CampaignTemplate int64
+	Name             *string
+	Description      *string
+	Spec             *string
+}
+
+// UpdateCampaignTemplate updates the CampaignTemplate with the given
+// arguments. Its version is incremented if its spec changes.
+func (s *Service) UpdateCampaignTemplate(ctx context.Context, args UpdateCampaignTemplateArgs) (t *campaigns.CampaignTemplate, err error) {
+	tr, ctx := trace.New(ctx, "service.UpdateCampaignTemplate", fmt.Sprintf("template: %d", args.CampaignTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may update campaign templates, like they
+	// are the only ones who may create them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Done(&err)
+
+	t, err = tx.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: args.CampaignTemplate})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting campaign template")
+	}
+
+	if args.Name != nil {
+		if strings.TrimSpace(*args.Name) == "" {
+			return nil, ErrCampaignTemplateNameBlank
+		}
+		t.Name = *args.Name
+	}
+
+	if args.Description != nil {
+		t.Description = *args.Description
+	}
+
+	if args.Spec != nil && *args.Spec != t.Spec {
+		if _, err := ParseCampaignTemplateSpec(*args.Spec); err != nil {
+			return nil, err
+		}
+		t.Spec = *args.Spec
+		t.Version++
+	}
+
+	if err := tx.UpdateCampaignTemplate(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeleteCampaignTemplate deletes the CampaignTemplate with the given ID. The
+// campaigns created from it are kept, but can't be re-applied anymore.
+func (s *Service) DeleteCampaignTemplate(ctx context.Context, id int64) (err error) {
+	tr, ctx := trace.New(ctx, "service.DeleteCampaignTemplate", fmt.Sprintf("template: %d", id))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only site admins may delete campaign templates, like they
+	// are the only ones who may create them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.store.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: id}); err != nil {
+		return err
+	}
+
+	return s.store.DeleteCampaignTemplate(ctx, id)
+}
+
 // ErrUpdateProcessingCampaign is returned by UpdateCampaign if the Campaign
 // has been published at the time of update but its ChangesetJobs have not
 // finished execution.
@@ -768,6 +1446,7 @@ type UpdateCampaignArgs struct {
 	Description *string
 	Branch      *string
 	PatchSet    *int64
+	AutoMerge   *campaigns.AutoMerge
 }
 
 // ErrCampaignNameBlank is returned by CreateCampaign or UpdateCampaign if the
@@ -864,7 +1543,22 @@ func (s *Service) UpdateCampaign(ctx context.Context, args UpdateCampaignArgs) (
 		updateBranch = true
 	}
 
+	// The auto-merge settings only affect the background merging of
+	// changesets, so changing them doesn't require updating any changesets.
+	var updateAutoMerge bool
+	if args.AutoMerge != nil && campaign.AutoMerge != *args.AutoMerge {
+		if err := args.AutoMerge.Validate(); err != nil {
+			return nil, nil, err
+		}
+
+		campaign.AutoMerge = *args.AutoMerge
+		updateAutoMerge = true
+	}
+
 	if !updateAttributes && !updatePatchSetID && !updateBranch {
+		if updateAutoMerge {
+			return campaign, nil, tx.UpdateCampaign(ctx, campaign)
+		}
 		return campaign, nil, nil
 	}
 
@@ -1279,10 +1973,25 @@ func hasCampaignAdminPermissions(ctx context.Context, c *campaigns.Campaign) (bo
 	return true, nil
 }
 
+// checkDraftChangesetsSupported returns an error if the code host of any of the
+// given repositories doesn't support draft changesets.
+func checkDraftChangesetsSupported(rs ...*types.Repo) error {
+	var unsupported []string
+	for _, r := range rs {
+		if !repos.SupportsDraftChangesets(r.ExternalRepo.ServiceType) {
+			unsupported = append(unsupported, string(r.Name))
+		}
+	}
+	if len(unsupported) > 0 {
+		return errors.Errorf("draft changesets are not supported on the code hosts of repositories %s", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
 // accessibleRepos collects the RepoIDs of the changesets and returns a set of
 // the api.RepoID for which the subset of repositories for which the actor in
 // ctx has read permissions.
-func accessibleRepos(ctx context.Context, ids []api.RepoID) (map[api.RepoID]struct{}, error) {
+func accessibleRepos(ctx context.Context, ids []api.RepoID) (map[api.RepoID]*types.Repo, error) {
 	// 🚨 SECURITY: We use db.Repos.GetByIDs to filter out repositories the
 	// user doesn't have access to.
 	accessibleRepos, err := db.Repos.GetByIDs(ctx, ids...)
@@ -1290,9 +1999,9 @@ func accessibleRepos(ctx context.Context, ids []api.RepoID) (map[api.RepoID]stru
 		return nil, err
 	}
 
-	accessibleRepoIDs := make(map[api.RepoID]struct{}, len(accessibleRepos))
+	accessibleRepoIDs := make(map[api.RepoID]*types.Repo, len(accessibleRepos))
 	for _, r := range accessibleRepos {
-		accessibleRepoIDs[r.ID] = struct{}{}
+		accessibleRepoIDs[r.ID] = r
 	}
 
 	return accessibleRepoIDs, nil
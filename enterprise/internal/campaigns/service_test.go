@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"reflect"
 	"sort"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/db"
@@ -168,7 +170,7 @@ func TestServicePermissionLevels(t *testing.T) {
 			currentUserCtx := actor.WithActor(context.Background(), actor.FromUser(tc.currentUser))
 
 			t.Run("EnqueueChangesetJobs", func(t *testing.T) {
-				_, err = svc.EnqueueChangesetJobs(currentUserCtx, campaign.ID)
+				_, err = svc.EnqueueChangesetJobs(currentUserCtx, campaign.ID, PublishChangesetsOpts{})
 				tc.assertFunc(t, err)
 			})
 
@@ -179,7 +181,7 @@ func TestServicePermissionLevels(t *testing.T) {
 
 			t.Run("EnqueueChangesetJobForPatch", func(t *testing.T) {
 				for _, p := range patches {
-					err = svc.EnqueueChangesetJobForPatch(currentUserCtx, p.ID)
+					err = svc.EnqueueChangesetJobForPatch(currentUserCtx, p.ID, false)
 					tc.assertFunc(t, err)
 				}
 			})
@@ -198,6 +200,15 @@ func TestServicePermissionLevels(t *testing.T) {
 				tc.assertFunc(t, err)
 			})
 
+			t.Run("RunChangesetBulkAction", func(t *testing.T) {
+				err = svc.RunChangesetBulkAction(currentUserCtx, &campaigns.ChangesetBulkAction{
+					CampaignID: campaign.ID,
+					UserID:     tc.currentUser,
+					Type:       campaigns.ChangesetBulkActionClose,
+				}, ChangesetBulkFilter{})
+				tc.assertFunc(t, err)
+			})
+
 			t.Run("AddChangesetsToCampaign", func(t *testing.T) {
 				_, err = svc.AddChangesetsToCampaign(currentUserCtx, campaign.ID, []int64{99999})
 				tc.assertFunc(t, err)
@@ -390,7 +401,7 @@ func TestService(t *testing.T) {
 		}
 
 		// Create a processing changeset job.
-		err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID)
+		err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID, false)
 		if err != nil {
 			t.Fatalf("Failed to create ChangesetJob: %s", err)
 		}
@@ -446,7 +457,7 @@ func TestService(t *testing.T) {
 		}
 
 		// Create a processing changeset job.
-		err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID)
+		err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID, false)
 		if err != nil {
 			t.Fatalf("Failed to create ChangesetJob: %s", err)
 		}
@@ -541,14 +552,14 @@ func TestService(t *testing.T) {
 			return []*types.Repo{}, nil
 		}
 		// should result in a not found error
-		if err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID); !errcode.IsNotFound(err) {
+		if err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID, false); !errcode.IsNotFound(err) {
 			t.Fatalf("want not found error, got: %s", err)
 		}
 
 		// Now reset the filter
 		db.MockAuthzFilter = nil
 
-		if err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID); err != nil {
+		if err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID, false); err != nil {
 			t.Fatal(err)
 		}
 		haveJob, err := store.GetChangesetJob(ctx, GetChangesetJobOpts{
@@ -563,7 +574,7 @@ func TestService(t *testing.T) {
 		}
 
 		// Try to create again, check that it's the same one
-		err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID)
+		err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID, false)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -592,7 +603,7 @@ func TestService(t *testing.T) {
 		if !haveJob.UnsuccessfullyCompleted() {
 			t.Error("tried to error out the changesetJob and failed")
 		}
-		if err := svc.EnqueueChangesetJobForPatch(ctx, patch.ID); err != nil {
+		if err := svc.EnqueueChangesetJobForPatch(ctx, patch.ID, false); err != nil {
 			t.Fatal(err)
 		}
 		haveJob3, err := store.GetChangesetJob(ctx, GetChangesetJobOpts{
@@ -653,7 +664,7 @@ func TestService(t *testing.T) {
 		defer func() { db.MockAuthzFilter = nil }()
 
 		svc := NewServiceWithClock(store, cf, clock)
-		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID); err != nil {
+		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID, PublishChangesetsOpts{}); err != nil {
 			t.Fatal(err)
 		}
 
@@ -679,7 +690,7 @@ func TestService(t *testing.T) {
 		}
 
 		// Try again, check that no new jobs have been created
-		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID); err != nil {
+		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID, PublishChangesetsOpts{}); err != nil {
 			t.Fatal(err)
 		}
 		haveJobs2, _, err := store.ListChangesetJobs(ctx, ListChangesetJobsOpts{
@@ -709,7 +720,7 @@ func TestService(t *testing.T) {
 		}
 
 		// Try again, check that the job was not reset
-		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID); err != nil {
+		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID, PublishChangesetsOpts{}); err != nil {
 			t.Fatal(err)
 		}
 		haveJobs3, _, err := store.ListChangesetJobs(ctx, ListChangesetJobsOpts{
@@ -738,6 +749,110 @@ func TestService(t *testing.T) {
 		}
 	})
 
+	t.Run("EnqueueChangesetJobs in waves", func(t *testing.T) {
+		patchSet := &campaigns.PatchSet{UserID: user.ID}
+		if err = store.CreatePatchSet(ctx, patchSet); err != nil {
+			t.Fatal(err)
+		}
+
+		// The repositories are named repo-0 to repo-3, so the patches are
+		// created in the order of the waves.
+		patches := make([]*campaigns.Patch, 0, len(rs))
+		for _, r := range rs {
+			patch := testPatch(patchSet.ID, r.ID, now)
+			if err := store.CreatePatch(ctx, patch); err != nil {
+				t.Fatal(err)
+			}
+			patches = append(patches, patch)
+		}
+
+		campaign := testCampaign(user.ID, patchSet.ID)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		svc := NewServiceWithClock(store, cf, clock)
+		opts := PublishChangesetsOpts{Draft: true, WaveSize: 3, WaveInterval: 24 * time.Hour}
+		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID, opts); err != nil {
+			t.Fatal(err)
+		}
+
+		wantPublishAt := []time.Time{{}, {}, {}, now.Add(24 * time.Hour)}
+		for i, p := range patches {
+			job, err := store.GetChangesetJob(ctx, GetChangesetJobOpts{CampaignID: campaign.ID, PatchID: p.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !job.Draft {
+				t.Errorf("job %d is not a draft", job.ID)
+			}
+			if have, want := job.PublishAt, wantPublishAt[i]; !have.Equal(want) {
+				t.Errorf("job %d: have PublishAt %s, want %s", job.ID, have, want)
+			}
+		}
+
+		status, err := svc.GetCampaignStatus(ctx, campaign)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := status.Total, int32(3); have != want {
+			t.Errorf("scheduled jobs are counted: have total %d, want %d", have, want)
+		}
+
+		// Publishing a single scheduled changeset publishes it right away.
+		last := patches[len(patches)-1]
+		if err = svc.EnqueueChangesetJobForPatch(ctx, last.ID, false); err != nil {
+			t.Fatal(err)
+		}
+		job, err := store.GetChangesetJob(ctx, GetChangesetJobOpts{CampaignID: campaign.ID, PatchID: last.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !job.PublishAt.IsZero() || job.Draft {
+			t.Errorf("job was not rescheduled: %+v", job)
+		}
+
+		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID, PublishChangesetsOpts{WaveSize: 1}); err == nil {
+			t.Error("expected error for missing wave interval")
+		}
+	})
+
+	t.Run("EnqueueChangesetJobs drafts on code host without drafts", func(t *testing.T) {
+		bbsRepo := testRepo(len(rs), extsvc.TypeBitbucketServer)
+		if err := reposStore.UpsertRepos(ctx, bbsRepo); err != nil {
+			t.Fatal(err)
+		}
+
+		patchSet := &campaigns.PatchSet{UserID: user.ID}
+		if err = store.CreatePatchSet(ctx, patchSet); err != nil {
+			t.Fatal(err)
+		}
+		patch := testPatch(patchSet.ID, bbsRepo.ID, now)
+		if err := store.CreatePatch(ctx, patch); err != nil {
+			t.Fatal(err)
+		}
+		campaign := testCampaign(user.ID, patchSet.ID)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		svc := NewServiceWithClock(store, cf, clock)
+		if _, err = svc.EnqueueChangesetJobs(ctx, campaign.ID, PublishChangesetsOpts{Draft: true}); err == nil {
+			t.Error("expected error publishing drafts on Bitbucket Server")
+		}
+		if err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID, true); err == nil {
+			t.Error("expected error publishing a draft on Bitbucket Server")
+		}
+
+		jobs, _, err := store.ListChangesetJobs(ctx, ListChangesetJobsOpts{CampaignID: campaign.ID, Limit: -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 0 {
+			t.Errorf("have %d changeset jobs, want none", len(jobs))
+		}
+	})
+
 	t.Run("GetCampaignStatus", func(t *testing.T) {
 		// Make sure that user is an admin
 		if !user.SiteAdmin {
@@ -933,7 +1048,7 @@ func TestService(t *testing.T) {
 
 				if tc.createJob {
 					// Create a processing changeset job.
-					err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID)
+					err = svc.EnqueueChangesetJobForPatch(ctx, patch.ID, false)
 					if err != nil {
 						t.Fatalf("Failed to create ChangesetJob: %s", err)
 					}
@@ -1178,6 +1293,180 @@ func TestService(t *testing.T) {
 		}
 	})
 
+	t.Run("UndraftChangesets", func(t *testing.T) {
+		patchSet := &campaigns.PatchSet{UserID: user.ID}
+		if err = store.CreatePatchSet(ctx, patchSet); err != nil {
+			t.Fatal(err)
+		}
+
+		published := testPatch(patchSet.ID, rs[0].ID, now)
+		pending := testPatch(patchSet.ID, rs[1].ID, now)
+		if err := store.CreatePatch(ctx, published); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.CreatePatch(ctx, pending); err != nil {
+			t.Fatal(err)
+		}
+
+		campaign := testCampaign(user.ID, patchSet.ID)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		changeset := testChangeset(rs[0].ID, campaign.ID, 171819, campaigns.ChangesetStateOpen)
+		if err = store.CreateChangesets(ctx, changeset); err != nil {
+			t.Fatal(err)
+		}
+
+		publishedJob := &campaigns.ChangesetJob{
+			CampaignID:  campaign.ID,
+			PatchID:     published.ID,
+			ChangesetID: changeset.ID,
+			Draft:       true,
+			StartedAt:   now,
+			FinishedAt:  now,
+		}
+		pendingJob := &campaigns.ChangesetJob{CampaignID: campaign.ID, PatchID: pending.ID, Draft: true}
+		for _, j := range []*campaigns.ChangesetJob{publishedJob, pendingJob} {
+			if err := store.CreateChangesetJob(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		fakeSource := &FakeChangesetSource{}
+		svc := NewServiceWithClock(store, cf, clock)
+		svc.sourcer = repos.NewFakeSourcer(nil, fakeSource)
+
+		if _, err := svc.UndraftChangesets(ctx, campaign.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := len(fakeSource.UndraftedChangesets), 1; have != want {
+			t.Fatalf("UndraftedChangesets has wrong length. want=%d, have=%d", want, have)
+		}
+		if have, want := fakeSource.UndraftedChangesets[0].Changeset.ID, changeset.ID; have != want {
+			t.Fatalf("wrong changeset undrafted. want=%d, have=%d", want, have)
+		}
+
+		for _, j := range []*campaigns.ChangesetJob{publishedJob, pendingJob} {
+			have, err := store.GetChangesetJob(ctx, GetChangesetJobOpts{ID: j.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.Draft {
+				t.Errorf("job %d is still a draft", j.ID)
+			}
+		}
+	})
+
+	t.Run("RunChangesetBulkAction", func(t *testing.T) {
+		campaign := testCampaign(user.ID, 0)
+		if err = store.CreateCampaign(ctx, campaign); err != nil {
+			t.Fatal(err)
+		}
+
+		open := testChangeset(rs[0].ID, campaign.ID, 202122, campaigns.ChangesetStateOpen)
+		closed := testChangeset(rs[1].ID, campaign.ID, 202123, campaigns.ChangesetStateClosed)
+		if err = store.CreateChangesets(ctx, open, closed); err != nil {
+			t.Fatal(err)
+		}
+
+		svc := NewServiceWithClock(store, cf, clock)
+
+		invalid := &campaigns.ChangesetBulkAction{CampaignID: campaign.ID, UserID: user.ID, Type: campaigns.ChangesetBulkActionComment}
+		if err := svc.RunChangesetBulkAction(ctx, invalid, ChangesetBulkFilter{}); err == nil {
+			t.Fatal("comment without body created")
+		}
+
+		merged := campaigns.ChangesetStateMerged
+		action := &campaigns.ChangesetBulkAction{
+			CampaignID: campaign.ID,
+			UserID:     user.ID,
+			Type:       campaigns.ChangesetBulkActionComment,
+			Body:       "Please take a look",
+		}
+		err := svc.RunChangesetBulkAction(ctx, action, ChangesetBulkFilter{ExternalState: &merged})
+		if err != ErrNoChangesetsMatchFilter {
+			t.Fatalf("have err %v, want %v", err, ErrNoChangesetsMatchFilter)
+		}
+
+		state := campaigns.ChangesetStateOpen
+		if err := svc.RunChangesetBulkAction(ctx, action, ChangesetBulkFilter{ExternalState: &state}); err != nil {
+			t.Fatal(err)
+		}
+
+		jobs, _, err := store.ListChangesetBulkJobs(ctx, ListChangesetBulkJobsOpts{BulkActionID: action.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := len(jobs), 1; have != want {
+			t.Fatalf("have %d jobs, want %d", have, want)
+		}
+		if have, want := jobs[0].ChangesetID, open.ID; have != want {
+			t.Fatalf("have job for changeset %d, want %d", have, want)
+		}
+
+		fakeSource := &FakeChangesetSource{}
+		err = ExecChangesetBulkJob(ctx, jobs[0], ExecChangesetBulkJobOpts{
+			Clock:   clock,
+			Store:   store,
+			Sourcer: repos.NewFakeSourcer(nil, fakeSource),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have, want := fakeSource.Comments, []string{action.Body}; !reflect.DeepEqual(have, want) {
+			t.Fatalf("have comments %v, want %v", have, want)
+		}
+		if have, want := fakeSource.CommentedChangesets[0].Changeset.ID, open.ID; have != want {
+			t.Fatalf("wrong changeset commented on. want=%d, have=%d", want, have)
+		}
+
+		status, err := svc.GetChangesetBulkActionStatus(ctx, action)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := status.ProcessState, campaigns.BackgroundProcessStateCompleted; have != want {
+			t.Fatalf("have state %q, want %q", have, want)
+		}
+
+		t.Run("sync failure", func(t *testing.T) {
+			action := &campaigns.ChangesetBulkAction{
+				CampaignID: campaign.ID,
+				UserID:     user.ID,
+				Type:       campaigns.ChangesetBulkActionComment,
+				Body:       "Only once, please",
+			}
+			if err := svc.RunChangesetBulkAction(ctx, action, ChangesetBulkFilter{ExternalState: &state}); err != nil {
+				t.Fatal(err)
+			}
+			jobs, _, err := store.ListChangesetBulkJobs(ctx, ListChangesetBulkJobsOpts{BulkActionID: action.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			fakeSource := &FakeChangesetSource{LoadErr: errors.New("rate limited")}
+			opts := ExecChangesetBulkJobOpts{
+				Clock:   clock,
+				Store:   store,
+				Sourcer: repos.NewFakeSourcer(nil, fakeSource),
+			}
+			for i := 0; i < 2; i++ {
+				if err := ExecChangesetBulkJob(ctx, jobs[0], opts); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if have, want := fakeSource.Comments, []string{action.Body}; !reflect.DeepEqual(have, want) {
+				t.Fatalf("have comments %v, want %v", have, want)
+			}
+			if jobs[0].FinishedAt.IsZero() || jobs[0].Error != "" {
+				t.Fatalf("job not completed: finished_at=%s, error=%q", jobs[0].FinishedAt, jobs[0].Error)
+			}
+		})
+	})
+
 	t.Run("RetryPublishCampaign", func(t *testing.T) {
 		patchSet := &campaigns.PatchSet{UserID: user.ID}
 		if err = store.CreatePatchSet(ctx, patchSet); err != nil {
@@ -1581,7 +1870,7 @@ func TestService_UpdateCampaignWithNewPatchSetID(t *testing.T) {
 
 			if !tt.campaignWithoutPatchSet {
 				for _, p := range patchesByID {
-					if err := svc.EnqueueChangesetJobForPatch(ctx, p.ID); err != nil {
+					if err := svc.EnqueueChangesetJobForPatch(ctx, p.ID, false); err != nil {
 						t.Fatal(err)
 					}
 				}
@@ -1600,7 +1889,7 @@ func TestService_UpdateCampaignWithNewPatchSetID(t *testing.T) {
 						if j.RepoID == repo.ID {
 							toPublish[j.ID] = j
 
-							err = svc.EnqueueChangesetJobForPatch(ctx, j.ID)
+							err = svc.EnqueueChangesetJobForPatch(ctx, j.ID, false)
 							if err != nil {
 								t.Fatalf("Failed to individually created ChangesetJob: %s", err)
 							}
@@ -0,0 +1,175 @@
+package campaigns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// CampaignSpecStepType is the type of a CampaignSpecStep.
+type CampaignSpecStepType string
+
+// CampaignSpecStepType constants.
+const (
+	// CampaignSpecStepComby rewrites the repository with comby, using the
+	// replacer service.
+	CampaignSpecStepComby CampaignSpecStepType = "comby"
+	// CampaignSpecStepCommand runs a shell command in a sandboxed container
+	// over the extracted archive of the repository.
+	CampaignSpecStepCommand CampaignSpecStepType = "command"
+)
+
+// A CampaignSpec describes how the Patches of a PatchSet are computed on the
+// server: the Steps are executed in order in every repository that contains
+// a result for RepositoriesMatchingQuery.
+//
+// CommitMessage and ChangesetBody are optional text/template templates of the
+// commit message and of the body of the changeset created in each repository.
+// They are executed with a ChangesetTemplateData.
+type CampaignSpec struct {
+	RepositoriesMatchingQuery string             `json:"repositoriesMatchingQuery"`
+	Steps                     []CampaignSpecStep `json:"steps"`
+	CommitMessage             string             `json:"commitMessage,omitempty"`
+	ChangesetBody             string             `json:"changesetBody,omitempty"`
+}
+
+// A CampaignSpecStep is a single step of a CampaignSpec.
+type CampaignSpecStep struct {
+	Type CampaignSpecStepType `json:"type"`
+
+	// Fields of comby steps.
+	MatchTemplate    string `json:"matchTemplate,omitempty"`
+	RewriteTemplate  string `json:"rewriteTemplate,omitempty"`
+	FileExtension    string `json:"fileExtension,omitempty"`
+	DirectoryExclude string `json:"directoryExclude,omitempty"`
+
+	// Fields of command steps.
+	Container string `json:"container,omitempty"`
+	Run       string `json:"run,omitempty"`
+}
+
+// ParseCampaignSpec parses and validates the given JSON campaign spec.
+func ParseCampaignSpec(spec string) (*CampaignSpec, error) {
+	var s CampaignSpec
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(spec)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&s); err != nil {
+		return nil, errors.Wrap(err, "parsing campaign spec")
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate returns an error describing all the problems with the
+// CampaignSpec, or nil if it is valid.
+func (s *CampaignSpec) Validate() error {
+	var errs *multierror.Error
+
+	if strings.TrimSpace(s.RepositoriesMatchingQuery) == "" {
+		errs = multierror.Append(errs, errors.New("repositoriesMatchingQuery is required"))
+	}
+	if len(s.Steps) == 0 {
+		errs = multierror.Append(errs, errors.New("at least one step is required"))
+	}
+
+	for i, step := range s.Steps {
+		if err := step.validate(); err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("step %d: %s", i+1, err))
+		}
+	}
+
+	// Executing the templates with empty data also catches references to
+	// fields that don't exist.
+	if _, err := s.RenderCommitMessage(&ChangesetTemplateData{}, ""); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if _, err := s.RenderChangesetBody(&ChangesetTemplateData{}, ""); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	return errs.ErrorOrNil()
+}
+
+func (s *CampaignSpecStep) validate() error {
+	switch s.Type {
+	case CampaignSpecStepComby:
+		if s.MatchTemplate == "" {
+			return errors.New("matchTemplate is required in comby steps")
+		}
+		if s.Container != "" || s.Run != "" {
+			return errors.New("container and run are not allowed in comby steps")
+		}
+	case CampaignSpecStepCommand:
+		if s.Container == "" {
+			return errors.New("container is required in command steps")
+		}
+		if strings.TrimSpace(s.Run) == "" {
+			return errors.New("run is required in command steps")
+		}
+		if s.MatchTemplate != "" || s.RewriteTemplate != "" || s.FileExtension != "" || s.DirectoryExclude != "" {
+			return errors.New("comby fields are not allowed in command steps")
+		}
+	default:
+		return fmt.Errorf("unknown step type %q", s.Type)
+	}
+	return nil
+}
+
+// ChangesetTemplateData is the data with which the CommitMessage and
+// ChangesetBody templates of a CampaignSpec are executed for a repository.
+type ChangesetTemplateData struct {
+	Repository struct {
+		Name string
+		// Owners are the owners of the changed files according to the
+		// CODEOWNERS file of the repository.
+		Owners []string
+	}
+	Campaign struct {
+		Name        string
+		Description string
+	}
+}
+
+// changesetTemplateFuncs are the functions available in the CommitMessage and
+// ChangesetBody templates.
+var changesetTemplateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// RenderCommitMessage returns the commit message for the repository in data,
+// or fallback if the CampaignSpec has no CommitMessage template.
+func (s *CampaignSpec) RenderCommitMessage(data *ChangesetTemplateData, fallback string) (string, error) {
+	return renderChangesetTemplate("commitMessage", s.CommitMessage, data, fallback)
+}
+
+// RenderChangesetBody returns the body of the changeset for the repository in
+// data, or fallback if the CampaignSpec has no ChangesetBody template.
+func (s *CampaignSpec) RenderChangesetBody(data *ChangesetTemplateData, fallback string) (string, error) {
+	return renderChangesetTemplate("changesetBody", s.ChangesetBody, data, fallback)
+}
+
+func renderChangesetTemplate(name, text string, data *ChangesetTemplateData, fallback string) (string, error) {
+	if text == "" {
+		return fallback, nil
+	}
+
+	t, err := template.New(name).Funcs(changesetTemplateFuncs).Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %s", name)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", errors.Wrapf(err, "executing %s", name)
+	}
+	return out.String(), nil
+}
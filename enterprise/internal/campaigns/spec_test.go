@@ -0,0 +1,149 @@
+package campaigns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCampaignSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    *CampaignSpec
+		wantErr string
+	}{
+		{
+			name: "comby and command steps",
+			spec: `{
+				"repositoriesMatchingQuery": "lang:go fmt.Sprintf",
+				"steps": [
+					{"type": "comby", "matchTemplate": "fmt.Sprintf(\"%d\", :[v])", "rewriteTemplate": "strconv.Itoa(:[v])", "fileExtension": ".go"},
+					{"type": "command", "container": "golang:1.14-alpine", "run": "gofmt -w ."}
+				]
+			}`,
+			want: &CampaignSpec{
+				RepositoriesMatchingQuery: "lang:go fmt.Sprintf",
+				Steps: []CampaignSpecStep{
+					{
+						Type:            CampaignSpecStepComby,
+						MatchTemplate:   `fmt.Sprintf("%d", :[v])`,
+						RewriteTemplate: "strconv.Itoa(:[v])",
+						FileExtension:   ".go",
+					},
+					{
+						Type:      CampaignSpecStepCommand,
+						Container: "golang:1.14-alpine",
+						Run:       "gofmt -w .",
+					},
+				},
+			},
+		},
+		{
+			name:    "invalid JSON",
+			spec:    `{"repositoriesMatchingQuery": `,
+			wantErr: "parsing campaign spec",
+		},
+		{
+			name:    "unknown field",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "matchTemplate": "a", "image": "b"}]}`,
+			wantErr: `unknown field "image"`,
+		},
+		{
+			name:    "missing query and steps",
+			spec:    `{}`,
+			wantErr: "repositoriesMatchingQuery is required",
+		},
+		{
+			name:    "no steps",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": []}`,
+			wantErr: "at least one step is required",
+		},
+		{
+			name:    "unknown step type",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": [{"type": "sed"}]}`,
+			wantErr: `step 1: unknown step type "sed"`,
+		},
+		{
+			name:    "comby step without match template",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "rewriteTemplate": "b"}]}`,
+			wantErr: "step 1: matchTemplate is required in comby steps",
+		},
+		{
+			name:    "command step without container",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "matchTemplate": "a"}, {"type": "command", "run": "ls"}]}`,
+			wantErr: "step 2: container is required in command steps",
+		},
+		{
+			name:    "command step with comby fields",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": [{"type": "command", "container": "alpine", "run": "ls", "matchTemplate": "a"}]}`,
+			wantErr: "step 1: comby fields are not allowed in command steps",
+		},
+		{
+			name:    "invalid commit message template",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "matchTemplate": "a"}], "commitMessage": "{{.Repository.Name"}`,
+			wantErr: "parsing commitMessage",
+		},
+		{
+			name:    "changeset body template with unknown field",
+			spec:    `{"repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "matchTemplate": "a"}], "changesetBody": "{{.Repo}}"}`,
+			wantErr: "executing changesetBody",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := ParseCampaignSpec(tc.spec)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("have err %v, want err containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestCampaignSpecRenderChangesetTemplates(t *testing.T) {
+	spec := &CampaignSpec{
+		CommitMessage: "Upgrade {{.Repository.Name}}",
+		ChangesetBody: "{{.Campaign.Description}}\n\ncc {{join .Repository.Owners \", \"}}",
+	}
+
+	var data ChangesetTemplateData
+	data.Repository.Name = "github.com/sourcegraph/sourcegraph"
+	data.Repository.Owners = []string{"@alice", "@bob"}
+	data.Campaign.Name = "Upgrade"
+	data.Campaign.Description = "Upgrades the dependency."
+
+	message, err := spec.RenderCommitMessage(&data, "fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := message, "Upgrade github.com/sourcegraph/sourcegraph"; have != want {
+		t.Fatalf("have commit message %q, want %q", have, want)
+	}
+
+	body, err := spec.RenderChangesetBody(&data, "fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := body, "Upgrades the dependency.\n\ncc @alice, @bob"; have != want {
+		t.Fatalf("have changeset body %q, want %q", have, want)
+	}
+
+	message, err = (&CampaignSpec{}).RenderCommitMessage(&data, "fallback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := message, "fallback"; have != want {
+		t.Fatalf("have commit message %q, want %q", have, want)
+	}
+}
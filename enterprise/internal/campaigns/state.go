@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
@@ -315,6 +316,8 @@ func computeSingleChangesetState(c *cmpgn.Changeset) (s cmpgn.ChangesetState, er
 		} else {
 			s = cmpgn.ChangesetState(m.State)
 		}
+	case *azuredevops.PullRequest:
+		s = cmpgn.AzureDevOpsChangesetState(m.Status)
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -351,6 +354,18 @@ func computeSingleChangesetReviewState(c *cmpgn.Changeset) (s cmpgn.ChangesetRev
 				states[cmpgn.ChangesetReviewStateApproved] = true
 			}
 		}
+
+	case *azuredevops.PullRequest:
+		for _, r := range m.Reviewers {
+			switch {
+			case r.Vote <= azuredevops.VoteWaitingForAuthor:
+				states[cmpgn.ChangesetReviewStateChangesRequested] = true
+			case r.Vote >= azuredevops.VoteApprovedWithSuggestions:
+				states[cmpgn.ChangesetReviewStateApproved] = true
+			default:
+				states[cmpgn.ChangesetReviewStatePending] = true
+			}
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
@@ -317,6 +318,24 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: cmpgn.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "azuredevops - approved with suggestions",
+			changeset: azureDevOpsChangeset(daysAgo(10), "active", azuredevops.VoteApprovedWithSuggestions, azuredevops.VoteNone),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "azuredevops - waiting for author",
+			changeset: azureDevOpsChangeset(daysAgo(10), "active", azuredevops.VoteApproved, azuredevops.VoteWaitingForAuthor),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetReviewStateChangesRequested,
+		},
+		{
+			name:      "azuredevops - no votes",
+			changeset: azureDevOpsChangeset(daysAgo(10), "active", azuredevops.VoteNone),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetReviewStatePending,
+		},
 	}
 
 	for i, tc := range tests {
@@ -405,6 +424,24 @@ func TestComputeChangesetState(t *testing.T) {
 			},
 			want: cmpgn.ChangesetStateDeleted,
 		},
+		{
+			name:      "azuredevops - active",
+			changeset: azureDevOpsChangeset(daysAgo(10), "active"),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetStateOpen,
+		},
+		{
+			name:      "azuredevops - abandoned",
+			changeset: azureDevOpsChangeset(daysAgo(10), "abandoned"),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetStateClosed,
+		},
+		{
+			name:      "azuredevops - completed",
+			changeset: azureDevOpsChangeset(daysAgo(10), "completed"),
+			history:   []changesetStatesAtTime{},
+			want:      cmpgn.ChangesetStateMerged,
+		},
 	}
 
 	for i, tc := range tests {
@@ -443,6 +480,18 @@ func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *campai
 	}
 }
 
+func azureDevOpsChangeset(updatedAt time.Time, status string, votes ...int) *campaigns.Changeset {
+	pr := &azuredevops.PullRequest{Status: status}
+	for _, v := range votes {
+		pr.Reviewers = append(pr.Reviewers, &azuredevops.Reviewer{Vote: v})
+	}
+	return &campaigns.Changeset{
+		ExternalServiceType: extsvc.TypeAzureDevOps,
+		UpdatedAt:           updatedAt,
+		Metadata:            pr,
+	}
+}
+
 func githubChangeset(updatedAt time.Time, state string) *campaigns.Changeset {
 	return &campaigns.Changeset{
 		ExternalServiceType: extsvc.TypeGitHub,
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/db/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 )
@@ -102,6 +103,8 @@ UPDATE changeset_jobs j SET started_at = now() WHERE id = (
 	SELECT j.id FROM changeset_jobs j
 	JOIN campaigns c ON c.id = j.campaign_id
 	WHERE j.started_at IS NULL AND c.patch_set_id IS NOT NULL
+	-- Scheduled jobs only run once they're due and never in closed campaigns.
+	AND (j.publish_at IS NULL OR (j.publish_at <= now() AND c.closed_at IS NULL))
 	ORDER BY j.updated_at ASC
 	FOR UPDATE SKIP LOCKED LIMIT 1
 )
@@ -110,6 +113,9 @@ RETURNING j.id,
   j.patch_id,
   j.changeset_id,
   j.branch,
+  j.conflicted,
+  j.draft,
+  j.publish_at,
   j.error,
   j.started_at,
   j.finished_at,
@@ -1170,9 +1176,10 @@ INSERT INTO campaigns (
   updated_at,
   changeset_ids,
   patch_set_id,
-  closed_at
+  closed_at,
+  auto_merge
 )
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING
   id,
   name,
@@ -1185,7 +1192,8 @@ RETURNING
   updated_at,
   changeset_ids,
   patch_set_id,
-  closed_at
+  closed_at,
+  auto_merge
 `
 
 func (s *Store) createCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error) {
@@ -1194,6 +1202,11 @@ func (s *Store) createCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		return nil, err
 	}
 
+	autoMerge, err := json.Marshal(c.AutoMerge)
+	if err != nil {
+		return nil, err
+	}
+
 	if c.CreatedAt.IsZero() {
 		c.CreatedAt = s.now()
 	}
@@ -1215,6 +1228,7 @@ func (s *Store) createCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		changesetIDs,
 		nullInt64Column(c.PatchSetID),
 		nullTimeColumn(c.ClosedAt),
+		autoMerge,
 	), nil
 }
 
@@ -1272,8 +1286,9 @@ SET (
   updated_at,
   changeset_ids,
   patch_set_id,
-  closed_at
-) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+  closed_at,
+  auto_merge
+) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   id,
@@ -1287,7 +1302,8 @@ RETURNING
   updated_at,
   changeset_ids,
   patch_set_id,
-  closed_at
+  closed_at,
+  auto_merge
 `
 
 func (s *Store) updateCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error) {
@@ -1296,6 +1312,11 @@ func (s *Store) updateCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		return nil, err
 	}
 
+	autoMerge, err := json.Marshal(c.AutoMerge)
+	if err != nil {
+		return nil, err
+	}
+
 	c.UpdatedAt = s.now()
 
 	return sqlf.Sprintf(
@@ -1310,6 +1331,7 @@ func (s *Store) updateCampaignQuery(c *campaigns.Campaign) (*sqlf.Query, error)
 		changesetIDs,
 		nullInt64Column(c.PatchSetID),
 		nullTimeColumn(c.ClosedAt),
+		autoMerge,
 		c.ID,
 	), nil
 }
@@ -1427,7 +1449,8 @@ SELECT
   updated_at,
   changeset_ids,
   patch_set_id,
-  closed_at
+  closed_at,
+  auto_merge
 FROM campaigns
 WHERE %s
 LIMIT 1
@@ -1460,6 +1483,8 @@ type ListCampaignsOpts struct {
 	HasPatchSet *bool
 	// Only return campaigns where author_id is the given.
 	OnlyForAuthor int32
+	// Only return campaigns that have auto-merge enabled.
+	OnlyAutoMerge bool
 }
 
 // ListCampaigns lists Campaigns with the given filters.
@@ -1498,11 +1523,11 @@ SELECT
   updated_at,
   changeset_ids,
   patch_set_id,
-  closed_at
+  closed_at,
+  auto_merge
 FROM campaigns
 WHERE %s
 ORDER BY id ASC
-LIMIT %s
 `
 
 func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
@@ -1511,6 +1536,11 @@ func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
 	}
 	opts.Limit++
 
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("id >= %s", opts.Cursor),
 	}
@@ -1538,10 +1568,13 @@ func listCampaignsQuery(opts *ListCampaignsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("author_id = %d", opts.OnlyForAuthor))
 	}
 
+	if opts.OnlyAutoMerge {
+		preds = append(preds, sqlf.Sprintf(`auto_merge @> '{"enabled": true}'`))
+	}
+
 	return sqlf.Sprintf(
-		listCampaignsQueryFmtstr,
+		listCampaignsQueryFmtstr+limitClause,
 		sqlf.Join(preds, "\n AND "),
-		opts.Limit,
 	)
 }
 
@@ -1563,17 +1596,30 @@ var createPatchSetQueryFmtstr = `
 INSERT INTO patch_sets (
   created_at,
   updated_at,
-  user_id
+  user_id,
+  spec,
+  campaign_template_id,
+  campaign_template_version,
+  campaign_template_arguments
 )
-VALUES (%s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
 RETURNING
   id,
   created_at,
   updated_at,
-  user_id
+  user_id,
+  spec,
+  campaign_template_id,
+  campaign_template_version,
+  campaign_template_arguments
 `
 
 func (s *Store) createPatchSetQuery(c *campaigns.PatchSet) (*sqlf.Query, error) {
+	arguments, err := campaignTemplateArgumentsColumn(c.CampaignTemplateArguments)
+	if err != nil {
+		return nil, err
+	}
+
 	if c.CreatedAt.IsZero() {
 		c.CreatedAt = s.now()
 	}
@@ -1587,9 +1633,20 @@ func (s *Store) createPatchSetQuery(c *campaigns.PatchSet) (*sqlf.Query, error)
 		c.CreatedAt,
 		c.UpdatedAt,
 		c.UserID,
+		c.Spec,
+		nullInt64Column(c.CampaignTemplateID),
+		c.CampaignTemplateVersion,
+		arguments,
 	), nil
 }
 
+func campaignTemplateArgumentsColumn(arguments map[string]string) ([]byte, error) {
+	if arguments == nil {
+		arguments = map[string]string{}
+	}
+	return json.Marshal(arguments)
+}
+
 // UpdatePatchSet updates the given PatchSet.
 func (s *Store) UpdatePatchSet(ctx context.Context, c *campaigns.PatchSet) error {
 	q, err := s.updatePatchSetQuery(c)
@@ -1608,23 +1665,40 @@ var updatePatchSetQueryFmtstr = `
 UPDATE patch_sets
 SET (
   updated_at,
-  user_id
-) = (%s, %s)
+  user_id,
+  spec,
+  campaign_template_id,
+  campaign_template_version,
+  campaign_template_arguments
+) = (%s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   id,
   created_at,
   updated_at,
-  user_id
+  user_id,
+  spec,
+  campaign_template_id,
+  campaign_template_version,
+  campaign_template_arguments
 `
 
 func (s *Store) updatePatchSetQuery(c *campaigns.PatchSet) (*sqlf.Query, error) {
+	arguments, err := campaignTemplateArgumentsColumn(c.CampaignTemplateArguments)
+	if err != nil {
+		return nil, err
+	}
+
 	c.UpdatedAt = s.now()
 
 	return sqlf.Sprintf(
 		updatePatchSetQueryFmtstr,
 		c.UpdatedAt,
 		c.UserID,
+		c.Spec,
+		nullInt64Column(c.CampaignTemplateID),
+		c.CampaignTemplateVersion,
+		arguments,
 		c.ID,
 	), nil
 }
@@ -1730,7 +1804,11 @@ SELECT
   id,
   created_at,
   updated_at,
-  user_id
+  user_id,
+  spec,
+  campaign_template_id,
+  campaign_template_version,
+  campaign_template_arguments
 FROM patch_sets
 WHERE %s
 LIMIT 1
@@ -1773,15 +1851,15 @@ func (s *Store) GetCampaignStatus(ctx context.Context, opts GetCampaignStatusOpt
 }
 
 func getCampaignStatusQuery(opts *GetCampaignStatusOpts) *sqlf.Query {
-	var preds []*sqlf.Query
+	// ChangesetJobs scheduled for a later wave are left out, so that a
+	// campaign isn't processing until the last wave was published.
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("(publish_at IS NULL OR publish_at <= now())"),
+	}
 	if opts.ID != 0 {
 		preds = append(preds, sqlf.Sprintf("campaign_id = %s", opts.ID))
 	}
 
-	if len(preds) == 0 {
-		preds = append(preds, sqlf.Sprintf("TRUE"))
-	}
-
 	var errorsPreds []*sqlf.Query
 	if opts.ExcludeErrors {
 		errorsPreds = append(errorsPreds, sqlf.Sprintf("FALSE"))
@@ -1886,7 +1964,11 @@ SELECT
   id,
   created_at,
   updated_at,
-  user_id
+  user_id,
+  spec,
+  campaign_template_id,
+  campaign_template_version,
+  campaign_template_arguments
 FROM patch_sets
 WHERE %s
 ORDER BY id ASC
@@ -2343,19 +2425,25 @@ INSERT INTO changeset_jobs (
   patch_id,
   changeset_id,
   branch,
+  conflicted,
+  draft,
+  publish_at,
   error,
   started_at,
   finished_at,
   created_at,
   updated_at
 )
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING
   id,
   campaign_id,
   patch_id,
   changeset_id,
   branch,
+  conflicted,
+  draft,
+  publish_at,
   error,
   started_at,
   finished_at,
@@ -2378,6 +2466,9 @@ func (s *Store) createChangesetJobQuery(c *campaigns.ChangesetJob) (*sqlf.Query,
 		c.PatchID,
 		nullInt64Column(c.ChangesetID),
 		c.Branch,
+		c.Conflicted,
+		c.Draft,
+		nullTimeColumn(c.PublishAt),
 		nullStringColumn(c.Error),
 		nullTimeColumn(c.StartedAt),
 		nullTimeColumn(c.FinishedAt),
@@ -2407,11 +2498,14 @@ SET (
   patch_id,
   changeset_id,
   branch,
+  conflicted,
+  draft,
+  publish_at,
   error,
   started_at,
   finished_at,
   updated_at
-) = (%s, %s, %s, %s, %s, %s, %s, %s)
+) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   id,
@@ -2419,6 +2513,9 @@ RETURNING
   patch_id,
   changeset_id,
   branch,
+  conflicted,
+  draft,
+  publish_at,
   error,
   started_at,
   finished_at,
@@ -2435,6 +2532,9 @@ func (s *Store) updateChangesetJobQuery(c *campaigns.ChangesetJob) (*sqlf.Query,
 		c.PatchID,
 		nullInt64Column(c.ChangesetID),
 		c.Branch,
+		c.Conflicted,
+		c.Draft,
+		nullTimeColumn(c.PublishAt),
 		nullStringColumn(c.Error),
 		nullTimeColumn(c.StartedAt),
 		nullTimeColumn(c.FinishedAt),
@@ -2529,6 +2629,9 @@ SELECT
   patch_id,
   changeset_id,
   branch,
+  conflicted,
+  draft,
+  publish_at,
   error,
   started_at,
   finished_at,
@@ -2603,6 +2706,9 @@ SELECT
   changeset_jobs.patch_id,
   changeset_jobs.changeset_id,
   changeset_jobs.branch,
+  changeset_jobs.conflicted,
+  changeset_jobs.draft,
+  changeset_jobs.publish_at,
   changeset_jobs.error,
   changeset_jobs.started_at,
   changeset_jobs.finished_at,
@@ -2785,146 +2891,1588 @@ func (s *Store) GetRepoIDsForFailedChangesetJobs(ctx context.Context, campaign i
 	return ids, nil
 }
 
-func (s *Store) exec(ctx context.Context, q *sqlf.Query, sc scanFunc) error {
-	_, _, err := s.query(ctx, q, sc)
-	return err
+// ListChangesetJobsToRebase lists the ChangesetJobs that successfully created
+// a changeset that is still open, in campaigns that are not closed. Their
+// patches are rebased when the base branch moves.
+func (s *Store) ListChangesetJobsToRebase(ctx context.Context) ([]*campaigns.ChangesetJob, error) {
+	q := sqlf.Sprintf(listChangesetJobsToRebaseQueryFmtstr, campaigns.ChangesetStateOpen)
+
+	var js []*campaigns.ChangesetJob
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var j campaigns.ChangesetJob
+		if err = scanChangesetJob(&j, sc); err != nil {
+			return 0, 0, err
+		}
+		js = append(js, &j)
+		return j.ID, 1, err
+	})
+	return js, err
 }
 
-func (s *Store) query(ctx context.Context, q *sqlf.Query, sc scanFunc) (last, count int64, err error) {
-	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+var listChangesetJobsToRebaseQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListChangesetJobsToRebase
+SELECT
+  changeset_jobs.id,
+  changeset_jobs.campaign_id,
+  changeset_jobs.patch_id,
+  changeset_jobs.changeset_id,
+  changeset_jobs.branch,
+  changeset_jobs.conflicted,
+  changeset_jobs.draft,
+  changeset_jobs.publish_at,
+  changeset_jobs.error,
+  changeset_jobs.started_at,
+  changeset_jobs.finished_at,
+  changeset_jobs.created_at,
+  changeset_jobs.updated_at
+FROM changeset_jobs
+JOIN campaigns ON campaigns.id = changeset_jobs.campaign_id
+JOIN changesets ON changesets.id = changeset_jobs.changeset_id
+WHERE
+  changeset_jobs.finished_at IS NOT NULL
+AND
+  changeset_jobs.branch != ''
+AND
+  campaigns.closed_at IS NULL
+AND
+  changesets.external_state = %s
+AND
+  changesets.external_deleted_at IS NULL
+ORDER BY changeset_jobs.id ASC
+`
+
+// CreatePatchSetJob creates the given PatchSetJob.
+func (s *Store) CreatePatchSetJob(ctx context.Context, j *campaigns.PatchSetJob) error {
+	q, err := s.createPatchSetJobQuery(j)
 	if err != nil {
-		return 0, 0, err
+		return err
 	}
-	return scanAll(rows, sc)
-}
 
-// scanner captures the Scan method of sql.Rows and sql.Row
-type scanner interface {
-	Scan(dst ...interface{}) error
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanPatchSetJob(j, sc)
+		return j.ID, 1, err
+	})
 }
 
-// a scanFunc scans one or more rows from a scanner, returning
-// the last id column scanned and the count of scanned rows.
-type scanFunc func(scanner) (last, count int64, err error)
+var createPatchSetJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreatePatchSetJob
+INSERT INTO patch_set_jobs (
+  patch_set_id,
+  repo_id,
+  rev,
+  patch_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+  id,
+  patch_set_id,
+  repo_id,
+  rev,
+  patch_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+`
 
-func scanAll(rows *sql.Rows, scan scanFunc) (last, count int64, err error) {
-	defer closeErr(rows, &err)
+func (s *Store) createPatchSetJobQuery(j *campaigns.PatchSetJob) (*sqlf.Query, error) {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
 
-	last = -1
-	for rows.Next() {
-		var n int64
-		if last, n, err = scan(rows); err != nil {
-			return last, count, err
-		}
-		count += n
+	if j.UpdatedAt.IsZero() {
+		j.UpdatedAt = j.CreatedAt
 	}
 
-	return last, count, rows.Err()
+	return sqlf.Sprintf(
+		createPatchSetJobQueryFmtstr,
+		j.PatchSetID,
+		j.RepoID,
+		j.Rev,
+		nullInt64Column(j.PatchID),
+		j.Error,
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		j.CreatedAt,
+		j.UpdatedAt,
+	), nil
 }
 
-func closeErr(c io.Closer, err *error) {
-	if e := c.Close(); err != nil && *err == nil {
-		*err = e
+// UpdatePatchSetJob updates the given PatchSetJob.
+func (s *Store) UpdatePatchSetJob(ctx context.Context, j *campaigns.PatchSetJob) error {
+	q, err := s.updatePatchSetJobQuery(j)
+	if err != nil {
+		return err
 	}
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanPatchSetJob(j, sc)
+		return j.ID, 1, err
+	})
 }
 
-func scanChangeset(t *campaigns.Changeset, s scanner) error {
-	var metadata json.RawMessage
+var updatePatchSetJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UpdatePatchSetJob
+UPDATE patch_set_jobs
+SET (
+  patch_set_id,
+  repo_id,
+  rev,
+  patch_id,
+  error,
+  started_at,
+  finished_at,
+  updated_at
+) = (%s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  id,
+  patch_set_id,
+  repo_id,
+  rev,
+  patch_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+`
 
-	var (
-		externalState       string
-		externamReviewState string
-		externalCheckState  string
-	)
-	err := s.Scan(
-		&t.ID,
-		&t.RepoID,
-		&t.CreatedAt,
-		&t.UpdatedAt,
-		&metadata,
-		&dbutil.JSONInt64Set{Set: &t.CampaignIDs},
-		&t.ExternalID,
-		&t.ExternalServiceType,
-		&t.ExternalBranch,
-		&dbutil.NullTime{Time: &t.ExternalDeletedAt},
-		&dbutil.NullTime{Time: &t.ExternalUpdatedAt},
-		&dbutil.NullString{S: &externalState},
-		&dbutil.NullString{S: &externamReviewState},
-		&dbutil.NullString{S: &externalCheckState},
-		&t.CreatedByCampaign,
-		&t.AddedToCampaign,
-	)
-	if err != nil {
-		return errors.Wrap(err, "scanning changeset")
-	}
+func (s *Store) updatePatchSetJobQuery(j *campaigns.PatchSetJob) (*sqlf.Query, error) {
+	j.UpdatedAt = s.now()
 
-	t.ExternalState = campaigns.ChangesetState(externalState)
-	t.ExternalReviewState = campaigns.ChangesetReviewState(externamReviewState)
-	t.ExternalCheckState = campaigns.ChangesetCheckState(externalCheckState)
+	return sqlf.Sprintf(
+		updatePatchSetJobQueryFmtstr,
+		j.PatchSetID,
+		j.RepoID,
+		j.Rev,
+		nullInt64Column(j.PatchID),
+		j.Error,
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		j.UpdatedAt,
+		j.ID,
+	), nil
+}
 
-	switch t.ExternalServiceType {
-	case extsvc.TypeGitHub:
-		t.Metadata = new(github.PullRequest)
-	case extsvc.TypeBitbucketServer:
-		t.Metadata = new(bitbucketserver.PullRequest)
-	default:
-		return errors.New("unknown external service type")
-	}
+// ListPatchSetJobsOpts captures the query options needed for listing patch
+// set jobs.
+type ListPatchSetJobsOpts struct {
+	PatchSetID int64
+	Cursor     int64
+	Limit      int
+}
 
-	if err = json.Unmarshal(metadata, t.Metadata); err != nil {
-		return errors.Wrapf(err, "scanChangeset: failed to unmarshal %q metadata", t.ExternalServiceType)
+// ListPatchSetJobs lists PatchSetJobs with the given filters.
+func (s *Store) ListPatchSetJobs(ctx context.Context, opts ListPatchSetJobsOpts) (js []*campaigns.PatchSetJob, next int64, err error) {
+	q := listPatchSetJobsQuery(&opts)
+
+	js = make([]*campaigns.PatchSetJob, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var j campaigns.PatchSetJob
+		if err = scanPatchSetJob(&j, sc); err != nil {
+			return 0, 0, err
+		}
+		js = append(js, &j)
+		return j.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(js) == opts.Limit {
+		next = js[len(js)-1].ID
+		js = js[:len(js)-1]
 	}
 
-	return nil
+	return js, next, err
 }
 
-func scanChangesetEvent(e *campaigns.ChangesetEvent, s scanner) error {
-	var metadata json.RawMessage
+var listPatchSetJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListPatchSetJobs
+SELECT
+  id,
+  patch_set_id,
+  repo_id,
+  rev,
+  patch_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+FROM patch_set_jobs
+WHERE %s
+ORDER BY id ASC
+LIMIT %s
+`
 
-	err := s.Scan(
-		&e.ID,
-		&e.ChangesetID,
-		&e.Kind,
-		&e.Key,
-		&e.CreatedAt,
-		&e.UpdatedAt,
-		&metadata,
-	)
-	if err != nil {
-		return err
+func listPatchSetJobsQuery(opts *ListPatchSetJobsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
 	}
+	opts.Limit++
 
-	e.Metadata, err = campaigns.NewChangesetEventMetadata(e.Kind)
-	if err != nil {
-		return err
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("id >= %s", opts.Cursor),
 	}
 
-	if err = json.Unmarshal(metadata, e.Metadata); err != nil {
-		return errors.Wrapf(err, "scanChangesetEvent: failed to unmarshal %q metadata", e.Kind)
+	if opts.PatchSetID != 0 {
+		preds = append(preds, sqlf.Sprintf("patch_set_id = %s", opts.PatchSetID))
 	}
 
-	return nil
+	return sqlf.Sprintf(
+		listPatchSetJobsQueryFmtstr,
+		sqlf.Join(preds, "\n AND "),
+		opts.Limit,
+	)
 }
 
-func scanCampaign(c *campaigns.Campaign, s scanner) error {
-	return s.Scan(
-		&c.ID,
-		&c.Name,
-		&dbutil.NullString{S: &c.Description},
-		&dbutil.NullString{S: &c.Branch},
-		&c.AuthorID,
-		&dbutil.NullInt32{N: &c.NamespaceUserID},
-		&dbutil.NullInt32{N: &c.NamespaceOrgID},
-		&c.CreatedAt,
+// ProcessPendingPatchSetJobs attempts to fetch one pending patch set job,
+// which is one that has never been started. If found, 'process' is called
+// with exclusive access to the job, in the same way as
+// ProcessPendingChangesetJobs.
+// NOTE: It should not be called from within an existing transaction
+func (s *Store) ProcessPendingPatchSetJobs(ctx context.Context, process func(ctx context.Context, s *Store, job campaigns.PatchSetJob) error) (didRun bool, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Done(&err)
+	q := sqlf.Sprintf(getPendingPatchSetJobQuery)
+	var job campaigns.PatchSetJob
+	_, count, err := tx.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanPatchSetJob(&job, sc)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "scanning patch set job row")
+		}
+		return job.ID, 1, nil
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "querying for pending patch set job")
+	}
+	if count == 0 {
+		return false, nil
+	}
+	err = process(ctx, tx, job)
+	return true, err
+}
+
+const getPendingPatchSetJobQuery = `
+-- source: enterprise/internal/campaigns/store.go:ProcessPendingPatchSetJobs
+UPDATE patch_set_jobs j SET started_at = now() WHERE id = (
+	SELECT j.id FROM patch_set_jobs j
+	WHERE j.started_at IS NULL
+	ORDER BY j.updated_at ASC
+	FOR UPDATE SKIP LOCKED LIMIT 1
+)
+RETURNING j.id,
+  j.patch_set_id,
+  j.repo_id,
+  j.rev,
+  j.patch_id,
+  j.error,
+  j.started_at,
+  j.finished_at,
+  j.created_at,
+  j.updated_at
+`
+
+// GetPatchSetStatusOpts captures the query options needed for getting the
+// BackgroundProcessStatus for a PatchSet.
+type GetPatchSetStatusOpts struct {
+	ID int64
+
+	// ExcludeErrorsInRepos filters out error messages from PatchSetJobs in
+	// the repositories with the given IDs. This is used to filter out error
+	// messages from repositories the user doesn't have access to.
+	ExcludeErrorsInRepos []api.RepoID
+}
+
+// GetPatchSetStatus gets the campaigns.BackgroundProcessStatus of the
+// PatchSetJobs of a PatchSet.
+func (s *Store) GetPatchSetStatus(ctx context.Context, opts GetPatchSetStatusOpts) (*campaigns.BackgroundProcessStatus, error) {
+	errorsPreds := []*sqlf.Query{sqlf.Sprintf("error != ''")}
+	if len(opts.ExcludeErrorsInRepos) > 0 {
+		ids := make([]*sqlf.Query, 0, len(opts.ExcludeErrorsInRepos))
+		for _, repoID := range opts.ExcludeErrorsInRepos {
+			ids = append(ids, sqlf.Sprintf("%s", repoID))
+		}
+		errorsPreds = append(errorsPreds, sqlf.Sprintf("repo_id NOT IN (%s)", sqlf.Join(ids, ",")))
+	}
+
+	q := sqlf.Sprintf(
+		getPatchSetStatusQueryFmtstr,
+		sqlf.Join(errorsPreds, " AND "),
+		opts.ID,
+	)
+	return s.queryBackgroundProcessStatus(ctx, q)
+}
+
+var getPatchSetStatusQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetPatchSetStatus
+SELECT
+  -- canceled is here so that this can be used with scanBackgroundProcessStatus
+  false AS canceled,
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE finished_at IS NULL) AS pending,
+  COUNT(*) FILTER (WHERE finished_at IS NOT NULL) AS completed,
+  COUNT(*) FILTER (WHERE error != '') AS failed,
+  array_agg(error) FILTER (WHERE %s) AS errors
+FROM patch_set_jobs
+WHERE patch_set_id = %s
+LIMIT 1
+`
+
+// GetRepoIDsForFailedPatchSetJobs returns the repository IDs of the failed
+// patch set jobs of the specified patch set, so that the error messages of
+// the repositories the user can't access can be filtered out.
+func (s *Store) GetRepoIDsForFailedPatchSetJobs(ctx context.Context, patchSet int64) ([]api.RepoID, error) {
+	const queryFmtString = `
+	SELECT repo_id
+	FROM patch_set_jobs
+	WHERE
+	  patch_set_id = %s
+	AND
+	  error != ''
+	AND
+	  finished_at IS NOT NULL;
+	`
+
+	q := sqlf.Sprintf(queryFmtString, patchSet)
+	var ids []api.RepoID
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var id api.RepoID
+		err = sc.Scan(&id)
+		if err != nil {
+			return 0, 0, err
+		}
+		ids = append(ids, id)
+		return 0, 1, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CreateChangesetBulkAction creates the given ChangesetBulkAction.
+func (s *Store) CreateChangesetBulkAction(ctx context.Context, a *campaigns.ChangesetBulkAction) error {
+	q, err := s.createChangesetBulkActionQuery(a)
+	if err != nil {
+		return err
+	}
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetBulkAction(a, sc)
+		return a.ID, 1, err
+	})
+}
+
+var createChangesetBulkActionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateChangesetBulkAction
+INSERT INTO changeset_bulk_actions (
+  campaign_id,
+  user_id,
+  type,
+  body,
+  labels,
+  reviewers,
+  created_at,
+  updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+  id,
+  campaign_id,
+  user_id,
+  type,
+  body,
+  labels,
+  reviewers,
+  created_at,
+  updated_at
+`
+
+func (s *Store) createChangesetBulkActionQuery(a *campaigns.ChangesetBulkAction) (*sqlf.Query, error) {
+	labels, err := jsonStringSlice(a.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := jsonStringSlice(a.Reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = s.now()
+	}
+
+	if a.UpdatedAt.IsZero() {
+		a.UpdatedAt = a.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createChangesetBulkActionQueryFmtstr,
+		a.CampaignID,
+		a.UserID,
+		a.Type,
+		a.Body,
+		labels,
+		reviewers,
+		a.CreatedAt,
+		a.UpdatedAt,
+	), nil
+}
+
+// GetChangesetBulkActionOpts captures the query options needed for getting a
+// ChangesetBulkAction.
+type GetChangesetBulkActionOpts struct {
+	ID int64
+}
+
+// GetChangesetBulkAction gets a ChangesetBulkAction matching the given
+// options.
+func (s *Store) GetChangesetBulkAction(ctx context.Context, opts GetChangesetBulkActionOpts) (*campaigns.ChangesetBulkAction, error) {
+	q := sqlf.Sprintf(getChangesetBulkActionQueryFmtstr, opts.ID)
+
+	var a campaigns.ChangesetBulkAction
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanChangesetBulkAction(&a, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if a.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &a, nil
+}
+
+var getChangesetBulkActionQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetChangesetBulkAction
+SELECT
+  id,
+  campaign_id,
+  user_id,
+  type,
+  body,
+  labels,
+  reviewers,
+  created_at,
+  updated_at
+FROM changeset_bulk_actions
+WHERE id = %s
+LIMIT 1
+`
+
+// ListChangesetBulkActionsOpts captures the query options needed for listing
+// ChangesetBulkActions.
+type ListChangesetBulkActionsOpts struct {
+	CampaignID int64
+	Cursor     int64
+	Limit      int
+}
+
+// ListChangesetBulkActions lists the ChangesetBulkActions with the given
+// filters, most recent first.
+func (s *Store) ListChangesetBulkActions(ctx context.Context, opts ListChangesetBulkActionsOpts) (as []*campaigns.ChangesetBulkAction, next int64, err error) {
+	q := listChangesetBulkActionsQuery(&opts)
+
+	as = make([]*campaigns.ChangesetBulkAction, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var a campaigns.ChangesetBulkAction
+		if err = scanChangesetBulkAction(&a, sc); err != nil {
+			return 0, 0, err
+		}
+		as = append(as, &a)
+		return a.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(as) == opts.Limit {
+		next = as[len(as)-1].ID
+		as = as[:len(as)-1]
+	}
+
+	return as, next, err
+}
+
+var listChangesetBulkActionsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListChangesetBulkActions
+SELECT
+  id,
+  campaign_id,
+  user_id,
+  type,
+  body,
+  labels,
+  reviewers,
+  created_at,
+  updated_at
+FROM changeset_bulk_actions
+WHERE %s
+ORDER BY id DESC
+`
+
+func listChangesetBulkActionsQuery(opts *ListChangesetBulkActionsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id <= %s", opts.Cursor))
+	}
+
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_id = %s", opts.CampaignID))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetBulkActionsQueryFmtstr+limitClause,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CreateChangesetBulkJob creates the given ChangesetBulkJob.
+func (s *Store) CreateChangesetBulkJob(ctx context.Context, j *campaigns.ChangesetBulkJob) error {
+	q := s.createChangesetBulkJobQuery(j)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetBulkJob(j, sc)
+		return j.ID, 1, err
+	})
+}
+
+var createChangesetBulkJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateChangesetBulkJob
+INSERT INTO changeset_bulk_jobs (
+  bulk_action_id,
+  changeset_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+RETURNING
+  id,
+  bulk_action_id,
+  changeset_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+`
+
+func (s *Store) createChangesetBulkJobQuery(j *campaigns.ChangesetBulkJob) *sqlf.Query {
+	if j.CreatedAt.IsZero() {
+		j.CreatedAt = s.now()
+	}
+
+	if j.UpdatedAt.IsZero() {
+		j.UpdatedAt = j.CreatedAt
+	}
+
+	return sqlf.Sprintf(
+		createChangesetBulkJobQueryFmtstr,
+		j.BulkActionID,
+		j.ChangesetID,
+		j.Error,
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		j.CreatedAt,
+		j.UpdatedAt,
+	)
+}
+
+// UpdateChangesetBulkJob updates the given ChangesetBulkJob.
+func (s *Store) UpdateChangesetBulkJob(ctx context.Context, j *campaigns.ChangesetBulkJob) error {
+	j.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateChangesetBulkJobQueryFmtstr,
+		j.BulkActionID,
+		j.ChangesetID,
+		j.Error,
+		nullTimeColumn(j.StartedAt),
+		nullTimeColumn(j.FinishedAt),
+		j.UpdatedAt,
+		j.ID,
+	)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetBulkJob(j, sc)
+		return j.ID, 1, err
+	})
+}
+
+var updateChangesetBulkJobQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UpdateChangesetBulkJob
+UPDATE changeset_bulk_jobs
+SET (
+  bulk_action_id,
+  changeset_id,
+  error,
+  started_at,
+  finished_at,
+  updated_at
+) = (%s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  id,
+  bulk_action_id,
+  changeset_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+`
+
+// ListChangesetBulkJobsOpts captures the query options needed for listing
+// ChangesetBulkJobs.
+type ListChangesetBulkJobsOpts struct {
+	BulkActionID int64
+	Cursor       int64
+	Limit        int
+}
+
+// ListChangesetBulkJobs lists ChangesetBulkJobs with the given filters.
+func (s *Store) ListChangesetBulkJobs(ctx context.Context, opts ListChangesetBulkJobsOpts) (js []*campaigns.ChangesetBulkJob, next int64, err error) {
+	q := listChangesetBulkJobsQuery(&opts)
+
+	js = make([]*campaigns.ChangesetBulkJob, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var j campaigns.ChangesetBulkJob
+		if err = scanChangesetBulkJob(&j, sc); err != nil {
+			return 0, 0, err
+		}
+		js = append(js, &j)
+		return j.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(js) == opts.Limit {
+		next = js[len(js)-1].ID
+		js = js[:len(js)-1]
+	}
+
+	return js, next, err
+}
+
+var listChangesetBulkJobsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListChangesetBulkJobs
+SELECT
+  id,
+  bulk_action_id,
+  changeset_id,
+  error,
+  started_at,
+  finished_at,
+  created_at,
+  updated_at
+FROM changeset_bulk_jobs
+WHERE %s
+ORDER BY id ASC
+`
+
+func listChangesetBulkJobsQuery(opts *ListChangesetBulkJobsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("id >= %s", opts.Cursor),
+	}
+
+	if opts.BulkActionID != 0 {
+		preds = append(preds, sqlf.Sprintf("bulk_action_id = %s", opts.BulkActionID))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetBulkJobsQueryFmtstr+limitClause,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ProcessPendingChangesetBulkJobs attempts to fetch one pending changeset
+// bulk job, which is one that has never been started. If found, 'process' is
+// called with exclusive access to the job, in the same way as
+// ProcessPendingChangesetJobs.
+// NOTE: It should not be called from within an existing transaction
+func (s *Store) ProcessPendingChangesetBulkJobs(ctx context.Context, process func(ctx context.Context, s *Store, job campaigns.ChangesetBulkJob) error) (didRun bool, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "starting transaction")
+	}
+	defer tx.Done(&err)
+	q := sqlf.Sprintf(getPendingChangesetBulkJobQuery)
+	var job campaigns.ChangesetBulkJob
+	_, count, err := tx.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetBulkJob(&job, sc)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "scanning changeset bulk job row")
+		}
+		return job.ID, 1, nil
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "querying for pending changeset bulk job")
+	}
+	if count == 0 {
+		return false, nil
+	}
+	err = process(ctx, tx, job)
+	return true, err
+}
+
+const getPendingChangesetBulkJobQuery = `
+-- source: enterprise/internal/campaigns/store.go:ProcessPendingChangesetBulkJobs
+UPDATE changeset_bulk_jobs j SET started_at = now() WHERE id = (
+	SELECT j.id FROM changeset_bulk_jobs j
+	WHERE j.started_at IS NULL
+	ORDER BY j.updated_at ASC
+	FOR UPDATE SKIP LOCKED LIMIT 1
+)
+RETURNING j.id,
+  j.bulk_action_id,
+  j.changeset_id,
+  j.error,
+  j.started_at,
+  j.finished_at,
+  j.created_at,
+  j.updated_at
+`
+
+// GetChangesetBulkActionStatusOpts captures the query options needed for
+// getting the BackgroundProcessStatus of a ChangesetBulkAction.
+type GetChangesetBulkActionStatusOpts struct {
+	ID int64
+
+	// ExcludeErrorsInRepos filters out error messages from ChangesetBulkJobs
+	// of changesets in the repositories with the given IDs. This is used to
+	// filter out error messages from repositories the user doesn't have
+	// access to.
+	ExcludeErrorsInRepos []api.RepoID
+}
+
+// GetChangesetBulkActionStatus gets the campaigns.BackgroundProcessStatus of
+// the ChangesetBulkJobs of a ChangesetBulkAction.
+func (s *Store) GetChangesetBulkActionStatus(ctx context.Context, opts GetChangesetBulkActionStatusOpts) (*campaigns.BackgroundProcessStatus, error) {
+	errorsPreds := []*sqlf.Query{sqlf.Sprintf("changeset_bulk_jobs.error != ''")}
+	if len(opts.ExcludeErrorsInRepos) > 0 {
+		ids := make([]*sqlf.Query, 0, len(opts.ExcludeErrorsInRepos))
+		for _, repoID := range opts.ExcludeErrorsInRepos {
+			ids = append(ids, sqlf.Sprintf("%s", repoID))
+		}
+		errorsPreds = append(errorsPreds, sqlf.Sprintf("changesets.repo_id NOT IN (%s)", sqlf.Join(ids, ",")))
+	}
+
+	q := sqlf.Sprintf(
+		getChangesetBulkActionStatusQueryFmtstr,
+		sqlf.Join(errorsPreds, " AND "),
+		opts.ID,
+	)
+	return s.queryBackgroundProcessStatus(ctx, q)
+}
+
+var getChangesetBulkActionStatusQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetChangesetBulkActionStatus
+SELECT
+  -- canceled is here so that this can be used with scanBackgroundProcessStatus
+  false AS canceled,
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE changeset_bulk_jobs.finished_at IS NULL) AS pending,
+  COUNT(*) FILTER (WHERE changeset_bulk_jobs.finished_at IS NOT NULL) AS completed,
+  COUNT(*) FILTER (WHERE changeset_bulk_jobs.error != '') AS failed,
+  array_agg(changeset_bulk_jobs.error) FILTER (WHERE %s) AS errors
+FROM changeset_bulk_jobs
+JOIN changesets ON changesets.id = changeset_bulk_jobs.changeset_id
+WHERE changeset_bulk_jobs.bulk_action_id = %s
+LIMIT 1
+`
+
+// GetRepoIDsForFailedChangesetBulkJobs returns the repository IDs of the
+// changesets of the failed jobs of the specified changeset bulk action, so
+// that the error messages of the repositories the user can't access can be
+// filtered out.
+func (s *Store) GetRepoIDsForFailedChangesetBulkJobs(ctx context.Context, bulkAction int64) ([]api.RepoID, error) {
+	const queryFmtString = `
+	SELECT changesets.repo_id
+	FROM changeset_bulk_jobs
+	JOIN changesets ON changesets.id = changeset_bulk_jobs.changeset_id
+	WHERE
+	  changeset_bulk_jobs.bulk_action_id = %s
+	AND
+	  changeset_bulk_jobs.error != ''
+	AND
+	  changeset_bulk_jobs.finished_at IS NOT NULL;
+	`
+
+	q := sqlf.Sprintf(queryFmtString, bulkAction)
+	var ids []api.RepoID
+	_, _, err := s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var id api.RepoID
+		err = sc.Scan(&id)
+		if err != nil {
+			return 0, 0, err
+		}
+		ids = append(ids, id)
+		return 0, 1, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// CreateChangesetImport creates the given ChangesetImport.
+func (s *Store) CreateChangesetImport(ctx context.Context, i *campaigns.ChangesetImport) error {
+	if i.CreatedAt.IsZero() {
+		i.CreatedAt = s.now()
+	}
+
+	if i.UpdatedAt.IsZero() {
+		i.UpdatedAt = i.CreatedAt
+	}
+
+	q := sqlf.Sprintf(
+		createChangesetImportQueryFmtstr,
+		i.CampaignID,
+		i.UserID,
+		i.ExternalServiceID,
+		i.Query,
+		i.Error,
+		nullTimeColumn(i.LastRunAt),
+		i.CreatedAt,
+		i.UpdatedAt,
+	)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetImport(i, sc)
+		return i.ID, 1, err
+	})
+}
+
+var createChangesetImportQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateChangesetImport
+INSERT INTO changeset_imports (
+  campaign_id,
+  user_id,
+  external_service_id,
+  query,
+  error,
+  last_run_at,
+  created_at,
+  updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+  id,
+  campaign_id,
+  user_id,
+  external_service_id,
+  query,
+  error,
+  last_run_at,
+  created_at,
+  updated_at
+`
+
+// UpdateChangesetImport updates the given ChangesetImport.
+func (s *Store) UpdateChangesetImport(ctx context.Context, i *campaigns.ChangesetImport) error {
+	i.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateChangesetImportQueryFmtstr,
+		i.CampaignID,
+		i.UserID,
+		i.ExternalServiceID,
+		i.Query,
+		i.Error,
+		nullTimeColumn(i.LastRunAt),
+		i.UpdatedAt,
+		i.ID,
+	)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanChangesetImport(i, sc)
+		return i.ID, 1, err
+	})
+}
+
+var updateChangesetImportQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UpdateChangesetImport
+UPDATE changeset_imports
+SET (
+  campaign_id,
+  user_id,
+  external_service_id,
+  query,
+  error,
+  last_run_at,
+  updated_at
+) = (%s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  id,
+  campaign_id,
+  user_id,
+  external_service_id,
+  query,
+  error,
+  last_run_at,
+  created_at,
+  updated_at
+`
+
+// DeleteChangesetImport deletes the ChangesetImport with the given ID. The
+// changesets it imported stay in its campaign.
+func (s *Store) DeleteChangesetImport(ctx context.Context, id int64) error {
+	q := sqlf.Sprintf(deleteChangesetImportQueryFmtstr, id)
+	return s.exec(ctx, q, nil)
+}
+
+var deleteChangesetImportQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:DeleteChangesetImport
+DELETE FROM changeset_imports WHERE id = %s
+`
+
+// GetChangesetImportOpts captures the query options needed for getting a
+// ChangesetImport.
+type GetChangesetImportOpts struct {
+	ID int64
+}
+
+// GetChangesetImport gets a ChangesetImport matching the given options.
+func (s *Store) GetChangesetImport(ctx context.Context, opts GetChangesetImportOpts) (*campaigns.ChangesetImport, error) {
+	q := sqlf.Sprintf(getChangesetImportQueryFmtstr, opts.ID)
+
+	var i campaigns.ChangesetImport
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanChangesetImport(&i, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if i.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &i, nil
+}
+
+var getChangesetImportQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetChangesetImport
+SELECT
+  id,
+  campaign_id,
+  user_id,
+  external_service_id,
+  query,
+  error,
+  last_run_at,
+  created_at,
+  updated_at
+FROM changeset_imports
+WHERE id = %s
+LIMIT 1
+`
+
+// ListChangesetImportsOpts captures the query options needed for listing
+// ChangesetImports.
+type ListChangesetImportsOpts struct {
+	CampaignID int64
+	// LastRunBefore, if set, only includes the ChangesetImports that never
+	// ran or last ran before the given time.
+	LastRunBefore time.Time
+	// OnlyOpenCampaigns only includes the ChangesetImports of campaigns that
+	// aren't closed.
+	OnlyOpenCampaigns bool
+	Cursor            int64
+	Limit             int
+}
+
+// ListChangesetImports lists the ChangesetImports with the given filters.
+func (s *Store) ListChangesetImports(ctx context.Context, opts ListChangesetImportsOpts) (is []*campaigns.ChangesetImport, next int64, err error) {
+	q := listChangesetImportsQuery(&opts)
+
+	is = make([]*campaigns.ChangesetImport, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var i campaigns.ChangesetImport
+		if err = scanChangesetImport(&i, sc); err != nil {
+			return 0, 0, err
+		}
+		is = append(is, &i)
+		return i.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(is) == opts.Limit {
+		next = is[len(is)-1].ID
+		is = is[:len(is)-1]
+	}
+
+	return is, next, err
+}
+
+var listChangesetImportsQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListChangesetImports
+SELECT
+  id,
+  campaign_id,
+  user_id,
+  external_service_id,
+  query,
+  error,
+  last_run_at,
+  created_at,
+  updated_at
+FROM changeset_imports
+WHERE %s
+ORDER BY id ASC
+`
+
+func listChangesetImportsQuery(opts *ListChangesetImportsOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id >= %s", opts.Cursor))
+	}
+
+	if opts.CampaignID != 0 {
+		preds = append(preds, sqlf.Sprintf("campaign_id = %s", opts.CampaignID))
+	}
+
+	if !opts.LastRunBefore.IsZero() {
+		preds = append(preds, sqlf.Sprintf("(last_run_at IS NULL OR last_run_at < %s)", opts.LastRunBefore))
+	}
+
+	if opts.OnlyOpenCampaigns {
+		preds = append(preds, sqlf.Sprintf("campaign_id IN (SELECT id FROM campaigns WHERE closed_at IS NULL)"))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetImportsQueryFmtstr+limitClause,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CreateCampaignReportDigest records that a campaign report digest was sent
+// to the given recipients.
+func (s *Store) CreateCampaignReportDigest(ctx context.Context, recipients []string) error {
+	rs, err := jsonStringSlice(recipients)
+	if err != nil {
+		return err
+	}
+
+	q := sqlf.Sprintf(createCampaignReportDigestQueryFmtstr, rs, s.now())
+	return s.exec(ctx, q, nil)
+}
+
+var createCampaignReportDigestQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateCampaignReportDigest
+INSERT INTO campaign_report_digests (recipients, sent_at)
+VALUES (%s, %s)
+`
+
+// GetLatestCampaignReportDigest returns the time at which the latest campaign
+// report digest was sent, or the zero time if none was sent yet.
+func (s *Store) GetLatestCampaignReportDigest(ctx context.Context) (sentAt time.Time, err error) {
+	q := sqlf.Sprintf(getLatestCampaignReportDigestQueryFmtstr)
+	err = s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(&sentAt)
+		return 0, 1, err
+	})
+	return sentAt, err
+}
+
+var getLatestCampaignReportDigestQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetLatestCampaignReportDigest
+SELECT sent_at
+FROM campaign_report_digests
+ORDER BY sent_at DESC
+LIMIT 1
+`
+
+// CreateCampaignTemplate creates the given CampaignTemplate.
+func (s *Store) CreateCampaignTemplate(ctx context.Context, t *campaigns.CampaignTemplate) error {
+	if t.Version == 0 {
+		t.Version = 1
+	}
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	q := sqlf.Sprintf(
+		createCampaignTemplateQueryFmtstr,
+		t.Name,
+		t.Description,
+		t.Spec,
+		t.Version,
+		t.AuthorID,
+		nullInt32Column(t.NamespaceUserID),
+		nullInt32Column(t.NamespaceOrgID),
+		t.CreatedAt,
+		t.UpdatedAt,
+	)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanCampaignTemplate(t, sc)
+		return t.ID, 1, err
+	})
+}
+
+var createCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CreateCampaignTemplate
+INSERT INTO campaign_templates (
+  name,
+  description,
+  spec,
+  version,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING
+  id,
+  name,
+  description,
+  spec,
+  version,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at
+`
+
+// UpdateCampaignTemplate updates the given CampaignTemplate.
+func (s *Store) UpdateCampaignTemplate(ctx context.Context, t *campaigns.CampaignTemplate) error {
+	t.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateCampaignTemplateQueryFmtstr,
+		t.Name,
+		t.Description,
+		t.Spec,
+		t.Version,
+		t.AuthorID,
+		nullInt32Column(t.NamespaceUserID),
+		nullInt32Column(t.NamespaceOrgID),
+		t.UpdatedAt,
+		t.ID,
+	)
+
+	return s.exec(ctx, q, func(sc scanner) (last, count int64, err error) {
+		err = scanCampaignTemplate(t, sc)
+		return t.ID, 1, err
+	})
+}
+
+var updateCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:UpdateCampaignTemplate
+UPDATE campaign_templates
+SET (
+  name,
+  description,
+  spec,
+  version,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  updated_at
+) = (%s, %s, %s, %s, %s, %s, %s, %s)
+WHERE id = %s
+RETURNING
+  id,
+  name,
+  description,
+  spec,
+  version,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at
+`
+
+// DeleteCampaignTemplate deletes the CampaignTemplate with the given ID. The
+// PatchSets created from it are kept.
+func (s *Store) DeleteCampaignTemplate(ctx context.Context, id int64) error {
+	q := sqlf.Sprintf(deleteCampaignTemplateQueryFmtstr, id)
+	return s.exec(ctx, q, nil)
+}
+
+var deleteCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:DeleteCampaignTemplate
+DELETE FROM campaign_templates WHERE id = %s
+`
+
+// GetCampaignTemplateOpts captures the query options needed for getting a
+// CampaignTemplate.
+type GetCampaignTemplateOpts struct {
+	ID int64
+}
+
+// GetCampaignTemplate gets a CampaignTemplate matching the given options.
+func (s *Store) GetCampaignTemplate(ctx context.Context, opts GetCampaignTemplateOpts) (*campaigns.CampaignTemplate, error) {
+	q := sqlf.Sprintf(getCampaignTemplateQueryFmtstr, opts.ID)
+
+	var t campaigns.CampaignTemplate
+	err := s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		return 0, 0, scanCampaignTemplate(&t, sc)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &t, nil
+}
+
+var getCampaignTemplateQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:GetCampaignTemplate
+SELECT
+  id,
+  name,
+  description,
+  spec,
+  version,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at
+FROM campaign_templates
+WHERE id = %s
+LIMIT 1
+`
+
+// CountCampaignTemplatesOpts captures the query options needed for counting
+// CampaignTemplates.
+type CountCampaignTemplatesOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CountCampaignTemplates returns the number of CampaignTemplates in the
+// database.
+func (s *Store) CountCampaignTemplates(ctx context.Context, opts CountCampaignTemplatesOpts) (count int64, _ error) {
+	q := sqlf.Sprintf(
+		countCampaignTemplatesQueryFmtstr,
+		sqlf.Join(campaignTemplatesPreds(opts.NamespaceUserID, opts.NamespaceOrgID), "\n AND "),
+	)
+	return count, s.exec(ctx, q, func(sc scanner) (_, _ int64, err error) {
+		err = sc.Scan(&count)
+		return 0, count, err
+	})
+}
+
+var countCampaignTemplatesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:CountCampaignTemplates
+SELECT COUNT(id)
+FROM campaign_templates
+WHERE %s
+`
+
+// ListCampaignTemplatesOpts captures the query options needed for listing
+// CampaignTemplates.
+type ListCampaignTemplatesOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	Cursor          int64
+	Limit           int
+}
+
+// ListCampaignTemplates lists the CampaignTemplates with the given filters.
+func (s *Store) ListCampaignTemplates(ctx context.Context, opts ListCampaignTemplatesOpts) (ts []*campaigns.CampaignTemplate, next int64, err error) {
+	q := listCampaignTemplatesQuery(&opts)
+
+	ts = make([]*campaigns.CampaignTemplate, 0, opts.Limit)
+	_, _, err = s.query(ctx, q, func(sc scanner) (last, count int64, err error) {
+		var t campaigns.CampaignTemplate
+		if err = scanCampaignTemplate(&t, sc); err != nil {
+			return 0, 0, err
+		}
+		ts = append(ts, &t)
+		return t.ID, 1, err
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.Limit {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listCampaignTemplatesQueryFmtstr = `
+-- source: enterprise/internal/campaigns/store.go:ListCampaignTemplates
+SELECT
+  id,
+  name,
+  description,
+  spec,
+  version,
+  author_id,
+  namespace_user_id,
+  namespace_org_id,
+  created_at,
+  updated_at
+FROM campaign_templates
+WHERE %s
+ORDER BY id ASC
+`
+
+func listCampaignTemplatesQuery(opts *ListCampaignTemplatesOpts) *sqlf.Query {
+	if opts.Limit == 0 {
+		opts.Limit = defaultListLimit
+	}
+	opts.Limit++
+
+	var limitClause string
+	if opts.Limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT %d", opts.Limit)
+	}
+
+	preds := campaignTemplatesPreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	if opts.Cursor != 0 {
+		preds = append(preds, sqlf.Sprintf("id >= %s", opts.Cursor))
+	}
+
+	return sqlf.Sprintf(
+		listCampaignTemplatesQueryFmtstr+limitClause,
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+func campaignTemplatesPreds(namespaceUserID, namespaceOrgID int32) []*sqlf.Query {
+	preds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if namespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", namespaceUserID))
+	}
+	if namespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", namespaceOrgID))
+	}
+	return preds
+}
+
+func (s *Store) exec(ctx context.Context, q *sqlf.Query, sc scanFunc) error {
+	_, _, err := s.query(ctx, q, sc)
+	return err
+}
+
+func (s *Store) query(ctx context.Context, q *sqlf.Query, sc scanFunc) (last, count int64, err error) {
+	rows, err := s.db.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return 0, 0, err
+	}
+	return scanAll(rows, sc)
+}
+
+// scanner captures the Scan method of sql.Rows and sql.Row
+type scanner interface {
+	Scan(dst ...interface{}) error
+}
+
+// a scanFunc scans one or more rows from a scanner, returning
+// the last id column scanned and the count of scanned rows.
+type scanFunc func(scanner) (last, count int64, err error)
+
+func scanAll(rows *sql.Rows, scan scanFunc) (last, count int64, err error) {
+	defer closeErr(rows, &err)
+
+	last = -1
+	for rows.Next() {
+		var n int64
+		if last, n, err = scan(rows); err != nil {
+			return last, count, err
+		}
+		count += n
+	}
+
+	return last, count, rows.Err()
+}
+
+func closeErr(c io.Closer, err *error) {
+	if e := c.Close(); err != nil && *err == nil {
+		*err = e
+	}
+}
+
+func scanChangeset(t *campaigns.Changeset, s scanner) error {
+	var metadata json.RawMessage
+
+	var (
+		externalState       string
+		externamReviewState string
+		externalCheckState  string
+	)
+	err := s.Scan(
+		&t.ID,
+		&t.RepoID,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&metadata,
+		&dbutil.JSONInt64Set{Set: &t.CampaignIDs},
+		&t.ExternalID,
+		&t.ExternalServiceType,
+		&t.ExternalBranch,
+		&dbutil.NullTime{Time: &t.ExternalDeletedAt},
+		&dbutil.NullTime{Time: &t.ExternalUpdatedAt},
+		&dbutil.NullString{S: &externalState},
+		&dbutil.NullString{S: &externamReviewState},
+		&dbutil.NullString{S: &externalCheckState},
+		&t.CreatedByCampaign,
+		&t.AddedToCampaign,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning changeset")
+	}
+
+	t.ExternalState = campaigns.ChangesetState(externalState)
+	t.ExternalReviewState = campaigns.ChangesetReviewState(externamReviewState)
+	t.ExternalCheckState = campaigns.ChangesetCheckState(externalCheckState)
+
+	switch t.ExternalServiceType {
+	case extsvc.TypeGitHub:
+		t.Metadata = new(github.PullRequest)
+	case extsvc.TypeBitbucketServer:
+		t.Metadata = new(bitbucketserver.PullRequest)
+	case extsvc.TypeAzureDevOps:
+		t.Metadata = new(azuredevops.PullRequest)
+	default:
+		return errors.New("unknown external service type")
+	}
+
+	if err = json.Unmarshal(metadata, t.Metadata); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal %q metadata", t.ExternalServiceType)
+	}
+
+	return nil
+}
+
+func scanChangesetEvent(e *campaigns.ChangesetEvent, s scanner) error {
+	var metadata json.RawMessage
+
+	err := s.Scan(
+		&e.ID,
+		&e.ChangesetID,
+		&e.Kind,
+		&e.Key,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+		&metadata,
+	)
+	if err != nil {
+		return err
+	}
+
+	e.Metadata, err = campaigns.NewChangesetEventMetadata(e.Kind)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(metadata, e.Metadata); err != nil {
+		return errors.Wrapf(err, "scanChangesetEvent: failed to unmarshal %q metadata", e.Kind)
+	}
+
+	return nil
+}
+
+func scanCampaign(c *campaigns.Campaign, s scanner) error {
+	var autoMerge json.RawMessage
+
+	err := s.Scan(
+		&c.ID,
+		&c.Name,
+		&dbutil.NullString{S: &c.Description},
+		&dbutil.NullString{S: &c.Branch},
+		&c.AuthorID,
+		&dbutil.NullInt32{N: &c.NamespaceUserID},
+		&dbutil.NullInt32{N: &c.NamespaceOrgID},
+		&c.CreatedAt,
 		&c.UpdatedAt,
 		&dbutil.JSONInt64Set{Set: &c.ChangesetIDs},
 		&dbutil.NullInt64{N: &c.PatchSetID},
 		&dbutil.NullTime{Time: &c.ClosedAt},
+		&autoMerge,
 	)
+	if err != nil {
+		return err
+	}
+
+	c.AutoMerge = campaigns.AutoMerge{}
+	return json.Unmarshal(autoMerge, &c.AutoMerge)
 }
 
 func scanPatchSet(c *campaigns.PatchSet, s scanner) error {
-	return s.Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt, &c.UserID)
+	var arguments json.RawMessage
+
+	err := s.Scan(
+		&c.ID,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.UserID,
+		&c.Spec,
+		&dbutil.NullInt64{N: &c.CampaignTemplateID},
+		&c.CampaignTemplateVersion,
+		&arguments,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.CampaignTemplateArguments = nil
+	if err := json.Unmarshal(arguments, &c.CampaignTemplateArguments); err != nil {
+		return err
+	}
+	// PatchSets that weren't created from a CampaignTemplate have no
+	// arguments.
+	if len(c.CampaignTemplateArguments) == 0 {
+		c.CampaignTemplateArguments = nil
+	}
+	return nil
 }
 
 func scanPatch(c *campaigns.Patch, s scanner) error {
@@ -2950,6 +4498,9 @@ func scanChangesetJob(c *campaigns.ChangesetJob, s scanner) error {
 		&c.PatchID,
 		&dbutil.NullInt64{N: &c.ChangesetID},
 		&c.Branch,
+		&c.Conflicted,
+		&c.Draft,
+		&dbutil.NullTime{Time: &c.PublishAt},
 		&dbutil.NullString{S: &c.Error},
 		&dbutil.NullTime{Time: &c.StartedAt},
 		&dbutil.NullTime{Time: &c.FinishedAt},
@@ -2958,6 +4509,87 @@ func scanChangesetJob(c *campaigns.ChangesetJob, s scanner) error {
 	)
 }
 
+func scanChangesetBulkAction(a *campaigns.ChangesetBulkAction, s scanner) error {
+	var labels, reviewers json.RawMessage
+
+	err := s.Scan(
+		&a.ID,
+		&a.CampaignID,
+		&a.UserID,
+		&a.Type,
+		&a.Body,
+		&labels,
+		&reviewers,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(labels, &a.Labels); err != nil {
+		return err
+	}
+	return json.Unmarshal(reviewers, &a.Reviewers)
+}
+
+func scanChangesetBulkJob(j *campaigns.ChangesetBulkJob, s scanner) error {
+	return s.Scan(
+		&j.ID,
+		&j.BulkActionID,
+		&j.ChangesetID,
+		&j.Error,
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+}
+
+func scanChangesetImport(i *campaigns.ChangesetImport, s scanner) error {
+	return s.Scan(
+		&i.ID,
+		&i.CampaignID,
+		&i.UserID,
+		&i.ExternalServiceID,
+		&i.Query,
+		&i.Error,
+		&dbutil.NullTime{Time: &i.LastRunAt},
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+}
+
+func scanCampaignTemplate(t *campaigns.CampaignTemplate, s scanner) error {
+	return s.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&t.Spec,
+		&t.Version,
+		&t.AuthorID,
+		&dbutil.NullInt32{N: &t.NamespaceUserID},
+		&dbutil.NullInt32{N: &t.NamespaceOrgID},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+}
+
+func scanPatchSetJob(j *campaigns.PatchSetJob, s scanner) error {
+	return s.Scan(
+		&j.ID,
+		&j.PatchSetID,
+		&j.RepoID,
+		&j.Rev,
+		&dbutil.NullInt64{N: &j.PatchID},
+		&j.Error,
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+}
+
 func scanBackgroundProcessStatus(b *campaigns.BackgroundProcessStatus, s scanner) error {
 	return s.Scan(
 		&b.Canceled,
@@ -2992,3 +4624,10 @@ func jsonSetColumn(ids []int64) ([]byte, error) {
 	}
 	return json.Marshal(set)
 }
+
+func jsonStringSlice(ss []string) ([]byte, error) {
+	if ss == nil {
+		ss = []string{}
+	}
+	return json.Marshal(ss)
+}
@@ -77,6 +77,16 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 				c.ClosedAt = time.Time{}
 			}
 
+			if i == 1 {
+				c.AutoMerge = cmpgn.AutoMerge{
+					Enabled:          true,
+					Method:           cmpgn.ChangesetMergeMethodSquash,
+					MaxMergesPerHour: 10,
+					WindowStart:      "09:00",
+					WindowEnd:        "17:00",
+				}
+			}
+
 			if i%2 == 0 {
 				c.NamespaceOrgID = 23
 			} else {
@@ -301,6 +311,16 @@ func testStoreCampaigns(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 				}
 			}
 		})
+
+		t.Run("ListCampaigns OnlyAutoMerge set", func(t *testing.T) {
+			have, _, err := s.ListCampaigns(ctx, ListCampaignsOpts{OnlyAutoMerge: true, Limit: -1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, campaigns[1:2]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
 	})
 
 	t.Run("Update", func(t *testing.T) {
@@ -1497,6 +1517,11 @@ func testStorePatchSets(t *testing.T, ctx context.Context, s *Store, _ repos.Sto
 	t.Run("Create", func(t *testing.T) {
 		for i := 0; i < cap(patchSets); i++ {
 			c := &cmpgn.PatchSet{UserID: 999}
+			if i == 1 {
+				c.CampaignTemplateID = 1
+				c.CampaignTemplateVersion = 2
+				c.CampaignTemplateArguments = map[string]string{"query": "lang:go"}
+			}
 
 			want := c.Clone()
 			have := c
@@ -3108,6 +3133,274 @@ func testStoreChangesetJobs(t *testing.T, ctx context.Context, s *Store, _ repos
 	})
 }
 
+func testStorePatchSetJobs(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	jobs := make([]*cmpgn.PatchSetJob, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(jobs); i++ {
+			j := &cmpgn.PatchSetJob{
+				PatchSetID: 1,
+				RepoID:     api.RepoID(i + 1),
+				Rev:        "master",
+			}
+
+			want := j.Clone()
+			have := j
+
+			err := s.CreatePatchSetJob(ctx, have)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.CreatedAt = clock.now()
+			want.UpdatedAt = clock.now()
+
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatal(diff)
+			}
+
+			jobs = append(jobs, j)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, next, err := s.ListPatchSetJobs(ctx, ListPatchSetJobsOpts{PatchSetID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != 0 {
+			t.Fatalf("have next %d, want 0", next)
+		}
+		if diff := cmp.Diff(have, jobs); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, _, err = s.ListPatchSetJobs(ctx, ListPatchSetJobsOpts{PatchSetID: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("listed %d jobs of other patch set, want 0", len(have))
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		for i, j := range jobs {
+			clock.add(1 * time.Second)
+			j.StartedAt = clock.now()
+			j.FinishedAt = clock.now()
+			if i == 0 {
+				j.Error = "comby failed"
+			} else {
+				j.PatchID = int64(i)
+			}
+
+			want := j.Clone()
+			want.UpdatedAt = clock.now()
+
+			if err := s.UpdatePatchSetJob(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(j, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("GetPatchSetStatus", func(t *testing.T) {
+		want := &cmpgn.BackgroundProcessStatus{
+			Total:         int32(len(jobs)),
+			Completed:     int32(len(jobs)),
+			Failed:        1,
+			ProcessState:  cmpgn.BackgroundProcessStateErrored,
+			ProcessErrors: []string{"comby failed"},
+		}
+		have, err := s.GetPatchSetStatus(ctx, GetPatchSetStatusOpts{ID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		repoIDs, err := s.GetRepoIDsForFailedPatchSetJobs(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(repoIDs, []api.RepoID{jobs[0].RepoID}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		want.ProcessErrors = nil
+		have, err = s.GetPatchSetStatus(ctx, GetPatchSetStatusOpts{ID: 1, ExcludeErrorsInRepos: repoIDs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
+func testStoreChangesetBulkActions(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	actions := make([]*cmpgn.ChangesetBulkAction, 0, 2)
+	jobs := make([]*cmpgn.ChangesetBulkJob, 0, 3)
+
+	t.Run("CreateActions", func(t *testing.T) {
+		for i := 0; i < cap(actions); i++ {
+			a := &cmpgn.ChangesetBulkAction{
+				CampaignID: 1,
+				UserID:     1,
+				Type:       cmpgn.ChangesetBulkActionAddLabels,
+				Labels:     []string{"automated", fmt.Sprintf("label-%d", i)},
+				Reviewers:  []string{},
+			}
+
+			if err := s.CreateChangesetBulkAction(ctx, a); err != nil {
+				t.Fatal(err)
+			}
+			if a.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := a.CreatedAt, clock.now(); !have.Equal(want) {
+				t.Fatalf("have created at %v, want %v", have, want)
+			}
+
+			actions = append(actions, a)
+		}
+	})
+
+	t.Run("GetAction", func(t *testing.T) {
+		have, err := s.GetChangesetBulkAction(ctx, GetChangesetBulkActionOpts{ID: actions[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, actions[0]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetChangesetBulkAction(ctx, GetChangesetBulkActionOpts{ID: 0xdeadbeef})
+		if err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+
+	t.Run("ListActions", func(t *testing.T) {
+		have, _, err := s.ListChangesetBulkActions(ctx, ListChangesetBulkActionsOpts{CampaignID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Most recent first.
+		want := []*cmpgn.ChangesetBulkAction{actions[1], actions[0]}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, next, err := s.ListChangesetBulkActions(ctx, ListChangesetBulkActionsOpts{CampaignID: 1, Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, want[:1]); diff != "" {
+			t.Fatal(diff)
+		}
+		if next != actions[0].ID {
+			t.Fatalf("have next %d, want %d", next, actions[0].ID)
+		}
+	})
+
+	t.Run("CreateJobs", func(t *testing.T) {
+		for i := 0; i < cap(jobs); i++ {
+			c := &cmpgn.Changeset{
+				RepoID:              api.RepoID(i + 1),
+				CampaignIDs:         []int64{1},
+				ExternalID:          fmt.Sprintf("bulk-%d", i),
+				ExternalServiceType: extsvc.TypeGitHub,
+			}
+			if err := s.CreateChangesets(ctx, c); err != nil {
+				t.Fatal(err)
+			}
+
+			j := &cmpgn.ChangesetBulkJob{BulkActionID: actions[0].ID, ChangesetID: c.ID}
+			if err := s.CreateChangesetBulkJob(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+			if j.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			jobs = append(jobs, j)
+		}
+
+		have, _, err := s.ListChangesetBulkJobs(ctx, ListChangesetBulkJobsOpts{BulkActionID: actions[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, jobs); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("UpdateJobs", func(t *testing.T) {
+		for i, j := range jobs {
+			clock.add(1 * time.Second)
+			j.StartedAt = clock.now()
+			j.FinishedAt = clock.now()
+			if i == 0 {
+				j.Error = "label not found"
+			}
+
+			want := *j
+			want.UpdatedAt = clock.now()
+
+			if err := s.UpdateChangesetBulkJob(ctx, j); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(*j, want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("GetStatus", func(t *testing.T) {
+		want := &cmpgn.BackgroundProcessStatus{
+			Total:         int32(len(jobs)),
+			Completed:     int32(len(jobs)),
+			Failed:        1,
+			ProcessState:  cmpgn.BackgroundProcessStateErrored,
+			ProcessErrors: []string{"label not found"},
+		}
+		have, err := s.GetChangesetBulkActionStatus(ctx, GetChangesetBulkActionStatusOpts{ID: actions[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+
+		repoIDs, err := s.GetRepoIDsForFailedChangesetBulkJobs(ctx, actions[0].ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(repoIDs, []api.RepoID{1}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		want.ProcessErrors = nil
+		have, err = s.GetChangesetBulkActionStatus(ctx, GetChangesetBulkActionStatusOpts{ID: actions[0].ID, ExcludeErrorsInRepos: repoIDs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
+
 func testProcessChangesetJob(db *sql.DB, userID int32) func(*testing.T) {
 	return func(t *testing.T) {
 		now := time.Now().UTC().Truncate(time.Microsecond)
@@ -3223,6 +3516,47 @@ func testProcessChangesetJob(db *sql.DB, userID int32) func(*testing.T) {
 			}
 		})
 
+		t.Run("GetPendingChangesetJobWhenScheduled", func(t *testing.T) {
+			tx := dbtest.NewTx(t, db)
+			s := NewStoreWithClock(tx, clock)
+
+			process := func(ctx context.Context, s *Store, job cmpgn.ChangesetJob) error {
+				return errors.New("rollback")
+			}
+
+			job := &cmpgn.ChangesetJob{
+				CampaignID: campaign.ID,
+				PatchID:    patch.ID,
+				Draft:      true,
+				PublishAt:  time.Now().Add(time.Hour),
+			}
+			err := s.CreateChangesetJob(ctx, job)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ran, err := s.ProcessPendingChangesetJobs(ctx, process)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ran {
+				t.Fatalf("process function should not have run before the job is due")
+			}
+
+			job.PublishAt = time.Now().Add(-time.Hour)
+			if err = s.UpdateChangesetJob(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			ran, err = s.ProcessPendingChangesetJobs(ctx, process)
+			if err != nil && err.Error() != "rollback" {
+				t.Fatal(err)
+			}
+			if !ran {
+				t.Fatalf("process function should have run")
+			}
+		})
+
 		t.Run("GetPendingChangesetJobOrder", func(t *testing.T) {
 			// Test that we get the oldest job first
 			tx := dbtest.NewTx(t, db)
@@ -3367,3 +3701,278 @@ func testStoreLocking(db *sql.DB) func(*testing.T) {
 		}
 	}
 }
+
+func testStoreCampaignReportDigests(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	sentAt, err := s.GetLatestCampaignReportDigest(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sentAt.IsZero() {
+		t.Fatalf("have sent at %v, want zero time", sentAt)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := s.CreateCampaignReportDigest(ctx, []string{"alice@example.com"}); err != nil {
+			t.Fatal(err)
+		}
+
+		sentAt, err = s.GetLatestCampaignReportDigest(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := clock.now(); !sentAt.Equal(want) {
+			t.Fatalf("have sent at %v, want %v", sentAt, want)
+		}
+
+		clock.add(24 * time.Hour)
+	}
+}
+
+func testStoreChangesetImports(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	imports := make([]*cmpgn.ChangesetImport, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(imports); i++ {
+			ci := &cmpgn.ChangesetImport{
+				CampaignID:        int64(i%2 + 1),
+				UserID:            1,
+				ExternalServiceID: 1,
+				Query:             fmt.Sprintf("label:migration-%d", i),
+			}
+
+			if err := s.CreateChangesetImport(ctx, ci); err != nil {
+				t.Fatal(err)
+			}
+			if ci.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := ci.CreatedAt, clock.now(); !have.Equal(want) {
+				t.Fatalf("have created at %v, want %v", have, want)
+			}
+
+			imports = append(imports, ci)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetChangesetImport(ctx, GetChangesetImportOpts{ID: imports[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, imports[0]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetChangesetImport(ctx, GetChangesetImportOpts{ID: 0xdeadbeef})
+		if err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.add(time.Hour)
+
+		ci := imports[1]
+		ci.LastRunAt = clock.now()
+		ci.Error = "rate limit exceeded"
+		if err := s.UpdateChangesetImport(ctx, ci); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := ci.UpdatedAt, clock.now(); !have.Equal(want) {
+			t.Fatalf("have updated at %v, want %v", have, want)
+		}
+
+		have, err := s.GetChangesetImport(ctx, GetChangesetImportOpts{ID: ci.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, ci); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, next, err := s.ListChangesetImports(ctx, ListChangesetImportsOpts{Limit: -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != 0 {
+			t.Fatalf("have next %d, want 0", next)
+		}
+		if diff := cmp.Diff(have, imports); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, _, err = s.ListChangesetImports(ctx, ListChangesetImportsOpts{CampaignID: 1, Limit: -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, []*cmpgn.ChangesetImport{imports[0], imports[2]}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, _, err = s.ListChangesetImports(ctx, ListChangesetImportsOpts{LastRunBefore: clock.now(), Limit: -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, []*cmpgn.ChangesetImport{imports[0], imports[2]}); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, next, err = s.ListChangesetImports(ctx, ListChangesetImportsOpts{Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != imports[1].ID {
+			t.Fatalf("have next %d, want %d", next, imports[1].ID)
+		}
+		if diff := cmp.Diff(have, imports[:1]); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteChangesetImport(ctx, imports[0].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := s.GetChangesetImport(ctx, GetChangesetImportOpts{ID: imports[0].ID})
+		if err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+}
+
+func testStoreCampaignTemplates(t *testing.T, ctx context.Context, s *Store, _ repos.Store, clock clock) {
+	templates := make([]*cmpgn.CampaignTemplate, 0, 3)
+
+	t.Run("Create", func(t *testing.T) {
+		for i := 0; i < cap(templates); i++ {
+			ct := &cmpgn.CampaignTemplate{
+				Name:        fmt.Sprintf("template-%d", i),
+				Description: "Upgrade a dependency",
+				Spec:        `{"repositoriesMatchingQuery": "${{ query }}", "steps": [{"type": "comby", "matchTemplate": "a"}]}`,
+				AuthorID:    1,
+			}
+			if i%2 == 0 {
+				ct.NamespaceOrgID = 23
+			} else {
+				ct.NamespaceUserID = 42
+			}
+
+			if err := s.CreateCampaignTemplate(ctx, ct); err != nil {
+				t.Fatal(err)
+			}
+			if ct.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if have, want := ct.Version, int32(1); have != want {
+				t.Fatalf("have version %d, want %d", have, want)
+			}
+			if have, want := ct.CreatedAt, clock.now(); !have.Equal(want) {
+				t.Fatalf("have created at %v, want %v", have, want)
+			}
+
+			templates = append(templates, ct)
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountCampaignTemplates(ctx, CountCampaignTemplatesOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, int64(len(templates)); have != want {
+			t.Fatalf("have count %d, want %d", have, want)
+		}
+
+		count, err = s.CountCampaignTemplates(ctx, CountCampaignTemplatesOpts{NamespaceOrgID: 23})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, int64(2); have != want {
+			t.Fatalf("have count %d, want %d", have, want)
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: templates[0].ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, templates[0]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		_, err = s.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: 0xdeadbeef})
+		if err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.add(time.Hour)
+
+		ct := templates[1]
+		ct.Name = "upgrade-dependency"
+		ct.Spec = `{"repositoriesMatchingQuery": "${{ query }} lang:go", "steps": [{"type": "comby", "matchTemplate": "a"}]}`
+		ct.Version++
+		if err := s.UpdateCampaignTemplate(ctx, ct); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := ct.UpdatedAt, clock.now(); !have.Equal(want) {
+			t.Fatalf("have updated at %v, want %v", have, want)
+		}
+
+		have, err := s.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: ct.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, ct); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, next, err := s.ListCampaignTemplates(ctx, ListCampaignTemplatesOpts{Limit: -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != 0 {
+			t.Fatalf("have next %d, want 0", next)
+		}
+		if diff := cmp.Diff(have, templates); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, _, err = s.ListCampaignTemplates(ctx, ListCampaignTemplatesOpts{NamespaceUserID: 42, Limit: -1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have, templates[1:2]); diff != "" {
+			t.Fatal(diff)
+		}
+
+		have, next, err = s.ListCampaignTemplates(ctx, ListCampaignTemplatesOpts{Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != templates[1].ID {
+			t.Fatalf("have next %d, want %d", next, templates[1].ID)
+		}
+		if diff := cmp.Diff(have, templates[:1]); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteCampaignTemplate(ctx, templates[0].ID); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := s.GetCampaignTemplate(ctx, GetCampaignTemplateOpts{ID: templates[0].ID})
+		if err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+}
@@ -82,7 +82,7 @@ func (s *SyncRegistry) Add(extServiceID int64) {
 	service := services[0]
 
 	switch service.Kind {
-	case extsvc.KindGitHub, extsvc.KindBitbucketServer:
+	case extsvc.KindGitHub, extsvc.KindBitbucketServer, extsvc.KindAzureDevOps:
 	// Supported by campaigns
 	default:
 		log15.Debug("Campaigns syncer not started for unsupported code host", "kind", service.Kind)
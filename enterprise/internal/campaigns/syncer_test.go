@@ -500,6 +500,14 @@ func (m MockRepoStore) ListAllRepoNames(ctx context.Context) ([]api.RepoName, er
 	panic("implement me")
 }
 
+func (m MockRepoStore) ListExternalServiceSyncStates(ctx context.Context, args repos.StoreListExternalServiceSyncStatesArgs) ([]*repos.ExternalServiceSyncState, error) {
+	panic("implement me")
+}
+
+func (m MockRepoStore) UpsertExternalServiceSyncStates(ctx context.Context, states ...*repos.ExternalServiceSyncState) error {
+	panic("implement me")
+}
+
 func (m MockRepoStore) ListExternalServices(ctx context.Context, args repos.StoreListExternalServicesArgs) ([]*repos.ExternalService, error) {
 	return m.listExternalServices(ctx, args)
 }
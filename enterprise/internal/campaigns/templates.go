@@ -0,0 +1,164 @@
+package campaigns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+)
+
+// A CampaignTemplateSpec is the spec of a CampaignTemplate: a CampaignSpec in
+// whose strings the Parameters of the template are referenced as
+// ${{ name }}. The references are replaced by the arguments with which the
+// template is rendered.
+type CampaignTemplateSpec struct {
+	Parameters []CampaignTemplateParameter `json:"parameters,omitempty"`
+	CampaignSpec
+}
+
+// A CampaignTemplateParameter is a parameter of a CampaignTemplateSpec. It is
+// required if it has no Default.
+type CampaignTemplateParameter struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+var (
+	campaignTemplateParameterNameRx = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	campaignTemplateReferenceRx     = regexp.MustCompile(`\$\{\{\s*([^}\s]*)\s*\}\}`)
+)
+
+// ParseCampaignTemplateSpec parses and validates the given JSON campaign
+// template spec.
+func ParseCampaignTemplateSpec(spec string) (*CampaignTemplateSpec, error) {
+	var s CampaignTemplateSpec
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(spec)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&s); err != nil {
+		return nil, errors.Wrap(err, "parsing campaign template spec")
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate returns an error describing all the problems with the
+// CampaignTemplateSpec, or nil if it is valid.
+func (s *CampaignTemplateSpec) Validate() error {
+	var errs *multierror.Error
+
+	params := make(map[string]bool, len(s.Parameters))
+	for i, p := range s.Parameters {
+		if !campaignTemplateParameterNameRx.MatchString(p.Name) {
+			errs = multierror.Append(errs, fmt.Errorf("parameter %d: invalid name %q", i+1, p.Name))
+			continue
+		}
+		if params[p.Name] {
+			errs = multierror.Append(errs, fmt.Errorf("parameter %d: duplicate name %q", i+1, p.Name))
+		}
+		params[p.Name] = true
+	}
+
+	for _, str := range s.strings() {
+		for _, m := range campaignTemplateReferenceRx.FindAllStringSubmatch(*str, -1) {
+			if !params[m[1]] {
+				errs = multierror.Append(errs, fmt.Errorf("unknown parameter %q referenced in %q", m[1], m[0]))
+			}
+		}
+	}
+
+	if errs != nil {
+		return errs
+	}
+
+	// The rest of the spec can only be validated once the references are
+	// replaced, so we render it with placeholder arguments.
+	args := make(map[string]string, len(s.Parameters))
+	for _, p := range s.Parameters {
+		args[p.Name] = "placeholder"
+	}
+	_, err := s.Render(args)
+	return err
+}
+
+// Render replaces the references to the parameters of the
+// CampaignTemplateSpec with the given arguments, or the defaults of the
+// parameters that have no argument, and returns the validated CampaignSpec.
+func (s *CampaignTemplateSpec) Render(args map[string]string) (*CampaignSpec, error) {
+	var errs *multierror.Error
+
+	values := make(map[string]string, len(s.Parameters))
+	for _, p := range s.Parameters {
+		if v, ok := args[p.Name]; ok {
+			values[p.Name] = v
+		} else if p.Default != nil {
+			values[p.Name] = *p.Default
+		} else {
+			errs = multierror.Append(errs, fmt.Errorf("missing argument for required parameter %q", p.Name))
+		}
+	}
+
+	var unknown []string
+	for name := range args {
+		if !s.hasParameter(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = multierror.Append(errs, fmt.Errorf("unknown parameter %q", name))
+	}
+
+	if errs != nil {
+		return nil, errs
+	}
+
+	// Copy the spec, so that the template isn't modified.
+	rendered := &CampaignTemplateSpec{CampaignSpec: s.CampaignSpec}
+	rendered.Steps = append([]CampaignSpecStep(nil), s.Steps...)
+	for _, str := range rendered.strings() {
+		*str = campaignTemplateReferenceRx.ReplaceAllStringFunc(*str, func(ref string) string {
+			return values[campaignTemplateReferenceRx.FindStringSubmatch(ref)[1]]
+		})
+	}
+
+	if err := rendered.CampaignSpec.Validate(); err != nil {
+		return nil, err
+	}
+	return &rendered.CampaignSpec, nil
+}
+
+func (s *CampaignTemplateSpec) hasParameter(name string) bool {
+	for _, p := range s.Parameters {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// strings returns pointers to all the strings of the CampaignSpec of the
+// CampaignTemplateSpec in which parameters can be referenced.
+func (s *CampaignTemplateSpec) strings() []*string {
+	strs := []*string{&s.RepositoriesMatchingQuery, &s.CommitMessage, &s.ChangesetBody}
+	for i := range s.Steps {
+		step := &s.Steps[i]
+		strs = append(strs,
+			&step.MatchTemplate,
+			&step.RewriteTemplate,
+			&step.FileExtension,
+			&step.DirectoryExclude,
+			&step.Container,
+			&step.Run,
+		)
+	}
+	return strs
+}
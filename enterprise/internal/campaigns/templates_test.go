@@ -0,0 +1,141 @@
+package campaigns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCampaignTemplateSpecRender(t *testing.T) {
+	const spec = `{
+		"parameters": [
+			{"name": "module", "description": "The Go module to upgrade"},
+			{"name": "version", "default": "latest"}
+		],
+		"repositoriesMatchingQuery": "file:go.mod ${{ module }}",
+		"steps": [
+			{"type": "command", "container": "golang:1.14-alpine", "run": "go get ${{module}}@${{ version }}"}
+		],
+		"commitMessage": "Upgrade ${{ module }} in {{.Repository.Name}}"
+	}`
+
+	tests := []struct {
+		name    string
+		args    map[string]string
+		want    *CampaignSpec
+		wantErr string
+	}{
+		{
+			name: "all arguments",
+			args: map[string]string{"module": "github.com/pkg/errors", "version": "v0.9.1"},
+			want: &CampaignSpec{
+				RepositoriesMatchingQuery: "file:go.mod github.com/pkg/errors",
+				Steps: []CampaignSpecStep{
+					{
+						Type:      CampaignSpecStepCommand,
+						Container: "golang:1.14-alpine",
+						Run:       "go get github.com/pkg/errors@v0.9.1",
+					},
+				},
+				CommitMessage: "Upgrade github.com/pkg/errors in {{.Repository.Name}}",
+			},
+		},
+		{
+			name: "default argument",
+			args: map[string]string{"module": "github.com/pkg/errors"},
+			want: &CampaignSpec{
+				RepositoriesMatchingQuery: "file:go.mod github.com/pkg/errors",
+				Steps: []CampaignSpecStep{
+					{
+						Type:      CampaignSpecStepCommand,
+						Container: "golang:1.14-alpine",
+						Run:       "go get github.com/pkg/errors@latest",
+					},
+				},
+				CommitMessage: "Upgrade github.com/pkg/errors in {{.Repository.Name}}",
+			},
+		},
+		{
+			name:    "missing required argument",
+			args:    map[string]string{"version": "v0.9.1"},
+			wantErr: `missing argument for required parameter "module"`,
+		},
+		{
+			name:    "unknown argument",
+			args:    map[string]string{"module": "github.com/pkg/errors", "branch": "master"},
+			wantErr: `unknown parameter "branch"`,
+		},
+	}
+
+	s, err := ParseCampaignTemplateSpec(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := s.Render(tc.args)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("have err %v, want err containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+
+	// Rendering must not modify the template.
+	if have, want := s.Steps[0].Run, "go get ${{module}}@${{ version }}"; have != want {
+		t.Fatalf("have run %q, want %q", have, want)
+	}
+}
+
+func TestParseCampaignTemplateSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr string
+	}{
+		{
+			name:    "invalid parameter name",
+			spec:    `{"parameters": [{"name": "my-module"}], "repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "matchTemplate": "a"}]}`,
+			wantErr: `parameter 1: invalid name "my-module"`,
+		},
+		{
+			name:    "duplicate parameter",
+			spec:    `{"parameters": [{"name": "a"}, {"name": "a"}], "repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "matchTemplate": "a"}]}`,
+			wantErr: `parameter 2: duplicate name "a"`,
+		},
+		{
+			name:    "unknown parameter reference",
+			spec:    `{"repositoriesMatchingQuery": "${{ query }}", "steps": [{"type": "comby", "matchTemplate": "a"}]}`,
+			wantErr: `unknown parameter "query" referenced in "${{ query }}"`,
+		},
+		{
+			name:    "invalid rendered spec",
+			spec:    `{"parameters": [{"name": "query"}], "repositoriesMatchingQuery": "${{ query }}", "steps": []}`,
+			wantErr: "at least one step is required",
+		},
+		{
+			name:    "unknown field",
+			spec:    `{"params": [], "repositoriesMatchingQuery": "x", "steps": [{"type": "comby", "matchTemplate": "a"}]}`,
+			wantErr: `unknown field "params"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCampaignTemplateSpec(tc.spec)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("have err %v, want err containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
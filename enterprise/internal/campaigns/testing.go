@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
+	"github.com/sourcegraph/sourcegraph/internal/campaigns"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 )
 
@@ -29,12 +30,40 @@ type FakeChangesetSource struct {
 
 	// error to be returned from every method
 	Err error
+	// error to be returned from LoadChangesets only
+	LoadErr error
 
 	// ClosedChangesets contains the changesets that were passed to CloseChangeset
 	ClosedChangesets []*repos.Changeset
 
+	// MergedChangesets contains the changesets that were passed to MergeChangeset
+	MergedChangesets []*repos.Changeset
+	// MergeMethods contains the merge methods that were passed to MergeChangeset
+	MergeMethods []campaigns.ChangesetMergeMethod
+
 	// LoadedChangesets contains the changesets that were passed to LoadChangesets
 	LoadedChangesets []*repos.Changeset
+
+	// CreatedDrafts contains the changesets that were passed to CreateDraftChangeset
+	CreatedDrafts []*repos.Changeset
+	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
+	UndraftedChangesets []*repos.Changeset
+
+	// CommentedChangesets contains the changesets that were passed to CreateComment
+	CommentedChangesets []*repos.Changeset
+	// Comments contains the comment bodies that were passed to CreateComment
+	Comments []string
+	// ReopenedChangesets contains the changesets that were passed to ReopenChangeset
+	ReopenedChangesets []*repos.Changeset
+	// AddedLabels contains the labels that were passed to AddLabels
+	AddedLabels []string
+	// RequestedReviewers contains the usernames that were passed to RequestReviewers
+	RequestedReviewers []string
+
+	// FakeSearchResults are the changesets returned by SearchChangesets
+	FakeSearchResults []*repos.Changeset
+	// SearchQueries contains the queries that were passed to SearchChangesets
+	SearchQueries []string
 }
 
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
@@ -57,6 +86,23 @@ func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *repos.Chan
 	return s.ChangesetExists, s.Err
 }
 
+func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *repos.Changeset) (bool, error) {
+	exists, err := s.CreateChangeset(ctx, c)
+	if err != nil {
+		return exists, err
+	}
+	s.CreatedDrafts = append(s.CreatedDrafts, c)
+	return exists, nil
+}
+
+func (s *FakeChangesetSource) UndraftChangeset(ctx context.Context, c *repos.Changeset) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.UndraftedChangesets = append(s.UndraftedChangesets, c)
+	return nil
+}
+
 func (s *FakeChangesetSource) UpdateChangeset(ctx context.Context, c *repos.Changeset) error {
 	if s.Err != nil {
 		return s.Err
@@ -82,6 +128,9 @@ func (s *FakeChangesetSource) LoadChangesets(ctx context.Context, cs ...*repos.C
 	if s.Err != nil {
 		return s.Err
 	}
+	if s.LoadErr != nil {
+		return s.LoadErr
+	}
 	s.LoadedChangesets = append(s.LoadedChangesets, cs...)
 	return nil
 }
@@ -93,6 +142,60 @@ func (s *FakeChangesetSource) CloseChangeset(ctx context.Context, c *repos.Chang
 	return nil
 }
 
+func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *repos.Changeset, method campaigns.ChangesetMergeMethod) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.MergedChangesets = append(s.MergedChangesets, c)
+	s.MergeMethods = append(s.MergeMethods, method)
+
+	if s.FakeMetadata != nil {
+		c.Changeset.Metadata = s.FakeMetadata
+	}
+	return nil
+}
+
+func (s *FakeChangesetSource) CreateComment(ctx context.Context, c *repos.Changeset, body string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.CommentedChangesets = append(s.CommentedChangesets, c)
+	s.Comments = append(s.Comments, body)
+	return nil
+}
+
+func (s *FakeChangesetSource) ReopenChangeset(ctx context.Context, c *repos.Changeset) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.ReopenedChangesets = append(s.ReopenedChangesets, c)
+	return nil
+}
+
+func (s *FakeChangesetSource) AddLabels(ctx context.Context, c *repos.Changeset, labels []string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.AddedLabels = append(s.AddedLabels, labels...)
+	return nil
+}
+
+func (s *FakeChangesetSource) RequestReviewers(ctx context.Context, c *repos.Changeset, usernames []string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.RequestedReviewers = append(s.RequestedReviewers, usernames...)
+	return nil
+}
+
+func (s *FakeChangesetSource) SearchChangesets(ctx context.Context, query string) ([]*repos.Changeset, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	s.SearchQueries = append(s.SearchQueries, query)
+	return s.FakeSearchResults, nil
+}
+
 // FakeGitserverClient is a test implementation of the GitserverClient
 // interface required by ExecChangesetJob.
 type FakeGitserverClient struct {
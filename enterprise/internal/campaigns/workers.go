@@ -1,9 +1,12 @@
 package campaigns
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -12,11 +15,14 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go/log"
 	"github.com/pkg/errors"
+	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/cmd/repo-updater/repos"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -28,6 +34,50 @@ var maxWorkers = env.Get("CAMPAIGNS_MAX_WORKERS", "8", "maximum number of reposi
 
 const defaultWorkerCount = 8
 
+// runWorkers starts maxWorkers goroutines that call processNext until ctx is
+// canceled. processNext processes the next pending job of the given kind, if
+// any, and reports whether there was one. The workers back off on error or
+// when no jobs are available.
+func runWorkers(ctx context.Context, kind string, backoffDuration time.Duration, processNext func(ctx context.Context) (didRun bool, err error)) {
+	workerCount, err := strconv.Atoi(maxWorkers)
+	if err != nil {
+		log15.Error("Parsing max worker count failed. Falling back to default.", "default", defaultWorkerCount, "err", err)
+		workerCount = defaultWorkerCount
+	}
+
+	worker := func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				didRun, err := processNext(context.Background())
+				if err != nil {
+					log15.Error("Running "+kind, "err", err)
+				}
+				// Back off on error or when no jobs available
+				if err != nil || !didRun {
+					time.Sleep(backoffDuration)
+				}
+			}
+		}
+	}
+	for i := 0; i < workerCount; i++ {
+		go worker()
+	}
+}
+
+// execJobInTransaction calls exec, which executes the job with the given ID
+// inside the database transaction opened by one of the ProcessPending methods
+// of the Store, and saves its error in the job row. The error is only logged,
+// so that the transaction isn't rolled back.
+func execJobInTransaction(name string, jobID int64, exec func() error) error {
+	if err := exec(); err != nil {
+		log15.Error(name, "jobID", jobID, "err", err)
+	}
+	return nil
+}
+
 type GitserverClient interface {
 	CreateCommitFromPatch(ctx context.Context, req protocol.CreateCommitFromPatchRequest) (string, error)
 }
@@ -36,12 +86,6 @@ type GitserverClient interface {
 // for finding pending ChangesetJobs and executing them.
 // ctx should be canceled to terminate the function.
 func RunWorkers(ctx context.Context, s *Store, clock func() time.Time, gitClient GitserverClient, sourcer repos.Sourcer, backoffDuration time.Duration) {
-	workerCount, err := strconv.Atoi(maxWorkers)
-	if err != nil {
-		log15.Error("Parsing max worker count failed. Falling back to default.", "default", defaultWorkerCount, "err", err)
-		workerCount = defaultWorkerCount
-	}
-
 	externalURL := func() string {
 		return conf.Cached(func() interface{} {
 			return conf.Get().ExternalURL
@@ -58,39 +102,19 @@ func RunWorkers(ctx context.Context, s *Store, clock func() time.Time, gitClient
 			return errors.Wrap(err, "getting campaign")
 		}
 
-		if runErr := ExecChangesetJob(ctx, c, &job, ExecChangesetJobOpts{
-			Clock:       clock,
-			ExternalURL: externalURL(),
-			GitClient:   gitClient,
-			Sourcer:     sourcer,
-			Store:       s,
-		}); runErr != nil {
-			log15.Error("ExecChangesetJob", "jobID", job.ID, "err", runErr)
-		}
-		// We don't assign to err here so that we don't roll back the transaction
-		// ExecChangesetJob will save the error in the job row
-		return nil
-	}
-	worker := func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				didRun, err := s.ProcessPendingChangesetJobs(context.Background(), process)
-				if err != nil {
-					log15.Error("Running changeset job", "err", err)
-				}
-				// Back off on error or when no jobs available
-				if err != nil || !didRun {
-					time.Sleep(backoffDuration)
-				}
-			}
-		}
-	}
-	for i := 0; i < workerCount; i++ {
-		go worker()
+		return execJobInTransaction("ExecChangesetJob", job.ID, func() error {
+			return ExecChangesetJob(ctx, c, &job, ExecChangesetJobOpts{
+				Clock:       clock,
+				ExternalURL: externalURL(),
+				GitClient:   gitClient,
+				Sourcer:     sourcer,
+				Store:       s,
+			})
+		})
 	}
+	runWorkers(ctx, "changeset job", backoffDuration, func(ctx context.Context) (bool, error) {
+		return s.ProcessPendingChangesetJobs(ctx, process)
+	})
 }
 
 type ExecChangesetJobOpts struct {
@@ -168,6 +192,11 @@ func ExecChangesetJob(
 	}
 	repo := rs[0]
 
+	message, body, err := renderChangesetTemplates(ctx, opts.Store, c, patch, repo.Name, opts.ExternalURL)
+	if err != nil {
+		return err
+	}
+
 	branch := c.Branch
 	ensureUniqueRef := true
 	if job.Branch != "" {
@@ -187,7 +216,7 @@ func ExecChangesetJob(
 		TargetRef: branch,
 		UniqueRef: ensureUniqueRef,
 		CommitInfo: protocol.PatchCommitInfo{
-			Message:     c.Name,
+			Message:     message,
 			AuthorName:  "Sourcegraph Bot",
 			AuthorEmail: "campaigns@sourcegraph.com",
 			Date:        job.CreatedAt,
@@ -266,7 +295,7 @@ func ExecChangesetJob(
 
 	cs := repos.Changeset{
 		Title:   c.Name,
-		Body:    c.GenChangesetBody(opts.ExternalURL),
+		Body:    body,
 		BaseRef: baseRef,
 		HeadRef: git.EnsureRefPrefix(ref),
 		Repo:    repo,
@@ -285,7 +314,16 @@ func ExecChangesetJob(
 	// TODO: If we're updating the changeset, there's a race condition here.
 	// It's possible that `CreateChangeset` doesn't return the newest head ref
 	// commit yet, because the API of the codehost doesn't return it yet.
-	exists, err := ccs.CreateChangeset(ctx, &cs)
+	var exists bool
+	if job.Draft {
+		dcs, ok := ccs.(repos.DraftChangesetSource)
+		if !ok {
+			return errors.Errorf("creating draft changesets on code host of repo %q is not implemented", repo.Name)
+		}
+		exists, err = dcs.CreateDraftChangeset(ctx, &cs)
+	} else {
+		exists, err = ccs.CreateChangeset(ctx, &cs)
+	}
 	if err != nil {
 		return errors.Wrap(err, "creating changeset")
 	}
@@ -351,3 +389,105 @@ func ExecChangesetJob(
 	runFinalUpdate(ctx, opts.Store)
 	return err
 }
+
+// renderChangesetTemplates returns the commit message and the changeset body
+// for the Patch. They're rendered from the templates in the campaign spec of
+// the PatchSet of the Patch, if any, and default to the name and the
+// description of the Campaign.
+func renderChangesetTemplates(ctx context.Context, store *Store, c *campaigns.Campaign, patch *campaigns.Patch, repoName, externalURL string) (message, body string, err error) {
+	patchSet, err := store.GetPatchSet(ctx, GetPatchSetOpts{ID: patch.PatchSetID})
+	if err != nil {
+		return "", "", errors.Wrap(err, "getting patch set")
+	}
+
+	if patchSet.Spec == "" {
+		return c.Name, c.GenChangesetBody(externalURL), nil
+	}
+
+	spec, err := ParseCampaignSpec(patchSet.Spec)
+	if err != nil {
+		return "", "", err
+	}
+
+	if spec.CommitMessage == "" && spec.ChangesetBody == "" {
+		return c.Name, c.GenChangesetBody(externalURL), nil
+	}
+
+	var data ChangesetTemplateData
+	data.Repository.Name = repoName
+	data.Campaign.Name = c.Name
+	data.Campaign.Description = c.Description
+	data.Repository.Owners, err = patchOwners(ctx, repoName, patch)
+	if err != nil {
+		return "", "", errors.Wrap(err, "getting owners of changed files")
+	}
+
+	if message, err = spec.RenderCommitMessage(&data, c.Name); err != nil {
+		return "", "", err
+	}
+
+	description, err := spec.RenderChangesetBody(&data, c.Description)
+	if err != nil {
+		return "", "", err
+	}
+	withDescription := c.Clone()
+	withDescription.Description = description
+
+	return message, withDescription.GenChangesetBody(externalURL), nil
+}
+
+// codeownersMaxBytes is the maximum size of a CODEOWNERS file that is read.
+const codeownersMaxBytes = 1 << 20
+
+// patchOwners returns the owners of the files changed by the Patch, according
+// to the CODEOWNERS file of the repository at the revision of the Patch.
+func patchOwners(ctx context.Context, repoName string, patch *campaigns.Patch) ([]string, error) {
+	var rs *codeowners.Ruleset
+	for _, path := range codeowners.Paths {
+		data, err := git.ReadFile(ctx, gitserver.Repo{Name: api.RepoName(repoName)}, patch.Rev, path, codeownersMaxBytes)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		rs, err = codeowners.Parse(bytes.NewReader(data))
+		if err != nil {
+			// Don't fail the creation of the changeset because of a broken
+			// CODEOWNERS file.
+			log15.Warn("ignoring invalid CODEOWNERS file", "repo", repoName, "commit", patch.Rev, "path", path, "error", err)
+		}
+		break
+	}
+
+	owners := []string{}
+	if rs == nil {
+		return owners, nil
+	}
+
+	seen := map[string]bool{}
+	r := diff.NewMultiFileDiffReader(strings.NewReader(patch.Diff))
+	for {
+		fd, err := r.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range []string{fd.OrigName, fd.NewName} {
+			if name == "/dev/null" {
+				continue
+			}
+			for _, o := range rs.Match(name) {
+				if !seen[o] {
+					seen[o] = true
+					owners = append(owners, o)
+				}
+			}
+		}
+	}
+
+	return owners, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/codeintelutils"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/authz"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	bundles "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/bundles/client"
@@ -41,6 +42,13 @@ func NewUploadHandler(store store.Store, bundleManagerClient bundles.BundleManag
 func (h *UploadHandler) handleEnqueue(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// 🚨 SECURITY: Actors authenticated with a restricted access token must have the
+	// codeintel:upload scope to upload LSIF data.
+	if err := authz.CheckScope(ctx, authz.ScopeCodeIntelUpload); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
 	var repositoryID int
 	if !hasQuery(r, "uploadId") {
 		repoName := getQuery(r, "repository")
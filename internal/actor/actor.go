@@ -24,6 +24,11 @@ type Actor struct {
 	// to selectively display a logout link. (If the actor wasn't authenticated with a session
 	// cookie, logout would be ineffective.)
 	FromSessionCookie bool `json:"-"`
+
+	// Scopes are the scopes of the access token that was used to authenticate the actor, if the
+	// token is restricted to the operations allowed by its scopes (i.e., it doesn't have the
+	// "user:all" scope). It is nil if the actor is not restricted.
+	Scopes []string `json:"-"`
 }
 
 // FromUser returns an actor corresponding to a user
@@ -41,6 +46,12 @@ func (a *Actor) IsAuthenticated() bool {
 	return a != nil && a.UID != 0
 }
 
+// IsRestricted returns true if the Actor was authenticated with an access token that is restricted
+// to the operations allowed by its Scopes.
+func (a *Actor) IsRestricted() bool {
+	return a != nil && a.Scopes != nil
+}
+
 type key int
 
 const actorKey key = iota
@@ -250,6 +250,12 @@ func (c *internalClient) ReposListEnabled(ctx context.Context) ([]RepoName, erro
 	return names, err
 }
 
+// ReposCommitIndexUpdate notifies the frontend that the repository was
+// updated, so that its commit index is updated.
+func (c *internalClient) ReposCommitIndexUpdate(ctx context.Context, repo RepoName) error {
+	return c.postInternal(ctx, "repos/commit-index-update", repo, nil)
+}
+
 // MockInternalClientConfiguration mocks (*internalClient).Configuration.
 var MockInternalClientConfiguration func() (conftypes.RawUnified, error)
 
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/go-diff/diff"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -27,6 +28,7 @@ import (
 var SupportedExternalServices = map[string]struct{}{
 	extsvc.TypeGitHub:          {},
 	extsvc.TypeBitbucketServer: {},
+	extsvc.TypeAzureDevOps:     {},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
@@ -42,6 +44,19 @@ type PatchSet struct {
 
 	UserID int32
 
+	// Spec is the campaign spec (in JSON) from which the Patches are computed
+	// on the server. It is empty if the Patches were computed by the caller.
+	Spec string
+
+	// CampaignTemplateID is the ID of the CampaignTemplate that was rendered
+	// into the Spec, if any. CampaignTemplateVersion and
+	// CampaignTemplateArguments are the version of the template and the
+	// arguments it was rendered with, so that it can be rendered again once
+	// the template changes.
+	CampaignTemplateID        int64
+	CampaignTemplateVersion   int32
+	CampaignTemplateArguments map[string]string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -49,9 +64,37 @@ type PatchSet struct {
 // Clone returns a clone of a PatchSet.
 func (c *PatchSet) Clone() *PatchSet {
 	cc := *c
+	if c.CampaignTemplateArguments != nil {
+		cc.CampaignTemplateArguments = make(map[string]string, len(c.CampaignTemplateArguments))
+		for k, v := range c.CampaignTemplateArguments {
+			cc.CampaignTemplateArguments[k] = v
+		}
+	}
 	return &cc
 }
 
+// A CampaignTemplate is a parameterized campaign spec in a user or org
+// namespace, from which PatchSets are created with arguments. Its Version is
+// incremented every time its Spec changes.
+type CampaignTemplate struct {
+	ID              int64
+	Name            string
+	Description     string
+	Spec            string
+	Version         int32
+	AuthorID        int32
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Clone returns a clone of a CampaignTemplate.
+func (t *CampaignTemplate) Clone() *CampaignTemplate {
+	tt := *t
+	return &tt
+}
+
 // A Patch is the application of a CampaignType over PatchSet arguments in
 // a specific repository at a specific revision.
 type Patch struct {
@@ -124,6 +167,36 @@ func (p *Patch) DiffStat() (diff.Stat, bool) {
 	return s, true
 }
 
+// A PatchSetJob is the computation of the Patch of a PatchSet in a single
+// repository, by executing the steps of the PatchSet's Spec.
+type PatchSetJob struct {
+	ID         int64
+	PatchSetID int64
+
+	RepoID api.RepoID
+	// Rev is the revision that was requested in the repository. If empty, the
+	// default branch is used.
+	Rev string
+
+	// Only set once the PatchSetJob has successfully finished and produced a
+	// non-empty diff.
+	PatchID int64
+
+	Error string
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Clone returns a clone of a PatchSetJob.
+func (j *PatchSetJob) Clone() *PatchSetJob {
+	cc := *j
+	return &cc
+}
+
 // A Campaign of changesets over multiple Repos over time.
 type Campaign struct {
 	ID              int64
@@ -138,6 +211,73 @@ type Campaign struct {
 	ChangesetIDs    []int64
 	PatchSetID      int64
 	ClosedAt        time.Time
+	AutoMerge       AutoMerge
+}
+
+// AutoMerge are the settings with which the changesets of a Campaign are
+// merged on the code host once they're approved and their checks passed.
+type AutoMerge struct {
+	Enabled bool                 `json:"enabled"`
+	Method  ChangesetMergeMethod `json:"method,omitempty"`
+	// MaxMergesPerHour limits how many changesets of the Campaign are merged
+	// per hour on each code host. Zero means no limit.
+	MaxMergesPerHour int32 `json:"maxMergesPerHour,omitempty"`
+	// WindowStart and WindowEnd are the times of day in UTC, formatted as
+	// "15:04", between which changesets are merged. If both are empty,
+	// changesets are merged at any time of day.
+	WindowStart string `json:"windowStart,omitempty"`
+	WindowEnd   string `json:"windowEnd,omitempty"`
+}
+
+// Validate returns an error if the AutoMerge settings are invalid.
+func (a *AutoMerge) Validate() error {
+	if a.Method != "" && !a.Method.Valid() {
+		return errors.Errorf("invalid merge method %q", a.Method)
+	}
+	if a.MaxMergesPerHour < 0 {
+		return errors.New("maximum merges per hour cannot be negative")
+	}
+	if (a.WindowStart == "") != (a.WindowEnd == "") {
+		return errors.New("merge window needs both a start and an end")
+	}
+	for _, t := range []string{a.WindowStart, a.WindowEnd} {
+		if t == "" {
+			continue
+		}
+		if _, err := time.Parse(mergeWindowLayout, t); err != nil {
+			return errors.Errorf("invalid merge window time %q, expected format HH:MM", t)
+		}
+	}
+	return nil
+}
+
+const mergeWindowLayout = "15:04"
+
+// InWindow returns true if the given time is within the merge window. A
+// window whose end is before its start spans midnight.
+func (a *AutoMerge) InWindow(t time.Time) bool {
+	if a.WindowStart == "" || a.WindowEnd == "" {
+		return true
+	}
+
+	start, err := time.Parse(mergeWindowLayout, a.WindowStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(mergeWindowLayout, a.WindowEnd)
+	if err != nil {
+		return false
+	}
+
+	t = t.UTC()
+	minute := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from <= to {
+		return from <= minute && minute < to
+	}
+	return minute >= from || minute < to
 }
 
 // Clone returns a clone of a Campaign.
@@ -290,6 +430,136 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetMergeMethod defines the possible methods of merging a Changeset on
+// its code host.
+type ChangesetMergeMethod string
+
+// ChangesetMergeMethod constants.
+const (
+	ChangesetMergeMethodMerge  ChangesetMergeMethod = "MERGE"
+	ChangesetMergeMethodSquash ChangesetMergeMethod = "SQUASH"
+	ChangesetMergeMethodRebase ChangesetMergeMethod = "REBASE"
+)
+
+// Valid returns true if the given Changeset merge method is valid.
+func (m ChangesetMergeMethod) Valid() bool {
+	switch m {
+	case ChangesetMergeMethodMerge,
+		ChangesetMergeMethodSquash,
+		ChangesetMergeMethodRebase:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangesetBulkActionType defines the possible actions that can be run on
+// many changesets of a Campaign at once.
+type ChangesetBulkActionType string
+
+// ChangesetBulkActionType constants.
+const (
+	ChangesetBulkActionComment          ChangesetBulkActionType = "COMMENT"
+	ChangesetBulkActionClose            ChangesetBulkActionType = "CLOSE"
+	ChangesetBulkActionReopen           ChangesetBulkActionType = "REOPEN"
+	ChangesetBulkActionAddLabels        ChangesetBulkActionType = "ADD_LABELS"
+	ChangesetBulkActionRequestReviewers ChangesetBulkActionType = "REQUEST_REVIEWERS"
+)
+
+// Valid returns true if the given ChangesetBulkActionType is valid.
+func (t ChangesetBulkActionType) Valid() bool {
+	switch t {
+	case ChangesetBulkActionComment,
+		ChangesetBulkActionClose,
+		ChangesetBulkActionReopen,
+		ChangesetBulkActionAddLabels,
+		ChangesetBulkActionRequestReviewers:
+		return true
+	default:
+		return false
+	}
+}
+
+// A ChangesetBulkAction is an action run on a subset of the changesets of a
+// Campaign, with one ChangesetBulkJob per changeset.
+type ChangesetBulkAction struct {
+	ID         int64
+	CampaignID int64
+	UserID     int32
+	Type       ChangesetBulkActionType
+
+	// Body is the body of the comment of a COMMENT action.
+	Body string
+	// Labels are the names of the labels added by an ADD_LABELS action.
+	Labels []string
+	// Reviewers are the usernames of the users on the code host whose review
+	// is requested by a REQUEST_REVIEWERS action.
+	Reviewers []string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate returns an error if the ChangesetBulkAction is missing the
+// arguments of its Type.
+func (a *ChangesetBulkAction) Validate() error {
+	switch a.Type {
+	case ChangesetBulkActionComment:
+		if strings.TrimSpace(a.Body) == "" {
+			return errors.New("comment body is empty")
+		}
+	case ChangesetBulkActionAddLabels:
+		if len(a.Labels) == 0 {
+			return errors.New("no labels given")
+		}
+	case ChangesetBulkActionRequestReviewers:
+		if len(a.Reviewers) == 0 {
+			return errors.New("no reviewers given")
+		}
+	case ChangesetBulkActionClose, ChangesetBulkActionReopen:
+	default:
+		return errors.Errorf("invalid bulk action %q", a.Type)
+	}
+	return nil
+}
+
+// A ChangesetBulkJob is the run of a ChangesetBulkAction on a single
+// Changeset.
+type ChangesetBulkJob struct {
+	ID           int64
+	BulkActionID int64
+	ChangesetID  int64
+
+	Error string
+
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// A ChangesetImport is a search for existing changesets on the code host of
+// an external service. The changesets matching the search are periodically
+// imported into its Campaign.
+type ChangesetImport struct {
+	ID                int64
+	CampaignID        int64
+	UserID            int32
+	ExternalServiceID int64
+
+	// Query is the code host specific search for changesets, e.g. a GitHub
+	// search query.
+	Query string
+
+	// Error is the error of the last run of the search, if any.
+	Error     string
+	LastRunAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
 // A ChangesetJob is the creation of a Changeset on an external host from a
 // local Patch for a given Campaign.
 type ChangesetJob struct {
@@ -302,6 +572,19 @@ type ChangesetJob struct {
 
 	Branch string
 
+	// Conflicted is true if the Patch no longer applies to the head of its
+	// base branch, so the changeset could not be rebased.
+	Conflicted bool
+
+	// Draft is true if the changeset is published as a draft, e.g. a draft
+	// pull request on GitHub, and only marked as ready for review later.
+	Draft bool
+
+	// PublishAt is the time before which the ChangesetJob is not run, so
+	// that the changesets of a campaign can be published in waves. If zero,
+	// the ChangesetJob is run right away.
+	PublishAt time.Time
+
 	Error string
 
 	StartedAt  time.Time
@@ -333,6 +616,12 @@ func (c *ChangesetJob) UnsuccessfullyCompleted() bool {
 	return c.Error != "" && c.ChangesetID == 0 && c.Completed()
 }
 
+// Scheduled returns true if the ChangesetJob is not to be run before a time
+// after now.
+func (c *ChangesetJob) Scheduled(now time.Time) bool {
+	return !c.PublishAt.IsZero() && c.PublishAt.After(now)
+}
+
 // Reset sets the Error, StartedAt and FinishedAt fields to their respective
 // zero values, so that the ChangesetJob can be executed again.
 func (c *ChangesetJob) Reset() {
@@ -383,6 +672,12 @@ func (c *Changeset) SetMetadata(meta interface{}) error {
 		c.ExternalServiceType = extsvc.TypeBitbucketServer
 		c.ExternalBranch = git.AbbreviateRef(pr.FromRef.ID)
 		c.ExternalUpdatedAt = unixMilliToTime(int64(pr.UpdatedDate))
+	case *azuredevops.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.ID)
+		c.ExternalServiceType = extsvc.TypeAzureDevOps
+		c.ExternalBranch = git.AbbreviateRef(pr.SourceRefName)
+		c.ExternalUpdatedAt = pr.UpdatedAt()
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -406,6 +701,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bitbucketserver.PullRequest:
 		return m.Title, nil
+	case *azuredevops.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -420,6 +717,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt
 	case *bitbucketserver.PullRequest:
 		return unixMilliToTime(int64(m.CreatedDate))
+	case *azuredevops.PullRequest:
+		return m.CreationDate
 	default:
 		return time.Time{}
 	}
@@ -432,6 +731,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Body, nil
 	case *bitbucketserver.PullRequest:
 		return m.Description, nil
+	case *azuredevops.PullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -465,6 +766,8 @@ func (c *Changeset) state() (s ChangesetState, err error) {
 		} else {
 			s = ChangesetState(m.State)
 		}
+	case *azuredevops.PullRequest:
+		s = AzureDevOpsChangesetState(m.Status)
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -487,11 +790,28 @@ func (c *Changeset) URL() (s string, err error) {
 		}
 		selfLink := m.Links.Self[0]
 		return selfLink.Href, nil
+	case *azuredevops.PullRequest:
+		return m.WebURL(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
 }
 
+// AzureDevOpsChangesetState maps the status of an Azure DevOps pull request
+// to a ChangesetState.
+func AzureDevOpsChangesetState(status string) ChangesetState {
+	switch status {
+	case azuredevops.PullRequestStatusActive:
+		return ChangesetStateOpen
+	case azuredevops.PullRequestStatusAbandoned:
+		return ChangesetStateClosed
+	case azuredevops.PullRequestStatusCompleted:
+		return ChangesetStateMerged
+	default:
+		return ChangesetState(strings.ToUpper(status))
+	}
+}
+
 // Changesets is a slice of *Changesets.
 type Changesets []*Changeset
 
@@ -598,6 +918,11 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.HeadRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *azuredevops.PullRequest:
+		if m.LastMergeSourceCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeSourceCommit.CommitID, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -611,6 +936,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.HeadRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.FromRef.ID, nil
+	case *azuredevops.PullRequest:
+		return m.SourceRefName, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -625,6 +952,11 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.BaseRefOid, nil
 	case *bitbucketserver.PullRequest:
 		return "", nil
+	case *azuredevops.PullRequest:
+		if m.LastMergeTargetCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeTargetCommit.CommitID, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -638,6 +970,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.BaseRefName, nil
 	case *bitbucketserver.PullRequest:
 		return m.ToRef.ID, nil
+	case *azuredevops.PullRequest:
+		return m.TargetRefName, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -660,6 +994,48 @@ func (c *Changeset) Labels() []ChangesetLabel {
 	}
 }
 
+// Assignees returns the usernames of the users the Changeset is assigned to
+// on its code host. Bitbucket Server and Azure DevOps pull requests have no
+// assignees, so their reviewers are returned instead.
+func (c *Changeset) Assignees() []string {
+	assignees := []string{}
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		// GitHub pull requests only report their assignees through the
+		// events of their timeline.
+		assigned := map[string]bool{}
+		for _, ti := range m.TimelineItems {
+			switch e := ti.Item.(type) {
+			case *github.AssignedEvent:
+				if _, ok := assigned[e.Assignee.Login]; !ok {
+					assignees = append(assignees, e.Assignee.Login)
+				}
+				assigned[e.Assignee.Login] = true
+			case *github.UnassignedEvent:
+				assigned[e.Assignee.Login] = false
+			}
+		}
+		filtered := assignees[:0]
+		for _, a := range assignees {
+			if assigned[a] {
+				filtered = append(filtered, a)
+			}
+		}
+		assignees = filtered
+	case *bitbucketserver.PullRequest:
+		for _, r := range m.Reviewers {
+			if r.User != nil {
+				assignees = append(assignees, r.User.Name)
+			}
+		}
+	case *azuredevops.PullRequest:
+		for _, r := range m.Reviewers {
+			assignees = append(assignees, r.UniqueName)
+		}
+	}
+	return assignees
+}
+
 // A ChangesetEvent is an event that happened in the lifetime
 // and context of a Changeset.
 type ChangesetEvent struct {
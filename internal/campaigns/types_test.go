@@ -296,3 +296,110 @@ func TestChangesetEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestAutoMerge(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2020, 4, 1, hour, min, 0, 0, time.UTC)
+	}
+
+	t.Run("InWindow", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			window AutoMerge
+			t      time.Time
+			want   bool
+		}{
+			{name: "no window", window: AutoMerge{}, t: at(3, 0), want: true},
+			{name: "inside", window: AutoMerge{WindowStart: "09:00", WindowEnd: "17:00"}, t: at(9, 0), want: true},
+			{name: "before", window: AutoMerge{WindowStart: "09:00", WindowEnd: "17:00"}, t: at(8, 59), want: false},
+			{name: "at end", window: AutoMerge{WindowStart: "09:00", WindowEnd: "17:00"}, t: at(17, 0), want: false},
+			{name: "spanning midnight, late", window: AutoMerge{WindowStart: "22:00", WindowEnd: "04:30"}, t: at(23, 15), want: true},
+			{name: "spanning midnight, early", window: AutoMerge{WindowStart: "22:00", WindowEnd: "04:30"}, t: at(4, 29), want: true},
+			{name: "spanning midnight, outside", window: AutoMerge{WindowStart: "22:00", WindowEnd: "04:30"}, t: at(12, 0), want: false},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				if have := tc.window.InWindow(tc.t); have != tc.want {
+					t.Fatalf("have %t, want %t", have, tc.want)
+				}
+			})
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			a       AutoMerge
+			wantErr bool
+		}{
+			{name: "valid", a: AutoMerge{Enabled: true, Method: ChangesetMergeMethodSquash, MaxMergesPerHour: 5, WindowStart: "09:00", WindowEnd: "17:00"}},
+			{name: "invalid method", a: AutoMerge{Method: "FAST_FORWARD"}, wantErr: true},
+			{name: "negative limit", a: AutoMerge{MaxMergesPerHour: -1}, wantErr: true},
+			{name: "window without end", a: AutoMerge{WindowStart: "09:00"}, wantErr: true},
+			{name: "invalid window time", a: AutoMerge{WindowStart: "9am", WindowEnd: "17:00"}, wantErr: true},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				if err := tc.a.Validate(); (err != nil) != tc.wantErr {
+					t.Fatalf("have err %v, want error: %t", err, tc.wantErr)
+				}
+			})
+		}
+	})
+}
+
+func TestChangesetBulkActionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       ChangesetBulkAction
+		wantErr bool
+	}{
+		{name: "comment", a: ChangesetBulkAction{Type: ChangesetBulkActionComment, Body: "Please review"}},
+		{name: "comment without body", a: ChangesetBulkAction{Type: ChangesetBulkActionComment, Body: " \n"}, wantErr: true},
+		{name: "close", a: ChangesetBulkAction{Type: ChangesetBulkActionClose}},
+		{name: "reopen", a: ChangesetBulkAction{Type: ChangesetBulkActionReopen}},
+		{name: "add labels", a: ChangesetBulkAction{Type: ChangesetBulkActionAddLabels, Labels: []string{"automated"}}},
+		{name: "add no labels", a: ChangesetBulkAction{Type: ChangesetBulkActionAddLabels}, wantErr: true},
+		{name: "request reviewers", a: ChangesetBulkAction{Type: ChangesetBulkActionRequestReviewers, Reviewers: []string{"alice"}}},
+		{name: "request no reviewers", a: ChangesetBulkAction{Type: ChangesetBulkActionRequestReviewers}, wantErr: true},
+		{name: "invalid type", a: ChangesetBulkAction{Type: "MERGE"}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.a.Validate(); (err != nil) != tc.wantErr {
+				t.Fatalf("have err %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestChangesetAssignees(t *testing.T) {
+	assigned := func(login string) github.TimelineItem {
+		return github.TimelineItem{Type: "AssignedEvent", Item: &github.AssignedEvent{Assignee: github.Actor{Login: login}}}
+	}
+	unassigned := func(login string) github.TimelineItem {
+		return github.TimelineItem{Type: "UnassignedEvent", Item: &github.UnassignedEvent{Assignee: github.Actor{Login: login}}}
+	}
+
+	c := &Changeset{Metadata: &github.PullRequest{
+		TimelineItems: []github.TimelineItem{
+			assigned("alice"),
+			assigned("bob"),
+			unassigned("alice"),
+			assigned("carol"),
+			unassigned("bob"),
+			assigned("bob"),
+		},
+	}}
+
+	if diff := cmp.Diff(c.Assignees(), []string{"bob", "carol"}); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if have := (&Changeset{}).Assignees(); len(have) != 0 {
+		t.Fatalf("have assignees %v, want none", have)
+	}
+}
@@ -0,0 +1,196 @@
+// Package codeowners parses CODEOWNERS files and finds the owners of paths.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
+)
+
+// Paths are the paths (relative to the repository root) where a CODEOWNERS
+// file is looked up, in order of precedence.
+var Paths = []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	sections []*section
+}
+
+// section is a GitLab CODEOWNERS section. Rules outside of any section are in
+// an unnamed section, which is the only section of GitHub CODEOWNERS files.
+type section struct {
+	name          string
+	defaultOwners []string // owners of rules in the section that do not list any
+	rules         []*rule
+}
+
+type rule struct {
+	pattern string
+	owners  []string
+
+	matcher pathmatch.PathMatcher
+	dirOnly bool // the pattern ends with "/" and only matches directories
+	nested  bool // the pattern also matches the files below the matched directories
+}
+
+// sectionHeaderRx matches GitLab section headers such as "[Docs]",
+// "^[Docs]" (optional section) and "[Docs][2] @docs-team" (with the required
+// number of approvals and default owners).
+var sectionHeaderRx = lazyregexp.New(`^\^?\[([^\]]+)\](?:\[\d+\])?(?:\s+(.*))?$`)
+
+// Parse parses a CODEOWNERS file in GitHub or GitLab syntax.
+func Parse(r io.Reader) (*Ruleset, error) {
+	current := &section{}
+	rs := &Ruleset{sections: []*section{current}}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := sectionHeaderRx.FindStringSubmatch(line); m != nil {
+			current = &section{name: m[1], defaultOwners: fields(m[2])}
+			rs.sections = append(rs.sections, current)
+			continue
+		}
+
+		f := fields(line)
+		if len(f) == 0 {
+			// The line only consists of backslashes, which escape nothing.
+			continue
+		}
+		r, err := newRule(f[0], f[1:])
+		if err != nil {
+			return nil, fmt.Errorf("CODEOWNERS line %d: %s", lineNumber, err)
+		}
+		current.rules = append(current.rules, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// fields splits the line at whitespace that is not escaped with a backslash.
+// A field starting with "#" starts a comment that ends the line.
+func fields(line string) []string {
+	var (
+		fields  []string
+		b       strings.Builder
+		escaped bool
+	)
+	for _, c := range line {
+		switch {
+		case escaped:
+			b.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == ' ' || c == '\t':
+			if b.Len() > 0 {
+				fields = append(fields, b.String())
+				b.Reset()
+			}
+		case c == '#' && b.Len() == 0:
+			return fields
+		default:
+			b.WriteRune(c)
+		}
+	}
+	if b.Len() > 0 {
+		fields = append(fields, b.String())
+	}
+	return fields
+}
+
+// newRule compiles a CODEOWNERS pattern, which follows the gitignore
+// semantics: a pattern that contains no "/" (except at its end) matches at
+// any depth, otherwise it is relative to the repository root.
+func newRule(pattern string, owners []string) (*rule, error) {
+	r := &rule{pattern: pattern, owners: owners}
+
+	glob := pattern
+	if strings.HasSuffix(glob, "/") {
+		r.dirOnly = true
+		glob = strings.TrimRight(glob, "/")
+	}
+	if glob == "" {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+	if strings.Contains(glob, "/") {
+		glob = "/" + strings.TrimPrefix(glob, "/")
+	} else {
+		glob = "**/" + glob
+	}
+	// Like on GitHub, "docs/*" matches the files in docs, but not the files
+	// in its subdirectories.
+	r.nested = !strings.HasSuffix(glob, "/*")
+
+	var err error
+	r.matcher, err = pathmatch.CompilePattern(glob, pathmatch.CompileOptions{CaseSensitive: true, PathSeparator: true})
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %s", pattern, err)
+	}
+	return r, nil
+}
+
+// match reports whether the rule matches the file at path, which starts with
+// "/".
+func (r *rule) match(path string) bool {
+	if !r.dirOnly && r.matcher.MatchPath(path) {
+		return true
+	}
+	if !r.nested {
+		return false
+	}
+	// Match the directories containing the file.
+	for i := strings.LastIndexByte(path, '/'); i > 0; i = strings.LastIndexByte(path[:i], '/') {
+		if r.matcher.MatchPath(path[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns the owners of the file at path, which is relative to the
+// repository root. In each section, the last rule that matches the path
+// determines the owners; the owners of all sections are combined.
+func (rs *Ruleset) Match(path string) []string {
+	path = "/" + strings.TrimPrefix(path, "/")
+
+	var owners []string
+	seen := map[string]bool{}
+	for _, s := range rs.sections {
+		for i := len(s.rules) - 1; i >= 0; i-- {
+			r := s.rules[i]
+			if !r.match(path) {
+				continue
+			}
+			ruleOwners := r.owners
+			if len(ruleOwners) == 0 && s.name != "" {
+				ruleOwners = s.defaultOwners
+			}
+			for _, o := range ruleOwners {
+				if !seen[o] {
+					seen[o] = true
+					owners = append(owners, o)
+				}
+			}
+			break
+		}
+	}
+	return owners
+}
+
+// NormalizeOwner returns the canonical form of an owner for comparisons, which
+// is case-insensitive and does not require the leading "@" of user and team
+// names.
+func NormalizeOwner(owner string) string {
+	return strings.ToLower(strings.TrimPrefix(owner, "@"))
+}
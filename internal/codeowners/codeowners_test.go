@@ -0,0 +1,90 @@
+package codeowners
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	rs, err := Parse(strings.NewReader(`
+# Default owners.
+*                 @global-owner
+*.js              @js-owner   # inline comment
+/build/logs/      @doctocat
+docs/*            docs@example.com
+apps/             @octocat
+/scripts/         @doctocat @octocat
+**/logs           @logs-owner
+/config/**.yml    @config-owner
+/no-owner/
+My\ Documents/    @docs-owner
+\#notes           @notes-owner
+
+[Frontend][2] @frontend-team
+/client/
+/client/legacy/   @legacy-owner
+
+^[Security]
+/client/auth/     @security-team
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"README.md":                  {"@global-owner"},
+		"main.js":                    {"@js-owner"},
+		"lib/util.js":                {"@js-owner"},
+		"build/logs/a.txt":           {"@logs-owner"}, // the last matching rule wins
+		"src/build/logs/a.txt":       {"@logs-owner"},
+		"docs/getting-started.md":    {"docs@example.com"},
+		"docs/build-app/trouble.md":  {"@global-owner"},
+		"apps/web/main.go":           {"@octocat"},
+		"src/apps/main.go":           {"@octocat"},
+		"apps":                       {"@global-owner"},
+		"scripts/deploy.sh":          {"@doctocat", "@octocat"},
+		"deep/logs/x":                {"@logs-owner"},
+		"config/a/b.yml":             {"@config-owner"},
+		"no-owner/x.go":              nil,
+		"My Documents/a.txt":         {"@docs-owner"},
+		"#notes":                     {"@notes-owner"},
+		"client/index.ts":            {"@global-owner", "@frontend-team"},
+		"client/legacy/index.ts":     {"@global-owner", "@legacy-owner"},
+		"client/auth/login.ts":       {"@global-owner", "@frontend-team", "@security-team"},
+		"client/auth/deep/login.js":  {"@js-owner", "@frontend-team", "@security-team"},
+		"/client/auth/deep/login.js": {"@js-owner", "@frontend-team", "@security-team"},
+	} {
+		if have := rs.Match(path); !reflect.DeepEqual(have, want) {
+			t.Errorf("%s: got owners %q, want %q", path, have, want)
+		}
+	}
+}
+
+func TestParse_invalidPattern(t *testing.T) {
+	if _, err := Parse(strings.NewReader("*.go @a\n[ @b\n")); err == nil || err.Error() != `CODEOWNERS line 2: invalid pattern "[": unexpected end of input` {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestParse_onlyBackslash(t *testing.T) {
+	rs, err := Parse(strings.NewReader("* @a\n\\\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := rs.Match("README.md"), []string{"@a"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("got owners %q, want %q", have, want)
+	}
+}
+
+func TestNormalizeOwner(t *testing.T) {
+	for owner, want := range map[string]string{
+		"@Org/Team":       "org/team",
+		"alice":           "alice",
+		"Bob@example.com": "bob@example.com",
+	} {
+		if have := NormalizeOwner(owner); have != want {
+			t.Errorf("%s: got %q, want %q", owner, have, want)
+		}
+	}
+}
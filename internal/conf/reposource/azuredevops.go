@@ -0,0 +1,47 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOps struct {
+	*schema.AzureDevOpsConnection
+}
+
+var _ RepoSource = AzureDevOps{}
+
+func (c AzureDevOps) CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error) {
+	parsedCloneURL, baseURL, match, err := parseURLs(cloneURL, c.Url)
+	if err != nil {
+		return "", err
+	}
+	if !match {
+		return "", nil
+	}
+
+	// Clone URLs have the form {baseURL}/{project}/_git/{repo}.
+	organization := strings.Trim(baseURL.Path, "/")
+	path := strings.TrimPrefix(strings.TrimPrefix(parsedCloneURL.Path, "/"), organization)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 3 || parts[1] != "_git" {
+		return "", nil
+	}
+
+	return AzureDevOpsRepoName(c.RepositoryPathPattern, baseURL.Hostname(), organization, parts[0], strings.TrimSuffix(parts[2], ".git")), nil
+}
+
+func AzureDevOpsRepoName(repositoryPathPattern, host, organization, project, repo string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{organization}/{project}/{repo}"
+	}
+
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{organization}", organization,
+		"{project}", project,
+		"{repo}", repo,
+	).Replace(repositoryPathPattern))
+}
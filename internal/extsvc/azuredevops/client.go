@@ -0,0 +1,344 @@
+// Package azuredevops implements a client for the Azure DevOps (and Azure
+// DevOps Server) REST API.
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"golang.org/x/time/rate"
+)
+
+var requestCounter = metrics.NewRequestMeter("azuredevops_requests_count", "Total number of requests sent to the Azure DevOps API.")
+
+// These fields define the self-imposed Azure DevOps rate limit. Azure DevOps
+// throttles based on a sliding window of resource consumption rather than a
+// number of requests, so we stay well below what a single user would do.
+//
+// See https://docs.microsoft.com/en-us/azure/devops/integrate/concepts/rate-limits
+const (
+	rateLimitRequestsPerSecond = 5 // 300/min or 18000/hr
+	RateLimitMaxBurstRequests  = 500
+)
+
+// apiVersion is the REST API version requested by the Client.
+const apiVersion = "6.0"
+
+// Client access an Azure DevOps organization (or Azure DevOps Server
+// collection) via the REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of the organization or collection, such as
+	// https://dev.azure.com/myorg/.
+	URL *url.URL
+
+	// Token is the personal access token used to authenticate requests.
+	Token string
+
+	// RateLimit is the self-imposed rate limiter.
+	RateLimit *rate.Limiter
+}
+
+// NewClient creates a new Azure DevOps API client for the organization or collection at
+// baseURL. If a nil httpClient is provided, http.DefaultClient will be used.
+func NewClient(baseURL *url.URL, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The component after "_apis" maps to the area of the API request we
+		// are making, e.g. "projects" or "git".
+		parts := strings.Split(u.Path, "/")
+		for i := range parts {
+			if parts[i] == "_apis" && i+1 < len(parts) {
+				return parts[i+1]
+			}
+		}
+		return ""
+	})
+
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, RateLimitMaxBurstRequests)
+	l := ratelimit.DefaultRegistry.GetOrSet(baseURL.String(), defaultLimiter)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        baseURL,
+		RateLimit:  l,
+	}
+}
+
+// ListProjects returns a page of at most top projects of the organization, skipping the
+// first skip projects.
+//
+// See https://docs.microsoft.com/en-us/rest/api/azure/devops/core/projects/list
+func (c *Client) ListProjects(ctx context.Context, skip, top int) ([]*Project, error) {
+	qry := url.Values{}
+	qry.Set("$top", strconv.Itoa(top))
+	qry.Set("$skip", strconv.Itoa(skip))
+
+	var res struct {
+		Value []*Project `json:"value"`
+	}
+	err := c.get(ctx, "_apis/projects", qry, &res)
+	return res.Value, err
+}
+
+// ListRepositories returns all Git repositories of the given project.
+//
+// See https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/list
+func (c *Client) ListRepositories(ctx context.Context, project string) ([]*Repository, error) {
+	var res struct {
+		Value []*Repository `json:"value"`
+	}
+	err := c.get(ctx, url.PathEscape(project)+"/_apis/git/repositories", nil, &res)
+	return res.Value, err
+}
+
+// GetPullRequest returns the pull request with the given ID of the given repository.
+//
+// See https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20requests/get%20pull%20request
+func (c *Client) GetPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error) {
+	var pr PullRequest
+	err := c.get(ctx, pullRequestsPath(repo)+"/"+strconv.Itoa(id), nil, &pr)
+	if err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CreatePullRequestInput contains the fields of a pull request that are set on creation.
+type CreatePullRequestInput struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	IsDraft       bool   `json:"isDraft,omitempty"`
+}
+
+// CreatePullRequest creates a pull request in the given repository. If an active pull
+// request for the same source and target refs already exists, an *ErrAlreadyExists
+// containing it is returned.
+//
+// See https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20requests/create
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repository, in *CreatePullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	err := c.send(ctx, "POST", pullRequestsPath(repo), in, &pr)
+	if err == nil {
+		return &pr, nil
+	}
+
+	if e, ok := errors.Cause(err).(*httpError); !ok || e.StatusCode != http.StatusConflict {
+		return nil, err
+	}
+
+	qry := url.Values{}
+	qry.Set("searchCriteria.status", PullRequestStatusActive)
+	qry.Set("searchCriteria.sourceRefName", in.SourceRefName)
+	qry.Set("searchCriteria.targetRefName", in.TargetRefName)
+
+	var res struct {
+		Value []*PullRequest `json:"value"`
+	}
+	if err := c.get(ctx, pullRequestsPath(repo), qry, &res); err != nil {
+		return nil, errors.Wrap(err, "fetching existing pull request")
+	}
+	if len(res.Value) == 0 {
+		return nil, err
+	}
+	return nil, &ErrAlreadyExists{Existing: res.Value[0]}
+}
+
+// UpdatePullRequestInput contains the fields of a pull request that can be updated.
+// Empty fields are left unchanged.
+type UpdatePullRequestInput struct {
+	Title                 string             `json:"title,omitempty"`
+	Description           string             `json:"description,omitempty"`
+	TargetRefName         string             `json:"targetRefName,omitempty"`
+	Status                string             `json:"status,omitempty"`
+	IsDraft               *bool              `json:"isDraft,omitempty"`
+	LastMergeSourceCommit *Commit            `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *CompletionOptions `json:"completionOptions,omitempty"`
+}
+
+// CompletionOptions are the options with which a pull request is completed.
+type CompletionOptions struct {
+	MergeStrategy      string `json:"mergeStrategy,omitempty"`
+	DeleteSourceBranch bool   `json:"deleteSourceBranch,omitempty"`
+}
+
+// UpdatePullRequest updates the given pull request and returns its new state.
+//
+// See https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull%20requests/update
+func (c *Client) UpdatePullRequest(ctx context.Context, pr *PullRequest, in *UpdatePullRequestInput) (*PullRequest, error) {
+	var updated PullRequest
+	err := c.send(ctx, "PATCH", pullRequestsPath(&pr.Repository)+"/"+strconv.Itoa(pr.ID), in, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// AbandonPullRequest abandons the given pull request and returns its new state.
+func (c *Client) AbandonPullRequest(ctx context.Context, pr *PullRequest) (*PullRequest, error) {
+	return c.UpdatePullRequest(ctx, pr, &UpdatePullRequestInput{Status: PullRequestStatusAbandoned})
+}
+
+// CompletePullRequest completes (merges) the given pull request with the given
+// merge strategy (e.g. "noFastForward", "squash" or "rebase") and returns its
+// new state. If mergeStrategy is empty, the default strategy of the repository
+// is used.
+func (c *Client) CompletePullRequest(ctx context.Context, pr *PullRequest, mergeStrategy string) (*PullRequest, error) {
+	if pr.LastMergeSourceCommit == nil {
+		return nil, errors.New("last merge source commit of pull request unknown")
+	}
+
+	in := &UpdatePullRequestInput{
+		Status:                PullRequestStatusCompleted,
+		LastMergeSourceCommit: &Commit{CommitID: pr.LastMergeSourceCommit.CommitID},
+	}
+	if mergeStrategy != "" {
+		in.CompletionOptions = &CompletionOptions{MergeStrategy: mergeStrategy}
+	}
+	return c.UpdatePullRequest(ctx, pr, in)
+}
+
+func pullRequestsPath(repo *Repository) string {
+	return url.PathEscape(repo.Project.ID) + "/_apis/git/repositories/" + url.PathEscape(repo.ID) + "/pullrequests"
+}
+
+func (c *Client) get(ctx context.Context, path string, qry url.Values, result interface{}) error {
+	if qry == nil {
+		qry = make(url.Values)
+	}
+	qry.Set("api-version", apiVersion)
+
+	req, err := http.NewRequest("GET", path+"?"+qry.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload, result interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	qry := url.Values{"api-version": {apiVersion}}
+	req, err := http.NewRequest(method, path+"?"+qry.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Azure DevOps"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	// Personal access tokens are sent as the password of basic auth with an
+	// empty username.
+	req.SetBasicAuth("", c.Token)
+
+	startWait := time.Now()
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Azure DevOps self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Azure DevOps answers requests with invalid credentials with a sign-in
+	// page and a 203 status code instead of a 401.
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		resp.StatusCode = http.StatusUnauthorized
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result != nil {
+		return json.Unmarshal(bs, result)
+	}
+
+	return nil
+}
+
+// ErrAlreadyExists is returned by CreatePullRequest when an active pull request
+// for the same source and target refs already exists.
+type ErrAlreadyExists struct {
+	Existing *PullRequest
+}
+
+func (e ErrAlreadyExists) Error() string {
+	return fmt.Sprintf("Azure DevOps pull request %d already exists", e.Existing.ID)
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is an Azure DevOps API HTTP 404 error.
+func IsNotFound(err error) bool {
+	e, ok := errors.Cause(err).(*httpError)
+	return ok && e.NotFound()
+}
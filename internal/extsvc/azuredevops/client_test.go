@@ -0,0 +1,170 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) (*Client, func()) {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, token, _ := r.BasicAuth(); token != "secret" {
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			return
+		}
+		if have, want := r.URL.Query().Get("api-version"), apiVersion; have != want {
+			t.Errorf("api-version: have %q, want %q", have, want)
+		}
+		h(w, r)
+	}))
+
+	u, _ := url.Parse(srv.URL + "/myorg/")
+	cli := NewClient(u, nil)
+	cli.Token = "secret"
+
+	return cli, srv.Close
+}
+
+func TestClient_ListProjects(t *testing.T) {
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/myorg/_apis/projects" {
+			http.NotFound(w, r)
+			return
+		}
+		if have, want := r.URL.Query().Get("$skip"), "100"; have != want {
+			t.Errorf("$skip: have %q, want %q", have, want)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"count": 1,
+			"value": []*Project{{ID: "p1", Name: "Fabrikam", Visibility: ProjectVisibilityPrivate}},
+		})
+	})
+	defer done()
+
+	projects, err := cli.ListProjects(context.Background(), 100, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Project{{ID: "p1", Name: "Fabrikam", Visibility: ProjectVisibilityPrivate}}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("projects: %s", cmp.Diff(projects, want))
+	}
+
+	cli.Token = "wrong"
+	if _, err = cli.ListProjects(context.Background(), 0, 100); err == nil {
+		t.Fatal("expected error with invalid token")
+	} else if e, ok := errors.Cause(err).(interface{ Unauthorized() bool }); !ok || !e.Unauthorized() {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	repo := &Repository{ID: "r1", Name: "fiber", Project: Project{ID: "p1", Name: "Fabrikam"}}
+	existing := &PullRequest{ID: 7, Status: PullRequestStatusActive, SourceRefName: "refs/heads/fix", TargetRefName: "refs/heads/master"}
+
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/myorg/p1/_apis/git/repositories/r1/pullrequests" {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.Method {
+		case "POST":
+			var in CreatePullRequestInput
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Fatal(err)
+			}
+			if in.SourceRefName == existing.SourceRefName {
+				http.Error(w, `{"message":"TF401179: An active pull request for the source and target branch already exists."}`, http.StatusConflict)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&PullRequest{ID: 8, Status: PullRequestStatusActive, Title: in.Title})
+		case "GET":
+			if have, want := r.URL.Query().Get("searchCriteria.sourceRefName"), existing.SourceRefName; have != want {
+				t.Errorf("sourceRefName: have %q, want %q", have, want)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": []*PullRequest{existing}})
+		}
+	})
+	defer done()
+
+	pr, err := cli.CreatePullRequest(context.Background(), repo, &CreatePullRequestInput{
+		SourceRefName: "refs/heads/other",
+		TargetRefName: "refs/heads/master",
+		Title:         "Other",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.ID != 8 || pr.Title != "Other" {
+		t.Errorf("unexpected pull request: %+v", pr)
+	}
+
+	_, err = cli.CreatePullRequest(context.Background(), repo, &CreatePullRequestInput{
+		SourceRefName: "refs/heads/fix",
+		TargetRefName: "refs/heads/master",
+	})
+	ae, ok := err.(*ErrAlreadyExists)
+	if !ok {
+		t.Fatalf("expected ErrAlreadyExists, got %v", err)
+	}
+	if !reflect.DeepEqual(ae.Existing, existing) {
+		t.Errorf("existing: %s", cmp.Diff(ae.Existing, existing))
+	}
+}
+
+func TestClient_CompletePullRequest(t *testing.T) {
+	repo := Repository{ID: "r1", Name: "fiber", Project: Project{ID: "p1", Name: "Fabrikam"}}
+	pr := &PullRequest{ID: 7, Repository: repo, Status: PullRequestStatusActive, LastMergeSourceCommit: &Commit{CommitID: "deadbeef"}}
+
+	cli, done := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/myorg/p1/_apis/git/repositories/r1/pullrequests/7" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var in UpdatePullRequestInput
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Fatal(err)
+		}
+		want := UpdatePullRequestInput{
+			Status:                PullRequestStatusCompleted,
+			LastMergeSourceCommit: &Commit{CommitID: "deadbeef"},
+			CompletionOptions:     &CompletionOptions{MergeStrategy: "squash"},
+		}
+		if diff := cmp.Diff(want, in); diff != "" {
+			t.Errorf("input: %s", diff)
+		}
+		_ = json.NewEncoder(w).Encode(&PullRequest{ID: 7, Status: PullRequestStatusCompleted})
+	})
+	defer done()
+
+	completed, err := cli.CompletePullRequest(context.Background(), pr, "squash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if completed.Status != PullRequestStatusCompleted {
+		t.Errorf("unexpected pull request: %+v", completed)
+	}
+
+	if _, err := cli.CompletePullRequest(context.Background(), &PullRequest{ID: 8, Repository: repo}, ""); err == nil {
+		t.Error("expected error for pull request without last merge source commit")
+	}
+}
+
+func TestPullRequest_WebURL(t *testing.T) {
+	pr := &PullRequest{ID: 42, Repository: Repository{WebURL: "https://dev.azure.com/myorg/Fabrikam/_git/fiber"}}
+	if have, want := pr.WebURL(), "https://dev.azure.com/myorg/Fabrikam/_git/fiber/pullrequest/42"; have != want {
+		t.Errorf("have %q, want %q", have, want)
+	}
+}
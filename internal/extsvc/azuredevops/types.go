@@ -0,0 +1,112 @@
+package azuredevops
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Project is an Azure DevOps project (TeamProjectReference).
+type Project struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	State       string `json:"state,omitempty"`
+	Visibility  string `json:"visibility,omitempty"`
+}
+
+// Project visibilities as reported by Azure DevOps.
+const (
+	ProjectVisibilityPrivate = "private"
+	ProjectVisibilityPublic  = "public"
+)
+
+// Repository is an Azure DevOps Git repository (GitRepository).
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	RemoteURL     string  `json:"remoteUrl"`
+	SSHURL        string  `json:"sshUrl,omitempty"`
+	WebURL        string  `json:"webUrl"`
+	IsFork        bool    `json:"isFork,omitempty"`
+	IsDisabled    bool    `json:"isDisabled,omitempty"`
+}
+
+// NameWithProject returns the "project/repo" name of the repository.
+func (r *Repository) NameWithProject() string {
+	return r.Project.Name + "/" + r.Name
+}
+
+// Pull request statuses as reported by Azure DevOps.
+const (
+	PullRequestStatusActive    = "active"
+	PullRequestStatusAbandoned = "abandoned"
+	PullRequestStatusCompleted = "completed"
+)
+
+// PullRequest is an Azure DevOps pull request (GitPullRequest).
+type PullRequest struct {
+	ID                    int         `json:"pullRequestId"`
+	Repository            Repository  `json:"repository"`
+	Status                string      `json:"status"`
+	CreatedBy             Identity    `json:"createdBy"`
+	CreationDate          time.Time   `json:"creationDate"`
+	ClosedDate            time.Time   `json:"closedDate,omitempty"`
+	Title                 string      `json:"title"`
+	Description           string      `json:"description,omitempty"`
+	SourceRefName         string      `json:"sourceRefName"`
+	TargetRefName         string      `json:"targetRefName"`
+	MergeStatus           string      `json:"mergeStatus,omitempty"`
+	IsDraft               bool        `json:"isDraft,omitempty"`
+	Reviewers             []*Reviewer `json:"reviewers,omitempty"`
+	LastMergeSourceCommit *Commit     `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *Commit     `json:"lastMergeTargetCommit,omitempty"`
+}
+
+// WebURL returns the URL of the pull request in the Azure DevOps web UI.
+func (pr *PullRequest) WebURL() string {
+	if pr.Repository.WebURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(pr.Repository.WebURL, "/") + "/pullrequest/" + strconv.Itoa(pr.ID)
+}
+
+// UpdatedAt returns the most recent time at which the pull request is known to
+// have changed, since Azure DevOps doesn't report a last updated time.
+func (pr *PullRequest) UpdatedAt() time.Time {
+	if pr.ClosedDate.After(pr.CreationDate) {
+		return pr.ClosedDate
+	}
+	return pr.CreationDate
+}
+
+// Identity is an Azure DevOps user (IdentityRef).
+type Identity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+}
+
+// Reviewer votes as reported by Azure DevOps.
+const (
+	VoteApproved                = 10
+	VoteApprovedWithSuggestions = 5
+	VoteNone                    = 0
+	VoteWaitingForAuthor        = -5
+	VoteRejected                = -10
+)
+
+// Reviewer is a reviewer of a pull request and their vote
+// (IdentityRefWithVote).
+type Reviewer struct {
+	Identity
+	Vote       int  `json:"vote"`
+	IsRequired bool `json:"isRequired,omitempty"`
+}
+
+// Commit is a reference to a Git commit (GitCommitRef).
+type Commit struct {
+	CommitID string `json:"commitId"`
+}
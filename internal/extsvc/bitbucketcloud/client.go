@@ -233,6 +233,14 @@ type Repo struct {
 	Parent      *Repo  `json:"parent"`
 	IsPrivate   bool   `json:"is_private"`
 	Links       Links  `json:"links"`
+	Language    string `json:"language,omitempty"`
+	MainBranch  *Ref   `json:"mainbranch,omitempty"`
+}
+
+// Ref is a reference to a branch or tag of a repository.
+type Ref struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
 }
 
 type Links struct {
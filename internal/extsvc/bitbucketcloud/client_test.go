@@ -36,6 +36,7 @@ func TestClient_Repos(t *testing.T) {
 				},
 				HTML: Link{"https://bitbucket.org/sglocal/mux"},
 			},
+			MainBranch: &Ref{Type: "branch", Name: "master"},
 		},
 		"python-langserver": {
 			Slug:      "python-langserver",
@@ -51,6 +52,7 @@ func TestClient_Repos(t *testing.T) {
 				},
 				HTML: Link{"https://bitbucket.org/sglocal/python-langserver"},
 			},
+			MainBranch: &Ref{Type: "branch", Name: "master"},
 		},
 	}
 
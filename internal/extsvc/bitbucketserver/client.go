@@ -428,6 +428,22 @@ func (c *Client) LoadPullRequest(ctx context.Context, pr *PullRequest) error {
 	return c.send(ctx, "GET", path, nil, nil, pr)
 }
 
+// PullRequests returns a page of the pull requests whose target is the
+// repository with the given project key and slug, filtered by the given query
+// strings, e.g. "state=ALL&filterText=security".
+func (c *Client) PullRequests(ctx context.Context, projectKey, repoSlug string, pageToken *PageToken, searchQueries ...string) ([]*PullRequest, *PageToken, error) {
+	qry, err := parseQueryStrings(searchQueries...)
+	if err != nil {
+		return nil, pageToken, err
+	}
+
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests", projectKey, repoSlug)
+
+	var prs []*PullRequest
+	next, err := c.page(ctx, path, qry, pageToken, &prs)
+	return prs, next, err
+}
+
 type UpdatePullRequestInput struct {
 	PullRequestID string `json:"-"`
 	Version       int    `json:"version"`
@@ -550,6 +566,100 @@ func (c *Client) DeclinePullRequest(ctx context.Context, pr *PullRequest) error
 	return c.send(ctx, "POST", path, qry, nil, pr)
 }
 
+// MergePullRequest merges the given PullRequest with the merge strategy with
+// the given ID (e.g. "no-ff", "squash" or "rebase-ff-only"), updating it. If
+// strategyID is empty, the default strategy of the repository is used.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, strategyID string) error {
+	if pr.ToRef.Repository.Slug == "" {
+		return errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return errors.New("project key empty")
+	}
+
+	path := fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/merge",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	)
+
+	qry := url.Values{"version": {strconv.Itoa(pr.Version)}}
+
+	var payload interface{}
+	if strategyID != "" {
+		payload = struct {
+			StrategyID string `json:"strategyId"`
+		}{StrategyID: strategyID}
+	}
+
+	return c.send(ctx, "POST", path, qry, payload, pr)
+}
+
+// ReopenPullRequest reopens the given declined PullRequest, updating it.
+func (c *Client) ReopenPullRequest(ctx context.Context, pr *PullRequest) error {
+	path, err := pullRequestPath(pr)
+	if err != nil {
+		return err
+	}
+
+	qry := url.Values{"version": {strconv.Itoa(pr.Version)}}
+
+	return c.send(ctx, "POST", path+"/reopen", qry, nil, pr)
+}
+
+// CreatePullRequestComment adds a general comment with the given text to the
+// given PullRequest.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, text string) error {
+	path, err := pullRequestPath(pr)
+	if err != nil {
+		return err
+	}
+
+	payload := struct {
+		Text string `json:"text"`
+	}{Text: text}
+
+	return c.send(ctx, "POST", path+"/comments", nil, payload, nil)
+}
+
+// AddPullRequestReviewer adds the user with the given name as a reviewer of
+// the given PullRequest.
+func (c *Client) AddPullRequestReviewer(ctx context.Context, pr *PullRequest, username string) error {
+	path, err := pullRequestPath(pr)
+	if err != nil {
+		return err
+	}
+
+	payload := struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+		Role string `json:"role"`
+	}{Role: "REVIEWER"}
+	payload.User.Name = username
+
+	return c.send(ctx, "POST", path+"/participants", nil, payload, nil)
+}
+
+func pullRequestPath(pr *PullRequest) (string, error) {
+	if pr.ToRef.Repository.Slug == "" {
+		return "", errors.New("repository slug empty")
+	}
+
+	if pr.ToRef.Repository.Project.Key == "" {
+		return "", errors.New("project key empty")
+	}
+
+	return fmt.Sprintf(
+		"rest/api/1.0/projects/%s/repos/%s/pull-requests/%d",
+		pr.ToRef.Repository.Project.Key,
+		pr.ToRef.Repository.Slug,
+		pr.ID,
+	), nil
+}
+
 // LoadPullRequestActivities loads the given PullRequest's timeline of activities,
 // returning an error in case of failure.
 func (c *Client) LoadPullRequestActivities(ctx context.Context, pr *PullRequest) (err error) {
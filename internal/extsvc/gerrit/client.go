@@ -0,0 +1,251 @@
+package gerrit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"golang.org/x/time/rate"
+)
+
+var requestCounter = metrics.NewRequestMeter("gerrit_requests_count", "Total number of requests sent to the Gerrit API.")
+
+// These fields define the self-imposed Gerrit rate limit (since Gerrit does not
+// report rate limits in HTTP response headers).
+//
+// See https://godoc.org/golang.org/x/time/rate#Limiter for an explanation of these fields.
+const (
+	rateLimitRequestsPerSecond = 8 // 480/min or 28800/hr
+	RateLimitMaxBurstRequests  = 500
+)
+
+// xssiPrefix is prepended by Gerrit to every JSON response body to prevent
+// cross-site script inclusion. It must be stripped before decoding.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api.html#output
+var xssiPrefix = []byte(")]}'")
+
+// Client access a Gerrit instance via its REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of the Gerrit instance.
+	URL *url.URL
+
+	// The username and HTTP password credentials for accessing the instance.
+	// If both are empty, requests are made anonymously.
+	Username, Password string
+
+	// RateLimit is the self-imposed rate limiter (since Gerrit does not have a concept
+	// of rate limiting in HTTP response headers).
+	RateLimit *rate.Limiter
+}
+
+// NewClient creates a new Gerrit API client with the given baseURL. If a nil httpClient
+// is provided, http.DefaultClient will be used.
+func NewClient(baseURL *url.URL, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The last REST endpoint component before any identifier maps to the
+		// type of API request we are making, e.g. "projects" or "changes".
+		path := strings.TrimPrefix(u.Path, baseURL.Path)
+		path = strings.TrimPrefix(strings.TrimPrefix(path, "/"), "a/")
+		if i := strings.Index(path, "/"); i >= 0 {
+			path = path[:i]
+		}
+		return path
+	})
+
+	// Normally our registry will return a default infinite limiter when nothing has been
+	// synced from config. However, we always want to ensure there is at least some form of rate
+	// limiting for Gerrit.
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, RateLimitMaxBurstRequests)
+	l := ratelimit.DefaultRegistry.GetOrSet(baseURL.String(), defaultLimiter)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        baseURL,
+		RateLimit:  l,
+	}
+}
+
+// Authenticated returns true if the client has credentials configured.
+func (c *Client) Authenticated() bool {
+	return c.Username != "" || c.Password != ""
+}
+
+// ListProjects returns the projects matching the given Gerrit project query,
+// skipping the first skip results and returning at most limit projects. The
+// returned boolean is true if there are more projects matching the query.
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#query-projects
+func (c *Client) ListProjects(ctx context.Context, query string, skip, limit int) (projects []*Project, more bool, err error) {
+	qry := url.Values{}
+	qry.Set("query", query)
+	if skip > 0 {
+		qry.Set("S", strconv.Itoa(skip))
+	}
+	if limit > 0 {
+		qry.Set("n", strconv.Itoa(limit))
+	}
+
+	req, err := http.NewRequest("GET", "projects/?"+qry.Encode(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err = c.do(ctx, req, &projects); err != nil {
+		return nil, false, err
+	}
+
+	if n := len(projects); n > 0 {
+		more = projects[n-1].MoreProjects
+	}
+
+	return projects, more, nil
+}
+
+// GetProject returns the project with the given name, such as "platform/build".
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#get-project
+func (c *Client) GetProject(ctx context.Context, name string) (*Project, error) {
+	req, err := http.NewRequest("GET", "projects/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Project
+	if err = c.do(ctx, req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	// Authenticated requests must be made against the "/a/" prefixed
+	// endpoints. See https://gerrit-review.googlesource.com/Documentation/rest-api.html#authentication
+	if c.Authenticated() {
+		req.URL.Path = "a/" + req.URL.Path
+		if req.URL.RawPath != "" {
+			req.URL.RawPath = "a/" + req.URL.RawPath
+		}
+	}
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Gerrit"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	if c.Authenticated() {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+
+	startWait := time.Now()
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Gerrit self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result != nil {
+		return json.Unmarshal(bytes.TrimPrefix(bs, xssiPrefix), result)
+	}
+
+	return nil
+}
+
+// Project is a Gerrit project as returned by the REST API (ProjectInfo).
+//
+// See https://gerrit-review.googlesource.com/Documentation/rest-api-projects.html#project-info
+type Project struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Parent      string    `json:"parent,omitempty"`
+	Description string    `json:"description,omitempty"`
+	State       string    `json:"state,omitempty"`
+	WebLinks    []WebLink `json:"web_links,omitempty"`
+
+	// MoreProjects is only set on the last project of a paginated response
+	// if there are more results.
+	MoreProjects bool `json:"_more_projects,omitempty"`
+}
+
+// WebLink is a link to an external site for a Gerrit entity (WebLinkInfo).
+type WebLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Project states as reported by Gerrit.
+const (
+	ProjectStateActive   = "ACTIVE"
+	ProjectStateReadOnly = "READ_ONLY"
+	ProjectStateHidden   = "HIDDEN"
+)
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gerrit API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsNotFound reports whether err is a Gerrit API HTTP 404 error.
+func IsNotFound(err error) bool {
+	e, ok := errors.Cause(err).(*httpError)
+	return ok && e.NotFound()
+}
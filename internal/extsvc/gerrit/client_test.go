@@ -0,0 +1,136 @@
+package gerrit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_ListProjects(t *testing.T) {
+	all := []*Project{
+		{ID: "All-Projects", Name: "All-Projects", State: ProjectStateActive},
+		{ID: "platform%2Fbuild", Name: "platform/build", Parent: "All-Projects", State: ProjectStateActive},
+		{ID: "sandbox", Name: "sandbox", Parent: "All-Projects", State: ProjectStateActive},
+	}
+
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+
+		q := r.URL.Query()
+		skip, _ := strconv.Atoi(q.Get("S"))
+		limit, _ := strconv.Atoi(q.Get("n"))
+
+		page := all[skip:]
+		more := false
+		if limit > 0 && len(page) > limit {
+			page, more = page[:limit], true
+		}
+
+		res := make([]Project, len(page))
+		for i, p := range page {
+			res[i] = *p
+		}
+		if more {
+			res[len(res)-1].MoreProjects = true
+		}
+
+		fmt.Fprint(w, ")]}'\n")
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		name     string
+		username string
+		skip     int
+		limit    int
+		path     string
+		projects []*Project
+		more     bool
+	}{
+		{
+			name:     "anonymous",
+			limit:    2,
+			path:     "/projects/",
+			projects: all[:2],
+			more:     true,
+		},
+		{
+			name:     "authenticated",
+			username: "admin",
+			skip:     2,
+			limit:    2,
+			path:     "/a/projects/",
+			projects: all[2:],
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requests = requests[:0]
+
+			u, _ := url.Parse(srv.URL + "/")
+			cli := NewClient(u, nil)
+			cli.Username = tc.username
+
+			projects, more, err := cli.ListProjects(context.Background(), "state:active", tc.skip, tc.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if have, want := requests[0].URL.Path, tc.path; have != want {
+				t.Errorf("path: have %q, want %q", have, want)
+			}
+			if have, want := requests[0].URL.Query().Get("query"), "state:active"; have != want {
+				t.Errorf("query: have %q, want %q", have, want)
+			}
+
+			for _, p := range projects {
+				p.MoreProjects = false
+			}
+			if !reflect.DeepEqual(projects, tc.projects) {
+				t.Errorf("projects: %s", cmp.Diff(projects, tc.projects))
+			}
+			if more != tc.more {
+				t.Errorf("more: have %v, want %v", more, tc.more)
+			}
+		})
+	}
+}
+
+func TestClient_GetProject(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/a/projects/platform%2Fbuild" {
+			http.Error(w, "Not found: "+r.URL.EscapedPath(), http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, ")]}'\n"+`{"id":"platform%2Fbuild","name":"platform/build","state":"ACTIVE"}`)
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL + "/")
+	cli := NewClient(u, nil)
+	cli.Username, cli.Password = "admin", "secret"
+
+	p, err := cli.GetProject(context.Background(), "platform/build")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Project{ID: "platform%2Fbuild", Name: "platform/build", State: ProjectStateActive}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("project: %s", cmp.Diff(p, want))
+	}
+
+	_, err = cli.GetProject(context.Background(), "does/not/exist")
+	if !IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
@@ -152,6 +153,10 @@ func TestClient_ListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr-repos/private-org-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 20, 40, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "BSD-2-Clause",
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzQwNzM=",
 					DatabaseID:       263034073,
@@ -159,18 +164,30 @@ func TestClient_ListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 20, 14, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "GPL-3.0",
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM5NDk=",
 					DatabaseID:       263033949,
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 19, 47, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "Apache-2.0",
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM3NjE=",
 					DatabaseID:       263033761,
 					NameWithOwner:    "sourcegraph-vcr-repos/public-org-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr-repos/public-org-repo-1",
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 18, 51, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "MIT",
 				},
 			},
 		},
@@ -184,12 +201,20 @@ func TestClient_ListAffiliatedRepositories(t *testing.T) {
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 19, 47, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "Apache-2.0",
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM3NjE=",
 					DatabaseID:       263033761,
 					NameWithOwner:    "sourcegraph-vcr-repos/public-org-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr-repos/public-org-repo-1",
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 18, 51, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "MIT",
 				},
 			},
 		},
@@ -204,6 +229,10 @@ func TestClient_ListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr-repos/private-org-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 20, 40, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "BSD-2-Clause",
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzQwNzM=",
 					DatabaseID:       263034073,
@@ -211,6 +240,10 @@ func TestClient_ListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					Topics:           []string{},
+					PushedAt:         time.Date(2020, 5, 11, 12, 20, 14, 0, time.UTC),
+					DefaultBranch:    "master",
+					License:          "GPL-3.0",
 				},
 			},
 		},
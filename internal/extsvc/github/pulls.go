@@ -464,6 +464,8 @@ type CreatePullRequestInput struct {
 	Title string `json:"title"`
 	// The body of the pull request (optional).
 	Body string `json:"body"`
+	// Whether the pull request is created as a draft (optional).
+	Draft bool `json:"draft,omitempty"`
 }
 
 // CreatePullRequest creates a PullRequest on Github.
@@ -595,6 +597,234 @@ func (c *Client) ClosePullRequest(ctx context.Context, pr *PullRequest) error {
 	return nil
 }
 
+// MergePullRequest merges the PullRequest on GitHub with the given merge
+// method, which is one of "MERGE", "SQUASH" or "REBASE", and updates it. The
+// merge fails if the head of the pull request is no longer its HeadRefOid,
+// so that commits that were pushed after it was loaded are not merged.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, method string) error {
+	var q strings.Builder
+	q.WriteString(pullRequestFragments)
+	q.WriteString(`mutation	MergePullRequest($input:MergePullRequestInput!) {
+  mergePullRequest(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MergePullRequest struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"mergePullRequest"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID              string `json:"pullRequestId"`
+		MergeMethod     string `json:"mergeMethod,omitempty"`
+		ExpectedHeadOid string `json:"expectedHeadOid,omitempty"`
+	}{ID: pr.ID, MergeMethod: method, ExpectedHeadOid: pr.HeadRefOid}}
+	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.MergePullRequest.PullRequest.PullRequest
+	pr.TimelineItems = result.MergePullRequest.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.MergePullRequest.PullRequest.Participants.Nodes
+
+	return nil
+}
+
+// MarkPullRequestReadyForReview marks the draft PullRequest on GitHub as
+// ready for review and updates it.
+func (c *Client) MarkPullRequestReadyForReview(ctx context.Context, pr *PullRequest) error {
+	var q strings.Builder
+	q.WriteString(pullRequestFragments)
+	q.WriteString(`mutation	MarkPullRequestReadyForReview($input:MarkPullRequestReadyForReviewInput!) {
+  markPullRequestReadyForReview(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		MarkPullRequestReadyForReview struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"markPullRequestReadyForReview"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID string `json:"pullRequestId"`
+	}{ID: pr.ID}}
+	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.MarkPullRequestReadyForReview.PullRequest.PullRequest
+	pr.TimelineItems = result.MarkPullRequestReadyForReview.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.MarkPullRequestReadyForReview.PullRequest.Participants.Nodes
+
+	return nil
+}
+
+// ReopenPullRequest reopens the closed PullRequest on GitHub and updates it.
+func (c *Client) ReopenPullRequest(ctx context.Context, pr *PullRequest) error {
+	var q strings.Builder
+	q.WriteString(pullRequestFragments)
+	q.WriteString(`mutation	ReopenPullRequest($input:ReopenPullRequestInput!) {
+  reopenPullRequest(input:$input) {
+    pullRequest {
+      ... pr
+    }
+  }
+}`)
+
+	var result struct {
+		ReopenPullRequest struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems struct{ Nodes []TimelineItem }
+			} `json:"pullRequest"`
+		} `json:"reopenPullRequest"`
+	}
+
+	input := map[string]interface{}{"input": struct {
+		ID string `json:"pullRequestId"`
+	}{ID: pr.ID}}
+	err := c.requestGraphQL(ctx, q.String(), input, &result)
+	if err != nil {
+		return err
+	}
+
+	*pr = result.ReopenPullRequest.PullRequest.PullRequest
+	pr.TimelineItems = result.ReopenPullRequest.PullRequest.TimelineItems.Nodes
+	pr.Participants = result.ReopenPullRequest.PullRequest.Participants.Nodes
+
+	return nil
+}
+
+// CreatePullRequestComment adds a comment with the given body to the
+// PullRequest on GitHub.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, body string) error {
+	q := `mutation	AddComment($input:AddCommentInput!) {
+  addComment(input:$input) {
+    subject { id }
+  }
+}`
+
+	input := map[string]interface{}{"input": struct {
+		SubjectID string `json:"subjectId"`
+		Body      string `json:"body"`
+	}{SubjectID: pr.ID, Body: body}}
+
+	var result struct{}
+	return c.requestGraphQL(ctx, q, input, &result)
+}
+
+// AddLabelsToPullRequest adds the labels with the given names to the
+// PullRequest on GitHub. The labels have to exist in the repository of the
+// PullRequest.
+func (c *Client) AddLabelsToPullRequest(ctx context.Context, pr *PullRequest, names []string) error {
+	q := `query PullRequestRepositoryLabels($id: ID!) {
+  node(id: $id) {
+    ... on PullRequest {
+      repository {
+        labels(first: 100) {
+          nodes { id, name }
+        }
+      }
+    }
+  }
+}`
+
+	var labels struct {
+		Node struct {
+			Repository struct {
+				Labels struct {
+					Nodes []struct{ ID, Name string }
+				}
+			}
+		}
+	}
+	if err := c.requestGraphQL(ctx, q, map[string]interface{}{"id": pr.ID}, &labels); err != nil {
+		return err
+	}
+
+	idsByName := make(map[string]string, len(labels.Node.Repository.Labels.Nodes))
+	for _, l := range labels.Node.Repository.Labels.Nodes {
+		idsByName[strings.ToLower(l.Name)] = l.ID
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		id, ok := idsByName[strings.ToLower(name)]
+		if !ok {
+			return errors.Errorf("label %q not found in repository", name)
+		}
+		ids = append(ids, id)
+	}
+
+	q = `mutation	AddLabelsToLabelable($input:AddLabelsToLabelableInput!) {
+  addLabelsToLabelable(input:$input) {
+    clientMutationId
+  }
+}`
+
+	input := map[string]interface{}{"input": struct {
+		LabelableID string   `json:"labelableId"`
+		LabelIDs    []string `json:"labelIds"`
+	}{LabelableID: pr.ID, LabelIDs: ids}}
+
+	var result struct{}
+	return c.requestGraphQL(ctx, q, input, &result)
+}
+
+// RequestPullRequestReviews requests reviews of the PullRequest on GitHub
+// from the users with the given logins, in addition to the already requested
+// reviewers.
+func (c *Client) RequestPullRequestReviews(ctx context.Context, pr *PullRequest, logins []string) error {
+	ids := make([]string, 0, len(logins))
+	for _, login := range logins {
+		var user struct {
+			User *struct{ ID string }
+		}
+		q := `query User($login: String!) { user(login: $login) { id } }`
+		if err := c.requestGraphQL(ctx, q, map[string]interface{}{"login": login}, &user); err != nil {
+			return err
+		}
+		if user.User == nil {
+			return errors.Errorf("user %q not found", login)
+		}
+		ids = append(ids, user.User.ID)
+	}
+
+	q := `mutation	RequestReviews($input:RequestReviewsInput!) {
+  requestReviews(input:$input) {
+    clientMutationId
+  }
+}`
+
+	input := map[string]interface{}{"input": struct {
+		PullRequestID string   `json:"pullRequestId"`
+		UserIDs       []string `json:"userIds"`
+		Union         bool     `json:"union"`
+	}{PullRequestID: pr.ID, UserIDs: ids, Union: true}}
+
+	var result struct{}
+	return c.requestGraphQL(ctx, q, input, &result)
+}
+
 // LoadPullRequests loads a list of PullRequests from Github.
 func (c *Client) LoadPullRequests(ctx context.Context, prs ...*PullRequest) error {
 	const batchSize = 15
@@ -681,6 +911,73 @@ func (c *Client) loadPullRequests(ctx context.Context, prs ...*PullRequest) erro
 	return nil
 }
 
+// A PullRequestSearchResult is a pull request found by SearchPullRequests.
+type PullRequestSearchResult struct {
+	Number     int64
+	Repository struct {
+		ID            string
+		NameWithOwner string
+	}
+}
+
+// SearchPullRequests returns the pull requests matching the given GitHub
+// search query, e.g. "org:sourcegraph label:security-fix". The query is
+// restricted to pull requests. GitHub's search returns at most 1000 results.
+func (c *Client) SearchPullRequests(ctx context.Context, query string) ([]*PullRequestSearchResult, error) {
+	q := `
+query($query: String!, $after: String) {
+  search(query: $query, type: ISSUE, first: 100, after: $after) {
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      ... on PullRequest {
+        number
+        repository {
+          id
+          nameWithOwner
+        }
+      }
+    }
+  }
+}`
+
+	var (
+		results []*PullRequestSearchResult
+		after   *string
+	)
+	for {
+		var page struct {
+			Search struct {
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+				Nodes []*PullRequestSearchResult
+			}
+		}
+
+		vars := map[string]interface{}{"query": "is:pr " + query, "after": after}
+		if err := c.requestGraphQL(ctx, q, vars, &page); err != nil {
+			return nil, err
+		}
+
+		for _, n := range page.Search.Nodes {
+			// Issues matching the query are returned as empty nodes.
+			if n != nil && n.Number != 0 {
+				results = append(results, n)
+			}
+		}
+
+		if !page.Search.PageInfo.HasNextPage {
+			return results, nil
+		}
+		cursor := page.Search.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
 // GetOpenPullRequestByRefs fetches the the pull request associated with the supplied
 // refs. GitHub only allows one open PR by ref at a time.
 // If nothing is found an error is returned.
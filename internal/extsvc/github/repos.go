@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
@@ -35,6 +36,13 @@ type Repository struct {
 	IsFork           bool   // whether the repository is a fork of another repository
 	IsArchived       bool   // whether the repository is archived on the code host
 	ViewerPermission string // ADMIN, WRITE, READ, or empty if unknown. Only the graphql api populates this. https://developer.github.com/v4/enum/repositorypermission/
+
+	Topics         []string  // topics of the repository
+	Language       string    // primary language of the repository
+	StargazerCount int       // number of stars of the repository
+	PushedAt       time.Time // time of the last push to the repository
+	DefaultBranch  string    // name of the default branch of the repository
+	License        string    // SPDX identifier of the repository license, or empty if unknown
 }
 
 // repositoryFieldsGraphQLFragment returns a GraphQL fragment that contains the fields needed to populate the
@@ -52,6 +60,12 @@ fragment RepositoryFields on Repository {
 	isFork
 	isArchived
 	viewerPermission
+	repositoryTopics(first: 100) { nodes { topic { name } } }
+	primaryLanguage { name }
+	stargazers { totalCount }
+	pushedAt
+	defaultBranchRef { name }
+	licenseInfo { spdxId }
 }
 	`
 	}
@@ -68,10 +82,67 @@ fragment RepositoryFields on Repository {
 	isPrivate
 	isFork
 	isArchived
+	repositoryTopics(first: 100) { nodes { topic { name } } }
+	primaryLanguage { name }
+	stargazers { totalCount }
+	pushedAt
+	defaultBranchRef { name }
+	licenseInfo { spdxId }
 }
 	`
 }
 
+// graphqlRepository is a repository as returned by the GraphQL API, using the
+// fields of repositoryFieldsGraphQLFragment.
+type graphqlRepository struct {
+	Repository
+
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string
+			}
+		}
+	}
+	PrimaryLanguage *struct {
+		Name string
+	}
+	Stargazers struct {
+		TotalCount int
+	}
+	DefaultBranchRef *struct {
+		Name string
+	}
+	LicenseInfo *struct {
+		SPDXID string `json:"spdxId"`
+	}
+}
+
+// convertGraphQLRepo converts repository information returned by the GraphQL
+// API into a Repository.
+func convertGraphQLRepo(r *graphqlRepository) *Repository {
+	if r == nil {
+		return nil
+	}
+	repo := r.Repository
+	for _, n := range r.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, n.Topic.Name)
+	}
+	if r.PrimaryLanguage != nil {
+		repo.Language = r.PrimaryLanguage.Name
+	}
+	repo.StargazerCount = r.Stargazers.TotalCount
+	if r.DefaultBranchRef != nil {
+		repo.DefaultBranch = r.DefaultBranchRef.Name
+	}
+	// Like the REST API, GitHub reports "NOASSERTION" for licenses it can't
+	// identify.
+	if r.LicenseInfo != nil && r.LicenseInfo.SPDXID != "NOASSERTION" {
+		repo.License = r.LicenseInfo.SPDXID
+	}
+	return &repo
+}
+
 func ownerNameCacheKey(owner, name string) string       { return "0:" + owner + "/" + name }
 func nameWithOwnerCacheKey(nameWithOwner string) string { return "0:" + nameWithOwner }
 func nodeIDCacheKey(id string) string                   { return "1:" + id }
@@ -228,6 +299,18 @@ type restRepository struct {
 	Fork        bool
 	Archived    bool
 	Permissions restRepositoryPermissions `json:"permissions"`
+	UpdatedAt   time.Time                 `json:"updated_at"`
+
+	Topics          []string     `json:"topics"`
+	Language        string       `json:"language"`
+	StargazersCount int          `json:"stargazers_count"`
+	PushedAt        time.Time    `json:"pushed_at"`
+	DefaultBranch   string       `json:"default_branch"`
+	License         *restLicense `json:"license"`
+}
+
+type restLicense struct {
+	SPDXID string `json:"spdx_id"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -259,9 +342,24 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		IsFork:           restRepo.Fork,
 		IsArchived:       restRepo.Archived,
 		ViewerPermission: convertRestRepoPermissions(restRepo.Permissions),
+		Topics:           restRepo.Topics,
+		Language:         restRepo.Language,
+		StargazerCount:   restRepo.StargazersCount,
+		PushedAt:         restRepo.PushedAt,
+		DefaultBranch:    restRepo.DefaultBranch,
+		License:          convertRestLicense(restRepo.License),
 	}
 }
 
+// convertRestLicense returns the SPDX identifier of the license returned by
+// the rest API. GitHub reports "NOASSERTION" for licenses it can't identify.
+func convertRestLicense(l *restLicense) string {
+	if l == nil || l.SPDXID == "NOASSERTION" {
+		return ""
+	}
+	return l.SPDXID
+}
+
 // convertRestRepoPermissions converts repo information returned by the rest API
 // to a standard format.
 func convertRestRepoPermissions(restRepoPermissions restRepositoryPermissions) string {
@@ -294,7 +392,7 @@ func (c *Client) getPublicRepositories(ctx context.Context, sinceRepoID int64) (
 // API without use of the redis cache.
 func (c *Client) getRepositoryByNodeIDFromAPI(ctx context.Context, id string) (*Repository, error) {
 	var result struct {
-		Node *Repository `json:"node"`
+		Node *graphqlRepository `json:"node"`
 	}
 	if err := c.requestGraphQL(ctx, `
 query Repository($id: ID!) {
@@ -319,7 +417,7 @@ query Repository($id: ID!) {
 	if result.Node == nil {
 		return nil, ErrNotFound
 	}
-	return result.Node, nil
+	return convertGraphQLRepo(result.Node), nil
 }
 
 // MaxNodeIDs is the maximum number of repository nodes that can be queried in one call to the
@@ -333,7 +431,7 @@ var MaxNodeIDs = 100
 // error). This method does not cache.
 func (c *Client) GetRepositoriesByNodeIDFromAPI(ctx context.Context, nodeIDs []string) (map[string]*Repository, error) {
 	var result struct {
-		Nodes []*Repository
+		Nodes []*graphqlRepository
 	}
 	err := c.requestGraphQL(ctx, `
 query Repositories($ids: [ID!]!) {
@@ -360,7 +458,7 @@ query Repositories($ids: [ID!]!) {
 	repos := make(map[string]*Repository)
 	for _, r := range result.Nodes {
 		if r != nil {
-			repos[r.ID] = r
+			repos[r.ID] = convertGraphQLRepo(r)
 		}
 	}
 	return repos, nil
@@ -392,7 +490,7 @@ func (c *Client) GetReposByNameWithOwner(ctx context.Context, namesWithOwners ..
 		return nil, err
 	}
 
-	var result map[string]*Repository
+	var result map[string]*graphqlRepository
 	err = c.requestGraphQL(ctx, query, map[string]interface{}{}, &result)
 	if err != nil {
 		if gqlErrs, ok := err.(graphqlErrors); ok {
@@ -411,7 +509,7 @@ func (c *Client) GetReposByNameWithOwner(ctx context.Context, namesWithOwners ..
 	repos := make([]*Repository, 0, len(result))
 	for _, r := range result {
 		if r != nil {
-			repos = append(repos, r)
+			repos = append(repos, convertGraphQLRepo(r))
 		}
 	}
 	return repos, nil
@@ -472,6 +570,28 @@ func (c *Client) ListAffiliatedRepositories(ctx context.Context, visibility Visi
 	return repos, len(repos) > 0, 1, err
 }
 
+// ListAffiliatedRepositoriesSince is like ListAffiliatedRepositories, but
+// only lists the repositories that were updated after the given time.
+func (c *Client) ListAffiliatedRepositoriesSince(ctx context.Context, visibility Visibility, since time.Time, page int) (
+	repos []*Repository,
+	hasNextPage bool,
+	rateLimitCost int,
+	err error,
+) {
+	path := fmt.Sprintf(
+		"user/repos?sort=updated&visibility=%s&since=%s&page=%d&per_page=100",
+		visibility,
+		url.QueryEscape(since.UTC().Format(time.RFC3339)),
+		page,
+	)
+	repos, err = c.listRepositories(ctx, path)
+	if err == nil {
+		c.addRepositoriesToCache(repos)
+	}
+
+	return repos, len(repos) > 0, 1, err
+}
+
 // ListOrgRepositories lists GitHub repositories from the specified organization.
 // org is the name of the organization. page is the page of results to return.
 // Pages are 1-indexed (so the first call should be for page 1).
@@ -481,6 +601,29 @@ func (c *Client) ListOrgRepositories(ctx context.Context, org string, page int)
 	return repos, len(repos) > 0, 1, err
 }
 
+// ListOrgRepositoriesSince is like ListOrgRepositories, but only lists the
+// repositories of the organization that were updated after the given time.
+// Since the /orgs/:org/repos endpoint has no filter for that, repositories
+// are listed most recently updated first and hasNextPage is false once a
+// repository updated before since is found.
+func (c *Client) ListOrgRepositoriesSince(ctx context.Context, org string, since time.Time, page int) (repos []*Repository, hasNextPage bool, rateLimitCost int, err error) {
+	path := fmt.Sprintf("orgs/%s/repos?sort=updated&direction=desc&page=%d&per_page=100", org, page)
+	var restRepos []restRepository
+	if err := c.requestGet(ctx, path, &restRepos); err != nil {
+		return nil, false, 1, err
+	}
+
+	hasNextPage = len(restRepos) > 0
+	for _, r := range restRepos {
+		if !r.UpdatedAt.After(since) {
+			hasNextPage = false
+			break
+		}
+		repos = append(repos, convertRestRepo(r))
+	}
+	return repos, hasNextPage, 1, nil
+}
+
 // ListUserRepositories lists GitHub repositories from the specified user.
 // Pages are 1-indexed (so the first call should be for page 1)
 func (c *Client) ListUserRepositories(ctx context.Context, user string, page int) (repos []*Repository, hasNextPage bool, rateLimitCost int, err error) {
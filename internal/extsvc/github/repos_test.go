@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
@@ -413,6 +414,49 @@ func TestClient_ListOrgRepositories(t *testing.T) {
 	}
 }
 
+func TestClient_ListOrgRepositoriesSince(t *testing.T) {
+	mock := mockHTTPResponseBody{
+		responseBody: `[
+  {
+    "node_id": "i",
+    "full_name": "o/r",
+    "description": "d",
+    "html_url": "https://github.example.com/o/r",
+    "updated_at": "2020-03-02T00:00:00Z"
+  },
+  {
+    "node_id": "j",
+    "full_name": "o/b",
+    "description": "c",
+    "html_url": "https://github.example.com/o/b",
+    "updated_at": "2020-02-28T00:00:00Z"
+  }
+]
+`}
+
+	c := newTestClient(t, &mock)
+	wantRepos := []*Repository{
+		{
+			ID:            "i",
+			NameWithOwner: "o/r",
+			Description:   "d",
+			URL:           "https://github.example.com/o/r",
+		},
+	}
+
+	since := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	repos, hasNextPage, _, err := c.ListOrgRepositoriesSince(context.Background(), "o", since, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !repoListsAreEqual(repos, wantRepos) {
+		t.Errorf("got repositories:\n%s\nwant:\n%s", stringForRepoList(repos), stringForRepoList(wantRepos))
+	}
+	if hasNextPage {
+		t.Errorf("got hasNextPage: true want: false")
+	}
+}
+
 func stringForRepoList(repos []*Repository) string {
 	repoStrings := []string{}
 	for _, repo := range repos {
@@ -426,7 +470,7 @@ func repoListsAreEqual(a []*Repository, b []*Repository) bool {
 		return false
 	}
 	for i := 0; i < len(a); i++ {
-		if *a[i] != *b[i] {
+		if !reflect.DeepEqual(a[i], b[i]) {
 			return false
 		}
 	}
@@ -651,6 +695,12 @@ func TestClient_GetReposByNameWithOwner(t *testing.T) {
 		IsFork:           false,
 		IsArchived:       true,
 		ViewerPermission: "ADMIN",
+		Topics:           []string{"go", "graphs"},
+		Language:         "Go",
+		StargazerCount:   42,
+		PushedAt:         time.Date(2020, 5, 11, 12, 20, 40, 0, time.UTC),
+		DefaultBranch:    "master",
+		License:          "MIT",
 	}
 
 	clojureGrapherRepo := &Repository{
@@ -686,7 +736,13 @@ func TestClient_GetReposByNameWithOwner(t *testing.T) {
       "isPrivate": true,
       "isFork": false,
       "isArchived": true,
-      "viewerPermission": "ADMIN"
+      "viewerPermission": "ADMIN",
+      "repositoryTopics": { "nodes": [{ "topic": { "name": "go" } }, { "topic": { "name": "graphs" } }] },
+      "primaryLanguage": { "name": "Go" },
+      "stargazers": { "totalCount": 42 },
+      "pushedAt": "2020-05-11T12:20:40Z",
+      "defaultBranchRef": { "name": "master" },
+      "licenseInfo": { "spdxId": "MIT" }
     },
     "repo_sourcegraph_clojure_grapher": {
       "id": "MDEwOlJlcG9zaXRvcnkxNTc1NjkwOA==",
@@ -718,7 +774,13 @@ func TestClient_GetReposByNameWithOwner(t *testing.T) {
       "isPrivate": true,
       "isFork": false,
       "isArchived": true,
-      "viewerPermission": "ADMIN"
+      "viewerPermission": "ADMIN",
+      "repositoryTopics": { "nodes": [{ "topic": { "name": "go" } }, { "topic": { "name": "graphs" } }] },
+      "primaryLanguage": { "name": "Go" },
+      "stargazers": { "totalCount": 42 },
+      "pushedAt": "2020-05-11T12:20:40Z",
+      "defaultBranchRef": { "name": "master" },
+      "licenseInfo": { "spdxId": "MIT" }
     },
     "repo_sourcegraph_clojure_grapher": null
   },
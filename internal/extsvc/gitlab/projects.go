@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/peterhellberg/link"
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,6 +28,10 @@ type Project struct {
 	Visibility        Visibility     `json:"visibility"`                    // "private", "internal", or "public"
 	ForkedFromProject *ProjectCommon `json:"forked_from_project,omitempty"` // If non-nil, the project from which this project was forked
 	Archived          bool           `json:"archived"`
+	TagList           []string       `json:"tag_list,omitempty"`         // the topics of the project
+	StarCount         int            `json:"star_count"`                 // the number of stars of the project
+	DefaultBranch     string         `json:"default_branch,omitempty"`   // the default branch, or empty for empty projects
+	LastActivityAt    time.Time      `json:"last_activity_at,omitempty"` // the time of the latest push, comment or other activity
 }
 
 type ProjectCommon struct {
@@ -0,0 +1,169 @@
+// Package fuzzy implements fzf-style fuzzy matching and scoring of strings
+// such as file paths.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Scores and bonuses of a match, modeled after those of fzf.
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+
+	// bonusSeparator is given to a character matched after a "/" (or at the
+	// start of the string), and bonusBoundary to a character matched after
+	// another non-word character.
+	bonusSeparator = 10
+	bonusBoundary  = 8
+	// bonusCamel is given to an uppercase character matched after a
+	// lowercase one and to a digit matched after a letter.
+	bonusCamel = 7
+	// bonusConsecutive is the minimum bonus of a character matched right
+	// after the previous matched character. Otherwise, such a character gets
+	// the bonus of the first character of the consecutive characters.
+	bonusConsecutive = 4
+	// bonusBasename is given to a character matched in the last path
+	// component.
+	bonusBasename = 2
+	// bonusFirstCharMultiplier multiplies the bonus of the first
+	// character of a term.
+	bonusFirstCharMultiplier = 2
+)
+
+// Match reports whether s matches the pattern. The pattern consists of terms
+// separated by whitespace, all of whose characters must occur in s in order.
+// Matching is case-insensitive unless the pattern contains an uppercase
+// character.
+//
+// It returns the score of the match (higher is better) and the sorted indexes
+// of the runes of s that were matched.
+func Match(pattern, s string) (score int, positions []int, ok bool) {
+	caseSensitive := strings.IndexFunc(pattern, unicode.IsUpper) >= 0
+	text := []rune(s)
+	basename := strings.LastIndexByte(s, '/') + 1
+	basenameStart := len([]rune(s[:basename]))
+
+	matched := map[int]bool{}
+	for _, term := range strings.Fields(pattern) {
+		termScore, termPositions, ok := matchTerm([]rune(term), text, caseSensitive, basenameStart)
+		if !ok {
+			return 0, nil, false
+		}
+		score += termScore
+		for _, p := range termPositions {
+			if !matched[p] {
+				matched[p] = true
+				positions = append(positions, p)
+			}
+		}
+	}
+	sort.Ints(positions)
+	return score, positions, true
+}
+
+// matchTerm matches a single term like fzf's v1 algorithm: it finds the first
+// occurrence of the term's characters in text, then shrinks the match by
+// searching backwards from its end, and scores the resulting match.
+func matchTerm(term, text []rune, caseSensitive bool, basenameStart int) (score int, positions []int, ok bool) {
+	if len(term) == 0 {
+		return 0, nil, true
+	}
+	eq := func(a, b rune) bool {
+		if caseSensitive {
+			return a == b
+		}
+		return unicode.ToLower(a) == unicode.ToLower(b)
+	}
+
+	// Find the end of the first match.
+	end, ti := -1, 0
+	for i, c := range text {
+		if eq(c, term[ti]) {
+			ti++
+			if ti == len(term) {
+				end = i + 1
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Find the latest start of a match that ends there.
+	start, ti := 0, len(term)-1
+	for i := end - 1; i >= 0; i-- {
+		if eq(text[i], term[ti]) {
+			ti--
+			if ti < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	// Score the match.
+	ti = 0
+	prevMatch, chunkBonus := -1, 0
+	for i := start; i < end && ti < len(term); i++ {
+		if !eq(text[i], term[ti]) {
+			continue
+		}
+
+		charScore := scoreMatch
+		bonus := charBonus(text, i)
+		if prevMatch >= 0 && i == prevMatch+1 {
+			bonus = max(bonus, max(chunkBonus, bonusConsecutive))
+		} else {
+			if prevMatch >= 0 {
+				charScore += scoreGapStart + (i-prevMatch-2)*scoreGapExtension
+			}
+			chunkBonus = bonus
+		}
+		if ti == 0 {
+			bonus *= bonusFirstCharMultiplier
+		}
+		if i >= basenameStart {
+			bonus += bonusBasename
+		}
+
+		score += charScore + bonus
+		positions = append(positions, i)
+		prevMatch = i
+		ti++
+	}
+	return score, positions, true
+}
+
+// charBonus returns the bonus for matching text[i], depending on the
+// character before it.
+func charBonus(text []rune, i int) int {
+	if i == 0 {
+		return bonusSeparator
+	}
+	prev, c := text[i-1], text[i]
+	switch {
+	case prev == '/':
+		return bonusSeparator
+	case !isWordChar(prev) && isWordChar(c):
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(c), unicode.IsLetter(prev) && unicode.IsDigit(c):
+		return bonusCamel
+	}
+	return 0
+}
+
+func isWordChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
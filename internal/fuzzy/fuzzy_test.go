@@ -0,0 +1,56 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, s    string
+		wantPositions []int
+		wantOK        bool
+	}{
+		{pattern: "", s: "a/b.go", wantOK: true},
+		{pattern: "abgo", s: "a/b.go", wantPositions: []int{0, 2, 4, 5}, wantOK: true},
+		{pattern: "ABGO", s: "a/b.go", wantOK: false},
+		{pattern: "B.go", s: "a/B.go", wantPositions: []int{2, 3, 4, 5}, wantOK: true},
+		{pattern: "gob", s: "a/b.go", wantOK: false},
+		{pattern: "go cmd", s: "cmd/main.go", wantPositions: []int{0, 1, 2, 9, 10}, wantOK: true},
+		{pattern: "go rs", s: "cmd/main.go", wantOK: false},
+		// The match is shrunk to the latest start before its end.
+		{pattern: "ab", s: "a/xa/b", wantPositions: []int{3, 5}, wantOK: true},
+		{pattern: "é", s: "x/é.go", wantPositions: []int{2}, wantOK: true},
+	} {
+		_, positions, ok := Match(tc.pattern, tc.s)
+		if ok != tc.wantOK {
+			t.Errorf("%q ~ %q: got ok %v, want %v", tc.pattern, tc.s, ok, tc.wantOK)
+			continue
+		}
+		if !reflect.DeepEqual(positions, tc.wantPositions) {
+			t.Errorf("%q ~ %q: got positions %v, want %v", tc.pattern, tc.s, positions, tc.wantPositions)
+		}
+	}
+}
+
+func TestMatch_score(t *testing.T) {
+	// Each pattern matches the paths better in order.
+	for pattern, paths := range map[string][]string{
+		"main":   {"cmd/main.go", "m/a/i/n.go", "cmd/domain.go"},
+		"fb":     {"foo/bar.go", "fab.go", "afxb.go"},
+		"readme": {"README.md", "read/me.md", "src/thread_mem.go"},
+		"git":    {"git.go", "internal/git/blob.go", "cmd/digit.go"},
+	} {
+		prev := 0
+		for i, path := range paths {
+			score, _, ok := Match(pattern, path)
+			if !ok {
+				t.Fatalf("%q does not match %q", pattern, path)
+			}
+			if i > 0 && score >= prev {
+				t.Errorf("%q: got score %d for %q, want less than %d of %q", pattern, score, path, prev, paths[i-1])
+			}
+			prev = score
+		}
+	}
+}
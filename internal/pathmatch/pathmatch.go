@@ -45,6 +45,7 @@ func (m *regexpMatcher) String() string {
 type CompileOptions struct {
 	RegExp        bool // whether the patterns are regular expressions (false means globs)
 	CaseSensitive bool // whether the patterns are case sensitive
+	PathSeparator bool // whether "*" in globs does not match "/" ("**" still does)
 }
 
 // CompilePattern compiles pattern into a PathMatcher func.
@@ -66,7 +67,11 @@ func CompilePattern(pattern string, options CompileOptions) (PathMatcher, error)
 	if !options.CaseSensitive {
 		pattern = strings.ToLower(pattern)
 	}
-	p, err := glob.Compile(pattern)
+	var separators []rune
+	if options.PathSeparator {
+		separators = []rune{'/'}
+	}
+	p, err := glob.Compile(pattern, separators...)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,22 @@ func TestCompilePattern(t *testing.T) {
 				"main.go":   false,
 			},
 		},
+		{
+			pattern: `/docs/*.md`,
+			options: CompileOptions{PathSeparator: true},
+			want: map[string]bool{
+				"/docs/README.md":     true,
+				"/docs/api/README.md": false,
+			},
+		},
+		{
+			pattern: `/docs/**.md`,
+			options: CompileOptions{PathSeparator: true},
+			want: map[string]bool{
+				"/docs/README.md":     true,
+				"/docs/api/README.md": true,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.pattern, func(t *testing.T) {
@@ -0,0 +1,57 @@
+// Package requestclient provides information about the client that made the
+// request being handled.
+package requestclient
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Client describes the client that made a request.
+type Client struct {
+	// IP is the IP address of the immediate peer of the request, which is a
+	// proxy if Sourcegraph is deployed behind one.
+	IP string
+
+	// ForwardedFor is the value of the X-Forwarded-For header of the request.
+	// Clients can set it to arbitrary values, so it can only be trusted if the
+	// proxies in front of Sourcegraph overwrite it.
+	ForwardedFor string
+}
+
+type key int
+
+const clientKey key = iota
+
+// FromContext returns the client of the request in ctx, or nil if there is
+// none.
+func FromContext(ctx context.Context) *Client {
+	c, _ := ctx.Value(clientKey).(*Client)
+	return c
+}
+
+// WithClient returns a copy of ctx with the given client.
+func WithClient(ctx context.Context, c *Client) context.Context {
+	return context.WithValue(ctx, clientKey, c)
+}
+
+// FromRequest returns the client that made the HTTP request.
+func FromRequest(r *http.Request) *Client {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return &Client{
+		IP:           ip,
+		ForwardedFor: strings.TrimSpace(r.Header.Get("X-Forwarded-For")),
+	}
+}
+
+// HTTPMiddleware adds the client of each request to the request context.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithClient(r.Context(), FromRequest(r))))
+	})
+}
@@ -0,0 +1,53 @@
+package requestclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		remoteAddr   string
+		forwardedFor string
+		want         *Client
+	}{
+		"host and port": {
+			remoteAddr: "192.0.2.1:1234",
+			want:       &Client{IP: "192.0.2.1"},
+		},
+		"IPv6 host and port": {
+			remoteAddr: "[2001:db8::1]:1234",
+			want:       &Client{IP: "2001:db8::1"},
+		},
+		"no port": {
+			remoteAddr: "192.0.2.1",
+			want:       &Client{IP: "192.0.2.1"},
+		},
+		"forwarded": {
+			remoteAddr:   "192.0.2.1:1234",
+			forwardedFor: " 198.51.100.1, 192.0.2.2 ",
+			want:         &Client{IP: "192.0.2.1", ForwardedFor: "198.51.100.1, 192.0.2.2"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got *Client
+			h := HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", test.forwardedFor)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ var allFields = map[string]struct{}{
 	FieldType:               empty,
 	FieldPatternType:        empty,
 	FieldContent:            empty,
+	FieldSelect:             empty,
+	FieldOrder:              empty,
 	FieldRepoHasFile:        empty,
 	FieldRepoHasCommitAfter: empty,
 	FieldBefore:             empty,
@@ -32,6 +34,7 @@ var allFields = map[string]struct{}{
 	FieldIndex:              empty,
 	FieldCount:              empty,
 	FieldStable:             empty,
+	FieldMultiline:          empty,
 	FieldMax:                empty,
 	FieldTimeout:            empty,
 	FieldReplace:            empty,
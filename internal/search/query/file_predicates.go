@@ -0,0 +1,60 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// FilePredicate is a file: field value of the form has.<name>(<value>), such
+// as file:has.owner(@team), which matches files by their metadata instead of
+// by their path.
+type FilePredicate struct {
+	Name  string
+	Value string
+}
+
+// All file predicate names.
+const (
+	FilePredicateOwner = "owner"
+)
+
+// filePredicateRx matches the file predicates. As with repo: values, other
+// values of the form has.<name>(<value>) are valid file path patterns (such as
+// file:has.go(x)), so only the known predicate names are matched.
+var filePredicateRx = lazyregexp.New(`^has\.(` + FilePredicateOwner + `)\((.*)\)$`)
+
+// ParseFilePredicate parses the file: field value v as a FilePredicate. It
+// returns nil if v is not a file predicate, in which case v is a file path
+// pattern, and an error if the predicate has no value.
+func ParseFilePredicate(v string) (*FilePredicate, error) {
+	m := filePredicateRx.FindStringSubmatch(v)
+	if m == nil {
+		return nil, nil
+	}
+
+	name := m[1]
+	value := strings.TrimSpace(m[2])
+	if value == "" {
+		return nil, fmt.Errorf("file:has.%s() requires a value", name)
+	}
+	return &FilePredicate{Name: name, Value: value}, nil
+}
+
+// SplitFilePredicates separates the given file: field values into file path
+// patterns and file predicates.
+func SplitFilePredicates(values []string) (patterns []string, predicates []FilePredicate, err error) {
+	for _, v := range values {
+		p, err := ParseFilePredicate(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p == nil {
+			patterns = append(patterns, v)
+			continue
+		}
+		predicates = append(predicates, *p)
+	}
+	return patterns, predicates, nil
+}
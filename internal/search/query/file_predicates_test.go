@@ -0,0 +1,45 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseFilePredicate(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    *FilePredicate
+		wantErr string
+	}{
+		{value: `\.go$`},
+		{value: "has.owner(@org/team)", want: &FilePredicate{Name: FilePredicateOwner, Value: "@org/team"}},
+		{value: "has.owner( alice )", want: &FilePredicate{Name: FilePredicateOwner, Value: "alice"}},
+		{value: "has.owner()", wantErr: "file:has.owner() requires a value"},
+		{value: "has.topic(go)"},
+		{value: "^has\\.owner\\(x\\)$"},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			have, err := ParseFilePredicate(tc.value)
+			if have, want := errString(err), tc.wantErr; have != want {
+				t.Fatalf("error: have %q, want %q", have, want)
+			}
+			if diff := cmp.Diff(have, tc.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestSplitFilePredicates(t *testing.T) {
+	patterns, predicates, err := SplitFilePredicates([]string{`\.go$`, "has.owner(@a)", "^cmd/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(patterns, []string{`\.go$`, "^cmd/"}); diff != "" {
+		t.Errorf("patterns: %s", diff)
+	}
+	if diff := cmp.Diff(predicates, []FilePredicate{{Name: FilePredicateOwner, Value: "@a"}}); diff != "" {
+		t.Errorf("predicates: %s", diff)
+	}
+}
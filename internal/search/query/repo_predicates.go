@@ -0,0 +1,78 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// RepoPredicate is a repo: field value of the form has.<name>(<value>), such as
+// repo:has.topic(go), which matches repositories by the metadata synced from
+// their code host instead of by their name.
+type RepoPredicate struct {
+	Name  string
+	Value string
+}
+
+// All repo predicate names.
+const (
+	RepoPredicateTopic    = "topic"
+	RepoPredicateLanguage = "language"
+	RepoPredicateLicense  = "license"
+)
+
+// repoPredicateRx matches the repo predicates. Other values of the form
+// has.<name>(<value>) are valid repository name patterns (such as
+// repo:has.stars(10)), so only the known predicate names are matched.
+var repoPredicateRx = lazyregexp.New(`^has\.(` + RepoPredicateTopic + `|` + RepoPredicateLanguage + `|` + RepoPredicateLicense + `)\((.*)\)$`)
+
+// ParseRepoPredicate parses the repo: field value v as a RepoPredicate. It
+// returns nil if v is not a repo predicate, in which case v is a repository
+// name pattern, and an error if the predicate has no value.
+func ParseRepoPredicate(v string) (*RepoPredicate, error) {
+	m := repoPredicateRx.FindStringSubmatch(v)
+	if m == nil {
+		return nil, nil
+	}
+
+	name := m[1]
+	value := strings.TrimSpace(m[2])
+	if value == "" {
+		return nil, fmt.Errorf("repo:has.%s() requires a value", name)
+	}
+	return &RepoPredicate{Name: name, Value: value}, nil
+}
+
+// SplitRepoPredicates separates the given repo: field values into repository
+// name patterns and repo predicates.
+func SplitRepoPredicates(values []string) (patterns []string, predicates []RepoPredicate, err error) {
+	for _, v := range values {
+		p, err := ParseRepoPredicate(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p == nil {
+			patterns = append(patterns, v)
+			continue
+		}
+		predicates = append(predicates, *p)
+	}
+	return patterns, predicates, nil
+}
+
+// RepoOrderStars is the value of the order: field that searches the most
+// starred repositories first, which matters when a search hits the repository
+// limit. Without order:, repositories are searched in the default order.
+const RepoOrderStars = "stars"
+
+// ParseRepoOrder parses the order: field value v. It returns the empty string
+// if v is empty.
+func ParseRepoOrder(v string) (string, error) {
+	switch v {
+	case "", RepoOrderStars:
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid value %q for field %s, the only valid value is %s", v, FieldOrder, RepoOrderStars)
+	}
+}
@@ -0,0 +1,68 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRepoPredicate(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    *RepoPredicate
+		wantErr string
+	}{
+		{value: "github.com/foo/bar"},
+		{value: "^has\\.topic\\(go\\)$"},
+		{value: "has.topic(go)", want: &RepoPredicate{Name: RepoPredicateTopic, Value: "go"}},
+		{value: "has.language( Go )", want: &RepoPredicate{Name: RepoPredicateLanguage, Value: "Go"}},
+		{value: "has.license(MIT)", want: &RepoPredicate{Name: RepoPredicateLicense, Value: "MIT"}},
+		{value: "has.topic()", wantErr: "repo:has.topic() requires a value"},
+		{value: "has.stars(10)"},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			have, err := ParseRepoPredicate(tc.value)
+			if have, want := errString(err), tc.wantErr; have != want {
+				t.Fatalf("error: have %q, want %q", have, want)
+			}
+			if diff := cmp.Diff(have, tc.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestSplitRepoPredicates(t *testing.T) {
+	patterns, predicates, err := SplitRepoPredicates([]string{"foo", "has.topic(go)", "bar@master", "has.license(MIT)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(patterns, []string{"foo", "bar@master"}); diff != "" {
+		t.Errorf("patterns: %s", diff)
+	}
+	wantPredicates := []RepoPredicate{
+		{Name: RepoPredicateTopic, Value: "go"},
+		{Name: RepoPredicateLicense, Value: "MIT"},
+	}
+	if diff := cmp.Diff(predicates, wantPredicates); diff != "" {
+		t.Errorf("predicates: %s", diff)
+	}
+}
+
+func TestParseRepoOrder(t *testing.T) {
+	for value, want := range map[string]string{"": "", "stars": RepoOrderStars} {
+		if have, err := ParseRepoOrder(value); err != nil || have != want {
+			t.Errorf("ParseRepoOrder(%q) = %q, %v, want %q", value, have, err, want)
+		}
+	}
+	if _, err := ParseRepoOrder("forks"); err == nil {
+		t.Error("want error for order:forks")
+	}
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -26,6 +26,8 @@ const (
 	FieldPatternType        = "patterntype"
 	FieldContent            = "content"
 	FieldVisibility         = "visibility"
+	FieldSelect             = "select"
+	FieldOrder              = "order"
 
 	// For diff and commit search only:
 	FieldBefore    = "before"
@@ -42,6 +44,7 @@ const (
 	FieldTimeout   = "timeout"
 	FieldReplace   = "replace"
 	FieldCombyRule = "rule"
+	FieldMultiline = "multiline"
 )
 
 var (
@@ -62,6 +65,8 @@ var (
 			FieldPatternType: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldContent:     {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldVisibility:  {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldSelect:      {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldOrder:       {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 
 			FieldRepoHasFile:        regexpNegatableFieldType,
 			FieldRepoHasCommitAfter: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
@@ -80,6 +85,7 @@ var (
 			FieldTimeout:   {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldReplace:   {Literal: types.StringType, Quoted: types.StringType, Singular: true},
 			FieldCombyRule: {Literal: types.StringType, Quoted: types.StringType, Singular: true},
+			FieldMultiline: {Literal: types.BoolType, Quoted: types.BoolType, Singular: true},
 		},
 		FieldAliases: map[string]string{
 			"r":        FieldRepo,
@@ -0,0 +1,78 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Select is the parsed value of the select: field, which projects search
+// results onto a coarser result type. For example, select:repo returns the
+// repositories that contain a match instead of the matches themselves.
+type Select struct {
+	// Type is the result type to select: one of SelectRepo, SelectFile,
+	// SelectSymbol or SelectCommitAuthor.
+	Type string
+
+	// SymbolKind restricts SelectSymbol to symbols of this kind, such as
+	// "function" for select:symbol.function. It is empty if symbols of all
+	// kinds are selected.
+	SymbolKind string
+}
+
+// All select: types.
+const (
+	SelectRepo         = "repo"
+	SelectFile         = "file"
+	SelectSymbol       = "symbol"
+	SelectCommitAuthor = "commit.author"
+)
+
+// symbolKinds are the symbol kinds that may follow select:symbol. They are the
+// lowercase names of the GraphQL SymbolKind enum values.
+var symbolKinds = map[string]struct{}{
+	"file":          empty,
+	"module":        empty,
+	"namespace":     empty,
+	"package":       empty,
+	"class":         empty,
+	"method":        empty,
+	"property":      empty,
+	"field":         empty,
+	"constructor":   empty,
+	"enum":          empty,
+	"interface":     empty,
+	"function":      empty,
+	"variable":      empty,
+	"constant":      empty,
+	"string":        empty,
+	"number":        empty,
+	"boolean":       empty,
+	"array":         empty,
+	"object":        empty,
+	"key":           empty,
+	"null":          empty,
+	"enummember":    empty,
+	"struct":        empty,
+	"event":         empty,
+	"operator":      empty,
+	"typeparameter": empty,
+}
+
+// ParseSelect parses the value of the select: field.
+func ParseSelect(value string) (*Select, error) {
+	v := strings.ToLower(value)
+	switch v {
+	case SelectRepo, SelectFile, SelectSymbol, SelectCommitAuthor:
+		return &Select{Type: v}, nil
+	}
+
+	if kind := strings.TrimPrefix(v, SelectSymbol+"."); kind != v {
+		if _, ok := symbolKinds[kind]; !ok {
+			return nil, fmt.Errorf("invalid symbol kind %q for select:symbol, valid kinds are for example symbol.function, symbol.class and symbol.variable", kind)
+		}
+		return &Select{Type: SelectSymbol, SymbolKind: kind}, nil
+	}
+
+	return nil, fmt.Errorf("invalid value %q for field select, valid values are %s, %s, %s.<kind> and %s",
+		value, SelectRepo, SelectFile, SelectSymbol, SelectCommitAuthor)
+}
@@ -0,0 +1,33 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSelect(t *testing.T) {
+	for _, tc := range []struct {
+		value   string
+		want    *Select
+		wantErr string
+	}{
+		{value: "repo", want: &Select{Type: SelectRepo}},
+		{value: "File", want: &Select{Type: SelectFile}},
+		{value: "symbol", want: &Select{Type: SelectSymbol}},
+		{value: "symbol.function", want: &Select{Type: SelectSymbol, SymbolKind: "function"}},
+		{value: "commit.author", want: &Select{Type: SelectCommitAuthor}},
+		{value: "symbol.lambda", wantErr: `invalid symbol kind "lambda" for select:symbol, valid kinds are for example symbol.function, symbol.class and symbol.variable`},
+		{value: "commit", wantErr: `invalid value "commit" for field select, valid values are repo, file, symbol.<kind> and commit.author`},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			have, err := ParseSelect(tc.value)
+			if have, want := errString(err), tc.wantErr; have != want {
+				t.Fatalf("error: have %q, want %q", have, want)
+			}
+			if diff := cmp.Diff(have, tc.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -210,7 +210,8 @@ func (q AndOrQuery) valueToTypedValue(field, value string, label labels) []*type
 		return []*types.Value{{String: &value}}
 
 	case
-		FieldCase:
+		FieldCase,
+		FieldMultiline:
 		b, _ := parseBool(value)
 		return []*types.Value{{Bool: &b}}
 
@@ -232,7 +233,8 @@ func (q AndOrQuery) valueToTypedValue(field, value string, label labels) []*type
 		FieldLang, "l", "language",
 		FieldType,
 		FieldPatternType,
-		FieldContent:
+		FieldContent,
+		FieldOrder:
 		return []*types.Value{{String: &value}}
 
 	case FieldRepoHasFile:
@@ -232,6 +232,16 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return nil
 	}
 
+	isSelect := func() error {
+		_, err := ParseSelect(value)
+		return err
+	}
+
+	isRepoOrder := func() error {
+		_, err := ParseRepoOrder(value)
+		return err
+	}
+
 	isUnrecognizedField := func() error {
 		return fmt.Errorf("unrecognized field %q", field)
 	}
@@ -275,6 +285,12 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldPatternType,
 		FieldContent:
 		return satisfies(isSingular, isNotNegated)
+	case
+		FieldSelect:
+		return satisfies(isSingular, isNotNegated, isSelect)
+	case
+		FieldOrder:
+		return satisfies(isSingular, isNotNegated, isRepoOrder)
 	case
 		FieldRepoHasFile:
 		return satisfies(isValidRegexp)
@@ -297,7 +313,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldCount:
 		return satisfies(isSingular, isNumber, isNotNegated)
 	case
-		FieldStable:
+		FieldStable,
+		FieldMultiline:
 		return satisfies(isSingular, isBoolean, isNotNegated)
 	case
 		FieldMax,
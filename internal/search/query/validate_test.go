@@ -33,6 +33,14 @@ func TestAndOrQuery_Validation(t *testing.T) {
 			input: "stable:???",
 			want:  `invalid boolean "???"`,
 		},
+		{
+			input: "multiline:???",
+			want:  `invalid boolean "???"`,
+		},
+		{
+			input: "-multiline:yes",
+			want:  `field "multiline" does not support negation`,
+		},
 		{
 			input: "count:sedonuts",
 			want:  "field count has value sedonuts, sedonuts is not a number",
@@ -45,6 +53,22 @@ func TestAndOrQuery_Validation(t *testing.T) {
 			input: "count:-1",
 			want:  "field count requires a positive number",
 		},
+		{
+			input: "select:repo select:file",
+			want:  `field "select" may not be used more than once`,
+		},
+		{
+			input: "-select:repo",
+			want:  `field "select" does not support negation`,
+		},
+		{
+			input: "order:forks",
+			want:  `invalid value "forks" for field order, the only valid value is stars`,
+		},
+		{
+			input: "select:owner",
+			want:  `invalid value "owner" for field select, valid values are repo, file, symbol.<kind> and commit.author`,
+		},
 	}
 	for _, c := range cases {
 		t.Run("validate and/or query", func(t *testing.T) {
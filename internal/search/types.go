@@ -114,14 +114,22 @@ type TextPatternInfo struct {
 	IsCaseSensitive bool
 	FileMatchLimit  int32
 
-	// We do not support IsMultiline
-	// IsMultiline     bool
+	// IsMultiline if true lets regexp patterns match across lines, with .
+	// matching newlines.
+	IsMultiline bool
+
 	IncludePatterns []string
 	ExcludePattern  string
 
 	FilePatternsReposMustInclude []string
 	FilePatternsReposMustExclude []string
 
+	// FileOwnersMustInclude and FileOwnersMustExclude are the owners (from
+	// the file:has.owner() filters) that matched files must and must not
+	// have according to the repository's CODEOWNERS file.
+	FileOwnersMustInclude []string
+	FileOwnersMustExclude []string
+
 	PathPatternsAreRegExps       bool
 	PathPatternsAreCaseSensitive bool
 
@@ -149,6 +157,9 @@ func (p *TextPatternInfo) String() string {
 	if p.IsCaseSensitive {
 		args = append(args, "case")
 	}
+	if p.IsMultiline {
+		args = append(args, "multiline")
+	}
 	if !p.PatternMatchesContent {
 		args = append(args, "nocontent")
 	}
@@ -168,6 +179,12 @@ func (p *TextPatternInfo) String() string {
 	for _, dec := range p.FilePatternsReposMustExclude {
 		args = append(args, fmt.Sprintf("-repositoryPathPattern:%s", dec))
 	}
+	for _, owner := range p.FileOwnersMustInclude {
+		args = append(args, fmt.Sprintf("owner:%s", owner))
+	}
+	for _, owner := range p.FileOwnersMustExclude {
+		args = append(args, fmt.Sprintf("-owner:%s", owner))
+	}
 
 	path := "glob"
 	if p.PathPatternsAreRegExps {
@@ -0,0 +1,79 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+// CommitPatch is a commit and the patch of its changes.
+type CommitPatch struct {
+	Commit *Commit
+
+	// Patch is the patch of the commit's changes without context lines, as
+	// output by `git log --patch --unified=0 --no-prefix`. It is empty if the
+	// commit has no changes.
+	Patch []byte
+}
+
+// CommitPatches returns the commits matching the options (except merge
+// commits) with their patches. Only the Range, N and Skip options are
+// supported.
+func CommitPatches(ctx context.Context, repo gitserver.Repo, opt CommitsOptions) ([]*CommitPatch, error) {
+	if Mocks.CommitPatches != nil {
+		return Mocks.CommitPatches(repo, opt)
+	}
+
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: CommitPatches")
+	span.SetTag("Opt", opt)
+	defer span.Finish()
+
+	args, err := commitLogArgs([]string{"log", "--no-merges", "-z", "--patch", "--unified=0", "--no-prefix", logFormatWithoutRefs}, CommitsOptions{
+		Range: opt.Range,
+		N:     opt.N,
+		Skip:  opt.Skip,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := gitserver.DefaultClient.Command("git", args...)
+	cmd.Repo = repo
+	data, stderr, err := cmd.DividedOutput(ctx)
+	if err != nil {
+		if isBadObjectErr(string(stderr), opt.Range) || isInvalidRevisionRangeError(string(stderr), opt.Range) {
+			return nil, &gitserver.RevisionNotFoundError{Repo: repo.Name, Spec: opt.Range}
+		}
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, bytes.TrimSpace(stderr)))
+	}
+
+	var patches []*CommitPatch
+	for len(data) > 0 {
+		var commit *Commit
+		commit, _, data, err = parseCommitFromLog(data)
+		if err != nil {
+			return nil, err
+		}
+		p := &CommitPatch{Commit: commit}
+
+		// The fields of the commit are followed by "\x00" if it has no
+		// changes, or by "\n" and its patch ending with "\n\x00".
+		switch {
+		case len(data) > 0 && data[0] == '\x00':
+			data = data[1:]
+		case len(data) > 0 && data[0] == '\n':
+			data = data[1:]
+			if end := bytes.Index(data, []byte("\n\x00")); end != -1 {
+				p.Patch, data = data[:end+1], data[end+2:]
+			} else {
+				p.Patch, data = data, nil
+			}
+		}
+		patches = append(patches, p)
+	}
+	return patches, nil
+}
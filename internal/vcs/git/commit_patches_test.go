@@ -0,0 +1,60 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestCommitPatches(t *testing.T) {
+	t.Parallel()
+
+	repo := MakeGitRepository(t,
+		"echo -e 'a\\nb' > f",
+		"git add f",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z git commit -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:06Z git commit --allow-empty -m empty --author='a <a@a.com>' --date 2006-01-02T15:04:06Z",
+		"echo -e 'a\\nc' > f",
+		"GIT_COMMITTER_NAME=b GIT_COMMITTER_EMAIL=b@b.com GIT_COMMITTER_DATE=2006-01-02T15:04:07Z git commit -am 'bar\n\nbody' --author='b <b@b.com>' --date 2006-01-02T15:04:07Z",
+	)
+
+	patches, err := CommitPatches(ctx, repo, CommitsOptions{Range: "HEAD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var messages, diffs []string
+	for _, p := range patches {
+		messages = append(messages, p.Commit.Message)
+		diffs = append(diffs, string(p.Patch))
+	}
+	if want := []string{"bar\n\nbody", "empty", "foo"}; !stringSlicesEqual(messages, want) {
+		t.Errorf("got messages %q, want %q", messages, want)
+	}
+	if patches[0].Commit.Author.Name != "b" {
+		t.Errorf("got author %q, want b", patches[0].Commit.Author.Name)
+	}
+	for i, want := range []string{"-b\n+c\n", "", "+a\n+b\n"} {
+		if len(diffs[i]) < len(want) || diffs[i][len(diffs[i])-len(want):] != want {
+			t.Errorf("commit %d: got patch %q, want suffix %q", i, diffs[i], want)
+		}
+	}
+
+	patches, err = CommitPatches(ctx, repo, CommitsOptions{Range: "HEAD~2..HEAD", N: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 || patches[0].Commit.Message != "bar\n\nbody" {
+		t.Errorf("got %d patches, want only the latest commit", len(patches))
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -19,6 +19,41 @@ func compilePathMatcher(options PathOptions) (pathmatch.PathMatcher, error) {
 	)
 }
 
+// FilterDiff returns the hunks of the raw diff (as output by `git log --patch
+// --no-prefix`) that match the query in the files that match the path options,
+// with the query matches highlighted. It returns a nil diff if no hunks match.
+func FilterDiff(rawDiff []byte, query TextSearchOptions, paths PathOptions) (*RawDiff, []Highlight, error) {
+	queryRegexp, err := compileTextSearchQuery(query)
+	if err != nil {
+		return nil, nil, err
+	}
+	pathMatcher, err := compilePathMatcher(paths)
+	if err != nil {
+		return nil, nil, err
+	}
+	filtered, highlights, err := filterAndHighlightDiff(rawDiff, queryRegexp, true, pathMatcher)
+	if err != nil || filtered == nil {
+		return nil, nil, err
+	}
+	return &RawDiff{Raw: string(filtered)}, highlights, nil
+}
+
+// compileTextSearchQuery compiles the query's pattern into a regexp, or returns
+// nil if the pattern is empty.
+func compileTextSearchQuery(query TextSearchOptions) (*regexp.Regexp, error) {
+	pattern := query.Pattern
+	if pattern == "" {
+		return nil, nil
+	}
+	if !query.IsRegExp {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !query.IsCaseSensitive {
+		pattern = "(?i:" + pattern + ")"
+	}
+	return regexp.Compile(pattern)
+}
+
 // filterAndHighlightDiff returns the raw diff with query matches highlighted
 // and only hunks that satisfy the query (if onlyMatchingHunks) and path matcher.
 func filterAndHighlightDiff(rawDiff []byte, query *regexp.Regexp, onlyMatchingHunks bool, pathMatcher pathmatch.PathMatcher) ([]byte, []Highlight, error) {
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS external_service_sync_states (
+  external_service_id bigint PRIMARY KEY REFERENCES external_services(id) ON DELETE CASCADE,
+  last_sync_started_at timestamp with time zone,
+  last_sync_finished_at timestamp with time zone,
+  last_full_sync_at timestamp with time zone,
+  next_sync_at timestamp with time zone,
+  last_sync_error text NOT NULL DEFAULT '',
+  repos_sourced integer NOT NULL DEFAULT 0,
+  repos_added integer NOT NULL DEFAULT 0,
+  repos_modified integer NOT NULL DEFAULT 0,
+  repos_deleted integer NOT NULL DEFAULT 0
+);
+
+COMMIT;
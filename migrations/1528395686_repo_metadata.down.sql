@@ -0,0 +1,12 @@
+BEGIN;
+
+DROP INDEX IF EXISTS repo_stars;
+DROP INDEX IF EXISTS repo_topics_gin_idx;
+
+ALTER TABLE repo DROP COLUMN IF EXISTS topics;
+ALTER TABLE repo DROP COLUMN IF EXISTS stars;
+ALTER TABLE repo DROP COLUMN IF EXISTS pushed_at;
+ALTER TABLE repo DROP COLUMN IF EXISTS default_branch;
+ALTER TABLE repo DROP COLUMN IF EXISTS license;
+
+COMMIT;
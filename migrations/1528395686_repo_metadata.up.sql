@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE repo ADD COLUMN IF NOT EXISTS topics jsonb NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE repo ADD COLUMN IF NOT EXISTS stars integer NOT NULL DEFAULT 0;
+ALTER TABLE repo ADD COLUMN IF NOT EXISTS pushed_at timestamp with time zone;
+ALTER TABLE repo ADD COLUMN IF NOT EXISTS default_branch text NOT NULL DEFAULT '';
+ALTER TABLE repo ADD COLUMN IF NOT EXISTS license text NOT NULL DEFAULT '';
+
+ALTER TABLE repo ADD CONSTRAINT repo_topics_check CHECK (jsonb_typeof(topics) = 'array');
+
+CREATE INDEX IF NOT EXISTS repo_topics_gin_idx ON repo USING gin (topics);
+CREATE INDEX IF NOT EXISTS repo_stars ON repo (stars);
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS search_exports (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    query text NOT NULL,
+    version text NOT NULL,
+    pattern_type text,
+    format text NOT NULL,
+    state text NOT NULL DEFAULT 'queued',
+    result_count integer NOT NULL DEFAULT 0,
+    error text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    CONSTRAINT search_exports_format_check CHECK (format IN ('csv', 'jsonl')),
+    CONSTRAINT search_exports_state_check CHECK (state IN ('queued', 'processing', 'completed', 'errored', 'canceled'))
+);
+
+CREATE INDEX IF NOT EXISTS search_exports_user_id ON search_exports (user_id);
+CREATE INDEX IF NOT EXISTS search_exports_state ON search_exports (state);
+
+CREATE TABLE IF NOT EXISTS search_export_chunks (
+    search_export_id bigint NOT NULL REFERENCES search_exports(id) ON DELETE CASCADE,
+    seq integer NOT NULL,
+    data bytea NOT NULL,
+    PRIMARY KEY (search_export_id, seq)
+);
+
+COMMIT;
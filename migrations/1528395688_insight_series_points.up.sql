@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS insight_series_points (
+    series_id text NOT NULL,
+    query text NOT NULL,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    "time" timestamp with time zone NOT NULL,
+    value integer NOT NULL,
+    PRIMARY KEY (series_id, query, repo_id, "time")
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_points_repo_id ON insight_series_points (repo_id);
+
+COMMIT;
@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS commit_index (
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    commit_id text NOT NULL,
+    author_name text NOT NULL,
+    author_email text NOT NULL,
+    author_date timestamp with time zone NOT NULL,
+    committer_name text NOT NULL,
+    committer_email text NOT NULL,
+    committer_date timestamp with time zone NOT NULL,
+    message text NOT NULL,
+    diff text NOT NULL,
+    PRIMARY KEY (repo_id, commit_id)
+);
+
+CREATE INDEX IF NOT EXISTS commit_index_author_date ON commit_index (author_date);
+CREATE INDEX IF NOT EXISTS commit_index_message_trgm ON commit_index USING gin (message gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS commit_index_diff_trgm ON commit_index USING gin (diff gin_trgm_ops);
+
+CREATE TABLE IF NOT EXISTS commit_index_repos (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    head_commit text NOT NULL,
+    indexed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS search_history (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    query text NOT NULL,
+    search_count integer NOT NULL DEFAULT 1,
+    first_searched_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_searched_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT search_history_user_id_query_unique UNIQUE (user_id, query)
+);
+
+CREATE INDEX IF NOT EXISTS search_history_user_id_last_searched_at ON search_history (user_id, last_searched_at DESC);
+CREATE INDEX IF NOT EXISTS search_history_last_searched_at ON search_history (last_searched_at);
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE patch_sets ADD COLUMN IF NOT EXISTS spec text NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS patch_set_jobs (
+    id bigserial PRIMARY KEY,
+    patch_set_id bigint NOT NULL REFERENCES patch_sets(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    rev text NOT NULL DEFAULT '',
+    patch_id bigint REFERENCES patches(id) ON DELETE SET NULL DEFERRABLE,
+    error text NOT NULL DEFAULT '',
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS patch_set_jobs_patch_set_id ON patch_set_jobs (patch_set_id);
+CREATE INDEX IF NOT EXISTS patch_set_jobs_pending ON patch_set_jobs (updated_at) WHERE started_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE changeset_jobs ADD COLUMN IF NOT EXISTS conflicted boolean NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE campaigns ADD COLUMN IF NOT EXISTS auto_merge jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE changeset_jobs DROP COLUMN IF EXISTS draft;
+ALTER TABLE changeset_jobs DROP COLUMN IF EXISTS publish_at;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE changeset_jobs ADD COLUMN IF NOT EXISTS draft boolean NOT NULL DEFAULT false;
+ALTER TABLE changeset_jobs ADD COLUMN IF NOT EXISTS publish_at timestamp with time zone;
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS changeset_bulk_actions (
+    id bigserial PRIMARY KEY,
+    campaign_id bigint NOT NULL REFERENCES campaigns(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    type text NOT NULL,
+    body text NOT NULL DEFAULT '',
+    labels jsonb NOT NULL DEFAULT '[]'::jsonb,
+    reviewers jsonb NOT NULL DEFAULT '[]'::jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_bulk_actions_campaign_id ON changeset_bulk_actions (campaign_id);
+
+CREATE TABLE IF NOT EXISTS changeset_bulk_jobs (
+    id bigserial PRIMARY KEY,
+    bulk_action_id bigint NOT NULL REFERENCES changeset_bulk_actions(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    error text NOT NULL DEFAULT '',
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_bulk_jobs_bulk_action_id ON changeset_bulk_jobs (bulk_action_id);
+CREATE INDEX IF NOT EXISTS changeset_bulk_jobs_pending ON changeset_bulk_jobs (updated_at) WHERE started_at IS NULL;
+
+COMMIT;
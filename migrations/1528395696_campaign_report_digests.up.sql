@@ -0,0 +1,9 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_report_digests (
+    id bigserial PRIMARY KEY,
+    recipients jsonb NOT NULL DEFAULT '[]'::jsonb,
+    sent_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMIT;
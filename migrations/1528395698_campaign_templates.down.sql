@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE patch_sets
+    DROP COLUMN IF EXISTS campaign_template_id,
+    DROP COLUMN IF EXISTS campaign_template_version,
+    DROP COLUMN IF EXISTS campaign_template_arguments;
+
+DROP TABLE IF EXISTS campaign_templates;
+
+COMMIT;
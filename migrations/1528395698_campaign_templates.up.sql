@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS campaign_templates (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    spec text NOT NULL,
+    version integer NOT NULL DEFAULT 1,
+    author_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    namespace_user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT campaign_templates_has_1_namespace CHECK ((namespace_user_id IS NULL) <> (namespace_org_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS campaign_templates_namespace_user_id ON campaign_templates (namespace_user_id);
+CREATE INDEX IF NOT EXISTS campaign_templates_namespace_org_id ON campaign_templates (namespace_org_id);
+
+ALTER TABLE patch_sets
+    ADD COLUMN IF NOT EXISTS campaign_template_id bigint REFERENCES campaign_templates(id) ON DELETE SET NULL DEFERRABLE,
+    ADD COLUMN IF NOT EXISTS campaign_template_version integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS campaign_template_arguments jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id bigserial PRIMARY KEY,
+    actor_user_id integer NOT NULL,
+    actor_ip text NOT NULL DEFAULT '',
+    actor_forwarded_for text NOT NULL DEFAULT '',
+    action text NOT NULL,
+    target text NOT NULL DEFAULT '',
+    arguments jsonb NOT NULL DEFAULT '{}'::jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_actor_user_id ON audit_logs (actor_user_id);
+CREATE INDEX IF NOT EXISTS audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS audit_logs_created_at ON audit_logs (created_at);
+
+-- Audit log entries must never be modified or deleted. actor_user_id has no
+-- foreign key for the same reason: entries must outlive the users they refer
+-- to.
+CREATE OR REPLACE FUNCTION audit_logs_immutable() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+    BEGIN
+        RAISE EXCEPTION 'audit_logs entries are immutable';
+    END;
+$$;
+
+DROP TRIGGER IF EXISTS trig_audit_logs_immutable ON audit_logs;
+CREATE TRIGGER trig_audit_logs_immutable BEFORE UPDATE OR DELETE ON audit_logs FOR EACH ROW EXECUTE PROCEDURE audit_logs_immutable();
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE search_exports DROP COLUMN IF EXISTS attempt;
+
+COMMIT;
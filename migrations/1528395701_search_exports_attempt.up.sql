@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE search_exports ADD COLUMN IF NOT EXISTS attempt integer NOT NULL DEFAULT 0;
+
+COMMIT;
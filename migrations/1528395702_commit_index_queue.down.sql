@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS commit_index_queue;
+
+ALTER TABLE commit_index_repos DROP COLUMN IF EXISTS horizon;
+
+COMMIT;
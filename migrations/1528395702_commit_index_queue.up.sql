@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE commit_index_repos ADD COLUMN IF NOT EXISTS horizon timestamp with time zone;
+
+CREATE TABLE IF NOT EXISTS commit_index_queue (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    queued_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMIT;
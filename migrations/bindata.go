@@ -70,6 +70,42 @@
 // 1528395683_empty.up.sql (159B)
 // 1528395684_lsif_num_resets.down.sql (293B)
 // 1528395684_lsif_num_resets.up.sql (340B)
+// 1528395685_external_service_sync_states.down.sql (68B)
+// 1528395685_external_service_sync_states.up.sql (575B)
+// 1528395686_repo_metadata.down.sql (339B)
+// 1528395686_repo_metadata.up.sql (637B)
+// 1528395687_search_exports.down.sql (97B)
+// 1528395687_search_exports.up.sql (1.163kB)
+// 1528395688_insight_series_points.down.sql (61B)
+// 1528395688_insight_series_points.up.sql (413B)
+// 1528395689_commit_index.down.sql (93B)
+// 1528395689_commit_index.up.sql (985B)
+// 1528395690_search_history.down.sql (54B)
+// 1528395690_search_history.up.sql (666B)
+// 1528395691_patch_set_jobs.down.sql (106B)
+// 1528395691_patch_set_jobs.up.sql (893B)
+// 1528395692_changeset_jobs_conflicted.down.sql (78B)
+// 1528395692_changeset_jobs_conflicted.up.sql (112B)
+// 1528395693_campaigns_auto_merge.down.sql (73B)
+// 1528395693_campaigns_auto_merge.up.sql (111B)
+// 1528395694_changeset_jobs_rollout.down.sql (134B)
+// 1528395694_changeset_jobs_rollout.up.sql (196B)
+// 1528395695_changeset_bulk_actions.down.sql (104B)
+// 1528395695_changeset_bulk_actions.up.sql (1398B)
+// 1528395696_campaign_report_digests.down.sql (63B)
+// 1528395696_campaign_report_digests.up.sql (214B)
+// 1528395697_changeset_imports.down.sql (57B)
+// 1528395697_changeset_imports.up.sql (691B)
+// 1528395698_campaign_templates.down.sql (238B)
+// 1528395698_campaign_templates.up.sql (1249B)
+// 1528395699_access_tokens_expires_at.down.sql (77B)
+// 1528395699_access_tokens_expires_at.up.sql (105B)
+// 1528395700_audit_logs.down.sql (98B)
+// 1528395700_audit_logs.up.sql (1163B)
+// 1528395701_search_exports_attempt.down.sql (75B)
+// 1528395701_search_exports_attempt.up.sql (105B)
+// 1528395702_commit_index_queue.down.sql (121B)
+// 1528395702_commit_index_queue.up.sql (292B)
 
 package migrations
 
@@ -1538,6 +1574,726 @@ func _1528395684_lsif_num_resetsUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395685_external_service_sync_statesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x44\x00\xbb\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x65\x78\x74\x65\x72\x6e\x61\x6c\x5f\x73\x65\x72\x76\x69\x63\x65\x5f\x73\x79\x6e\x63\x5f\x73\x74\x61\x74\x65\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x68\x91\x4d\x64\x44\x00\x00\x00")
+
+func _1528395685_external_service_sync_statesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395685_external_service_sync_statesDownSql,
+		"1528395685_external_service_sync_states.down.sql",
+	)
+}
+
+func _1528395685_external_service_sync_statesDownSql() (*asset, error) {
+	bytes, err := _1528395685_external_service_sync_statesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395685_external_service_sync_states.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x70, 0xf5, 0xdd, 0x2d, 0xfd, 0xd5, 0xfe, 0xca, 0xa0, 0xd1, 0x3c, 0x1d, 0x5e, 0x1a, 0x3f, 0xad, 0xa, 0xa6, 0x39, 0xf7, 0x52, 0x38, 0xc3, 0xcb, 0x58, 0x93, 0xab, 0x8b, 0x78, 0x87, 0x88, 0xb5}}
+	return a, nil
+}
+
+var __1528395685_external_service_sync_statesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xd0\xc1\x4a\xc3\x40\x10\x80\xe1\xfb\x3e\xc5\xdc\xda\x82\x07\xef\x3d\x6d\xd3\xa9\x04\xd3\x54\x92\x2d\xd8\x53\x58\xb3\x93\x76\x20\xd9\x94\xdd\xa9\x46\x9f\x5e\xac\x60\x91\x80\xe6\xb8\xf0\xfd\x3b\xc3\xac\xf0\x21\xcd\x97\x4a\x25\x05\x6a\x83\x60\xf4\x2a\x43\x48\x37\x90\xef\x0c\xe0\x73\x5a\x9a\x12\x68\x10\x0a\xde\xb6\x55\xa4\xf0\xca\x35\x55\xf1\xdd\xd7\x55\x14\x2b\x14\x61\xae\x60\x0c\xd8\xc1\x0b\x1f\xd9\x0b\x3c\x15\xe9\x56\x17\x07\x78\xc4\x03\x14\xb8\xc1\x02\xf3\x04\xc7\x5f\xc6\x39\xbb\x05\xec\x72\x58\x63\x86\x06\x21\xd1\x65\xa2\xd7\x78\xa7\x00\x5a\x1b\xe5\x67\x62\x10\x72\x95\x15\x10\xee\x28\x8a\xed\xce\xf0\xc6\x72\xba\x3e\xe1\xa3\xf7\xf4\x3b\x68\xd8\x73\x3c\x4d\x2c\x9a\x4b\xdb\x7e\x67\xff\x68\x4f\x83\x4c\x82\xb7\x45\x28\x84\x3e\x80\xd0\x20\xd7\xbb\xe6\xfb\x2c\x83\x35\x6e\xf4\x3e\x33\x30\x9b\x7d\xd9\x40\xe7\x3e\x56\xb1\xbf\x84\x9a\x1c\xb0\x17\x3a\x52\x18\xe3\xfb\x9b\xb5\xce\x4d\x94\x5d\xef\xb8\xe1\x89\xd8\x51\x4b\xf2\xa7\x55\x8b\xa5\x52\xc9\x6e\xbb\x4d\xcd\x52\x7d\x0e\x00\x53\xa7\x31\xfe\x3f\x02\x00\x00")
+
+func _1528395685_external_service_sync_statesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395685_external_service_sync_statesUpSql,
+		"1528395685_external_service_sync_states.up.sql",
+	)
+}
+
+func _1528395685_external_service_sync_statesUpSql() (*asset, error) {
+	bytes, err := _1528395685_external_service_sync_statesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395685_external_service_sync_states.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6f, 0x21, 0x3c, 0x88, 0xad, 0xdf, 0xdf, 0x99, 0x4a, 0x7d, 0xa8, 0xfd, 0x48, 0x68, 0xa9, 0x40, 0xd9, 0xe3, 0xde, 0x5f, 0x68, 0xd5, 0x4c, 0xd8, 0x83, 0x37, 0xee, 0xba, 0x9a, 0x59, 0xdd, 0xef}}
+	return a, nil
+}
+
+var __1528395686_repo_metadataDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\xce\xcb\xaa\xc3\x20\x14\x85\xe1\xf9\x7e\x8a\xfd\x1e\x7b\x94\x8b\xe7\x20\xe4\x52\x12\x0b\x99\x89\x55\xdb\x08\xc1\x88\x1a\xe8\xe3\x17\xd2\x0e\x3a\x2a\xce\xff\x6f\xb1\x6a\xf6\xcf\x07\x02\x68\xa7\xf1\x82\x7c\x68\xd9\x82\xfc\x0f\xd9\xc2\x67\x31\x63\xb4\x61\x97\x29\xab\x98\xe8\x47\x90\xf7\xe0\x74\x92\x0f\xe7\xa5\x33\x4f\x02\xa8\x3a\xc1\x26\x14\x55\xdd\xb1\x73\x02\x4f\xdb\x8c\xdd\xb5\x1f\xbe\xf0\xdb\x51\x69\xfe\xf9\x51\x58\x87\x23\xad\xd6\x48\x95\x8b\x85\xb1\x77\x75\x6c\x59\xde\xa2\xf2\x7a\x2d\x66\x9b\xd3\xd6\x27\x4b\x00\xcd\xd8\xf7\x5c\x10\xbc\x06\x00\xcf\x7d\xe2\x19\x53\x01\x00\x00")
+
+func _1528395686_repo_metadataDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395686_repo_metadataDownSql,
+		"1528395686_repo_metadata.down.sql",
+	)
+}
+
+func _1528395686_repo_metadataDownSql() (*asset, error) {
+	bytes, err := _1528395686_repo_metadataDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395686_repo_metadata.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa9, 0x41, 0x44, 0x50, 0xb9, 0x9c, 0x85, 0x11, 0xdd, 0xb1, 0x8d, 0xb1, 0x64, 0xca, 0x1e, 0xda, 0xce, 0xb7, 0xf7, 0x17, 0xaf, 0xd0, 0x43, 0xe5, 0x6b, 0x79, 0xaa, 0x8d, 0x72, 0x13, 0xc8, 0x30}}
+	return a, nil
+}
+
+var __1528395686_repo_metadataUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\xd1\xcf\x6b\xc2\x30\x14\x07\xf0\x7b\xff\x8a\xef\xad\x7a\xdb\xd9\xb2\x43\x6d\xa3\x0b\xab\x29\xd8\x14\x84\x31\x4a\xac\x4f\x9b\x4d\xd3\xd2\x44\xa6\xfb\xeb\x07\x29\xc2\xd8\x4f\x7a\x7c\x2f\xe1\x93\x97\xf7\x9d\xb3\x25\x17\x51\x10\xc4\x99\x64\x6b\xc8\x78\x9e\x31\xf4\xd4\xb5\x88\xd3\x14\x49\x9e\x95\x2b\x01\xbe\x80\xc8\x25\xd8\x86\x17\xb2\x80\x6b\x3b\x5d\x5b\xbc\xd8\xd6\x6c\x7d\x5f\x94\x59\x86\x94\x2d\xe2\x32\x93\x08\x9f\x9e\xc3\xd9\xcc\x1f\x46\x23\x50\xeb\x54\x6f\xa1\x8d\xa3\x03\xf5\xdf\xd5\xbb\x31\x56\x77\xb6\x0d\xed\x2a\xe5\xe0\xf4\x89\xac\x53\xa7\x0e\x6f\xda\x35\xbe\xc4\x7b\x6b\x68\x8c\xb6\xa3\xbd\x3a\x1f\x5d\xb5\xed\x95\xa9\x1b\x38\xba\xb8\x1f\x7e\x1d\x8e\x21\x8f\xba\x26\x63\xe9\x0f\xeb\x37\x4c\x14\x72\x1d\x73\x21\x7d\xaf\x1a\x92\xa8\xea\x86\xea\x57\x24\x0f\x2c\x79\xc4\xc4\x6f\xbe\x72\xd7\x8e\xda\xfd\x64\xb8\x30\xc5\x3d\x42\xd5\xf7\xea\x1a\x4e\xa3\x20\x48\xd6\x2c\x96\x0c\x5c\xa4\x6c\xf3\x65\xb0\xcf\xea\x41\x9b\x4a\xef\x2e\xc8\x85\x7f\x0c\x65\xc1\xc5\x12\x07\x6d\x70\x63\xa3\x7f\xa9\x21\xd5\x9b\x30\xf1\xa5\x1f\x21\x5f\xad\xb8\x8c\x82\x8f\x01\x00\xf9\x46\x93\x59\x7d\x02\x00\x00")
+
+func _1528395686_repo_metadataUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395686_repo_metadataUpSql,
+		"1528395686_repo_metadata.up.sql",
+	)
+}
+
+func _1528395686_repo_metadataUpSql() (*asset, error) {
+	bytes, err := _1528395686_repo_metadataUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395686_repo_metadata.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6, 0x23, 0x9d, 0x9f, 0x99, 0xfa, 0xc, 0xbb, 0x65, 0xba, 0xa4, 0xa8, 0x8f, 0xbb, 0x43, 0xe9, 0xee, 0x35, 0x60, 0xd0, 0x29, 0x50, 0x3d, 0xe7, 0x51, 0xe9, 0xdd, 0xc4, 0x95, 0x7a, 0x44, 0x8c}}
+	return a, nil
+}
+
+var __1528395687_search_exportsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x61\x00\x9e\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x65\x61\x72\x63\x68\x5f\x65\x78\x70\x6f\x72\x74\x5f\x63\x68\x75\x6e\x6b\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x65\x61\x72\x63\x68\x5f\x65\x78\x70\x6f\x72\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xa9\x94\x8d\x65\x61\x00\x00\x00")
+
+func _1528395687_search_exportsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395687_search_exportsDownSql,
+		"1528395687_search_exports.down.sql",
+	)
+}
+
+func _1528395687_search_exportsDownSql() (*asset, error) {
+	bytes, err := _1528395687_search_exportsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395687_search_exports.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1a, 0x95, 0xf0, 0xc0, 0x15, 0x6e, 0x25, 0x99, 0xc, 0x7b, 0xcb, 0x21, 0x7d, 0x59, 0xb2, 0xb, 0x84, 0xe8, 0x8c, 0x29, 0xee, 0xce, 0xec, 0xf, 0x61, 0xfd, 0xb5, 0x69, 0xa6, 0xa9, 0xa2, 0x6b}}
+	return a, nil
+}
+
+var __1528395687_search_exportsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x94\xcf\x6e\x9c\x30\x10\xc6\xef\x3c\xc5\xdc\x00\x29\x87\xde\xf7\x44\x58\x6f\x8b\xc2\x9a\x0a\x88\x94\x9c\x90\x63\xa6\x8b\x1b\xd6\x66\xed\x21\xc9\xf6\xe9\xab\x60\xb6\xcd\xfe\x51\xd2\xa8\x37\xec\xdf\xcc\xa7\xf1\x7c\x33\x5c\xb3\xaf\x19\x5f\x04\x41\x5a\xb2\xa4\x66\x50\x27\xd7\x39\x83\x6c\x05\xbc\xa8\x81\xdd\x65\x55\x5d\x81\x43\x61\x65\xd7\xe0\xcb\x60\x2c\x39\x88\x02\x00\x00\xd5\xc2\x83\xda\x38\xb4\x4a\xf4\xf0\xbd\xcc\xd6\x49\x79\x0f\x37\xec\xfe\x6a\xa2\xa3\x43\xdb\xa8\x16\x94\x26\xdc\xa0\x9d\xd4\xf8\x6d\x9e\x43\xc9\x56\xac\x64\x3c\x65\xd5\x14\xe3\x22\xd5\xc6\x50\x70\x58\xb2\x9c\xd5\x0c\xd2\xa4\x4a\x93\x25\xf3\x22\xbb\x11\xed\x1e\x08\x5f\xe8\x4f\xbe\x07\x4f\x68\x9d\x32\xfa\x12\x1a\x04\x11\x5a\xdd\xd0\x7e\xc0\x89\xfb\xeb\x1f\xc6\x6e\x05\x5d\x4a\x70\x24\x08\x8f\x01\x2c\xd9\x2a\xb9\xcd\x6b\x08\x77\x23\x8e\xd8\x86\x3e\xd2\xa2\x1b\x7b\x6a\xa4\x19\x35\x9d\x3f\xec\x90\xf3\xc5\x07\xa3\xb5\xc6\xbe\x29\x40\x5a\x14\x84\x6d\xf3\x5a\x84\xda\xa2\x23\xb1\x1d\xe0\x59\x51\x37\x1d\xe1\x97\xd1\x78\xae\xa5\xcd\x73\x14\xcf\x0d\x1d\xda\xff\xca\x77\x24\xec\x07\xf9\x73\xa7\x94\x56\xae\xfb\x97\xc8\xb4\xe0\x55\x5d\x26\x19\xaf\x4f\x26\xa4\xf1\xdd\x6e\x64\x87\xf2\x11\xd2\x6f\x2c\xbd\x81\x68\x76\x20\xe3\x10\x85\xd2\x3d\x85\x57\x10\xfe\x74\x46\xf7\x61\x1c\x7f\x24\x37\x59\x74\xac\x36\x5d\x79\xb1\x83\x47\x10\x0e\xd6\x48\x74\x4e\xe9\xcd\xeb\x49\x9a\xed\xd0\x23\x79\x34\xd9\xe1\x3f\xa5\xd0\x12\x7b\x6c\xc3\x38\x0e\xe2\xbf\x83\x9f\xf1\x25\xbb\x7b\x77\xf0\x9b\xc3\x50\x17\xfc\x84\x40\x34\xa3\x78\xf1\x09\x39\xff\x86\x0b\x62\x13\x88\x3f\xb1\x93\x8d\xec\x46\xfd\x78\xd8\xcc\x63\xe4\xf7\x54\x69\xba\xb8\x83\x47\xb1\xef\x2e\xa3\xc3\xdd\xd9\xd0\x7b\xe3\x5a\x41\x02\x1e\xf6\x84\xe2\x04\xbc\xf9\x2b\x40\x74\x5a\xd5\x15\x38\xdc\xcd\x0e\x14\xeb\x75\x56\x2f\x82\xdf\x03\x00\xf1\x8c\xa7\xc2\x8b\x04\x00\x00")
+
+func _1528395687_search_exportsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395687_search_exportsUpSql,
+		"1528395687_search_exports.up.sql",
+	)
+}
+
+func _1528395687_search_exportsUpSql() (*asset, error) {
+	bytes, err := _1528395687_search_exportsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395687_search_exports.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbd, 0xbd, 0x6a, 0xbe, 0x9b, 0x7c, 0x4e, 0x8e, 0xd3, 0x9f, 0x75, 0x18, 0x8e, 0xd1, 0x8d, 0x72, 0x44, 0x45, 0xe1, 0x1a, 0x32, 0xd8, 0x6a, 0xde, 0xf8, 0x6, 0x29, 0x79, 0x3d, 0x83, 0x9f, 0x47}}
+	return a, nil
+}
+
+var __1528395688_insight_series_pointsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3d\x00\xc2\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x69\x6e\x73\x69\x67\x68\x74\x5f\x73\x65\x72\x69\x65\x73\x5f\x70\x6f\x69\x6e\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x2c\x5d\x29\xa7\x3d\x00\x00\x00")
+
+func _1528395688_insight_series_pointsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395688_insight_series_pointsDownSql,
+		"1528395688_insight_series_points.down.sql",
+	)
+}
+
+func _1528395688_insight_series_pointsDownSql() (*asset, error) {
+	bytes, err := _1528395688_insight_series_pointsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395688_insight_series_points.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x8f, 0x9d, 0xf7, 0xca, 0xa9, 0x1, 0xbf, 0x47, 0xb, 0xa5, 0xb7, 0x98, 0x7f, 0x24, 0x30, 0xfb, 0x2, 0x9d, 0x8b, 0xcc, 0x26, 0x2d, 0xb, 0x71, 0xf, 0x34, 0x27, 0x77, 0x6e, 0xbd, 0xf, 0x55}}
+	return a, nil
+}
+
+var __1528395688_insight_series_pointsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x50\xcb\x8a\xb3\x30\x18\xdd\xfb\x14\x87\xae\x14\x7c\x83\xae\xac\x7e\xfd\x09\xbf\xc6\x21\x66\xa0\x5d\x49\xc1\x8f\xf6\x83\xa9\x3a\x26\x9d\xdb\xd3\x0f\x13\x6d\x07\xe6\x02\xb3\x09\x24\xe7\xe4\xdc\x36\xf4\x4f\xe9\x75\x14\xe5\x86\x32\x4b\xb0\xd9\xa6\x24\xa8\x2d\x74\x6d\x41\x3b\xd5\xd8\x06\xd2\x3b\x39\x9e\x7c\xeb\x78\x12\x76\xed\x38\x48\xef\x1d\xe2\x08\x00\x96\x37\xe9\xe0\xf9\xc5\x87\x5f\xfa\xbe\x2c\xd3\x00\x3e\x5e\x78\x7a\xfd\x09\x98\x78\x1c\x5a\xe9\x20\xbd\xe7\x23\x4f\x37\x14\x86\xb6\x64\x48\xe7\xd4\x04\x4e\x2c\x5d\x82\x5a\xa3\xa0\x92\x2c\x21\xcf\x9a\x3c\x2b\x68\xd6\x58\x79\x39\xf3\x0a\x1f\xa7\xf3\x87\xf3\x88\x67\xf1\xa7\x70\xc5\xdb\xd0\xf3\x17\xc7\xa7\xc3\xc3\x85\xbf\xf9\xcd\x4a\x77\x46\x55\x99\xd9\xe3\x3f\xed\x11\xdf\x0a\xa5\x73\xfc\xf4\x1a\x36\x5d\x1c\x93\x28\xf9\x9c\x4b\xe9\x82\x76\x7f\x99\xab\xbd\x56\xae\xf5\x6f\x7b\x2e\x8c\xa0\x5e\x57\x95\xb2\xeb\xe8\x7d\x00\x79\x88\xb6\x32\x9d\x01\x00\x00")
+
+func _1528395688_insight_series_pointsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395688_insight_series_pointsUpSql,
+		"1528395688_insight_series_points.up.sql",
+	)
+}
+
+func _1528395688_insight_series_pointsUpSql() (*asset, error) {
+	bytes, err := _1528395688_insight_series_pointsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395688_insight_series_points.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x72, 0xcf, 0x8e, 0x34, 0x39, 0xc, 0xf3, 0x1a, 0xf0, 0x91, 0x9a, 0x99, 0x33, 0x47, 0xd1, 0x3f, 0x98, 0x56, 0x12, 0x9c, 0xe0, 0x4a, 0x8, 0x9d, 0x64, 0x9b, 0x13, 0xd5, 0x90, 0x25, 0x19, 0xb8}}
+	return a, nil
+}
+
+var __1528395689_commit_indexDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x5d\x00\xa2\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x69\x74\x5f\x69\x6e\x64\x65\x78\x5f\x72\x65\x70\x6f\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x69\x74\x5f\x69\x6e\x64\x65\x78\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xc4\x1d\xf3\x6d\x5d\x00\x00\x00")
+
+func _1528395689_commit_indexDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395689_commit_indexDownSql,
+		"1528395689_commit_index.down.sql",
+	)
+}
+
+func _1528395689_commit_indexDownSql() (*asset, error) {
+	bytes, err := _1528395689_commit_indexDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395689_commit_index.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x6b, 0x98, 0x6f, 0x47, 0x8d, 0xd8, 0x5, 0xbc, 0x87, 0x99, 0x90, 0x2, 0x4b, 0x35, 0xf5, 0x9d, 0x16, 0xf4, 0x9d, 0xef, 0x95, 0xba, 0x32, 0xca, 0xb9, 0x23, 0x2e, 0x6b, 0x17, 0x7a, 0x3c, 0xa4}}
+	return a, nil
+}
+
+var __1528395689_commit_indexUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xcb\x6e\xf2\x30\x10\x85\xf7\x79\x8a\x59\x26\x12\x6f\xc0\x2a\x24\x03\xb2\xfe\xe0\xfc\x4a\x8c\x04\x2b\xcb\xaa\x87\x60\xa9\x76\x50\xe2\x0a\xd4\xa7\xaf\x30\xd7\x52\xae\xcb\xe4\x1c\x9f\xcf\x9e\x33\x23\x9c\x30\x3e\x8c\xa2\xac\xc2\x54\x20\x88\x74\x54\x20\xb0\x31\xf0\x52\x00\xce\x59\x2d\x6a\xf8\x68\xad\x35\x5e\x1a\xa7\x69\x0b\x71\x04\x00\xd0\xd1\xba\x95\x46\x83\x71\x9e\x1a\xea\x82\x9b\xcf\x8a\x02\x2a\x1c\x63\x85\x3c\xc3\x3a\x78\x62\xa3\x13\x28\x39\xe4\x58\xa0\x40\xc8\xd2\x3a\x4b\x73\x1c\x84\x8c\x63\xac\x06\x4f\x5b\x7f\x8a\xd8\x8b\xea\xcb\xaf\xda\x4e\x3a\x65\xe9\x81\x4c\x56\x99\xcf\x07\xba\x56\x9e\xc0\x1b\x4b\xbd\x57\x76\x0d\x1b\xe3\x57\xe1\x13\xbe\x5b\x47\x57\x47\xf6\xd7\xf1\x74\x1f\x7a\x76\xdc\xe5\x9e\x2d\xef\xa0\x2d\xf5\xbd\x6a\x6e\x32\xb5\x59\x2e\x6f\xfd\xff\x5f\xb1\x69\x5a\x2d\xe0\x1f\x2e\x20\x3e\xb4\x31\x38\x35\xa5\x93\x28\x39\x77\xca\x78\x8e\xf3\x07\x9d\xca\xcb\x69\x95\xfc\x97\x06\xf1\x85\x98\x0c\x5f\x8e\x3c\x3c\x49\xfa\xae\xb1\x7f\x32\x67\x35\xe3\x13\x68\x8c\x83\xf8\xf8\xf4\xc6\xb8\xe0\x95\xed\xba\x7f\x03\xb3\x1b\xcf\x53\xc6\xce\x74\x0d\x78\x75\xdf\xe5\x6e\xb6\xfd\x9d\xad\xbf\x2c\xe1\x8d\xc5\x5f\x91\xd2\x72\x0f\xb9\x55\x6d\xb8\x3f\x69\xa9\xfc\xf3\xfd\x81\x1c\xc7\xe9\xac\x10\xe0\xda\x4d\x7c\x28\xbd\x9c\x4e\x99\x18\x46\x3f\x03\x00\x29\x62\xab\xd0\xd9\x03\x00\x00")
+
+func _1528395689_commit_indexUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395689_commit_indexUpSql,
+		"1528395689_commit_index.up.sql",
+	)
+}
+
+func _1528395689_commit_indexUpSql() (*asset, error) {
+	bytes, err := _1528395689_commit_indexUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395689_commit_index.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xa9, 0x8d, 0xac, 0x21, 0x85, 0xb0, 0x8d, 0x67, 0xd0, 0x53, 0x77, 0xe2, 0xa2, 0x55, 0xa9, 0xea, 0x5e, 0xdc, 0xa2, 0xd7, 0x78, 0x28, 0x53, 0x21, 0x7a, 0x50, 0x91, 0x6c, 0xe9, 0x92, 0x3d, 0x2a}}
+	return a, nil
+}
+
+var __1528395690_search_historyDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x36\x00\xc9\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x65\x61\x72\x63\x68\x5f\x68\x69\x73\x74\x6f\x72\x79\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xea\x94\xc8\x31\x36\x00\x00\x00")
+
+func _1528395690_search_historyDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395690_search_historyDownSql,
+		"1528395690_search_history.down.sql",
+	)
+}
+
+func _1528395690_search_historyDownSql() (*asset, error) {
+	bytes, err := _1528395690_search_historyDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395690_search_history.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7b, 0xe2, 0x7b, 0x2c, 0x41, 0x63, 0x83, 0x5a, 0xef, 0x4, 0xa3, 0x4d, 0xac, 0xb0, 0xb5, 0xfc, 0x68, 0x11, 0xd8, 0xe6, 0xa, 0xd, 0x78, 0xfd, 0xad, 0x28, 0x35, 0x5a, 0x66, 0x97, 0x70, 0xc5}}
+	return a, nil
+}
+
+var __1528395690_search_historyUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x91\xc1\x6a\xf3\x30\x10\x84\xef\x7e\x8a\x39\xda\x90\xcb\x7f\xce\x49\xb1\x37\x3f\xa2\x8e\xdc\xda\x32\x24\x27\xe1\xc6\x6a\x22\x48\xec\x46\x92\x49\xd3\xa7\x2f\xc8\x21\x2d\x31\x94\x16\x7a\x14\x33\xfb\x8d\x66\x77\x41\xff\xb9\x98\x47\x51\x5a\x12\x93\x04\xc9\x16\x39\x81\x2f\x21\x0a\x09\x5a\xf3\x4a\x56\x70\xba\xb1\xdb\xbd\xda\x1b\xe7\x7b\x7b\x41\x1c\x01\x80\x69\xf1\x6c\x76\x4e\x5b\xd3\x1c\xf0\x58\xf2\x15\x2b\x37\x78\xa0\xcd\x2c\xa8\x83\xd3\x56\x99\x16\xa6\xf3\x7a\xa7\x6d\xa0\x89\x3a\xcf\x51\xd2\x92\x4a\x12\x29\x55\xc1\xe3\x62\xd3\x26\x28\x04\x32\xca\x49\x12\x52\x56\xa5\x2c\xa3\x11\x72\x1a\xb4\xbd\xc0\xeb\x37\x7f\x9b\x1f\x85\xeb\x87\xb6\xfd\xd0\xf9\x69\x44\x46\x4b\x56\xe7\x12\xff\x46\xf3\x8b\xb1\xce\xab\x71\x44\xb7\xaa\xf1\xf0\xe6\xa8\x9d\x6f\x8e\xaf\x38\x1b\xbf\x0f\x4f\xbc\xf7\x9d\x9e\x22\xba\xfe\x1c\x27\x23\xe6\xd0\xfc\x05\x25\x2d\x44\x25\x4b\xc6\x85\xbc\xdb\xaa\xba\x6e\x4c\x85\xd2\x6a\xe8\xcc\x69\xd0\xa8\x05\x7f\xaa\x09\xf1\x55\x9c\x21\xa8\x49\x94\x7c\x1e\x8c\x8b\x8c\xd6\xdf\x1e\xec\x86\x9e\x54\x28\xc4\x9d\xf5\x4b\xd2\xc4\x9c\x51\x95\x26\xf3\x5f\xc4\xfe\x24\xee\xde\x13\x8a\x15\xab\x15\x97\xf3\xe8\x63\x00\xc6\x46\xdc\xe8\x9a\x02\x00\x00")
+
+func _1528395690_search_historyUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395690_search_historyUpSql,
+		"1528395690_search_history.up.sql",
+	)
+}
+
+func _1528395690_search_historyUpSql() (*asset, error) {
+	bytes, err := _1528395690_search_historyUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395690_search_history.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf, 0x60, 0xcc, 0xa, 0xec, 0x23, 0x71, 0xe5, 0x5, 0x7a, 0x8, 0x36, 0x86, 0x52, 0xa8, 0x2a, 0xa, 0xb2, 0x20, 0x4b, 0x45, 0x3c, 0x75, 0x60, 0x30, 0x93, 0x2f, 0xb2, 0x73, 0xe1, 0xe0, 0x57}}
+	return a, nil
+}
+
+var __1528395691_patch_set_jobsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6a\x00\x95\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x70\x61\x74\x63\x68\x5f\x73\x65\x74\x5f\x6a\x6f\x62\x73\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x70\x61\x74\x63\x68\x5f\x73\x65\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x70\x65\x63\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x27\x39\x2b\x55\x6a\x00\x00\x00")
+
+func _1528395691_patch_set_jobsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395691_patch_set_jobsDownSql,
+		"1528395691_patch_set_jobs.down.sql",
+	)
+}
+
+func _1528395691_patch_set_jobsDownSql() (*asset, error) {
+	bytes, err := _1528395691_patch_set_jobsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395691_patch_set_jobs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd4, 0xf0, 0x69, 0xe2, 0x26, 0xff, 0x2e, 0xe0, 0x0, 0xc6, 0x5b, 0xb2, 0xbb, 0x10, 0xfe, 0x14, 0x6c, 0x5c, 0x3c, 0x7b, 0x82, 0xf2, 0x1c, 0xe7, 0xd7, 0x47, 0xf0, 0xce, 0x56, 0xfb, 0x46, 0x25}}
+	return a, nil
+}
+
+var __1528395691_patch_set_jobsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\xcd\x6e\xab\x30\x10\x85\xf7\x7e\x8a\xd9\x05\xa4\xfb\x06\xac\x1c\x98\xdc\x8b\x2e\x3f\x95\x71\xd4\x64\x85\x48\x98\x26\xae\x1a\x40\xb6\xdb\x54\x7d\xfa\x0a\xa8\x6a\x92\xa6\x4a\xda\xa5\x99\x99\x6f\xce\x61\xce\x1c\xff\xc6\x59\xc0\x18\x4f\x24\x0a\x90\x7c\x9e\x20\x74\x95\xdd\xee\x4b\x43\xd6\x00\x8f\x22\x08\xf3\x64\x99\x66\x10\x2f\x20\xcb\x25\xe0\x2a\x2e\x64\x01\xa6\xa3\x2d\x58\x7a\xb5\xc3\xc7\x6c\x99\x24\x10\xe1\x82\x2f\x13\x09\xb3\x59\xc0\x58\x28\x90\x4b\xfc\x00\x9e\x8e\x7e\xe2\xcb\xc7\x76\x63\xc0\x63\x00\x00\xaa\x86\x8d\xda\x19\xd2\xaa\x7a\x82\x3b\x11\xa7\x5c\xac\xe1\x3f\xae\xff\x0c\x55\x37\x32\xf6\xa9\x66\xb2\x57\xe0\x02\x05\x66\x21\x4e\xd0\xc6\x53\xb5\x0f\x79\x06\x11\x26\x28\x11\x42\x5e\x84\x3c\xc2\x5e\x23\x0a\xd1\x8b\x1a\xc1\x9a\xba\xb6\x54\x35\xa8\xc6\xd2\x8e\xf4\x45\x68\xdf\x73\x33\xee\xe5\xdb\x9f\x32\x75\xe2\x5c\x9c\x8b\xa7\x73\xe5\x05\x3a\xd2\xc9\x2e\xd2\xba\xd5\x57\xb6\x19\x5b\x69\x4b\x75\x59\x59\xb0\xea\x40\xc6\x56\x87\x0e\x8e\xca\xee\x87\x27\xbc\xb5\x0d\x8d\xb2\x1e\x54\xa3\xcc\xfe\x96\xce\xad\xa6\xea\x0a\xf2\xab\x9e\xa6\x3d\x7a\xfe\x38\xff\xdc\xd5\xbf\x9c\x67\xbe\xcb\x55\x9c\x45\xb8\x3a\x8b\xa4\x0b\x49\x9f\xab\xd2\x3d\x55\xdd\x9f\xee\xb4\x0c\xde\xb4\xee\x07\x3f\x01\x53\x53\xab\x66\x77\x89\xe9\xcc\xf9\x70\xff\x0f\x05\x4e\x2f\x10\x17\x83\xa9\x80\xb1\x30\x4f\xd3\x58\x06\xec\x7d\x00\x60\xaf\x8f\x4b\x7d\x03\x00\x00")
+
+func _1528395691_patch_set_jobsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395691_patch_set_jobsUpSql,
+		"1528395691_patch_set_jobs.up.sql",
+	)
+}
+
+func _1528395691_patch_set_jobsUpSql() (*asset, error) {
+	bytes, err := _1528395691_patch_set_jobsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395691_patch_set_jobs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7b, 0xb8, 0x63, 0x38, 0xde, 0x17, 0x86, 0x82, 0x3d, 0x7e, 0x94, 0xa6, 0x6, 0x67, 0x72, 0xa8, 0x5b, 0x21, 0x8c, 0x7b, 0x6f, 0xab, 0x9, 0xe3, 0x5b, 0xfc, 0xac, 0xbf, 0xa8, 0x39, 0xcb, 0x5b}}
+	return a, nil
+}
+
+var __1528395692_changeset_jobs_conflictedDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4e\x00\xb1\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x6a\x6f\x62\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6e\x66\x6c\x69\x63\x74\x65\x64\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x6b\x12\xec\x48\x4e\x00\x00\x00")
+
+func _1528395692_changeset_jobs_conflictedDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395692_changeset_jobs_conflictedDownSql,
+		"1528395692_changeset_jobs_conflicted.down.sql",
+	)
+}
+
+func _1528395692_changeset_jobs_conflictedDownSql() (*asset, error) {
+	bytes, err := _1528395692_changeset_jobs_conflictedDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395692_changeset_jobs_conflicted.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf7, 0x95, 0x8b, 0xfc, 0x14, 0x54, 0xa0, 0x2b, 0xec, 0x69, 0x19, 0x10, 0x2d, 0x84, 0xbe, 0x9d, 0x4, 0x28, 0xaa, 0x5d, 0x41, 0x33, 0xf3, 0x2d, 0xc7, 0x15, 0x53, 0x1b, 0xe8, 0xa0, 0x64, 0x16}}
+	return a, nil
+}
+
+var __1528395692_changeset_jobs_conflictedUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x70\x00\x8f\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x6a\x6f\x62\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6e\x66\x6c\x69\x63\x74\x65\x64\x20\x62\x6f\x6f\x6c\x65\x61\x6e\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x66\x61\x6c\x73\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x77\x7e\xf8\x0d\x70\x00\x00\x00")
+
+func _1528395692_changeset_jobs_conflictedUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395692_changeset_jobs_conflictedUpSql,
+		"1528395692_changeset_jobs_conflicted.up.sql",
+	)
+}
+
+func _1528395692_changeset_jobs_conflictedUpSql() (*asset, error) {
+	bytes, err := _1528395692_changeset_jobs_conflictedUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395692_changeset_jobs_conflicted.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3d, 0x18, 0x65, 0x16, 0x46, 0xa5, 0x74, 0xce, 0xae, 0xf3, 0x86, 0x76, 0x5a, 0x4d, 0xe9, 0xc, 0xfc, 0xd5, 0x4, 0x85, 0xf0, 0xd6, 0x2f, 0xcd, 0xaa, 0x6b, 0xf5, 0xf7, 0xa6, 0x24, 0x53, 0x9b}}
+	return a, nil
+}
+
+var __1528395693_campaigns_auto_mergeDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x49\x00\xb6\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x74\x6f\x5f\x6d\x65\x72\x67\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x98\x25\x68\x98\x49\x00\x00\x00")
+
+func _1528395693_campaigns_auto_mergeDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395693_campaigns_auto_mergeDownSql,
+		"1528395693_campaigns_auto_merge.down.sql",
+	)
+}
+
+func _1528395693_campaigns_auto_mergeDownSql() (*asset, error) {
+	bytes, err := _1528395693_campaigns_auto_mergeDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395693_campaigns_auto_merge.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb5, 0x4e, 0x4, 0x62, 0x10, 0xda, 0x54, 0x76, 0x35, 0x26, 0x1a, 0xef, 0xa3, 0x91, 0xdc, 0x68, 0xe2, 0x5d, 0xeb, 0x4a, 0xb2, 0xc7, 0xe2, 0xc, 0xae, 0x5b, 0xd9, 0x90, 0x7c, 0x76, 0x54, 0xa0}}
+	return a, nil
+}
+
+var __1528395693_campaigns_auto_mergeUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x6f\x00\x90\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x74\x6f\x5f\x6d\x65\x72\x67\x65\x20\x6a\x73\x6f\x6e\x62\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x27\x7b\x7d\x27\x3a\x3a\x6a\x73\x6f\x6e\x62\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb8\xff\xc7\x81\x6f\x00\x00\x00")
+
+func _1528395693_campaigns_auto_mergeUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395693_campaigns_auto_mergeUpSql,
+		"1528395693_campaigns_auto_merge.up.sql",
+	)
+}
+
+func _1528395693_campaigns_auto_mergeUpSql() (*asset, error) {
+	bytes, err := _1528395693_campaigns_auto_mergeUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395693_campaigns_auto_merge.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x45, 0x31, 0xb7, 0xc9, 0xac, 0xe4, 0x20, 0x43, 0x10, 0xb5, 0x18, 0x5f, 0xbf, 0x17, 0xbe, 0x43, 0x3, 0x32, 0x51, 0x6b, 0xee, 0x17, 0xe2, 0x19, 0x87, 0xde, 0x81, 0x63, 0xe2, 0x82, 0x9, 0xc}}
+	return a, nil
+}
+
+var __1528395694_changeset_jobs_rolloutDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x48\xce\x48\xcc\x4b\x4f\x2d\x4e\x2d\x89\xcf\xca\x4f\x2a\x56\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x29\x4a\x4c\x2b\xb1\x26\x5d\x5f\x41\x69\x52\x4e\x66\x71\x46\x7c\x62\x89\x35\x17\x97\xb3\xbf\xaf\xaf\x67\x88\x35\x17\x60\x00\x6f\xd0\xb8\xec\x86\x00\x00\x00")
+
+func _1528395694_changeset_jobs_rolloutDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395694_changeset_jobs_rolloutDownSql,
+		"1528395694_changeset_jobs_rollout.down.sql",
+	)
+}
+
+func _1528395694_changeset_jobs_rolloutDownSql() (*asset, error) {
+	bytes, err := _1528395694_changeset_jobs_rolloutDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395694_changeset_jobs_rollout.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x86, 0x4f, 0xed, 0x70, 0x4f, 0xfc, 0xc0, 0x7, 0xc2, 0xa1, 0xa0, 0xd3, 0x3f, 0x62, 0x70, 0x5a, 0xee, 0x60, 0xc6, 0xb1, 0xa, 0x8a, 0xcd, 0x74, 0xe2, 0xf, 0x94, 0xc7, 0x21, 0x19, 0xbe, 0x97}}
+	return a, nil
+}
+
+var __1528395694_changeset_jobs_rolloutUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\xcc\x41\xaa\x83\x30\x14\x46\xe1\x79\x56\xf1\xef\x23\xa3\xa8\xf1\x11\x88\x11\x9e\x11\x3a\x93\x6b\x7b\xad\x16\x35\xd2\xdc\x52\xe8\xea\x0b\x2e\xa1\xc3\xc3\x81\xaf\xb0\x7f\x2e\x68\xa5\x8c\x8f\xf6\x1f\xd1\x14\xde\xe2\x3a\xd3\x7e\xe7\xcc\x32\x3c\xd2\x98\x61\xaa\x0a\x65\xeb\xfb\x26\xc0\xd5\x08\x6d\x84\xbd\xb8\x2e\x76\xb8\x3d\x69\x12\x8c\x29\xad\x4c\xfb\x39\x42\xef\x3d\x2a\x5b\x9b\xde\x47\x4c\xb4\x66\xd6\x3f\xc9\xc7\x6b\x5c\x97\x3c\x0f\x24\x90\x65\xe3\x2c\xb4\x1d\x78\x2f\x32\x9f\x89\x4f\xda\x59\x2b\x55\xb6\x4d\xe3\xa2\x56\xdf\x01\x00\xdd\xaa\x30\x8a\xc4\x00\x00\x00")
+
+func _1528395694_changeset_jobs_rolloutUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395694_changeset_jobs_rolloutUpSql,
+		"1528395694_changeset_jobs_rollout.up.sql",
+	)
+}
+
+func _1528395694_changeset_jobs_rolloutUpSql() (*asset, error) {
+	bytes, err := _1528395694_changeset_jobs_rolloutUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395694_changeset_jobs_rollout.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3, 0xa9, 0x5d, 0x34, 0xc3, 0xbd, 0x97, 0xdd, 0xc2, 0xd2, 0xa9, 0x4e, 0x17, 0xaf, 0x91, 0xef, 0x31, 0x14, 0x67, 0x5b, 0x70, 0x85, 0x51, 0x74, 0x2b, 0xa4, 0xcd, 0x3, 0x31, 0x75, 0xae, 0x48}}
+	return a, nil
+}
+
+var __1528395695_changeset_bulk_actionsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x68\x00\x97\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x62\x75\x6c\x6b\x5f\x6a\x6f\x62\x73\x3b\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x62\x75\x6c\x6b\x5f\x61\x63\x74\x69\x6f\x6e\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xf3\xa0\xf6\x49\x68\x00\x00\x00")
+
+func _1528395695_changeset_bulk_actionsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395695_changeset_bulk_actionsDownSql,
+		"1528395695_changeset_bulk_actions.down.sql",
+	)
+}
+
+func _1528395695_changeset_bulk_actionsDownSql() (*asset, error) {
+	bytes, err := _1528395695_changeset_bulk_actionsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395695_changeset_bulk_actions.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xd2, 0x5b, 0xeb, 0x32, 0xc0, 0xd9, 0x9f, 0xd9, 0x9c, 0x65, 0x23, 0x59, 0x7a, 0xf2, 0xd1, 0x9, 0xde, 0xd2, 0x4, 0x93, 0xe5, 0xe7, 0xb1, 0x35, 0x61, 0x0, 0x48, 0x4e, 0xf, 0x3, 0x8f, 0xd4}}
+	return a, nil
+}
+
+var __1528395695_changeset_bulk_actionsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x92\xc1\x8e\x9b\x30\x10\x86\xef\x3c\xc5\xdc\x02\x52\x9f\x60\x39\xb1\x30\xdb\xa2\x12\xa8\x80\x55\x77\x55\x55\xc8\xc0\x94\x38\x25\x06\xd9\x4e\xd3\xf4\xe9\x2b\xa0\x2a\x44\x25\x8a\xd3\xdb\x1e\xb1\xbf\xf9\x67\xf0\x7c\x8f\xf8\x3e\x8c\x5d\xcb\xf2\x53\xf4\x72\x84\xdc\x7b\x8c\x10\xc2\x27\x88\x93\x1c\xf0\x25\xcc\xf2\x0c\xaa\x1d\x13\x0d\x29\xd2\x45\x79\x6c\xbf\x17\xac\xd2\xbc\x13\x0a\x6c\x0b\x00\x80\xd7\x50\xf2\x46\x91\xe4\xac\x85\x4f\x69\xb8\xf5\xd2\x57\xf8\x88\xaf\xef\xc6\xdb\x8a\x1d\x7a\xc6\x1b\x51\x4c\x18\x17\x7a\x0c\x8e\x9f\xa3\x08\x52\x7c\xc2\x14\x63\x1f\xb3\xbf\x98\xb2\x79\xed\x40\x12\x43\x80\x11\xe6\x08\xbe\x97\xf9\x5e\x80\x10\x0c\x68\x3a\x8c\x36\xc5\x1e\x15\xc9\x21\x92\x0b\x4d\x0d\xc9\xd5\xcc\x81\x31\xcd\xd3\xe7\x9e\x40\xd3\xcf\x79\xba\xe9\xbc\xec\xea\xf3\xe5\xf9\x30\x8a\xf7\x1c\xe5\xb0\xd9\x4c\x48\xcb\x4a\x6a\x15\xec\x55\x27\xca\x15\xea\xcb\xd7\xcd\xc3\xc3\x78\x39\xe1\x92\x7e\x70\x3a\x91\x34\xaf\xa8\x24\x31\x4d\x75\xc1\x34\x68\x7e\x20\xa5\xd9\xa1\x87\x13\xd7\xbb\xf1\x13\x7e\x75\x82\xfe\x4d\x11\xdd\xc9\x76\xfe\xbc\x55\x5f\xff\x67\xbd\xe5\xcc\x5e\x84\x71\x80\x2f\x46\x5e\x14\xcb\x9d\x27\xf1\x55\x7b\x16\x98\x73\x97\x7f\xfb\xae\x34\x93\x6f\xd1\xef\x96\x7f\xab\x33\x1a\xca\x33\x17\x1b\x36\x31\xb5\x92\xa4\xec\xe4\x0d\xfd\x94\x66\xf2\xc6\x76\xa7\xb0\x6f\x5c\x70\xb5\x33\x21\xdf\x8c\x70\x83\x08\xcb\x85\xad\x0b\x37\x50\x60\x5f\x62\x8e\x7b\x6f\x9b\x9e\x44\xcd\x45\x73\x35\x7f\xfe\x69\x07\x3e\x7f\xc0\x14\x97\x9b\x09\xb3\xd1\x38\xd7\xb2\xfc\x64\xbb\x0d\x73\xd7\xfa\x3d\x00\xd7\xb1\x6d\x3c\x76\x05\x00\x00")
+
+func _1528395695_changeset_bulk_actionsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395695_changeset_bulk_actionsUpSql,
+		"1528395695_changeset_bulk_actions.up.sql",
+	)
+}
+
+func _1528395695_changeset_bulk_actionsUpSql() (*asset, error) {
+	bytes, err := _1528395695_changeset_bulk_actionsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395695_changeset_bulk_actions.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x18, 0xb8, 0x1c, 0x9a, 0xeb, 0x86, 0x7e, 0x76, 0x47, 0xc1, 0x1, 0x4d, 0x2c, 0x3, 0x7e, 0x30, 0xfe, 0x8a, 0x37, 0x72, 0xcf, 0xe0, 0xf6, 0x6d, 0xf1, 0x3f, 0x33, 0x8a, 0x80, 0x64, 0x2f, 0x60}}
+	return a, nil
+}
+
+var __1528395696_campaign_report_digestsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x3f\x00\xc0\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x61\x6d\x70\x61\x69\x67\x6e\x5f\x72\x65\x70\x6f\x72\x74\x5f\x64\x69\x67\x65\x73\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x0b\x71\xc6\x71\x3f\x00\x00\x00")
+
+func _1528395696_campaign_report_digestsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395696_campaign_report_digestsDownSql,
+		"1528395696_campaign_report_digests.down.sql",
+	)
+}
+
+func _1528395696_campaign_report_digestsDownSql() (*asset, error) {
+	bytes, err := _1528395696_campaign_report_digestsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395696_campaign_report_digests.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x42, 0x5f, 0x1a, 0xbe, 0xc1, 0xd3, 0xe2, 0x36, 0x20, 0xee, 0xf4, 0x48, 0xbb, 0xe3, 0xca, 0x1b, 0xa, 0xd8, 0xaf, 0xf3, 0x5b, 0x52, 0x21, 0x18, 0x24, 0xc0, 0x18, 0xf1, 0x34, 0xe8, 0x77, 0x3c}}
+	return a, nil
+}
+
+var __1528395696_campaign_report_digestsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xcc\xc1\x6a\x02\x31\x14\x85\xe1\x7d\x9e\xe2\xec\x54\xe8\x13\x38\xab\xd1\xc6\x12\x9a\x19\xcb\x18\xa1\x52\xca\x10\xf5\x32\xbd\xa5\x93\x84\xe4\xc2\x40\x9f\xbe\x90\x2e\x5d\x1e\x7e\xce\xb7\xd3\x2f\xa6\x6f\x94\xda\x0f\xba\x75\x1a\xae\xdd\x59\x0d\x73\x40\x7f\x74\xd0\xef\xe6\xe4\x4e\xb8\xf9\x39\x79\x9e\xc2\x98\x29\xc5\x2c\xe3\x9d\x27\x2a\x52\xb0\x56\x00\xc0\x77\x5c\x79\x2a\x94\xd9\xff\xe0\x6d\x30\x5d\x3b\x5c\xf0\xaa\x2f\x4f\xb5\x66\xba\x71\x62\x0a\x52\xf0\x5d\x62\xb8\x56\xb7\x3f\x5b\x8b\x67\x7d\x68\xcf\xd6\x61\xf5\xf1\xb9\xda\x6e\x6b\xfc\xbf\x14\x0a\x32\x7a\x81\xf0\x4c\x45\xfc\x9c\xb0\xb0\x7c\xd5\x89\xdf\x18\xe8\x91\x08\x71\x59\x6f\xd4\xa6\x51\x6a\x7f\xec\x3a\xe3\x1a\xf5\x37\x00\xe4\xb6\x27\x82\xd6\x00\x00\x00")
+
+func _1528395696_campaign_report_digestsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395696_campaign_report_digestsUpSql,
+		"1528395696_campaign_report_digests.up.sql",
+	)
+}
+
+func _1528395696_campaign_report_digestsUpSql() (*asset, error) {
+	bytes, err := _1528395696_campaign_report_digestsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395696_campaign_report_digests.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x68, 0x17, 0x63, 0x1, 0xea, 0xc6, 0xf, 0x36, 0x6e, 0x2b, 0xae, 0x78, 0xcd, 0x24, 0xec, 0xb5, 0x6b, 0xe, 0xc3, 0x78, 0xf8, 0xd8, 0x4f, 0xb4, 0xc3, 0x91, 0xc8, 0xaa, 0x7d, 0xb8, 0xb2, 0x1b}}
+	return a, nil
+}
+
+var __1528395697_changeset_importsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x39\x00\xc6\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x68\x61\x6e\x67\x65\x73\x65\x74\x5f\x69\x6d\x70\x6f\x72\x74\x73\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xdb\x5d\x00\x47\x39\x00\x00\x00")
+
+func _1528395697_changeset_importsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395697_changeset_importsDownSql,
+		"1528395697_changeset_imports.down.sql",
+	)
+}
+
+func _1528395697_changeset_importsDownSql() (*asset, error) {
+	bytes, err := _1528395697_changeset_importsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395697_changeset_imports.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x95, 0x5a, 0xf9, 0x38, 0xc0, 0x29, 0xb4, 0x43, 0x29, 0x15, 0x29, 0xb, 0x23, 0xe6, 0xee, 0x37, 0xc1, 0xef, 0xdf, 0xfb, 0xd8, 0x8a, 0x5, 0x12, 0x4, 0xab, 0x35, 0x7b, 0xe8, 0xbb, 0x67, 0x20}}
+	return a, nil
+}
+
+var __1528395697_changeset_importsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x92\xc1\x6e\x82\x40\x10\x86\xef\x3c\xc5\xdc\xd4\xa4\x6f\xe0\x09\x61\x6c\x48\x71\x69\x60\x4d\xf4\xb4\xd9\xca\x04\x37\x91\x85\xee\x8e\xd5\xf6\xe9\x1b\x90\x54\xdb\x9a\x6a\x7a\x24\xf3\xf1\xfd\xb3\x93\x7f\x86\x8f\x89\x98\x06\x41\x94\x63\x28\x11\x64\x38\x4b\x11\x92\x39\x88\x4c\x02\xae\x92\x42\x16\xb0\xd9\x6a\x5b\x91\x27\x56\xa6\x6e\x1b\xc7\x1e\xc6\x01\x00\x80\x29\xe1\xc5\x54\x9e\x9c\xd1\x3b\x78\xce\x93\x45\x98\xaf\xe1\x09\xd7\x0f\xfd\x74\xa3\xeb\x56\x9b\xca\xaa\x13\x66\x2c\xf7\x4e\xb1\x4c\x53\xc8\x71\x8e\x39\x8a\x08\x8b\x2f\xcc\x8f\x4d\x39\x81\x4c\x40\x8c\x29\x4a\x84\x28\x2c\xa2\x30\x46\x88\x3b\x34\xef\xb6\x3a\x69\xf7\x9e\x5c\xa7\x34\x96\xa9\x22\x77\xd5\xd9\x31\xf7\xfa\xe8\xc8\xe4\xac\xde\x29\x4f\xee\xcd\x6c\xe8\xc6\xba\x3f\xf1\x7b\x63\x5e\xf7\xe4\xde\x81\xe9\x78\xd6\x0e\xf9\xce\x35\xee\xfb\xa0\x7b\x73\xb8\x4c\x25\x8c\x46\x27\x66\xa7\x3d\x2b\xb7\xb7\x4a\x33\xb0\xa9\xc9\xb3\xae\x5b\x38\x18\xde\xf6\x9f\xf0\xd1\x58\x1a\x8e\xee\x48\x33\x95\x7f\x81\xbf\x63\x6c\x73\x18\x4f\x86\xeb\xb6\xe5\x3f\xff\x0f\x26\xe7\x12\x25\x22\xc6\xd5\xad\x12\xa9\xcb\x82\x64\xe2\x5a\xcb\x2e\x88\xde\x9e\x2d\x16\x89\x9c\x06\x9f\x03\x00\xd7\xdd\x8d\xed\xb3\x02\x00\x00")
+
+func _1528395697_changeset_importsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395697_changeset_importsUpSql,
+		"1528395697_changeset_imports.up.sql",
+	)
+}
+
+func _1528395697_changeset_importsUpSql() (*asset, error) {
+	bytes, err := _1528395697_changeset_importsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395697_changeset_imports.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7f, 0x45, 0xd9, 0x43, 0xd, 0x1b, 0xcb, 0xcf, 0xd2, 0xa8, 0xd5, 0x10, 0xf3, 0x7f, 0x99, 0x51, 0x13, 0x1c, 0xe4, 0x16, 0x96, 0xe5, 0xd, 0x82, 0x46, 0xd1, 0x29, 0xd9, 0xcd, 0x46, 0x17, 0xb8}}
+	return a, nil
+}
+
+var __1528395698_campaign_templatesDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x72\x72\x75\xf7\xf4\xb3\xe6\xe2\x72\xf4\x09\x71\x0d\x52\x08\x71\x74\xf2\x71\x55\x28\x48\x2c\x49\xce\x88\x2f\x4e\x2d\x29\xe6\x52\x50\x50\x50\x70\x09\xf2\x0f\x50\x70\xf6\xf7\x09\xf5\xf5\x53\xf0\x74\x53\x70\x8d\xf0\x0c\x0e\x09\x56\x48\x4e\xcc\x2d\x48\xcc\x4c\xcf\x8b\x2f\x49\xcd\x2d\xc8\x49\x2c\x49\x8d\xcf\x4c\xd1\x21\x49\x7d\x59\x6a\x51\x71\x66\x7e\x1e\x69\x9a\x12\x8b\xd2\x4b\x73\x53\xf3\x4a\x8a\xad\xb9\xb8\xc0\x7a\x20\x4e\xc6\xa3\x05\xa4\xd2\xd9\xdf\xd7\xd7\x33\xc4\x9a\x0b\x30\x00\x53\x44\xf4\xc3\xee\x00\x00\x00")
+
+func _1528395698_campaign_templatesDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395698_campaign_templatesDownSql,
+		"1528395698_campaign_templates.down.sql",
+	)
+}
+
+func _1528395698_campaign_templatesDownSql() (*asset, error) {
+	bytes, err := _1528395698_campaign_templatesDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395698_campaign_templates.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x53, 0x3c, 0xc7, 0x7f, 0xb, 0xb1, 0x33, 0xc9, 0xce, 0xc, 0x20, 0xb6, 0x51, 0xf, 0x23, 0xf4, 0x3c, 0x60, 0x20, 0x74, 0x8f, 0x91, 0x47, 0xb3, 0xa9, 0x90, 0x8b, 0x66, 0x3, 0xdb, 0x94, 0x3e}}
+	return a, nil
+}
+
+var __1528395698_campaign_templatesUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x53\xc1\x6e\x9c\x30\x10\xbd\xf3\x15\x73\x5b\x90\x7a\x68\xae\xd9\xaa\x12\x81\x49\x8b\xc2\x9a\xca\x78\xa5\xe4\x64\x79\x61\xc4\xba\x0a\x06\xd9\xde\xa6\x6a\xd5\x7f\xaf\x80\xb4\x9b\xd4\x5b\x6d\xd3\x1c\x61\xde\xbc\x37\xf3\xfc\xe6\x0a\x3f\x14\x6c\x1d\x45\x19\xc7\x54\x20\x88\xf4\xaa\x44\x28\xae\x81\x55\x02\xf0\xb6\xa8\x45\x0d\x8d\xea\x47\xa5\x3b\x23\x3d\xf5\xe3\xbd\xf2\xe4\x20\x8e\x00\x00\x74\x0b\x3b\xdd\x39\xb2\x5a\xdd\xc3\x27\x5e\x6c\x52\x7e\x07\x37\x78\xf7\x66\xae\x1a\xd5\x13\x78\xfa\xea\x67\x2e\xb6\x2d\xcb\xe5\x7f\x4b\xae\xb1\x7a\xf4\x7a\x30\xcf\xcb\x90\xe3\x75\xba\x2d\x05\xac\x56\x0b\xd2\x8d\xd4\x9c\x62\xf8\x42\xd6\x4d\xdd\xda\x78\xea\xc8\x86\x04\x17\x0b\x4e\x1d\xfc\x7e\xb0\x52\xb7\x21\x92\xe3\x35\x72\x64\x19\xd6\x70\x70\x64\x5d\xac\xdb\x04\x2a\x06\x39\x96\x28\x10\xb2\xb4\xce\xd2\x1c\xa7\x89\x90\xf3\xc9\x93\xe3\x4e\x6e\x54\x0d\xc9\xa9\xeb\x29\xf3\xab\x09\x07\xdb\xfd\x85\x6f\xb0\xdd\xbf\xd2\x35\x96\x94\xa7\x56\x2a\x0f\x5e\xf7\xe4\xbc\xea\x47\x78\xd0\x7e\x3f\x7f\xc2\xb7\xc1\x50\xe8\x96\x19\x1e\xe2\x64\xe9\x3f\x8c\xed\xab\xfa\xb3\x8a\xd5\x82\xa7\x05\x13\x27\x52\x23\xf7\xca\xc9\x0b\xf9\x7b\x65\xc8\x3e\x62\x76\x03\x71\x1c\xba\x5a\xd4\xb3\x44\x02\xef\xde\x43\x1c\x78\xf4\xab\x9a\x44\xc9\x31\xb9\x05\xcb\xf1\xf6\x6c\x72\x65\xa8\x55\xb1\x13\x38\x88\x03\x60\xb2\xfe\x6f\xa9\xc7\xb9\xcf\x2a\x2d\xb8\x69\xa9\xb4\x14\xc8\x1f\xaf\x71\x54\xbe\xd9\x4b\x47\xde\xcd\x1e\xa7\x79\x0e\x59\x55\x6e\x37\xec\xdc\x08\x93\x57\x3b\xdd\x69\xe3\x9f\xc6\x29\x1c\xe1\x8f\x70\xd5\x78\x7c\xe0\x67\xe9\x7a\x81\xf2\xd9\x13\x7d\xfb\x62\x4a\x65\xbb\x43\x4f\xc6\x3b\xf8\xec\x06\xb3\x0b\x29\x57\xdf\x7f\xac\x2e\x2f\xe7\xe2\x3a\x8a\xb2\x6a\xb3\x29\xc4\x3a\xfa\x39\x00\x2c\x2f\x98\x2c\xe1\x04\x00\x00")
+
+func _1528395698_campaign_templatesUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395698_campaign_templatesUpSql,
+		"1528395698_campaign_templates.up.sql",
+	)
+}
+
+func _1528395698_campaign_templatesUpSql() (*asset, error) {
+	bytes, err := _1528395698_campaign_templatesUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395698_campaign_templates.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x7e, 0x28, 0x35, 0xd8, 0x18, 0xec, 0x1, 0x82, 0xfb, 0xcf, 0xa9, 0x82, 0x98, 0xc0, 0x2d, 0x2a, 0x52, 0x2d, 0x50, 0xbc, 0x3a, 0x26, 0x76, 0x7b, 0x6e, 0xbd, 0x3e, 0x8e, 0x34, 0x11, 0xf4, 0x81}}
+	return a, nil
+}
+
+var __1528395699_access_tokens_expires_atDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4d\x00\xb2\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x61\x63\x63\x65\x73\x73\x5f\x74\x6f\x6b\x65\x6e\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x65\x78\x70\x69\x72\x65\x73\x5f\x61\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xfa\xc7\x84\x27\x4d\x00\x00\x00")
+
+func _1528395699_access_tokens_expires_atDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395699_access_tokens_expires_atDownSql,
+		"1528395699_access_tokens_expires_at.down.sql",
+	)
+}
+
+func _1528395699_access_tokens_expires_atDownSql() (*asset, error) {
+	bytes, err := _1528395699_access_tokens_expires_atDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395699_access_tokens_expires_at.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe9, 0xf4, 0xa, 0x25, 0x55, 0xaa, 0xae, 0x58, 0x3c, 0x51, 0x71, 0x39, 0x6b, 0x80, 0xd2, 0xe4, 0xa4, 0xa0, 0xf3, 0xca, 0xd3, 0x94, 0x7b, 0xf5, 0xb3, 0x32, 0xd6, 0x27, 0xad, 0x2a, 0x5c, 0x23}}
+	return a, nil
+}
+
+var __1528395699_access_tokens_expires_atUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x69\x00\x96\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x61\x63\x63\x65\x73\x73\x5f\x74\x6f\x6b\x65\x6e\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x65\x78\x70\x69\x72\x65\x73\x5f\x61\x74\x20\x74\x69\x6d\x65\x73\x74\x61\x6d\x70\x20\x77\x69\x74\x68\x20\x74\x69\x6d\x65\x20\x7a\x6f\x6e\x65\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\xb3\xf3\x05\x50\x69\x00\x00\x00")
+
+func _1528395699_access_tokens_expires_atUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395699_access_tokens_expires_atUpSql,
+		"1528395699_access_tokens_expires_at.up.sql",
+	)
+}
+
+func _1528395699_access_tokens_expires_atUpSql() (*asset, error) {
+	bytes, err := _1528395699_access_tokens_expires_atUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395699_access_tokens_expires_at.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x81, 0x60, 0x5d, 0xce, 0x98, 0x11, 0xf9, 0xc2, 0xb0, 0x2b, 0x1a, 0x7e, 0xe9, 0xc9, 0xd7, 0xb3, 0x8a, 0x5b, 0x2b, 0x5d, 0xeb, 0x76, 0xf1, 0xb3, 0x80, 0x8e, 0xc4, 0xc0, 0x46, 0x80, 0x17, 0xc4}}
+	return a, nil
+}
+
+var __1528395700_audit_logsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x62\x00\x9d\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x64\x69\x74\x5f\x6c\x6f\x67\x73\x3b\x0a\x44\x52\x4f\x50\x20\x46\x55\x4e\x43\x54\x49\x4f\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x75\x64\x69\x74\x5f\x6c\x6f\x67\x73\x5f\x69\x6d\x6d\x75\x74\x61\x62\x6c\x65\x28\x29\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x7e\xd2\x21\xa1\x62\x00\x00\x00")
+
+func _1528395700_audit_logsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395700_audit_logsDownSql,
+		"1528395700_audit_logs.down.sql",
+	)
+}
+
+func _1528395700_audit_logsDownSql() (*asset, error) {
+	bytes, err := _1528395700_audit_logsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395700_audit_logs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x24, 0xc8, 0x1b, 0xd8, 0x33, 0x9e, 0x4, 0x7c, 0xf9, 0xee, 0x76, 0x7, 0xed, 0xc6, 0x8f, 0xf, 0x95, 0x83, 0xc4, 0xe5, 0x52, 0xd5, 0x58, 0x53, 0x3b, 0x9a, 0x75, 0x4a, 0x21, 0x4c, 0x93, 0xf3}}
+	return a, nil
+}
+
+var __1528395700_audit_logsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\xdf\x6e\x9b\x4c\x10\xc5\xef\x79\x8a\x73\x61\xc9\xb6\xf4\x39\x0f\x10\x5f\x11\x18\xfb\x43\x25\x60\xad\x41\x75\xae\xd0\x3a\x8c\xc9\xb6\xc0\xba\xbb\x4b\xd2\xb4\xea\xbb\x57\x90\x7f\xa6\x49\x94\x48\x5c\x0c\x9c\x99\xdf\xee\x9c\x19\x2e\x68\x1d\x25\x4b\xcf\x0b\x04\xf9\x19\x21\xf3\x2f\x62\x42\xb4\x42\x92\x66\xa0\x5d\xb4\xcd\xb6\x90\x5d\xa9\x5c\x51\xeb\xca\x62\xe6\x01\x80\x2a\xb1\x57\x95\x65\xa3\x64\x8d\x8d\x88\x2e\x7d\x71\x85\x2f\x74\xf5\xdf\xa0\xca\x6b\xa7\x4d\xd1\x59\x36\x85\x2a\xa1\x5a\xc7\x15\x9b\x81\x97\xe4\x71\x7c\x9a\xa3\x8e\x70\xfc\xd3\x3d\x6b\x08\x69\xe5\xe7\x71\x86\xe9\xf4\x34\xed\xa0\xcd\x9d\x34\x25\x97\xc5\x41\x9b\x8f\x2b\x94\x6e\xc7\x49\x0f\x2c\x27\x4d\xc5\xee\xa3\x72\x53\x75\x0d\xb7\xce\xe2\x9b\xd5\xed\xfe\x8d\xc4\xdf\x7f\xa6\xe7\xe7\x83\xf8\x80\xbd\x36\x2c\x1d\x97\x85\x74\x70\xaa\x61\xeb\x64\x73\xc4\x9d\x72\x37\xc3\x2b\x7e\xe9\x96\x5f\x53\x5a\x7d\x37\x9b\x7b\xf3\x17\xdb\xa3\x24\xa4\xdd\xbb\xb6\x17\x63\x4b\xd3\x64\x34\x92\x91\x38\x5f\x7e\x1e\xd9\x1b\xf5\x9a\xa5\x74\xfb\x69\xc8\x49\xf3\xff\x80\x5e\x94\xbe\xcb\xc5\x02\x7e\x2f\xa2\xd6\x15\xb8\x75\x46\xb1\x45\xd3\x59\x87\x96\x6f\xd9\x60\xcf\x68\x74\xa9\x0e\x8a\x4b\x68\x83\x92\x6b\x76\x5c\x9e\x61\xd4\x1a\x6e\xa4\x45\xab\xbd\xc5\x02\x07\x6d\x58\x55\x2d\xbe\xf3\x7d\x1f\xc3\xdd\x30\xac\x6c\x18\x86\xa5\xd5\xed\xf9\xf8\x0c\xdd\xb9\x5a\xdd\xf2\x90\xd5\xdb\x64\xfb\xe8\x1e\x86\x0f\x6c\x7a\x9a\xd3\x67\x4f\xfd\xa6\x02\x82\x36\xb1\x1f\x10\x56\x79\x12\x64\xd1\xa8\xad\x42\x35\x4d\xe7\xe4\xbe\xe6\xd9\x1c\x82\xb2\x5c\x24\x5b\x38\xa3\xaa\x8a\xcd\xb0\x0c\xb1\x9f\xac\x73\x7f\x4d\x38\xd6\xc7\xca\xfe\xa8\x87\x8f\xfe\x16\x93\xc9\x10\x0d\xff\xda\x10\xf5\x8f\xf0\xa3\x2d\x81\x76\x01\x6d\x86\x73\xa6\x27\xfe\x3d\xdd\x5f\x1a\xc6\xf3\xa1\xd3\xe5\x50\x4b\x49\xb8\xf4\x26\x93\xa5\xe7\x85\x22\xdd\x20\x13\xd1\x7a\x4d\xa2\x5f\x9e\xc7\x01\xf5\x37\x2a\xde\xba\xf5\x78\xda\xcf\x43\x7e\x22\xbc\x5f\x77\x41\xab\x54\x10\xf2\x4d\xf8\x68\x52\x48\x31\x65\x34\xe6\x61\x95\x0a\x90\x1f\xfc\x0f\x91\x7e\x05\xed\x28\xc8\x33\xc2\x46\xa4\x01\x85\xb9\x20\xbc\x45\x9e\xf5\xdb\x11\xa4\x97\x97\x51\xb6\xf4\xfe\x0e\x00\xf7\x71\xc8\xab\x8b\x04\x00\x00")
+
+func _1528395700_audit_logsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395700_audit_logsUpSql,
+		"1528395700_audit_logs.up.sql",
+	)
+}
+
+func _1528395700_audit_logsUpSql() (*asset, error) {
+	bytes, err := _1528395700_audit_logsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395700_audit_logs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xff, 0x8b, 0xec, 0x22, 0x63, 0x55, 0xf8, 0x83, 0x48, 0xe6, 0xce, 0x2d, 0xdd, 0xe1, 0xb2, 0x55, 0x5b, 0xb8, 0x43, 0xa9, 0xc1, 0xf6, 0x20, 0x3e, 0x9b, 0x68, 0xff, 0xb9, 0x1a, 0xd1, 0x2c, 0x58}}
+	return a, nil
+}
+
+var __1528395701_search_exports_attemptDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x4b\x00\xb4\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x73\x65\x61\x72\x63\x68\x5f\x65\x78\x70\x6f\x72\x74\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x61\x74\x74\x65\x6d\x70\x74\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x8c\xcf\x6d\xf0\x4b\x00\x00\x00")
+
+func _1528395701_search_exports_attemptDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395701_search_exports_attemptDownSql,
+		"1528395701_search_exports_attempt.down.sql",
+	)
+}
+
+func _1528395701_search_exports_attemptDownSql() (*asset, error) {
+	bytes, err := _1528395701_search_exports_attemptDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395701_search_exports_attempt.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4e, 0xba, 0xb9, 0xc9, 0xcf, 0xf0, 0x5e, 0x12, 0xb0, 0xb8, 0x2d, 0xa7, 0xd3, 0x9e, 0x81, 0xf4, 0xc6, 0xad, 0xc0, 0x73, 0x4b, 0x11, 0xba, 0xa, 0x53, 0x7f, 0xfb, 0x70, 0x49, 0x9d, 0x6b, 0xf5}}
+	return a, nil
+}
+
+var __1528395701_search_exports_attemptUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x69\x00\x96\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x73\x65\x61\x72\x63\x68\x5f\x65\x78\x70\x6f\x72\x74\x73\x20\x41\x44\x44\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x4e\x4f\x54\x20\x45\x58\x49\x53\x54\x53\x20\x61\x74\x74\x65\x6d\x70\x74\x20\x69\x6e\x74\x65\x67\x65\x72\x20\x4e\x4f\x54\x20\x4e\x55\x4c\x4c\x20\x44\x45\x46\x41\x55\x4c\x54\x20\x30\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x20\x59\xc0\xd7\x69\x00\x00\x00")
+
+func _1528395701_search_exports_attemptUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395701_search_exports_attemptUpSql,
+		"1528395701_search_exports_attempt.up.sql",
+	)
+}
+
+func _1528395701_search_exports_attemptUpSql() (*asset, error) {
+	bytes, err := _1528395701_search_exports_attemptUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395701_search_exports_attempt.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf1, 0xd9, 0x91, 0x86, 0x95, 0xdb, 0x44, 0x1b, 0x1d, 0x78, 0x19, 0xf2, 0x7b, 0x7b, 0xb9, 0xda, 0x73, 0x3a, 0xc1, 0x98, 0xba, 0xf0, 0x7b, 0x0, 0x20, 0x31, 0x37, 0xb4, 0xe9, 0xd6, 0x41, 0xff}}
+	return a, nil
+}
+
+var __1528395702_commit_index_queueDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x79\x00\x86\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x63\x6f\x6d\x6d\x69\x74\x5f\x69\x6e\x64\x65\x78\x5f\x71\x75\x65\x75\x65\x3b\x0a\x0a\x41\x4c\x54\x45\x52\x20\x54\x41\x42\x4c\x45\x20\x63\x6f\x6d\x6d\x69\x74\x5f\x69\x6e\x64\x65\x78\x5f\x72\x65\x70\x6f\x73\x20\x44\x52\x4f\x50\x20\x43\x4f\x4c\x55\x4d\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x68\x6f\x72\x69\x7a\x6f\x6e\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x5f\xcf\xf8\xf1\x79\x00\x00\x00")
+
+func _1528395702_commit_index_queueDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395702_commit_index_queueDownSql,
+		"1528395702_commit_index_queue.down.sql",
+	)
+}
+
+func _1528395702_commit_index_queueDownSql() (*asset, error) {
+	bytes, err := _1528395702_commit_index_queueDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395702_commit_index_queue.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1e, 0xce, 0x10, 0x2c, 0x6, 0xf9, 0xe1, 0x58, 0x13, 0x58, 0xf0, 0xa3, 0x90, 0x94, 0x71, 0x80, 0x76, 0xc2, 0x71, 0xbb, 0xa5, 0x13, 0x89, 0x4c, 0x49, 0xf, 0xee, 0xda, 0x5a, 0x18, 0xc1, 0x4}}
+	return a, nil
+}
+
+var __1528395702_commit_index_queueUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x8e\xb1\x6a\xf3\x30\x14\x46\xf7\xfb\x14\xdf\x68\xc3\xff\x06\x9e\x14\xe9\xfa\x47\x54\x96\x8b\x2c\x43\x33\x99\x50\x8b\x46\x83\xa5\xd4\x51\x48\xc9\xd3\x97\x8a\x2e\x19\x3a\x5e\xb8\xdf\x39\xe7\xc0\xff\xb5\xed\x88\x84\xf1\xec\xe0\xc5\xc1\x30\xde\xf3\xb6\xc5\xb2\xc4\xb4\x86\xaf\x65\x0f\x97\x7c\x85\x50\x0a\x72\x34\xf3\x60\xa1\x7b\xd8\xd1\x83\xdf\xf4\xe4\x27\x9c\xf3\x1e\x1f\x39\xa1\xc4\x2d\x5c\xcb\x69\xbb\xe0\x1e\xcb\xb9\x9e\x78\xe4\x14\x3a\x22\xe9\x58\x78\xfe\x65\x3f\xaf\x9f\x4c\x9f\xb7\x70\x0b\x68\x08\x00\x7e\xac\x4b\x5c\x11\x53\x09\x1f\x61\xc7\xab\xd3\x83\x70\x47\xbc\xf0\x11\x8e\x7b\x76\x6c\x25\x4f\xf5\xad\x89\x6b\x8b\xd1\x42\xb1\x61\xcf\x90\x62\x92\x42\xf1\xbf\x8a\xa9\xc8\x75\x39\x95\x3f\xfb\x6a\x8d\x9d\x8d\x81\xe2\x5e\xcc\xc6\x23\xe5\x7b\xd3\x52\xdb\x11\xc9\x71\x18\xb4\xef\xe8\x7b\x00\xd7\x89\x36\x22\x24\x01\x00\x00")
+
+func _1528395702_commit_index_queueUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395702_commit_index_queueUpSql,
+		"1528395702_commit_index_queue.up.sql",
+	)
+}
+
+func _1528395702_commit_index_queueUpSql() (*asset, error) {
+	bytes, err := _1528395702_commit_index_queueUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395702_commit_index_queue.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x37, 0x1a, 0xe7, 0x75, 0xca, 0x67, 0x44, 0x57, 0xcd, 0x37, 0x7e, 0x5d, 0x1b, 0xb2, 0x29, 0x41, 0x5c, 0x3c, 0xbc, 0x13, 0x18, 0x98, 0x69, 0x8b, 0x6, 0xad, 0x15, 0x54, 0xa, 0xd, 0x79, 0x96}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -1699,6 +2455,42 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395683_empty.up.sql":                                                 _1528395683_emptyUpSql,
 	"1528395684_lsif_num_resets.down.sql":                                     _1528395684_lsif_num_resetsDownSql,
 	"1528395684_lsif_num_resets.up.sql":                                       _1528395684_lsif_num_resetsUpSql,
+	"1528395685_external_service_sync_states.down.sql":                        _1528395685_external_service_sync_statesDownSql,
+	"1528395685_external_service_sync_states.up.sql":                          _1528395685_external_service_sync_statesUpSql,
+	"1528395686_repo_metadata.down.sql":                                       _1528395686_repo_metadataDownSql,
+	"1528395686_repo_metadata.up.sql":                                         _1528395686_repo_metadataUpSql,
+	"1528395687_search_exports.down.sql":                                      _1528395687_search_exportsDownSql,
+	"1528395687_search_exports.up.sql":                                        _1528395687_search_exportsUpSql,
+	"1528395688_insight_series_points.down.sql":                               _1528395688_insight_series_pointsDownSql,
+	"1528395688_insight_series_points.up.sql":                                 _1528395688_insight_series_pointsUpSql,
+	"1528395689_commit_index.down.sql":                                        _1528395689_commit_indexDownSql,
+	"1528395689_commit_index.up.sql":                                          _1528395689_commit_indexUpSql,
+	"1528395690_search_history.down.sql":                                      _1528395690_search_historyDownSql,
+	"1528395690_search_history.up.sql":                                        _1528395690_search_historyUpSql,
+	"1528395691_patch_set_jobs.down.sql":                                      _1528395691_patch_set_jobsDownSql,
+	"1528395691_patch_set_jobs.up.sql":                                        _1528395691_patch_set_jobsUpSql,
+	"1528395692_changeset_jobs_conflicted.down.sql":                           _1528395692_changeset_jobs_conflictedDownSql,
+	"1528395692_changeset_jobs_conflicted.up.sql":                             _1528395692_changeset_jobs_conflictedUpSql,
+	"1528395693_campaigns_auto_merge.down.sql":                                _1528395693_campaigns_auto_mergeDownSql,
+	"1528395693_campaigns_auto_merge.up.sql":                                  _1528395693_campaigns_auto_mergeUpSql,
+	"1528395694_changeset_jobs_rollout.down.sql":                              _1528395694_changeset_jobs_rolloutDownSql,
+	"1528395694_changeset_jobs_rollout.up.sql":                                _1528395694_changeset_jobs_rolloutUpSql,
+	"1528395695_changeset_bulk_actions.down.sql":                              _1528395695_changeset_bulk_actionsDownSql,
+	"1528395695_changeset_bulk_actions.up.sql":                                _1528395695_changeset_bulk_actionsUpSql,
+	"1528395696_campaign_report_digests.down.sql":                             _1528395696_campaign_report_digestsDownSql,
+	"1528395696_campaign_report_digests.up.sql":                               _1528395696_campaign_report_digestsUpSql,
+	"1528395697_changeset_imports.down.sql":                                   _1528395697_changeset_importsDownSql,
+	"1528395697_changeset_imports.up.sql":                                     _1528395697_changeset_importsUpSql,
+	"1528395698_campaign_templates.down.sql":                                  _1528395698_campaign_templatesDownSql,
+	"1528395698_campaign_templates.up.sql":                                    _1528395698_campaign_templatesUpSql,
+	"1528395699_access_tokens_expires_at.down.sql":                            _1528395699_access_tokens_expires_atDownSql,
+	"1528395699_access_tokens_expires_at.up.sql":                              _1528395699_access_tokens_expires_atUpSql,
+	"1528395700_audit_logs.down.sql":                                          _1528395700_audit_logsDownSql,
+	"1528395700_audit_logs.up.sql":                                            _1528395700_audit_logsUpSql,
+	"1528395701_search_exports_attempt.down.sql":                              _1528395701_search_exports_attemptDownSql,
+	"1528395701_search_exports_attempt.up.sql":                                _1528395701_search_exports_attemptUpSql,
+	"1528395702_commit_index_queue.down.sql":                                  _1528395702_commit_index_queueDownSql,
+	"1528395702_commit_index_queue.up.sql":                                    _1528395702_commit_index_queueUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -1815,6 +2607,42 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395683_empty.up.sql":                                                 {_1528395683_emptyUpSql, map[string]*bintree{}},
 	"1528395684_lsif_num_resets.down.sql":                                     {_1528395684_lsif_num_resetsDownSql, map[string]*bintree{}},
 	"1528395684_lsif_num_resets.up.sql":                                       {_1528395684_lsif_num_resetsUpSql, map[string]*bintree{}},
+	"1528395685_external_service_sync_states.down.sql":                        {_1528395685_external_service_sync_statesDownSql, map[string]*bintree{}},
+	"1528395685_external_service_sync_states.up.sql":                          {_1528395685_external_service_sync_statesUpSql, map[string]*bintree{}},
+	"1528395686_repo_metadata.down.sql":                                       {_1528395686_repo_metadataDownSql, map[string]*bintree{}},
+	"1528395686_repo_metadata.up.sql":                                         {_1528395686_repo_metadataUpSql, map[string]*bintree{}},
+	"1528395687_search_exports.down.sql":                                      {_1528395687_search_exportsDownSql, map[string]*bintree{}},
+	"1528395687_search_exports.up.sql":                                        {_1528395687_search_exportsUpSql, map[string]*bintree{}},
+	"1528395688_insight_series_points.down.sql":                               {_1528395688_insight_series_pointsDownSql, map[string]*bintree{}},
+	"1528395688_insight_series_points.up.sql":                                 {_1528395688_insight_series_pointsUpSql, map[string]*bintree{}},
+	"1528395689_commit_index.down.sql":                                        {_1528395689_commit_indexDownSql, map[string]*bintree{}},
+	"1528395689_commit_index.up.sql":                                          {_1528395689_commit_indexUpSql, map[string]*bintree{}},
+	"1528395690_search_history.down.sql":                                      {_1528395690_search_historyDownSql, map[string]*bintree{}},
+	"1528395690_search_history.up.sql":                                        {_1528395690_search_historyUpSql, map[string]*bintree{}},
+	"1528395691_patch_set_jobs.down.sql":                                      {_1528395691_patch_set_jobsDownSql, map[string]*bintree{}},
+	"1528395691_patch_set_jobs.up.sql":                                        {_1528395691_patch_set_jobsUpSql, map[string]*bintree{}},
+	"1528395692_changeset_jobs_conflicted.down.sql":                           {_1528395692_changeset_jobs_conflictedDownSql, map[string]*bintree{}},
+	"1528395692_changeset_jobs_conflicted.up.sql":                             {_1528395692_changeset_jobs_conflictedUpSql, map[string]*bintree{}},
+	"1528395693_campaigns_auto_merge.down.sql":                                {_1528395693_campaigns_auto_mergeDownSql, map[string]*bintree{}},
+	"1528395693_campaigns_auto_merge.up.sql":                                  {_1528395693_campaigns_auto_mergeUpSql, map[string]*bintree{}},
+	"1528395694_changeset_jobs_rollout.down.sql":                              {_1528395694_changeset_jobs_rolloutDownSql, map[string]*bintree{}},
+	"1528395694_changeset_jobs_rollout.up.sql":                                {_1528395694_changeset_jobs_rolloutUpSql, map[string]*bintree{}},
+	"1528395695_changeset_bulk_actions.down.sql":                              {_1528395695_changeset_bulk_actionsDownSql, map[string]*bintree{}},
+	"1528395695_changeset_bulk_actions.up.sql":                                {_1528395695_changeset_bulk_actionsUpSql, map[string]*bintree{}},
+	"1528395696_campaign_report_digests.down.sql":                             {_1528395696_campaign_report_digestsDownSql, map[string]*bintree{}},
+	"1528395696_campaign_report_digests.up.sql":                               {_1528395696_campaign_report_digestsUpSql, map[string]*bintree{}},
+	"1528395697_changeset_imports.down.sql":                                   {_1528395697_changeset_importsDownSql, map[string]*bintree{}},
+	"1528395697_changeset_imports.up.sql":                                     {_1528395697_changeset_importsUpSql, map[string]*bintree{}},
+	"1528395698_campaign_templates.down.sql":                                  {_1528395698_campaign_templatesDownSql, map[string]*bintree{}},
+	"1528395698_campaign_templates.up.sql":                                    {_1528395698_campaign_templatesUpSql, map[string]*bintree{}},
+	"1528395699_access_tokens_expires_at.down.sql":                            {_1528395699_access_tokens_expires_atDownSql, map[string]*bintree{}},
+	"1528395699_access_tokens_expires_at.up.sql":                              {_1528395699_access_tokens_expires_atUpSql, map[string]*bintree{}},
+	"1528395700_audit_logs.down.sql":                                          {_1528395700_audit_logsDownSql, map[string]*bintree{}},
+	"1528395700_audit_logs.up.sql":                                            {_1528395700_audit_logsUpSql, map[string]*bintree{}},
+	"1528395701_search_exports_attempt.down.sql":                              {_1528395701_search_exports_attemptDownSql, map[string]*bintree{}},
+	"1528395701_search_exports_attempt.up.sql":                                {_1528395701_search_exports_attemptUpSql, map[string]*bintree{}},
+	"1528395702_commit_index_queue.down.sql":                                  {_1528395702_commit_index_queueDownSql, map[string]*bintree{}},
+	"1528395702_commit_index_queue.up.sql":                                    {_1528395702_commit_index_queueUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
@@ -73,6 +73,15 @@ const AWSCodeCommitSchemaJSON = `{
       "type": "boolean",
       "default": false
     },
+    "indexBranches": {
+      "description": "A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as ` + "`" + `release-*` + "`" + `, which index every matching branch. Branches listed for a repository in the ` + "`" + `search.index.branches` + "`" + ` site configuration are indexed too.",
+      "type": "array",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      },
+      "examples": [["release-*"], ["main", "release-2.*"]]
+    },
     "exclude": {
       "description": "A list of repositories to never mirror from AWS CodeCommit. \n\nSupports excluding by name ({\"name\": \"git-codecommit.us-west-1.amazonaws.com/repo-name\"}) or by ARN ({\"id\": \"arn:aws:codecommit:us-west-1:999999999999:name\"}).",
       "type": "array",
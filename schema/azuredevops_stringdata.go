@@ -0,0 +1,81 @@
+// Code generated by stringdata. DO NOT EDIT.
+
+package schema
+
+// AzureDevOpsSchemaJSON is the content of the file "azuredevops.schema.json".
+const AzureDevOpsSchemaJSON = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "azuredevops.schema.json#",
+  "title": "AzureDevOpsConnection",
+  "description": "Configuration for a connection to Azure DevOps.",
+  "allowComments": true,
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["url", "token"],
+  "properties": {
+    "url": {
+      "description": "URL of an Azure DevOps organization or Azure DevOps Server collection, such as https://dev.azure.com/myorg or https://tfs.example.com/DefaultCollection.",
+      "type": "string",
+      "pattern": "^https?://",
+      "not": {
+        "type": "string",
+        "pattern": "example\\.com"
+      },
+      "format": "uri",
+      "examples": ["https://dev.azure.com/myorg", "https://tfs.example.com/DefaultCollection"]
+    },
+    "token": {
+      "description": "A personal access token with the Code (Read) scope. To create and manage pull requests for campaigns, the token also needs the Code (Read & write) scope.",
+      "type": "string",
+      "minLength": 1
+    },
+    "projects": {
+      "description": "A list of Azure DevOps projects whose repositories should be mirrored on Sourcegraph. If not set, the repositories of all projects in the organization that the token has access to are mirrored.",
+      "type": "array",
+      "items": { "type": "string", "minLength": 1 },
+      "examples": [["Fabrikam", "Fabrikam Fiber"]]
+    },
+    "indexBranches": {
+      "description": "A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as ` + "`" + `release-*` + "`" + `, which index every matching branch. Branches listed for a repository in the ` + "`" + `search.index.branches` + "`" + ` site configuration are indexed too.",
+      "type": "array",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      },
+      "examples": [["release-*"], ["main", "release-2.*"]]
+    },
+    "exclude": {
+      "description": "A list of repositories to never mirror from this Azure DevOps organization. Takes precedence over \"projects\" configuration.\n\nSupports excluding by name ({\"name\": \"myproject/myrepo\"}), by ID ({\"id\": \"5febef5a-833d-4e14-b9c0-14cb638f91e6\"}) or by pattern ({\"pattern\": \"^myproject/.*\"}).",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "title": "ExcludedAzureDevOpsRepo",
+        "additionalProperties": false,
+        "anyOf": [{ "required": ["name"] }, { "required": ["id"] }, { "required": ["pattern"] }],
+        "properties": {
+          "name": {
+            "description": "The name of an Azure DevOps repository, prefixed with its project (\"myproject/myrepo\"), to exclude from mirroring.",
+            "type": "string",
+            "pattern": "^[^/]+/[^/]+$"
+          },
+          "id": {
+            "description": "The ID of an Azure DevOps repository (as returned by the Azure DevOps REST API) to exclude from mirroring.",
+            "type": "string"
+          },
+          "pattern": {
+            "description": "Regular expression which matches against the \"project/repo\" name of an Azure DevOps repository.",
+            "type": "string",
+            "format": "regex"
+          }
+        }
+      },
+      "examples": [[{ "name": "myproject/myrepo" }, { "pattern": "^archive/.*" }]]
+    },
+    "repositoryPathPattern": {
+      "description": "The pattern used to generate the corresponding Sourcegraph repository name for an Azure DevOps repository.\n\n - \"{host}\" is replaced with the Azure DevOps URL's host (such as dev.azure.com), \"{organization}\" with the organization or collection (such as \"myorg\"), \"{project}\" with the project name and \"{repo}\" with the repository name.\n\nFor example, if your Azure DevOps organization is https://dev.azure.com/myorg and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of \"{host}/{organization}/{project}/{repo}\" would mean that the Azure DevOps repository at https://dev.azure.com/myorg/myproject/_git/myrepo is available on Sourcegraph at https://src.example.com/dev.azure.com/myorg/myproject/myrepo.\n\nIt is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.",
+      "type": "string",
+      "default": "{host}/{organization}/{project}/{repo}"
+    }
+  }
+}
+`
@@ -84,6 +84,15 @@ const BitbucketCloudSchemaJSON = `{
       "items": { "type": "string", "pattern": "^[\\w-]+$" },
       "examples": [["name"], ["kubernetes", "golang", "facebook"]]
     },
+    "indexBranches": {
+      "description": "A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as ` + "`" + `release-*` + "`" + `, which index every matching branch. Branches listed for a repository in the ` + "`" + `search.index.branches` + "`" + ` site configuration are indexed too.",
+      "type": "array",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      },
+      "examples": [["release-*"], ["main", "release-2.*"]]
+    },
     "exclude": {
       "description": "A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over \"teams\" configuration.\n\nSupports excluding by name ({\"name\": \"myorg/myrepo\"}) or by UUID ({\"uuid\": \"{fceb73c7-cef6-4abe-956d-e471281126bd}\"}).",
       "type": "array",
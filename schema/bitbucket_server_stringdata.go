@@ -160,6 +160,15 @@ const BitbucketServerSchemaJSON = `{
       },
       "examples": [["myproject/myrepo", "myproject/myotherrepo"]]
     },
+    "indexBranches": {
+      "description": "A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as ` + "`" + `release-*` + "`" + `, which index every matching branch. Branches listed for a repository in the ` + "`" + `search.index.branches` + "`" + ` site configuration are indexed too.",
+      "type": "array",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      },
+      "examples": [["release-*"], ["main", "release-2.*"]]
+    },
     "exclude": {
       "description": "A list of repositories to never mirror from this Bitbucket Server instance. Takes precedence over \"repos\" and \"repositoryQuery\".\n\nSupports excluding by name ({\"name\": \"projectKey/repositorySlug\"}) or by ID ({\"id\": 42}).",
       "type": "array",
@@ -0,0 +1,88 @@
+// Code generated by stringdata. DO NOT EDIT.
+
+package schema
+
+// GerritSchemaJSON is the content of the file "gerrit.schema.json".
+const GerritSchemaJSON = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "gerrit.schema.json#",
+  "title": "GerritConnection",
+  "description": "Configuration for a connection to Gerrit.",
+  "allowComments": true,
+  "type": "object",
+  "additionalProperties": false,
+  "required": ["url"],
+  "properties": {
+    "url": {
+      "description": "URL of a Gerrit instance, such as https://gerrit.example.com.",
+      "type": "string",
+      "pattern": "^https?://",
+      "not": {
+        "type": "string",
+        "pattern": "example\\.com"
+      },
+      "format": "uri",
+      "examples": ["https://gerrit.example.com", "https://review.example.com/gerrit"]
+    },
+    "username": {
+      "description": "The username to use when authenticating to the Gerrit instance. Also set the corresponding \"password\" field.\n\nIf not set, only the projects visible to anonymous users are mirrored.",
+      "type": "string"
+    },
+    "password": {
+      "description": "The HTTP password of the user to authenticate as, generated on the Gerrit instance's HTTP Credentials settings page. Also set the corresponding \"username\" field.",
+      "type": "string"
+    },
+    "projects": {
+      "description": "A list of Gerrit projects to mirror from this Gerrit instance, such as \"myproject\" or \"platform/build\".",
+      "type": "array",
+      "minItems": 1,
+      "items": { "type": "string", "pattern": "^[^\\s]+$" },
+      "examples": [["myproject", "platform/build"]]
+    },
+    "projectQuery": {
+      "description": "An array of Gerrit project queries (https://gerrit-review.googlesource.com/Documentation/user-search-projects.html) whose resulting projects are mirrored on Sourcegraph, such as \"state:active\" or \"parent:platform\". The special value \"none\" disables the default query.",
+      "type": "array",
+      "default": ["state:active"],
+      "items": { "type": "string", "minLength": 1 },
+      "minItems": 1,
+      "examples": [["state:active"], ["parent:platform", "inname:infra"]]
+    },
+    "indexBranches": {
+      "description": "A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as ` + "`" + `release-*` + "`" + `, which index every matching branch. Branches listed for a repository in the ` + "`" + `search.index.branches` + "`" + ` site configuration are indexed too.",
+      "type": "array",
+      "items": {
+        "type": "string",
+        "minLength": 1
+      },
+      "examples": [["release-*"], ["main", "release-2.*"]]
+    },
+    "exclude": {
+      "description": "A list of projects to never mirror from this Gerrit instance. Takes precedence over \"projects\" and \"projectQuery\" configuration.\n\nSupports excluding by name ({\"name\": \"myproject\"}) or by pattern ({\"pattern\": \"^sandbox/.*\"}).",
+      "type": "array",
+      "items": {
+        "type": "object",
+        "title": "ExcludedGerritProject",
+        "additionalProperties": false,
+        "anyOf": [{ "required": ["name"] }, { "required": ["pattern"] }],
+        "properties": {
+          "name": {
+            "description": "The name of a Gerrit project (\"myproject\") to exclude from mirroring.",
+            "type": "string"
+          },
+          "pattern": {
+            "description": "Regular expression which matches against the name of a Gerrit project.",
+            "type": "string",
+            "format": "regex"
+          }
+        }
+      },
+      "examples": [[{ "name": "myproject" }, { "pattern": "^sandbox/.*" }]]
+    },
+    "repositoryPathPattern": {
+      "description": "The pattern used to generate the corresponding Sourcegraph repository name for a Gerrit project.\n\n - \"{host}\" is replaced with the Gerrit URL's host (such as gerrit.example.com), and \"{name}\" is replaced with the Gerrit project's name (such as \"platform/build\").\n\nFor example, if your Gerrit is https://gerrit.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of \"{host}/{name}\" would mean that a Gerrit project at https://gerrit.example.com/platform/build is available on Sourcegraph at https://src.example.com/gerrit.example.com/platform/build.\n\nIt is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.",
+      "type": "string",
+      "default": "{host}/{name}"
+    }
+  }
+}
+`
@@ -21,6 +21,8 @@ type AWSCodeCommitConnection struct {
 	// See the AWS CodeCommit documentation on Git credentials for CodeCommit: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_ssh-keys.html#git-credentials-code-commit.
 	// For detailed instructions on how to create the credentials in IAM, see this page: https://docs.aws.amazon.com/codecommit/latest/userguide/setting-up-gc.html
 	GitCredentials AWSCodeCommitGitCredentials `json:"gitCredentials"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. AWS CodeCommit repositories can no longer be enabled or disabled explicitly. Configure which repositories should not be mirrored via "exclude" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Region description: The AWS region in which to access AWS CodeCommit. See the list of supported regions at https://docs.aws.amazon.com/codecommit/latest/userguide/regions.html#regions-git.
@@ -111,6 +113,30 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
 }
 
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
+type AzureDevOpsConnection struct {
+	// Exclude description: A list of repositories to never mirror from this Azure DevOps organization. Takes precedence over "projects" configuration.
+	//
+	// Supports excluding by name ({"name": "myproject/myrepo"}), by ID ({"id": "5febef5a-833d-4e14-b9c0-14cb638f91e6"}) or by pattern ({"pattern": "^myproject/.*"}).
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
+	// Projects description: A list of Azure DevOps projects whose repositories should be mirrored on Sourcegraph. If not set, the repositories of all projects in the organization that the token has access to are mirrored.
+	Projects []string `json:"projects,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for an Azure DevOps repository.
+	//
+	//  - "{host}" is replaced with the Azure DevOps URL's host (such as dev.azure.com), "{organization}" with the organization or collection (such as "myorg"), "{project}" with the project name and "{repo}" with the repository name.
+	//
+	// For example, if your Azure DevOps organization is https://dev.azure.com/myorg and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{organization}/{project}/{repo}" would mean that the Azure DevOps repository at https://dev.azure.com/myorg/myproject/_git/myrepo is available on Sourcegraph at https://src.example.com/dev.azure.com/myorg/myproject/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Token description: A personal access token with the Code (Read) scope. To create and manage pull requests for campaigns, the token also needs the Code (Read & write) scope.
+	Token string `json:"token"`
+	// Url description: URL of an Azure DevOps organization or Azure DevOps Server collection, such as https://dev.azure.com/myorg or https://tfs.example.com/DefaultCollection.
+	Url string `json:"url"`
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
@@ -127,6 +153,8 @@ type BitbucketCloudConnection struct {
 	//
 	// If "ssh", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form git@bitbucket.org:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 	RateLimit *BitbucketCloudRateLimit `json:"rateLimit,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Bitbucket Cloud repository.
@@ -187,6 +215,8 @@ type BitbucketServerConnection struct {
 	//
 	// If "ssh", Sourcegraph will access Bitbucket Server repositories using Git URLs of the form ssh://git@example.bitbucket.com/myproject/myrepo.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
 	// InitialRepositoryEnablement description: Defines whether repositories from this Bitbucket Server instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable Bitbucket Server repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by Bitbucket Server); site admins can still disable them explicitly, and they'll remain disabled.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Password description: The password to use when authenticating to the Bitbucket Server instance. Also set the corresponding "username" field.
@@ -311,6 +341,14 @@ type BuiltinAuthProvider struct {
 	Type        string `json:"type"`
 }
 
+// CampaignsReportDigest description: Sends an email digest with the burndown of all open campaigns to the given recipients on a schedule. Requires `email.smtp` to be configured.
+type CampaignsReportDigest struct {
+	// IntervalDays description: The number of days between two digests.
+	IntervalDays int `json:"intervalDays,omitempty"`
+	// Recipients description: The email addresses the digest is sent to.
+	Recipients []string `json:"recipients"`
+}
+
 // CloneURLToRepositoryName description: Describes a mapping from clone URL to repository name. The `from` field contains a regular expression with named capturing groups. The `to` field contains a template string that references capturing group names. For instance, if `from` is "^../(?P<name>\w+)$" and `to` is "github.com/user/{name}", the clone URL "../myRepository" would be mapped to the repository name "github.com/user/myRepository".
 type CloneURLToRepositoryName struct {
 	// From description: A regular expression that matches a set of clone URLs. The regular expression should use the Go regular expression syntax (https://golang.org/pkg/regexp/) and contain at least one named capturing group. The regular expression matches partially by default, so use "^...$" if whole-string matching is desired.
@@ -344,6 +382,14 @@ type ExcludedAWSCodeCommitRepo struct {
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedAzureDevOpsRepo struct {
+	// Id description: The ID of an Azure DevOps repository (as returned by the Azure DevOps REST API) to exclude from mirroring.
+	Id string `json:"id,omitempty"`
+	// Name description: The name of an Azure DevOps repository, prefixed with its project ("myproject/myrepo"), to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the "project/repo" name of an Azure DevOps repository.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedBitbucketCloudRepo struct {
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -360,6 +406,12 @@ type ExcludedBitbucketServerRepo struct {
 	// Pattern description: Regular expression which matches against the name of a Bitbucket Server repo.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedGerritProject struct {
+	// Name description: The name of a Gerrit project ("myproject") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gerrit project.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitHubRepo struct {
 	// Archived description: If set to true, archived repositories will be excluded.
 	Archived bool `json:"archived,omitempty"`
@@ -430,6 +482,36 @@ type ExternalIdentity struct {
 	Type           string `json:"type"`
 }
 
+// GerritConnection description: Configuration for a connection to Gerrit.
+type GerritConnection struct {
+	// Exclude description: A list of projects to never mirror from this Gerrit instance. Takes precedence over "projects" and "projectQuery" configuration.
+	//
+	// Supports excluding by name ({"name": "myproject"}) or by pattern ({"pattern": "^sandbox/.*"}).
+	Exclude []*ExcludedGerritProject `json:"exclude,omitempty"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
+	// Password description: The HTTP password of the user to authenticate as, generated on the Gerrit instance's HTTP Credentials settings page. Also set the corresponding "username" field.
+	Password string `json:"password,omitempty"`
+	// ProjectQuery description: An array of Gerrit project queries (https://gerrit-review.googlesource.com/Documentation/user-search-projects.html) whose resulting projects are mirrored on Sourcegraph, such as "state:active" or "parent:platform". The special value "none" disables the default query.
+	ProjectQuery []string `json:"projectQuery,omitempty"`
+	// Projects description: A list of Gerrit projects to mirror from this Gerrit instance, such as "myproject" or "platform/build".
+	Projects []string `json:"projects,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gerrit project.
+	//
+	//  - "{host}" is replaced with the Gerrit URL's host (such as gerrit.example.com), and "{name}" is replaced with the Gerrit project's name (such as "platform/build").
+	//
+	// For example, if your Gerrit is https://gerrit.example.com and your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{name}" would mean that a Gerrit project at https://gerrit.example.com/platform/build is available on Sourcegraph at https://src.example.com/gerrit.example.com/platform/build.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: URL of a Gerrit instance, such as https://gerrit.example.com.
+	Url string `json:"url"`
+	// Username description: The username to use when authenticating to the Gerrit instance. Also set the corresponding "password" field.
+	//
+	// If not set, only the projects visible to anonymous users are mirrored.
+	Username string `json:"username,omitempty"`
+}
+
 // GitHubAuthProvider description: Configures the GitHub (or GitHub Enterprise) OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth App on your GitHub instance: https://developer.github.com/apps/building-oauth-apps/creating-an-oauth-app/. When a user signs into Sourcegraph or links their GitHub account to their existing Sourcegraph account, GitHub will prompt the user for the repo scope.
 type GitHubAuthProvider struct {
 	// AllowOrgs description: Restricts new logins to members of these GitHub organizations. Existing sessions won't be invalidated. Leave empty or unset for no org restrictions.
@@ -476,6 +558,8 @@ type GitHubConnection struct {
 	//
 	// If "ssh", Sourcegraph will access GitHub repositories using Git URLs of the form git@github.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitHub repositories can no longer be enabled or disabled explicitly. Configure repositories to be mirrored via "repos", "exclude" and "repositoryQuery" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
@@ -569,6 +653,8 @@ type GitLabConnection struct {
 	//
 	// If "ssh", Sourcegraph will access GitLab repositories using Git URLs of the form git@example.gitlab.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
 	// InitialRepositoryEnablement description: Defines whether repositories from this GitLab instance should be enabled and cloned when they are first seen by Sourcegraph. If false, the site admin must explicitly enable GitLab repositories (in the site admin area) to clone them and make them searchable on Sourcegraph. If true, they will be enabled and cloned immediately (subject to rate limiting by GitLab); site admins can still disable them explicitly, and they'll remain disabled.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// NameTransformations description: An array of transformations will apply to the repository name. Currently, only regex replacement is supported. All transformations happen after "repositoryPathPattern" is processed.
@@ -621,6 +707,8 @@ type GitoliteConnection struct {
 	Exclude []*ExcludedGitoliteRepo `json:"exclude,omitempty"`
 	// Host description: Gitolite host that stores the repositories (e.g., git@gitolite.example.com, ssh://git@gitolite.example.com:2222/).
 	Host string `json:"host"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
 	// Phabricator description: Phabricator instance that integrates with this Gitolite instance
 	Phabricator *Phabricator `json:"phabricator,omitempty"`
 	// PhabricatorMetadataCommand description: This is DEPRECATED. Use the `phabricator` field instead.
@@ -725,6 +813,19 @@ func (v *IdentityProvider) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"oauth", "username", "external"})
 }
 
+type InsightSeries struct {
+	// HistoricalPoints description: The number of past points to backfill by searching past commits.
+	HistoricalPoints *int `json:"historicalPoints,omitempty"`
+	// Id description: A unique identifier for the series. Changing it discards all recorded points of the series.
+	Id string `json:"id"`
+	// Interval description: How often a point is recorded.
+	Interval string `json:"interval,omitempty"`
+	// Query description: The search query whose match count is recorded. Queries with a `repo:` filter are recommended, as the query is run against every repository it matches.
+	Query string `json:"query"`
+	// Title description: The title of the series shown on charts.
+	Title string `json:"title"`
+}
+
 // Log description: Configuration for logging and alerting, including to external services.
 type Log struct {
 	// Sentry description: Configuration for Sentry
@@ -828,6 +929,8 @@ type OpenIDConnectAuthProvider struct {
 // OtherExternalServiceConnection description: Configuration for a Connection to Git repositories for which an external service integration isn't yet available.
 type OtherExternalServiceConnection struct {
 	Repos []string `json:"repos"`
+	// IndexBranches description: A list of extra branches to index for search in every repository of this code host connection, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Branches listed for a repository in the `search.index.branches` site configuration are indexed too.
+	IndexBranches []string `json:"indexBranches,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -929,6 +1032,32 @@ type SMTPServerConfig struct {
 	// Username description: The username to use when communicating with the SMTP server.
 	Username string `json:"username,omitempty"`
 }
+
+// SearchHistory description: Retention limits for the search history of users, which is used to suggest their recent and frequent queries and repositories. Users can turn off their search history with the `search.history.enabled` setting.
+type SearchHistory struct {
+	// MaxAgeDays description: The number of days after which a query that has not been searched again is deleted. Defaults to 90.
+	MaxAgeDays *int `json:"maxAgeDays,omitempty"`
+	// MaxEntriesPerUser description: The maximum number of distinct queries kept per user. The least recently searched queries are deleted first. Defaults to 500.
+	MaxEntriesPerUser *int `json:"maxEntriesPerUser,omitempty"`
+}
+
+// SearchRanking description: The weights of the signals that rank the file matches of searches that do not use `stable:yes`. Each file match is scored by the sum of its weighted signals, and results with higher scores are shown first. A weight of 0 disables a signal.
+type SearchRanking struct {
+	// LookUpSymbols description: Look up the symbols of the highest-ranked file matches with the symbols service, so that files that define a symbol matching the search pattern rank higher (see symbolDefinitions). This sends a request to the symbols service for each repository revision of a search's results. When disabled, only symbol search results count as symbol definitions. Defaults to false.
+	LookUpSymbols bool `json:"lookUpSymbols,omitempty"`
+	// MatchDensity description: The weight of the number of matches in the file (logarithmic). Defaults to 1.
+	MatchDensity *float64 `json:"matchDensity,omitempty"`
+	// PathDepth description: The penalty per directory level of the file's path. Defaults to 0.25.
+	PathDepth *float64 `json:"pathDepth,omitempty"`
+	// RepoStars description: The weight of the number of stars of the repository on its code host (logarithmic). Defaults to 1.
+	RepoStars *float64 `json:"repoStars,omitempty"`
+	// SymbolDefinitions description: The weight of files that define a symbol matching the search pattern. Defaults to 2.
+	SymbolDefinitions *float64 `json:"symbolDefinitions,omitempty"`
+	// TestFiles description: The penalty for files that look like tests. Defaults to 1.
+	TestFiles *float64 `json:"testFiles,omitempty"`
+	// VendoredFiles description: The penalty for vendored, third-party and minified files. Defaults to 2.
+	VendoredFiles *float64 `json:"vendoredFiles,omitempty"`
+}
 type SearchSavedQueries struct {
 	// Description description: Description of this saved query
 	Description string `json:"description"`
@@ -992,6 +1121,8 @@ type Settings struct {
 	SearchContextLines int `json:"search.contextLines,omitempty"`
 	// SearchDefaultPatternType description: The default pattern type (literal or regexp) that search queries will be intepreted as.
 	SearchDefaultPatternType string `json:"search.defaultPatternType,omitempty"`
+	// SearchHistoryEnabled description: Whether the searches you run are saved to your search history, which is used to suggest your recent and frequent queries and repositories as you type. Turning this off stops saving new searches and suggesting from your history, but does not delete your existing history.
+	SearchHistoryEnabled *bool `json:"search.history.enabled,omitempty"`
 	// SearchIncludeArchived description: Whether searches should include searching archived repositories.
 	SearchIncludeArchived *bool `json:"search.includeArchived,omitempty"`
 	// SearchIncludeForks description: Whether searches should include searching forked repositories.
@@ -1058,6 +1189,8 @@ type SiteConfiguration struct {
 	Branding *Branding `json:"branding,omitempty"`
 	// CampaignsReadAccessEnabled description: Enables read-only access to campaigns for non-site-admin users. This is a setting for the experimental campaigns feature. These will only have an effect when campaigns is enabled with `{"experimentalFeatures": {"automation": "enabled"}}`.
 	CampaignsReadAccessEnabled *bool `json:"campaigns.readAccess.enabled,omitempty"`
+	// CampaignsReportDigest description: Sends an email digest with the burndown of all open campaigns to the given recipients on a schedule. Requires `email.smtp` to be configured.
+	CampaignsReportDigest *CampaignsReportDigest `json:"campaigns.reportDigest,omitempty"`
 	// CorsOrigin description: Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.
 	CorsOrigin string `json:"corsOrigin,omitempty"`
 	// DebugSearchSymbolsParallelism description: (debug) controls the amount of symbol search parallelism. Defaults to 20. It is not recommended to change this outside of debugging scenarios. This option will be removed in a future version.
@@ -1100,6 +1233,8 @@ type SiteConfiguration struct {
 	HtmlHeadBottom string `json:"htmlHeadBottom,omitempty"`
 	// HtmlHeadTop description: HTML to inject at the top of the `<head>` element on each page, for analytics scripts
 	HtmlHeadTop string `json:"htmlHeadTop,omitempty"`
+	// InsightsSeries description: Search queries whose match counts are recorded over time and shown as code insights. A background worker runs each query on the configured interval, and backfills historical points by running the query against past commits.
+	InsightsSeries []*InsightSeries `json:"insights.series,omitempty"`
 	// LicenseKey description: The license key associated with a Sourcegraph product subscription, which is necessary to activate Sourcegraph Enterprise functionality. To obtain this value, contact Sourcegraph to purchase a subscription. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	LicenseKey string `json:"licenseKey,omitempty"`
 	// LightstepAccessToken description: DEPRECATED. Use Jaeger (`"observability.tracing": { "sampling": "selective" }`), instead.
@@ -1128,12 +1263,20 @@ type SiteConfiguration struct {
 	PermissionsUserMapping *PermissionsUserMapping `json:"permissions.userMapping,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
+	// SearchHistory description: Retention limits for the search history of users, which is used to suggest their recent and frequent queries and repositories. Users can turn off their search history with the `search.history.enabled` setting.
+	SearchHistory *SearchHistory `json:"search.history,omitempty"`
+	// SearchIndexBranches description: A map from repository name to a list of extra branches to index, in addition to the default branch. Entries may be branch names or glob patterns such as `release-*`, which index every matching branch. Searches of an indexed branch (such as `repo:^github\.com/example/repo$@release-1.2`) are served by the index instead of being searched on demand. To index branches in all repositories of a code host, use the `indexBranches` property of its code host connection.
+	SearchIndexBranches map[string][]string `json:"search.index.branches,omitempty"`
+	// SearchIndexCommits description: Whether the commit messages, authors and diffs of the default branches of repositories are indexed. Commit and diff searches (`type:commit` and `type:diff`) of the default branch of indexed repositories use the index, so they can search across all repositories instead of only a few at a time. Indexing runs in the background after repositories are updated.
+	SearchIndexCommits bool `json:"search.index.commits,omitempty"`
 	// SearchIndexEnabled description: Whether indexed search is enabled. If unset Sourcegraph detects the environment to decide if indexed search is enabled. Indexed search is RAM heavy, and is disabled by default in the single docker image. All other environments will have it enabled by default. The size of all your repository working copies is the amount of additional RAM required.
 	SearchIndexEnabled *bool `json:"search.index.enabled,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
 	SearchIndexSymbolsEnabled *bool `json:"search.index.symbols.enabled,omitempty"`
 	// SearchLargeFiles description: A list of file glob patterns where matching files will be indexed and searched regardless of their size. The glob pattern syntax can be found here: https://golang.org/pkg/path/filepath/#Match.
 	SearchLargeFiles []string `json:"search.largeFiles,omitempty"`
+	// SearchRanking description: The weights of the signals that rank the file matches of searches that do not use `stable:yes`. Each file match is scored by the sum of its weighted signals, and results with higher scores are shown first. A weight of 0 disables a signal.
+	SearchRanking *SearchRanking `json:"search.ranking,omitempty"`
 	// UpdateChannel description: The channel on which to automatically check for Sourcegraph updates.
 	UpdateChannel string `json:"update.channel,omitempty"`
 	// UseJaeger description: DEPRECATED. Use `"observability.tracing": { "sampling": "all" }`, instead. Enables Jaeger tracing.
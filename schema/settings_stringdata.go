@@ -194,6 +194,12 @@ const SettingsSchemaJSON = `{
       "type": "boolean",
       "default": false,
       "!go": { "pointer": true }
+    },
+    "search.history.enabled": {
+      "description": "Whether the searches you run are saved to your search history, which is used to suggest your recent and frequent queries and repositories as you type. Turning this off stops saving new searches and suggesting from your history, but does not delete your existing history.",
+      "type": "boolean",
+      "default": true,
+      "!go": { "pointer": true }
     }
   },
   "definitions": {
@@ -34,6 +34,25 @@ const SiteSchemaJSON = `{
       "!go": { "pointer": true },
       "group": "Search"
     },
+    "search.index.branches": {
+      "description": "A map from repository name to a list of extra branches to index, in addition to the default branch. Entries may be branch names or glob patterns such as ` + "`" + `release-*` + "`" + `, which index every matching branch. Searches of an indexed branch (such as ` + "`" + `repo:^github\\.com/example/repo$@release-1.2` + "`" + `) are served by the index instead of being searched on demand. To index branches in all repositories of a code host, use the ` + "`" + `indexBranches` + "`" + ` property of its code host connection.",
+      "type": "object",
+      "additionalProperties": {
+        "type": "array",
+        "items": {
+          "type": "string",
+          "minLength": 1
+        }
+      },
+      "group": "Search",
+      "examples": [{ "github.com/example/repo": ["release-1.2", "release-2.*"] }]
+    },
+    "search.index.commits": {
+      "description": "Whether the commit messages, authors and diffs of the default branches of repositories are indexed. Commit and diff searches (` + "`" + `type:commit` + "`" + ` and ` + "`" + `type:diff` + "`" + `) of the default branch of indexed repositories use the index, so they can search across all repositories instead of only a few at a time. Indexing runs in the background after repositories are updated.",
+      "type": "boolean",
+      "default": false,
+      "group": "Search"
+    },
     "search.largeFiles": {
       "description": "A list of file glob patterns where matching files will be indexed and searched regardless of their size. The glob pattern syntax can be found here: https://golang.org/pkg/path/filepath/#Match.",
       "type": "array",
@@ -43,6 +62,71 @@ const SiteSchemaJSON = `{
       "group": "Search",
       "examples": [["go.sum", "package-lock.json", "*.thrift"]]
     },
+    "search.ranking": {
+      "description": "The weights of the signals that rank the file matches of searches that do not use ` + "`" + `stable:yes` + "`" + `. Each file match is scored by the sum of its weighted signals, and results with higher scores are shown first. A weight of 0 disables a signal.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "repoStars": {
+          "description": "The weight of the number of stars of the repository on its code host (logarithmic). Defaults to 1.",
+          "type": "number",
+          "!go": { "pointer": true }
+        },
+        "pathDepth": {
+          "description": "The penalty per directory level of the file's path. Defaults to 0.25.",
+          "type": "number",
+          "!go": { "pointer": true }
+        },
+        "testFiles": {
+          "description": "The penalty for files that look like tests. Defaults to 1.",
+          "type": "number",
+          "!go": { "pointer": true }
+        },
+        "vendoredFiles": {
+          "description": "The penalty for vendored, third-party and minified files. Defaults to 2.",
+          "type": "number",
+          "!go": { "pointer": true }
+        },
+        "symbolDefinitions": {
+          "description": "The weight of files that define a symbol matching the search pattern. Defaults to 2.",
+          "type": "number",
+          "!go": { "pointer": true }
+        },
+        "lookUpSymbols": {
+          "description": "Look up the symbols of the highest-ranked file matches with the symbols service, so that files that define a symbol matching the search pattern rank higher (see symbolDefinitions). This sends a request to the symbols service for each repository revision of a search's results. When disabled, only symbol search results count as symbol definitions. Defaults to false.",
+          "type": "boolean",
+          "default": false
+        },
+        "matchDensity": {
+          "description": "The weight of the number of matches in the file (logarithmic). Defaults to 1.",
+          "type": "number",
+          "!go": { "pointer": true }
+        }
+      },
+      "group": "Search",
+      "examples": [{ "repoStars": 2, "testFiles": 0 }]
+    },
+    "search.history": {
+      "description": "Retention limits for the search history of users, which is used to suggest their recent and frequent queries and repositories. Users can turn off their search history with the ` + "`" + `search.history.enabled` + "`" + ` setting.",
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "maxEntriesPerUser": {
+          "description": "The maximum number of distinct queries kept per user. The least recently searched queries are deleted first. Defaults to 500.",
+          "type": "integer",
+          "minimum": 1,
+          "!go": { "pointer": true }
+        },
+        "maxAgeDays": {
+          "description": "The number of days after which a query that has not been searched again is deleted. Defaults to 90.",
+          "type": "integer",
+          "minimum": 1,
+          "!go": { "pointer": true }
+        }
+      },
+      "group": "Search",
+      "examples": [{ "maxEntriesPerUser": 100, "maxAgeDays": 30 }]
+    },
     "debug.search.symbolsParallelism": {
       "description": "(debug) controls the amount of symbol search parallelism. Defaults to 20. It is not recommended to change this outside of debugging scenarios. This option will be removed in a future version.",
       "type": "integer",
@@ -249,6 +333,77 @@ const SiteSchemaJSON = `{
       "!go": { "pointer": true },
       "group": "Campaigns"
     },
+    "campaigns.reportDigest": {
+      "description": "Sends an email digest with the burndown of all open campaigns to the given recipients on a schedule. Requires ` + "`" + `email.smtp` + "`" + ` to be configured.",
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["recipients"],
+      "properties": {
+        "recipients": {
+          "description": "The email addresses the digest is sent to.",
+          "type": "array",
+          "items": { "type": "string", "format": "email" },
+          "minItems": 1
+        },
+        "intervalDays": {
+          "description": "The number of days between two digests.",
+          "type": "integer",
+          "minimum": 1,
+          "default": 7
+        }
+      },
+      "examples": [{ "recipients": ["engineering-managers@example.com"], "intervalDays": 7 }],
+      "group": "Campaigns"
+    },
+    "insights.series": {
+      "description": "Search queries whose match counts are recorded over time and shown as code insights. A background worker runs each query on the configured interval, and backfills historical points by running the query against past commits.",
+      "type": "array",
+      "items": {
+        "title": "InsightSeries",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["id", "title", "query"],
+        "properties": {
+          "id": {
+            "description": "A unique identifier for the series. Changing it discards all recorded points of the series.",
+            "type": "string",
+            "pattern": "^[a-z0-9-]+$"
+          },
+          "title": {
+            "description": "The title of the series shown on charts.",
+            "type": "string"
+          },
+          "query": {
+            "description": "The search query whose match count is recorded. Queries with a ` + "`" + `repo:` + "`" + ` filter are recommended, as the query is run against every repository it matches.",
+            "type": "string"
+          },
+          "interval": {
+            "description": "How often a point is recorded.",
+            "type": "string",
+            "enum": ["day", "week", "month"],
+            "default": "week"
+          },
+          "historicalPoints": {
+            "description": "The number of past points to backfill by searching past commits.",
+            "type": "integer",
+            "minimum": 0,
+            "default": 12,
+            "!go": { "pointer": true }
+          }
+        }
+      },
+      "examples": [
+        [
+          {
+            "id": "deprecated-logger",
+            "title": "Uses of the deprecated logger",
+            "query": "repo:^github\\.com/example/ log15\\.",
+            "interval": "week"
+          }
+        ]
+      ],
+      "group": "Code insights"
+    },
     "corsOrigin": {
       "description": "Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.",
       "type": "string",
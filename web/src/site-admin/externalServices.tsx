@@ -5,10 +5,13 @@ import BitbucketIcon from 'mdi-react/BitbucketIcon'
 import GithubIcon from 'mdi-react/GithubIcon'
 import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
+import MicrosoftAzureIcon from 'mdi-react/MicrosoftAzureIcon'
 import React from 'react'
 import awsCodeCommitSchemaJSON from '../../../schema/aws_codecommit.schema.json'
+import azureDevOpsSchemaJSON from '../../../schema/azuredevops.schema.json'
 import bitbucketCloudSchemaJSON from '../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../schema/bitbucket_server.schema.json'
+import gerritSchemaJSON from '../../../schema/gerrit.schema.json'
 import githubSchemaJSON from '../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../schema/gitolite.schema.json'
@@ -631,6 +634,72 @@ const AWS_CODE_COMMIT: AddExternalServiceOptions = {
         },
     ],
 }
+const AZURE_DEVOPS: AddExternalServiceOptions = {
+    kind: GQL.ExternalServiceKind.AZUREDEVOPS,
+    title: 'Azure DevOps',
+    icon: MicrosoftAzureIcon,
+    jsonSchema: azureDevOpsSchemaJSON,
+    defaultDisplayName: 'Azure DevOps',
+    defaultConfig: `{
+  "url": "https://dev.azure.com/<organization>",
+  "token": "<personal access token>"
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of your Azure DevOps organization
+                    (or Azure DevOps Server collection).
+                </li>
+                <li>
+                    Create a personal access token (
+                    <a
+                        href="https://docs.microsoft.com/en-us/azure/devops/organizations/accounts/use-personal-access-tokens-to-authenticate"
+                        target="_blank"
+                        rel="noopener noreferrer"
+                    >
+                        instructions
+                    </a>
+                    ) with the <b>Code (Read)</b> scope, or <b>Code (Read &amp; write)</b> to use campaigns. Set it
+                    to be the value of the <Field>token</Field> field in the configuration below.
+                </li>
+                <li>
+                    Optionally, set <Field>projects</Field> to the list of projects whose repositories Sourcegraph
+                    should index. By default, the repositories of all projects are indexed.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'setAccessToken',
+            label: 'Set access token',
+            run: config => {
+                const value = '<personal access token>'
+                const edits = setProperty(config, ['token'], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'addProjects',
+            label: 'Add projects',
+            run: config => {
+                const value = '<project>'
+                const edits = setProperty(config, ['projects', -1], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'excludeRepo',
+            label: 'Exclude a repository',
+            run: config => {
+                const value = { name: '<project>/<repository>' }
+                const edits = setProperty(config, ['exclude', -1], value, defaultFormattingOptions)
+                return { edits, selectText: '<project>/<repository>' }
+            },
+        },
+    ],
+}
 const BITBUCKET_CLOUD: AddExternalServiceOptions = {
     kind: GQL.ExternalServiceKind.BITBUCKETCLOUD,
     title: 'Bitbucket.org',
@@ -903,6 +972,69 @@ const GITLAB_SELF_MANAGED: AddExternalServiceOptions = {
 }`,
 }
 
+const GERRIT: AddExternalServiceOptions = {
+    kind: GQL.ExternalServiceKind.GERRIT,
+    title: 'Gerrit',
+    icon: GitIcon,
+    jsonSchema: gerritSchemaJSON,
+    defaultDisplayName: 'Gerrit',
+    defaultConfig: `{
+  "url": "https://gerrit.example.com",
+  "username": "<username>",
+  "password": "<HTTP password>",
+  "projectQuery": ["state:active"]
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of the Gerrit instance.
+                </li>
+                <li>
+                    Generate an HTTP password on the Gerrit instance's <b>Settings &gt; HTTP Credentials</b> page and
+                    set the <Field>username</Field> and <Field>password</Field> fields. Omit both to only mirror
+                    projects visible to anonymous users.
+                </li>
+                <li>
+                    Set <Field>projects</Field> or <Field>projectQuery</Field> to select the projects Sourcegraph
+                    should index.
+                </li>
+            </ol>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'setCredentials',
+            label: 'Set HTTP credentials',
+            run: config => {
+                const value = { username: '<username>', password: '<HTTP password>' }
+                const edits = [
+                    ...setProperty(config, ['username'], value.username, defaultFormattingOptions),
+                    ...setProperty(config, ['password'], value.password, defaultFormattingOptions),
+                ]
+                return { edits, selectText: value.username }
+            },
+        },
+        {
+            id: 'addProjects',
+            label: 'Add projects',
+            run: config => {
+                const value = '<project name>'
+                const edits = setProperty(config, ['projects', -1], value, defaultFormattingOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'excludeProject',
+            label: 'Exclude a project',
+            run: config => {
+                const value = { name: '<project name>' }
+                const edits = setProperty(config, ['exclude', -1], value, defaultFormattingOptions)
+                return { edits, selectText: '<project name>' }
+            },
+        },
+    ],
+}
 const GITOLITE: AddExternalServiceOptions = {
     kind: GQL.ExternalServiceKind.GITOLITE,
     title: 'Gitolite',
@@ -1073,6 +1205,8 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     bitbucket: BITBUCKET_CLOUD,
     bitbucketserver: BITBUCKET_SERVER,
     aws_codecommit: AWS_CODE_COMMIT,
+    azuredevops: AZURE_DEVOPS,
+    gerrit: GERRIT,
     gitolite: GITOLITE,
     git: GENERIC_GIT,
 }
@@ -1090,9 +1224,11 @@ export const defaultExternalServices: Record<GQL.ExternalServiceKind, AddExterna
     [GQL.ExternalServiceKind.GITHUB]: GITHUB_DOTCOM,
     [GQL.ExternalServiceKind.BITBUCKETCLOUD]: BITBUCKET_CLOUD,
     [GQL.ExternalServiceKind.BITBUCKETSERVER]: BITBUCKET_SERVER,
+    [GQL.ExternalServiceKind.GERRIT]: GERRIT,
     [GQL.ExternalServiceKind.GITLAB]: GITLAB_DOTCOM,
     [GQL.ExternalServiceKind.GITOLITE]: GITOLITE,
     [GQL.ExternalServiceKind.PHABRICATOR]: PHABRICATOR_SERVICE,
     [GQL.ExternalServiceKind.OTHER]: GENERIC_GIT,
     [GQL.ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
+    [GQL.ExternalServiceKind.AZUREDEVOPS]: AZURE_DEVOPS,
 }